@@ -35,15 +35,15 @@ var Orphaned action.GTSAction = func(ctx context.Context) error {
 
 	dry := config.GetAdminMediaPruneDryRun()
 
-	pruned, err := prune.manager.PruneOrphaned(ctx, dry)
+	pruned, bytes, err := prune.manager.GarbageCollectStorage(ctx, dry)
 	if err != nil {
 		return fmt.Errorf("error pruning: %s", err)
 	}
 
 	if dry /* dick heyyoooooo */ {
-		log.Infof(ctx, "DRY RUN: %d items are orphaned and eligible to be pruned", pruned)
+		log.Infof(ctx, "DRY RUN: %d items (%d bytes) are orphaned and eligible to be pruned", pruned, bytes)
 	} else {
-		log.Infof(ctx, "%d orphaned items were pruned", pruned)
+		log.Infof(ctx, "%d orphaned items (%d bytes) were pruned", pruned, bytes)
 	}
 
 	return prune.shutdown(ctx)
@@ -45,7 +45,12 @@ var Remote action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error pruning: %w", err)
 	}
 
-	total := pruned + uncached
+	dead, err := prune.manager.PruneDeadRemote(ctx, dry)
+	if err != nil {
+		return fmt.Errorf("error pruning: %w", err)
+	}
+
+	total := pruned + uncached + dead
 
 	if dry /* dick heyyoooooo */ {
 		log.Infof(ctx, "DRY RUN: %d remote items are unused/stale and eligible to be pruned", total)
@@ -21,7 +21,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -119,6 +121,16 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	filter := visibility.NewFilter(&state)
 	federatingDB := federatingdb.New(&state, typeConverter)
 	transportController := transport.NewController(&state, federatingDB, &federation.Clock{}, client)
+
+	// Let the media manager fetch remote media
+	// again to warm the cache after pruning.
+	mediaManager.SetDereferenceMedia(func(ctx context.Context, iri *url.URL) (io.ReadCloser, int64, error) {
+		t, err := transportController.NewTransportForUsername(ctx, "")
+		if err != nil {
+			return nil, 0, err
+		}
+		return t.DereferenceMedia(ctx, iri)
+	})
 	federator := federation.NewFederator(&state, federatingDB, transportController, typeConverter, mediaManager)
 
 	// Decide whether to create a noop email
@@ -138,6 +150,20 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		}
 	}
 
+	// Load any admin-customized email
+	// templates into the email sender.
+	emailTemplates, err := dbService.GetEmailTemplates(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting email templates: %w", err)
+	}
+
+	for _, t := range emailTemplates {
+		if err := emailSender.SetTemplate(t.Name, t.SubjectTemplate, t.BodyTemplate); err != nil {
+			// Not fatal, the compiled-in default will be used.
+			log.Errorf(ctx, "error loading email template %s: %v", t.Name, err)
+		}
+	}
+
 	// Initialize timelines.
 	state.Timelines.Home = timeline.NewManager(
 		tlprocessor.HomeTimelineGrab(&state),
@@ -161,6 +187,11 @@ var Start action.GTSAction = func(ctx context.Context) error {
 
 	// Create the processor using all the other services we've created so far.
 	processor := processing.NewProcessor(typeConverter, federator, oauthServer, mediaManager, &state, emailSender)
+	defer func() {
+		if err := processor.Close(); err != nil {
+			log.Errorf(ctx, "error closing processor: %v", err)
+		}
+	}()
 
 	// Set state client / federator worker enqueue functions
 	state.Workers.EnqueueClientAPI = processor.EnqueueClientAPI
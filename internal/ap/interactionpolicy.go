@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package ap
+
+import (
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Keys used to represent a status interaction policy as an extension
+// property on a Statusable. There's no vocab property for this, so it's
+// carried in the unknown properties of the type and serialized as-is.
+const (
+	InteractionPolicyKey = "interactionPolicy"
+	CanReplyKey          = "canReply"
+	CanAnnounceKey       = "canAnnounce"
+	CanLikeKey           = "canLike"
+)
+
+// SetInteractionPolicy sets the given interaction policy on the item as an
+// 'interactionPolicy' extension property. If the policy is the default (ie.,
+// nothing is restricted), then the property is left unset, to save bytes.
+func SetInteractionPolicy(item WithUnknownProperties, policy gtsmodel.StatusInteractionPolicy) {
+	if policy == (gtsmodel.StatusInteractionPolicy{}) {
+		return
+	}
+
+	policy = policy.Normalize()
+	item.GetUnknownProperties()[InteractionPolicyKey] = map[string]interface{}{
+		CanReplyKey:    string(policy.CanReplyTo),
+		CanAnnounceKey: string(policy.CanBoost),
+		CanLikeKey:     string(policy.CanLike),
+	}
+}
+
+// ExtractInteractionPolicy extracts the 'interactionPolicy' extension property
+// from the item. Missing or unrecognized values are returned empty, which is
+// equivalent to gtsmodel.InteractionPolicyEveryone.
+func ExtractInteractionPolicy(item WithUnknownProperties) gtsmodel.StatusInteractionPolicy {
+	var policy gtsmodel.StatusInteractionPolicy
+
+	raw, ok := item.GetUnknownProperties()[InteractionPolicyKey].(map[string]interface{})
+	if !ok {
+		return policy
+	}
+
+	for key, target := range map[string]*gtsmodel.InteractionPolicyValue{
+		CanReplyKey:    &policy.CanReplyTo,
+		CanAnnounceKey: &policy.CanBoost,
+		CanLikeKey:     &policy.CanLike,
+	} {
+		s, _ := raw[key].(string)
+
+		switch value := gtsmodel.InteractionPolicyValue(s); value {
+		case gtsmodel.InteractionPolicyFollowersOnly,
+			gtsmodel.InteractionPolicyMentionedOnly,
+			gtsmodel.InteractionPolicySelfOnly:
+			*target = value
+		}
+	}
+
+	return policy
+}
@@ -183,6 +183,11 @@ type WithTypeName interface {
 	GetTypeName() string
 }
 
+// WithUnknownProperties represents an activity or object with extension properties not covered by the vocab.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithPreferredUsername represents an activity with ActivityStreamsPreferredUsernameProperty
 type WithPreferredUsername interface {
 	GetActivityStreamsPreferredUsername() vocab.ActivityStreamsPreferredUsernameProperty
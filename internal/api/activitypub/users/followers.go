@@ -29,6 +29,7 @@ import (
 )
 
 // FollowersGETHandler returns a collection of URIs for followers of the target user, formatted so that other AP servers can understand it.
+// If the target user hides their collections, the collection will be empty.
 func (m *Module) FollowersGETHandler(c *gin.Context) {
 	// usernames on our instance are always lowercase
 	requestedUsername := strings.ToLower(c.Param(UsernameKey))
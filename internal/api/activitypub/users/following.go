@@ -29,6 +29,7 @@ import (
 )
 
 // FollowingGETHandler returns a collection of URIs for accounts that the target user follows, formatted so that other AP servers can understand it.
+// If the target user hides their collections, the collection will be empty.
 func (m *Module) FollowingGETHandler(c *gin.Context) {
 	// usernames on our instance are always lowercase
 	requestedUsername := strings.ToLower(c.Param(UsernameKey))
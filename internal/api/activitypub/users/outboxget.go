@@ -141,5 +141,9 @@ func (m *Module) OutboxGETHandler(c *gin.Context) {
 		return
 	}
 
+	// The outbox only ever contains public statuses, so
+	// the requester can cache it for a little while, in
+	// place of the no-store set for activitypub routes.
+	c.Header("Cache-Control", "private, max-age=60")
 	c.Data(http.StatusOK, format, b)
 }
@@ -81,8 +81,10 @@ func (suite *OutboxGetTestSuite) TestGetOutbox() {
   "@context": "https://www.w3.org/ns/activitystreams",
   "first": "http://localhost:8080/users/the_mighty_zork/outbox?page=true",
   "id": "http://localhost:8080/users/the_mighty_zork/outbox",
+  "totalItems": 5,
   "type": "OrderedCollection"
 }`, dst.String())
+	suite.Equal("private, max-age=60", result.Header.Get("Cache-Control"))
 
 	m := make(map[string]interface{})
 	err = json.Unmarshal(b, &m)
@@ -56,23 +56,27 @@ const (
 	OauthFinalizePath = "/finalize"
 	// OauthOobTokenPath is the path for serving an html representation of an oob token page.
 	OauthOobTokenPath = "/oob" // #nosec G101 else we get a hardcoded credentials warning
+	// OauthRevokePath is the API path for clients to revoke tokens they were granted
+	OauthRevokePath = "/revoke"
 
 	/*
 		params / session keys
 	*/
 
-	callbackStateParam   = "state"
-	callbackCodeParam    = "code"
-	sessionUserID        = "userid"
-	sessionClientID      = "client_id"
-	sessionRedirectURI   = "redirect_uri"
-	sessionForceLogin    = "force_login"
-	sessionResponseType  = "response_type"
-	sessionScope         = "scope"
-	sessionInternalState = "internal_state"
-	sessionClientState   = "client_state"
-	sessionClaims        = "claims"
-	sessionAppID         = "app_id"
+	callbackStateParam         = "state"
+	callbackCodeParam          = "code"
+	sessionUserID              = "userid"
+	sessionClientID            = "client_id"
+	sessionRedirectURI         = "redirect_uri"
+	sessionForceLogin          = "force_login"
+	sessionResponseType        = "response_type"
+	sessionScope               = "scope"
+	sessionInternalState       = "internal_state"
+	sessionClientState         = "client_state"
+	sessionClaims              = "claims"
+	sessionAppID               = "app_id"
+	sessionCodeChallenge       = "code_challenge"
+	sessionCodeChallengeMethod = "code_challenge_method"
 )
 
 type Module struct {
@@ -106,6 +110,7 @@ func (m *Module) RouteOauth(attachHandler func(method string, path string, f ...
 	attachHandler(http.MethodPost, OauthAuthorizePath, m.AuthorizePOSTHandler)
 	attachHandler(http.MethodPost, OauthFinalizePath, m.FinalizePOSTHandler)
 	attachHandler(http.MethodGet, OauthOobTokenPath, m.OobHandler)
+	attachHandler(http.MethodPost, OauthRevokePath, m.RevokePOSTHandler)
 }
 
 func (m *Module) clearSession(s sessions.Session) {
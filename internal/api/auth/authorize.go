@@ -32,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/oauth2/v4"
 )
 
 // AuthorizeGETHandler should be served as GET at https://example.org/oauth/authorize
@@ -197,6 +198,14 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		clientState = s
 	}
 
+	var codeChallenge, codeChallengeMethod string
+	if s, ok := s.Get(sessionCodeChallenge).(string); ok {
+		codeChallenge = s
+	}
+	if s, ok := s.Get(sessionCodeChallengeMethod).(string); ok {
+		codeChallengeMethod = s
+	}
+
 	userID, ok := s.Get(sessionUserID).(string)
 	if !ok {
 		errs = append(errs, fmt.Sprintf("key %s was not found in session", sessionUserID))
@@ -260,6 +269,11 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		c.Request.Form.Set("state", clientState)
 	}
 
+	if codeChallenge != "" {
+		c.Request.Form.Set(sessionCodeChallenge, codeChallenge)
+		c.Request.Form.Set(sessionCodeChallengeMethod, codeChallengeMethod)
+	}
+
 	if errWithCode := m.processor.OAuthHandleAuthorizeRequest(c.Writer, c.Request); errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 	}
@@ -293,6 +307,19 @@ func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gt
 		form.Scope = "read"
 	}
 
+	// PKCE is optional, but if it's used then
+	// only the S256 method is acceptable; plain
+	// offers no protection beyond no PKCE at all.
+	if form.CodeChallenge == "" && form.CodeChallengeMethod != "" {
+		err := errors.New("field code_challenge_method was set on OAuthAuthorize form, but code_challenge was not")
+		return gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice)
+	}
+
+	if form.CodeChallenge != "" && form.CodeChallengeMethod != string(oauth2.CodeChallengeS256) {
+		err := fmt.Errorf("code_challenge_method %q is not supported, must be %s", form.CodeChallengeMethod, oauth2.CodeChallengeS256)
+		return gtserror.NewErrorBadRequest(err, err.Error(), oauth.HelpfulAdvice)
+	}
+
 	// save these values from the form so we can use them elsewhere in the session
 	s.Set(sessionForceLogin, form.ForceLogin)
 	s.Set(sessionResponseType, form.ResponseType)
@@ -301,6 +328,8 @@ func saveAuthFormToSession(s sessions.Session, form *apimodel.OAuthAuthorize) gt
 	s.Set(sessionScope, form.Scope)
 	s.Set(sessionInternalState, uuid.NewString())
 	s.Set(sessionClientState, form.State)
+	s.Set(sessionCodeChallenge, form.CodeChallenge)
+	s.Set(sessionCodeChallengeMethod, form.CodeChallengeMethod)
 
 	if err := s.Save(); err != nil {
 		err := fmt.Errorf("error saving form values onto session: %s", err)
@@ -33,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
@@ -128,6 +129,15 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 	if user == nil {
+		if !config.GetOIDCAutoProvision() {
+			// no user exists and we're not allowed to create one
+			m.clearSession(s)
+			err := fmt.Errorf("no existing user found for oidc subject %s and oidc-auto-provision is disabled", claims.Sub)
+			help := "No account on this instance is linked to your login, and new accounts can't be created via single sign-on. Ask the instance admin to create an account for you."
+			apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, help), m.processor.InstanceGetV1)
+			return
+		}
+
 		// no user exists yet - let's ask them for their preferred username
 		instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
 		if errWithCode != nil {
@@ -150,6 +160,14 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		})
 		return
 	}
+
+	// Signing in cancels any scheduled account deletion.
+	if err := m.processor.Account().CancelScheduledSelfDelete(c.Request.Context(), user); err != nil {
+		log.Errorf(c.Request.Context(), "error cancelling scheduled deletion: %v", err)
+	}
+
+	m.processor.InstanceSignInRecord(c.Request.Context(), user.ID, net.ParseIP(c.ClientIP()))
+
 	s.Set(sessionUserID, user.ID)
 	if err := s.Save(); err != nil {
 		m.clearSession(s)
@@ -163,6 +181,13 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 func (m *Module) FinalizePOSTHandler(c *gin.Context) {
 	s := sessions.Default(c)
 
+	if !config.GetOIDCAutoProvision() {
+		m.clearSession(s)
+		err := errors.New("oidc-auto-provision is disabled for this server")
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
 	form := &extraInfo{}
 	if err := c.ShouldBind(form); err != nil {
 		m.clearSession(s)
@@ -225,6 +250,9 @@ func (m *Module) FinalizePOSTHandler(c *gin.Context) {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
+
+	m.processor.InstanceSignInRecord(c.Request.Context(), user.ID, net.ParseIP(c.ClientIP()))
+
 	s.Delete(sessionClaims)
 	s.Delete(sessionAppID)
 	s.Set(sessionUserID, user.ID)
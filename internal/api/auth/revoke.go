@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type revokeRequestForm struct {
+	Token         *string `form:"token" json:"token" xml:"token"`
+	TokenTypeHint *string `form:"token_type_hint" json:"token_type_hint" xml:"token_type_hint"`
+	ClientID      *string `form:"client_id" json:"client_id" xml:"client_id"`
+	ClientSecret  *string `form:"client_secret" json:"client_secret" xml:"client_secret"`
+}
+
+// RevokePOSTHandler should be served as a POST at https://example.org/oauth/revoke
+// It allows a client to revoke an access or refresh token it was granted, as per RFC 7009.
+func (m *Module) RevokePOSTHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &revokeRequestForm{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.InvalidRequest(), err.Error()))
+		return
+	}
+
+	help := []string{}
+
+	if form.Token == nil || *form.Token == "" {
+		help = append(help, "token was not set in the revoke request form")
+	}
+
+	if form.ClientID == nil || *form.ClientID == "" {
+		help = append(help, "client_id was not set in the revoke request form")
+	}
+
+	if form.ClientSecret == nil || *form.ClientSecret == "" {
+		help = append(help, "client_secret was not set in the revoke request form")
+	}
+
+	if len(help) != 0 {
+		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.InvalidRequest(), help...))
+		return
+	}
+
+	// token_type_hint is optional, and we can find
+	// the token without it, so it's ignored.
+	if errWithCode := m.processor.RevokeOAuthToken(
+		c.Request.Context(),
+		*form.ClientID,
+		*form.ClientSecret,
+		*form.Token,
+	); errWithCode != nil {
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RevokeTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *RevokeTestSuite) revoke(fields map[string]string) (int, string) {
+	requestBody, w, err := testrig.CreateMultipartFormData("", "", fields)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/revoke", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.RevokePOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		panic(err)
+	}
+
+	return recorder.Code, string(b)
+}
+
+func (suite *RevokeTestSuite) tokenRevoked(id string) bool {
+	dbToken := &gtsmodel.Token{}
+	if err := suite.db.GetByID(context.Background(), id, dbToken); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return !dbToken.RevokedAt.IsZero()
+}
+
+func (suite *RevokeTestSuite) TestRevokeAccessToken() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	code, body := suite.revoke(map[string]string{
+		"token":         testToken.Access,
+		"client_id":     testClient.ID,
+		"client_secret": testClient.Secret,
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{}`, body)
+	suite.True(suite.tokenRevoked(testToken.ID))
+
+	// The token should no longer validate.
+	req, err := http.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/accounts/verify_credentials", nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	req.Header.Set("Authorization", "Bearer "+testToken.Access)
+
+	ti, err := suite.processor.OAuthValidateBearerToken(req)
+	suite.Error(err)
+	suite.Nil(ti)
+}
+
+func (suite *RevokeTestSuite) TestRevokeUnknownToken() {
+	testClient := suite.testClients["local_account_1"]
+
+	code, body := suite.revoke(map[string]string{
+		"token":         "NOT_A_REAL_TOKEN",
+		"client_id":     testClient.ID,
+		"client_secret": testClient.Secret,
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{}`, body)
+}
+
+func (suite *RevokeTestSuite) TestRevokeOtherClientsToken() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_2"]
+
+	code, _ := suite.revoke(map[string]string{
+		"token":         testToken.Access,
+		"client_id":     testClient.ID,
+		"client_secret": testClient.Secret,
+	})
+	suite.Equal(http.StatusOK, code)
+	suite.False(suite.tokenRevoked(testToken.ID))
+}
+
+func (suite *RevokeTestSuite) TestRevokeWrongSecret() {
+	testClient := suite.testClients["local_account_1"]
+	testToken := suite.testTokens["local_account_1"]
+
+	code, body := suite.revoke(map[string]string{
+		"token":         testToken.Access,
+		"client_id":     testClient.ID,
+		"client_secret": "not the secret",
+	})
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.Equal(`{"error":"invalid_client","error_description":"Unauthorized: client authentication failed"}`, body)
+	suite.False(suite.tokenRevoked(testToken.ID))
+}
+
+func (suite *RevokeTestSuite) TestRevokeNoToken() {
+	testClient := suite.testClients["local_account_1"]
+
+	code, body := suite.revoke(map[string]string{
+		"client_id":     testClient.ID,
+		"client_secret": testClient.Secret,
+	})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: token was not set in the revoke request form"}`, body)
+}
+
+func TestRevokeTestSuite(t *testing.T) {
+	suite.Run(t, &RevokeTestSuite{})
+}
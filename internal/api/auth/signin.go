@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/gin-contrib/sessions"
@@ -28,6 +29,7 @@ import (
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -98,6 +100,8 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	m.processor.InstanceSignInRecord(c.Request.Context(), userid, net.ParseIP(c.ClientIP()))
+
 	s.Set(sessionUserID, userid)
 	if err := s.Save(); err != nil {
 		err := fmt.Errorf("error saving user id onto session: %s", err)
@@ -133,6 +137,11 @@ func (m *Module) ValidatePassword(ctx context.Context, email string, password st
 		return incorrectPassword(err)
 	}
 
+	// Signing in cancels any scheduled account deletion.
+	if err := m.processor.Account().CancelScheduledSelfDelete(ctx, user); err != nil {
+		log.Errorf(ctx, "error cancelling scheduled deletion: %v", err)
+	}
+
 	return user.ID, nil
 }
 
@@ -35,6 +35,7 @@ type tokenRequestForm struct {
 	ClientID     *string `form:"client_id" json:"client_id" xml:"client_id"`
 	ClientSecret *string `form:"client_secret" json:"client_secret" xml:"client_secret"`
 	Scope        *string `form:"scope" json:"scope" xml:"scope"`
+	CodeVerifier *string `form:"code_verifier" json:"code_verifier" xml:"code_verifier"`
 }
 
 // TokenPOSTHandler should be served as a POST at https://example.org/oauth/token
@@ -97,6 +98,10 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		c.Request.Form.Set("scope", *form.Scope)
 	}
 
+	if form.CodeVerifier != nil {
+		c.Request.Form.Set("code_verifier", *form.CodeVerifier)
+	}
+
 	if len(help) != 0 {
 		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oauth.InvalidRequest(), help...))
 		return
@@ -19,6 +19,8 @@ package auth_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -209,6 +211,82 @@ func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeWrongGrantType() {
 	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: a code was provided in the token request form, but grant_type was not set to authorization_code"}`, string(b))
 }
 
+func (suite *TokenTestSuite) putPKCEAuthorizationToken(verifier string) *gtsmodel.Token {
+	challenge := sha256.Sum256([]byte(verifier))
+
+	token := &gtsmodel.Token{}
+	*token = *suite.testTokens["local_account_1_user_authorization_token"]
+	token.ID = "01H3GZ1JM6V8F6C0ZJ5B1N3K7Q"
+	token.Code = "NZGXNTK2MJGTOGQ0NC0ZYJE3LTK4ZJETYJC5NDE1MZG0NDC4"
+	token.CodeChallenge = base64.RawURLEncoding.EncodeToString(challenge[:])
+	token.CodeChallengeMethod = "S256"
+
+	if err := suite.db.Put(context.Background(), token); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return token
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodePKCE() {
+	testClient := suite.testClients["local_account_1"]
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+	for _, testCase := range []struct {
+		description    string
+		verifier       *string
+		expectedStatus int
+	}{
+		{
+			description:    "correct verifier",
+			verifier:       &verifier,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			description:    "missing verifier",
+			verifier:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			description:    "wrong verifier",
+			verifier:       func() *string { s := "WrongVerifierWrongVerifierWrongVerifierWrong"; return &s }(),
+			expectedStatus: http.StatusBadRequest,
+		},
+	} {
+		// Put a fresh code token each time since
+		// a successful exchange consumes the code.
+		token := suite.putPKCEAuthorizationToken(verifier)
+
+		fields := map[string]string{
+			"grant_type":    "authorization_code",
+			"client_id":     testClient.ID,
+			"client_secret": testClient.Secret,
+			"redirect_uri":  "http://localhost:8080",
+			"code":          token.Code,
+		}
+		if testCase.verifier != nil {
+			fields["code_verifier"] = *testCase.verifier
+		}
+
+		requestBody, w, err := testrig.CreateMultipartFormData("", "", fields)
+		if err != nil {
+			panic(err)
+		}
+
+		ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", requestBody.Bytes(), w.FormDataContentType())
+		ctx.Request.Header.Set("accept", "application/json")
+
+		suite.authModule.TokenPOSTHandler(ctx)
+
+		suite.Equal(testCase.expectedStatus, recorder.Code, "failed on case: %s", testCase.description)
+
+		// Clean up the code token if it wasn't consumed.
+		if err := suite.db.DeleteByID(context.Background(), token.ID, &gtsmodel.Token{}); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+}
+
 func TestTokenTestSuite(t *testing.T) {
 	suite.Run(t, &TokenTestSuite{})
 }
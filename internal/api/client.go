@@ -27,16 +27,19 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/blocks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/bookmarks"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/customemojis"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/exports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/favourites"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/featuredtags"
 	filter "github.com/superseriousbusiness/gotosocial/internal/api/client/filters"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/imports"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/lists"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/media"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/savedsearches"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
@@ -58,16 +61,19 @@ type Client struct {
 	blocks         *blocks.Module         // api/v1/blocks
 	bookmarks      *bookmarks.Module      // api/v1/bookmarks
 	customEmojis   *customemojis.Module   // api/v1/custom_emojis
+	exports        *exports.Module        // api/v1/exports
 	favourites     *favourites.Module     // api/v1/favourites
 	featuredTags   *featuredtags.Module   // api/v1/featured_tags
 	filters        *filter.Module         // api/v1/filters
 	followRequests *followrequests.Module // api/v1/follow_requests
+	imports        *imports.Module        // api/v1/imports
 	instance       *instance.Module       // api/v1/instance
 	lists          *lists.Module          // api/v1/lists
 	media          *media.Module          // api/v1/media, api/v2/media
 	notifications  *notifications.Module  // api/v1/notifications
 	preferences    *preferences.Module    // api/v1/preferences
 	reports        *reports.Module        // api/v1/reports
+	savedSearches  *savedsearches.Module  // api/v1/saved_searches
 	search         *search.Module         // api/v1/search, api/v2/search
 	statuses       *statuses.Module       // api/v1/statuses
 	streaming      *streaming.Module      // api/v1/streaming
@@ -95,16 +101,19 @@ func (c *Client) Route(r router.Router, m ...gin.HandlerFunc) {
 	c.blocks.Route(h)
 	c.bookmarks.Route(h)
 	c.customEmojis.Route(h)
+	c.exports.Route(h)
 	c.favourites.Route(h)
 	c.featuredTags.Route(h)
 	c.filters.Route(h)
 	c.followRequests.Route(h)
+	c.imports.Route(h)
 	c.instance.Route(h)
 	c.lists.Route(h)
 	c.media.Route(h)
 	c.notifications.Route(h)
 	c.preferences.Route(h)
 	c.reports.Route(h)
+	c.savedSearches.Route(h)
 	c.search.Route(h)
 	c.statuses.Route(h)
 	c.streaming.Route(h)
@@ -123,16 +132,19 @@ func NewClient(db db.DB, p *processing.Processor) *Client {
 		blocks:         blocks.New(p),
 		bookmarks:      bookmarks.New(p),
 		customEmojis:   customemojis.New(p),
+		exports:        exports.New(p),
 		favourites:     favourites.New(p),
 		featuredTags:   featuredtags.New(p),
 		filters:        filter.New(p),
 		followRequests: followrequests.New(p),
+		imports:        imports.New(p),
 		instance:       instance.New(p),
 		lists:          lists.New(p),
 		media:          media.New(p),
 		notifications:  notifications.New(p),
 		preferences:    preferences.New(p),
 		reports:        reports.New(p),
+		savedSearches:  savedsearches.New(p),
 		search:         search.New(p),
 		statuses:       statuses.New(p),
 		streaming:      streaming.New(p, time.Second*30, 4096),
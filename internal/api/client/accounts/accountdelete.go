@@ -19,7 +19,9 @@ package accounts
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -33,6 +35,9 @@ import (
 //
 // Delete your account.
 //
+// To take your follows and blocks along to another instance,
+// download them from /api/v1/exports/bundle.zip before deleting.
+//
 //	---
 //	tags:
 //	- accounts
@@ -47,6 +52,23 @@ import (
 //		description: Password of the account user, for confirmation.
 //		type: string
 //		required: true
+//	-
+//		name: scheduled_at
+//		in: formData
+//		description: >-
+//			Optional ISO 8601 datetime at which to delete the account.
+//			If set, deletion will be scheduled for this time instead of happening immediately,
+//			and will be cancelled if you sign in again before then.
+//		type: string
+//	-
+//		name: force
+//		in: formData
+//		description: >-
+//			Delete the account immediately even if it belongs to the last active admin of the instance.
+//			Without this, deleting the last admin is refused, as it would leave the instance unadministrable.
+//			Only intended for tearing down an instance.
+//		type: boolean
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -54,11 +76,13 @@ import (
 //
 //	responses:
 //		'202':
-//			description: "The account deletion has been accepted and the account will be deleted."
+//			description: "The account deletion has been accepted and the account will be deleted (or scheduled for deletion)."
 //		'400':
 //			description: bad request
 //		'401':
 //			description: unauthorized
+//		'403':
+//			description: forbidden
 //		'404':
 //			description: not found
 //		'406':
@@ -91,7 +115,25 @@ func (m *Module) AccountDeletePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	if errWithCode := m.processor.Account().DeleteSelf(c.Request.Context(), authed.Account); errWithCode != nil {
+	if form.ScheduledAt != "" {
+		// Caller wants to delete their account later.
+		at, err := time.Parse(time.RFC3339, form.ScheduledAt)
+		if err != nil {
+			err = fmt.Errorf("error parsing scheduled_at: %w", err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+
+		if errWithCode := m.processor.Account().ScheduleSelfDelete(c.Request.Context(), authed.Account, at); errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"message": "accepted"})
+		return
+	}
+
+	if errWithCode := m.processor.Account().DeleteSelf(c.Request.Context(), authed.Account, form.Force); errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
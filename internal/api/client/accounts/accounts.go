@@ -41,6 +41,14 @@ const (
 	OnlyMediaKey = "only_media"
 	// OnlyPublicKey is for specifying that only statuses with visibility public should be returned in a list of returned statuses by account.
 	OnlyPublicKey = "only_public"
+	// SearchQueryKey is for specifying the query string of an account search.
+	SearchQueryKey = "q"
+	// SearchOffsetKey is for specifying how many account search results to skip.
+	SearchOffsetKey = "offset"
+	// SearchResolveKey is for specifying whether an account search should webfinger unknown remote accounts.
+	SearchResolveKey = "resolve"
+	// SearchFollowingKey is for specifying that an account search should only return followed accounts.
+	SearchFollowingKey = "following"
 
 	// IDKey is the key to use for retrieving account ID in requests
 	IDKey = "id"
@@ -72,6 +80,10 @@ const (
 	DeleteAccountPath = BasePath + "/delete"
 	// ListsPath is for seeing which lists an account is.
 	ListsPath = BasePathWithID + "/lists"
+	// ResendConfirmationPath is for resending an email address confirmation email
+	ResendConfirmationPath = BasePath + "/resend_confirmation"
+	// SearchPath is for searching for accounts
+	SearchPath = BasePath + "/search"
 )
 
 type Module struct {
@@ -94,6 +106,9 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	// delete account
 	attachHandler(http.MethodPost, DeleteAccountPath, m.AccountDeletePOSTHandler)
 
+	// resend email confirmation
+	attachHandler(http.MethodPost, ResendConfirmationPath, m.AccountResendConfirmationPOSTHandler)
+
 	// verify account
 	attachHandler(http.MethodGet, VerifyPath, m.AccountVerifyGETHandler)
 
@@ -110,6 +125,9 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	// get relationship with account
 	attachHandler(http.MethodGet, GetRelationshipsPath, m.AccountRelationshipsGETHandler)
 
+	// search for accounts
+	attachHandler(http.MethodGet, SearchPath, m.AccountSearchGETHandler)
+
 	// follow or unfollow account
 	attachHandler(http.MethodPost, FollowPath, m.AccountFollowPOSTHandler)
 	attachHandler(http.MethodPost, UnfollowPath, m.AccountUnfollowPOSTHandler)
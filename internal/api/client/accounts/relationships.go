@@ -22,7 +22,6 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -83,27 +82,19 @@ func (m *Module) AccountRelationshipsGETHandler(c *gin.Context) {
 		return
 	}
 
-	targetAccountIDs := c.QueryArray("id[]")
+	// Let's be generous and also accept
+	// ids given as 'id' rather than 'id[]'.
+	targetAccountIDs := append(c.QueryArray("id[]"), c.QueryArray("id")...)
 	if len(targetAccountIDs) == 0 {
-		// check fallback -- let's be generous and see if maybe it's just set as 'id'?
-		id := c.Query("id")
-		if id == "" {
-			err = errors.New("no account id(s) specified in query")
-			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
-			return
-		}
-		targetAccountIDs = append(targetAccountIDs, id)
+		err = errors.New("no account id(s) specified in query")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
 	}
 
-	relationships := []apimodel.Relationship{}
-
-	for _, targetAccountID := range targetAccountIDs {
-		r, errWithCode := m.processor.Account().RelationshipGet(c.Request.Context(), authed.Account, targetAccountID)
-		if errWithCode != nil {
-			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-			return
-		}
-		relationships = append(relationships, *r)
+	relationships, errWithCode := m.processor.Account().RelationshipsGet(c.Request.Context(), authed.Account, targetAccountIDs)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
 	}
 
 	c.JSON(http.StatusOK, relationships)
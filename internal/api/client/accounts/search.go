@@ -0,0 +1,192 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package accounts
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountSearchGETHandler swagger:operation GET /api/v1/accounts/search accountSearchGet
+//
+// Search for accounts by username, display name, or namestring.
+//
+// Accounts whose username exactly matches the query are returned first, followed by
+// accounts whose username starts with the query, and then accounts whose display name
+// starts with the query. Queries shorter than 2 characters (other than namestrings)
+// return no results.
+//
+//	---
+//	tags:
+//	- accounts
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: q
+//		type: string
+//		description: >-
+//			Query string to search for. This can be a username, a display name,
+//			or a namestring like `@someone@example.org`.
+//		in: query
+//		required: true
+//	-
+//		name: limit
+//		type: integer
+//		description: Number of accounts to return.
+//		default: 40
+//		maximum: 80
+//		minimum: 1
+//		in: query
+//		required: false
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n results.
+//		default: 0
+//		in: query
+//		required: false
+//	-
+//		name: resolve
+//		type: boolean
+//		description: >-
+//			If the query is a namestring of a remote account we don't know yet,
+//			attempt to look it up on its instance via webfinger.
+//		default: false
+//		in: query
+//		required: false
+//	-
+//		name: following
+//		type: boolean
+//		description: Only return accounts that the requesting account follows.
+//		default: false
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			name: accounts
+//			description: Array of matching accounts.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/account"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AccountSearchGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	query := c.Query(SearchQueryKey)
+	if query == "" {
+		err := errors.New("query parameter q was empty")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	limit := 40
+	limitString := c.Query(LimitKey)
+	if limitString != "" {
+		i, err := strconv.ParseInt(limitString, 10, 32)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", LimitKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		limit = int(i)
+	}
+	if limit > 80 {
+		limit = 80
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	offset := 0
+	offsetString := c.Query(SearchOffsetKey)
+	if offsetString != "" {
+		i, err := strconv.ParseInt(offsetString, 10, 32)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", SearchOffsetKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		offset = int(i)
+	}
+
+	resolve := false
+	resolveString := c.Query(SearchResolveKey)
+	if resolveString != "" {
+		i, err := strconv.ParseBool(resolveString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", SearchResolveKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		resolve = i
+	}
+
+	following := false
+	followingString := c.Query(SearchFollowingKey)
+	if followingString != "" {
+		i, err := strconv.ParseBool(followingString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", SearchFollowingKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		following = i
+	}
+
+	accounts, errWithCode := m.processor.SearchAccounts(c.Request.Context(), authed, query, limit, offset, resolve, following)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
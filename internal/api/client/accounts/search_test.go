@@ -0,0 +1,190 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package accounts_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/accounts"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountSearchTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountSearchTestSuite) search(params url.Values, expectedHTTPStatus int) []*apimodel.Account {
+	var (
+		recorder = httptest.NewRecorder()
+		ctx, _   = testrig.CreateGinTestContext(recorder, nil)
+		request  = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api"+accounts.SearchPath+"?"+params.Encode(), nil)
+	)
+
+	// Set up the test context.
+	ctx.Request = request
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	// Trigger the handler.
+	suite.accountsModule.AccountSearchGETHandler(ctx)
+
+	// Read the result.
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if resultCode := recorder.Code; expectedHTTPStatus != resultCode {
+		suite.FailNow("", "expected %d got %d (body %s)", expectedHTTPStatus, resultCode, string(b))
+	}
+
+	if expectedHTTPStatus != http.StatusOK {
+		return nil
+	}
+
+	resp := []*apimodel.Account{}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return resp
+}
+
+func (suite *AccountSearchTestSuite) usernames(accounts []*apimodel.Account) []string {
+	usernames := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		usernames = append(usernames, account.Username)
+	}
+	return usernames
+}
+
+func (suite *AccountSearchTestSuite) TestSearchUsernamePrefix() {
+	accounts := suite.search(url.Values{"q": {"1happy"}}, http.StatusOK)
+	suite.Equal([]string{"1happyturtle"}, suite.usernames(accounts))
+}
+
+func (suite *AccountSearchTestSuite) TestSearchDisplayName() {
+	// "happy" isn't a prefix of "1happyturtle", but
+	// the display name "happy little turtle :3" matches.
+	accounts := suite.search(url.Values{"q": {"Happy"}}, http.StatusOK)
+	suite.Equal([]string{"1happyturtle"}, suite.usernames(accounts))
+
+	// Display names only match by prefix.
+	accounts = suite.search(url.Values{"q": {"turtle"}}, http.StatusOK)
+	suite.Empty(accounts)
+}
+
+func (suite *AccountSearchTestSuite) TestSearchExactUsernameFirst() {
+	accounts := suite.search(url.Values{"q": {"admin"}}, http.StatusOK)
+	if !suite.NotEmpty(accounts) {
+		suite.FailNow("expected results")
+	}
+	suite.Equal("admin", accounts[0].Username)
+}
+
+func (suite *AccountSearchTestSuite) TestSearchUnderscoreNotWildcard() {
+	accounts := suite.search(url.Values{"q": {"the_"}}, http.StatusOK)
+	suite.Equal([]string{"the_mighty_zork"}, suite.usernames(accounts))
+
+	// "_" should only match itself, not any character.
+	accounts = suite.search(url.Values{"q": {"th_"}}, http.StatusOK)
+	suite.Empty(accounts)
+
+	// Same goes for "%".
+	accounts = suite.search(url.Values{"q": {"t%"}}, http.StatusOK)
+	suite.Empty(accounts)
+}
+
+func (suite *AccountSearchTestSuite) TestSearchFollowing() {
+	// local_account_1 follows 1happyturtle...
+	accounts := suite.search(url.Values{"q": {"1happy"}, "following": {"true"}}, http.StatusOK)
+	suite.Equal([]string{"1happyturtle"}, suite.usernames(accounts))
+
+	// ...but doesn't follow itself.
+	accounts = suite.search(url.Values{"q": {"the_mighty"}, "following": {"true"}}, http.StatusOK)
+	suite.Empty(accounts)
+}
+
+func (suite *AccountSearchTestSuite) TestSearchNamestring() {
+	accounts := suite.search(url.Values{"q": {"@foss_satan@fossbros-anonymous.io"}}, http.StatusOK)
+	if !suite.Len(accounts, 1) {
+		suite.FailNow("expected 1 account")
+	}
+	suite.Equal("foss_satan@fossbros-anonymous.io", accounts[0].Acct)
+
+	// Only one account can match a namestring.
+	accounts = suite.search(url.Values{"q": {"@foss_satan@fossbros-anonymous.io"}, "offset": {"1"}}, http.StatusOK)
+	suite.Empty(accounts)
+}
+
+func (suite *AccountSearchTestSuite) TestSearchNamestringNoResolve() {
+	accounts := suite.search(url.Values{"q": {"@brand_new_person@unknown-instance.com"}}, http.StatusOK)
+	suite.Empty(accounts)
+}
+
+func (suite *AccountSearchTestSuite) TestSearchLimitOffset() {
+	// Give admin a display name that shares
+	// a prefix with the_mighty_zork's username.
+	admin := suite.testAccounts["admin_account"]
+	admin.DisplayName = "the admin"
+	if err := suite.db.UpdateAccount(context.Background(), admin, "display_name"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	all := suite.search(url.Values{"q": {"th"}}, http.StatusOK)
+	if len(all) < 2 {
+		suite.FailNow("", "expected at least 2 results, got %d", len(all))
+	}
+
+	first := suite.search(url.Values{"q": {"th"}, "limit": {"1"}}, http.StatusOK)
+	suite.Equal(suite.usernames(all[:1]), suite.usernames(first))
+
+	second := suite.search(url.Values{"q": {"th"}, "limit": {"1"}, "offset": {"1"}}, http.StatusOK)
+	suite.Equal(suite.usernames(all[1:2]), suite.usernames(second))
+}
+
+func (suite *AccountSearchTestSuite) TestSearchEmptyQuery() {
+	suite.search(url.Values{"q": {""}}, http.StatusBadRequest)
+	suite.search(url.Values{"q": {"@"}}, http.StatusBadRequest)
+}
+
+func (suite *AccountSearchTestSuite) TestSearchQueryTooShort() {
+	// "a" would match plenty of accounts,
+	// but it's below the minimum length.
+	accounts := suite.search(url.Values{"q": {"a"}}, http.StatusOK)
+	suite.Empty(accounts)
+}
+
+func TestAccountSearchTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountSearchTestSuite))
+}
@@ -79,6 +79,10 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'409':
+//			description: conflict; account is already suspended
+//		'429':
+//			description: too many requests; admin has suspended too many accounts recently
 //		'500':
 //			description: internal server error
 func (m *Module) AccountActionPOSTHandler(c *gin.Context) {
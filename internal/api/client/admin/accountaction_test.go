@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type AccountActionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountActionTestSuite) suspend(targetAccountID string) int {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte("type=suspend"), admin.AccountsActionPath, "application/x-www-form-urlencoded")
+	ctx.AddParam(admin.IDKey, targetAccountID)
+
+	suite.adminModule.AccountActionPOSTHandler(ctx)
+	return recorder.Code
+}
+
+func (suite *AccountActionTestSuite) TestAccountActionDeleteLimit() {
+	config.SetAccountsAdminDeleteLimit(2)
+
+	suite.Equal(http.StatusOK, suite.suspend(suite.testAccounts["remote_account_1"].ID))
+	suite.Equal(http.StatusOK, suite.suspend(suite.testAccounts["remote_account_2"].ID))
+
+	// Third suspension within the
+	// window should be rejected.
+	suite.Equal(http.StatusTooManyRequests, suite.suspend(suite.testAccounts["local_account_2"].ID))
+}
+
+func (suite *AccountActionTestSuite) TestAccountActionDeleteLimitRejectedNotCounted() {
+	config.SetAccountsAdminDeleteLimit(2)
+
+	// Rejected suspensions don't
+	// count towards the limit.
+	suite.Equal(http.StatusNotFound, suite.suspend("01H3S1VJ7Z8K5N3R2M4P6Q8T0W"))
+	suite.Equal(http.StatusBadRequest, suite.suspend(suite.testAccounts["admin_account"].ID))
+
+	suite.Equal(http.StatusOK, suite.suspend(suite.testAccounts["remote_account_1"].ID))
+	suite.Equal(http.StatusOK, suite.suspend(suite.testAccounts["remote_account_2"].ID))
+	suite.Equal(http.StatusTooManyRequests, suite.suspend(suite.testAccounts["local_account_2"].ID))
+}
+
+func (suite *AccountActionTestSuite) TestAccountActionDeleteLimitDisabled() {
+	config.SetAccountsAdminDeleteLimit(0)
+
+	suite.Equal(http.StatusOK, suite.suspend(suite.testAccounts["remote_account_1"].ID))
+	suite.Equal(http.StatusOK, suite.suspend(suite.testAccounts["remote_account_2"].ID))
+	suite.Equal(http.StatusOK, suite.suspend(suite.testAccounts["local_account_2"].ID))
+}
+
+func TestAccountActionTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountActionTestSuite))
+}
@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AccountDeleteCheckTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountDeleteCheckTestSuite) TestAccountDeleteCheckLeftovers() {
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["local_account_1"]
+
+	// Mark the account as deleted without
+	// actually removing any of its data.
+	targetAccount.SuspendedAt = time.Now()
+	if err := suite.db.UpdateAccount(context.Background(), targetAccount, "suspended_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.AccountsDeleteCheckPath, "")
+	ctx.AddParam(admin.IDKey, targetAccount.ID)
+
+	suite.adminModule.AccountDeleteCheckGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	check := &apimodel.AdminAccountDeleteCheck{}
+	if err := json.Unmarshal(b, check); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(targetAccount.ID, check.AccountID)
+	suite.False(check.Complete)
+	suite.NotZero(check.Leftovers["statuses"])
+	suite.NotZero(check.Leftovers["tokens"])
+}
+
+func (suite *AccountDeleteCheckTestSuite) TestAccountDeleteCheckNotDeleted() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.AccountsDeleteCheckPath, "")
+	ctx.AddParam(admin.IDKey, suite.testAccounts["local_account_1"].ID)
+
+	suite.adminModule.AccountDeleteCheckGETHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func TestAccountDeleteCheckTestSuite(t *testing.T) {
+	suite.Run(t, &AccountDeleteCheckTestSuite{})
+}
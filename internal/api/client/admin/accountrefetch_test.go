@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+)
+
+type AccountRefetchTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountRefetchTestSuite) TestAccountRefetch() {
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, nil, admin.AccountsRefetchPath, "")
+	ctx.AddParam(admin.IDKey, targetAccount.ID)
+
+	suite.adminModule.AccountRefetchPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	// The account should have just been fetched.
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(time.Now(), dbAccount.FetchedAt, time.Minute)
+}
+
+func (suite *AccountRefetchTestSuite) TestAccountRefetchLocalAccount() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, nil, admin.AccountsRefetchPath, "")
+	ctx.AddParam(admin.IDKey, suite.testAccounts["local_account_1"].ID)
+
+	suite.adminModule.AccountRefetchPOSTHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *AccountRefetchTestSuite) TestAccountRefetchNotFound() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, nil, admin.AccountsRefetchPath, "")
+	ctx.AddParam(admin.IDKey, "01H2SBQ5XQ9J4Z1V5YF6T8M3RC")
+
+	suite.adminModule.AccountRefetchPOSTHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestAccountRefetchTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountRefetchTestSuite))
+}
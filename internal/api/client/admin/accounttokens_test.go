@@ -0,0 +1,158 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AccountTokensTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountTokensTestSuite) TestAccountTokensGet() {
+	targetAccount := suite.testAccounts["local_account_1"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.AccountsTokensPath, "")
+	ctx.AddParam(admin.IDKey, targetAccount.ID)
+
+	suite.adminModule.AccountTokensGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tokens := []*apimodel.AdminToken{}
+	if err := json.Unmarshal(b, &tokens); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only the access token should be returned,
+	// not the unexchanged authorization code.
+	suite.Len(tokens, 1)
+	suite.Equal(suite.testTokens["local_account_1"].ID, tokens[0].ID)
+	suite.Equal("read write follow push", tokens[0].Scope)
+	suite.Equal("really cool gts application", tokens[0].ClientName)
+	suite.Nil(tokens[0].LastUsedAt)
+	suite.Nil(tokens[0].IPAddress)
+}
+
+func (suite *AccountTokensTestSuite) TestAccountTokensGetRevokedOrExpired() {
+	targetAccount := suite.testAccounts["local_account_1"]
+	token := suite.testTokens["local_account_1"]
+
+	for _, update := range []func(*gtsmodel.Token) string{
+		func(t *gtsmodel.Token) string {
+			t.RevokedAt = time.Now()
+			return "revoked_at"
+		},
+		func(t *gtsmodel.Token) string {
+			t.AccessExpiresAt = time.Now().Add(-time.Hour)
+			return "access_expires_at"
+		},
+	} {
+		t := new(gtsmodel.Token)
+		*t = *token
+		column := update(t)
+		if err := suite.db.UpdateByID(context.Background(), t, t.ID, column); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		recorder := httptest.NewRecorder()
+		ctx := suite.newContext(recorder, http.MethodGet, nil, admin.AccountsTokensPath, "")
+		ctx.AddParam(admin.IDKey, targetAccount.ID)
+
+		suite.adminModule.AccountTokensGETHandler(ctx)
+		suite.Equal(http.StatusOK, recorder.Code)
+
+		tokens := []*apimodel.AdminToken{}
+		if err := json.NewDecoder(recorder.Body).Decode(&tokens); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		// The token is no longer
+		// usable, so isn't shown.
+		suite.Empty(tokens)
+
+		// Put the token back as it was.
+		if err := suite.db.UpdateByID(context.Background(), token, token.ID, column); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+}
+
+func (suite *AccountTokensTestSuite) TestAccountTokensGetRemoteAccount() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.AccountsTokensPath, "")
+	ctx.AddParam(admin.IDKey, suite.testAccounts["remote_account_1"].ID)
+
+	suite.adminModule.AccountTokensGETHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (suite *AccountTokensTestSuite) TestAccountTokenDelete() {
+	targetAccount := suite.testAccounts["local_account_1"]
+	token := suite.testTokens["local_account_1"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, nil, admin.AccountsTokenPath, "")
+	ctx.AddParam(admin.IDKey, targetAccount.ID)
+	ctx.AddParam(admin.TokenIDKey, token.ID)
+
+	suite.adminModule.AccountTokenDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	// Token should be gone from the database.
+	err := suite.db.GetByID(context.Background(), token.ID, &gtsmodel.Token{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AccountTokensTestSuite) TestAccountTokenDeleteWrongAccount() {
+	// Token belongs to local_account_2, not local_account_1.
+	token := suite.testTokens["local_account_2"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, nil, admin.AccountsTokenPath, "")
+	ctx.AddParam(admin.IDKey, suite.testAccounts["local_account_1"].ID)
+	ctx.AddParam(admin.TokenIDKey, token.ID)
+
+	suite.adminModule.AccountTokenDELETEHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+
+	// Token should still be there.
+	err := suite.db.GetByID(context.Background(), token.ID, &gtsmodel.Token{})
+	suite.NoError(err)
+}
+
+func TestAccountTokensTestSuite(t *testing.T) {
+	suite.Run(t, &AccountTokensTestSuite{})
+}
@@ -25,37 +25,72 @@ import (
 )
 
 const (
-	BasePath               = "/v1/admin"
-	EmojiPath              = BasePath + "/custom_emojis"
-	EmojiPathWithID        = EmojiPath + "/:" + IDKey
-	EmojiCategoriesPath    = EmojiPath + "/categories"
-	DomainBlocksPath       = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID = DomainBlocksPath + "/:" + IDKey
-	AccountsPath           = BasePath + "/accounts"
-	AccountsPathWithID     = AccountsPath + "/:" + IDKey
-	AccountsActionPath     = AccountsPathWithID + "/action"
-	MediaCleanupPath       = BasePath + "/media_cleanup"
-	MediaRefetchPath       = BasePath + "/media_refetch"
-	ReportsPath            = BasePath + "/reports"
-	ReportsPathWithID      = ReportsPath + "/:" + IDKey
-	ReportsResolvePath     = ReportsPathWithID + "/resolve"
-	EmailPath              = BasePath + "/email"
-	EmailTestPath          = EmailPath + "/test"
+	BasePath                = "/v1/admin"
+	EmojiPath               = BasePath + "/custom_emojis"
+	EmojiPathWithID         = EmojiPath + "/:" + IDKey
+	EmojiCategoriesPath     = EmojiPath + "/categories"
+	DomainBlocksPath        = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + IDKey
+	DomainCachePurgePath    = BasePath + "/domain_cache_purge"
+	DomainStatsPath         = BasePath + "/domain_stats"
+	InstancesPath           = BasePath + "/instances"
+	InstancesPathWithDomain = InstancesPath + "/:" + DomainKey
+	AccountsPath            = BasePath + "/accounts"
+	AccountsPathWithID      = AccountsPath + "/:" + IDKey
+	AccountsActionPath      = AccountsPathWithID + "/action"
+	AccountsRefetchPath     = AccountsPathWithID + "/refetch"
+	AccountsTokensPath      = AccountsPathWithID + "/tokens"
+	AccountsTokenPath       = AccountsTokensPath + "/:" + TokenIDKey
+	AccountsDeleteCheckPath = AccountsPathWithID + "/delete_check"
+	AccountsApprovePath     = AccountsPathWithID + "/approve"
+	AccountsRejectPath      = AccountsPathWithID + "/reject"
+	SuspendedCleanupPath    = BasePath + "/suspended_accounts_cleanup"
+	MediaCleanupPath        = BasePath + "/media_cleanup"
+	MediaPruneBytesPath     = BasePath + "/media_prune_bytes"
+	MediaRefetchPath        = BasePath + "/media_refetch"
+	MediaErrorsPath         = BasePath + "/media_errors"
+	MediaErrorsRefetchPath  = MediaErrorsPath + "/refetch"
+	MediaUsagePath          = BasePath + "/media_usage"
+	StorageGCPath           = BasePath + "/storage_gc"
+	ReportsPath             = BasePath + "/reports"
+	ReportsPathWithID       = ReportsPath + "/:" + IDKey
+	ReportsResolvePath      = ReportsPathWithID + "/resolve"
+	EmailPath               = BasePath + "/email"
+	EmailTestPath           = EmailPath + "/test"
+	EmailTemplatesPath      = BasePath + "/email_templates"
+	EmailTemplatePathName   = EmailTemplatesPath + "/:" + NameKey
+	InstancePoliciesPath    = BasePath + "/instance_policies"
+	InstancePolicyPathType  = InstancePoliciesPath + "/:" + TypeKey
+	SSOConfigPath           = BasePath + "/sso_config"
+	FederationStatePath     = BasePath + "/federation/state"
+	FederationActivityPath  = BasePath + "/federation/activity"
+	TagsPath                = BasePath + "/tags"
+	TagsPathWithName        = TagsPath + "/:" + NameKey
+	StatusesPath            = BasePath + "/statuses"
+	StatusesPathWithID      = StatusesPath + "/:" + IDKey
+	SignupsPath             = BasePath + "/signups"
+	SignupsCountPath        = SignupsPath + "/count"
 
 	ExportQueryKey        = "export"
 	ImportQueryKey        = "import"
 	IDKey                 = "id"
+	TokenIDKey            = "token_id"
+	NameKey               = "name"
+	TypeKey               = "type"
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
 	MinShortcodeDomainKey = "min_shortcode_domain"
 	LimitKey              = "limit"
 	DomainQueryKey        = "domain"
+	DomainKey             = "domain"
+	OffsetKey             = "offset"
 	ResolvedKey           = "resolved"
 	AccountIDKey          = "account_id"
 	TargetAccountIDKey    = "target_account_id"
 	MaxIDKey              = "max_id"
 	SinceIDKey            = "since_id"
 	MinIDKey              = "min_id"
+	PendingKey            = "pending"
 )
 
 type Module struct {
@@ -81,14 +116,29 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
 	attachHandler(http.MethodGet, DomainBlocksPath, m.DomainBlocksGETHandler)
 	attachHandler(http.MethodGet, DomainBlocksPathWithID, m.DomainBlockGETHandler)
+	attachHandler(http.MethodPut, DomainBlocksPathWithID, m.DomainBlockPUTHandler)
 	attachHandler(http.MethodDelete, DomainBlocksPathWithID, m.DomainBlockDELETEHandler)
 
 	// accounts stuff
+	attachHandler(http.MethodGet, AccountsPath, m.AccountsGETHandler)
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
+	attachHandler(http.MethodPost, AccountsRefetchPath, m.AccountRefetchPOSTHandler)
+	attachHandler(http.MethodGet, AccountsTokensPath, m.AccountTokensGETHandler)
+	attachHandler(http.MethodDelete, AccountsTokenPath, m.AccountTokenDELETEHandler)
+	attachHandler(http.MethodGet, AccountsDeleteCheckPath, m.AccountDeleteCheckGETHandler)
+	attachHandler(http.MethodGet, SignupsCountPath, m.SignupsCountGETHandler)
+	attachHandler(http.MethodPost, AccountsApprovePath, m.AccountApprovePOSTHandler)
+	attachHandler(http.MethodPost, AccountsRejectPath, m.AccountRejectPOSTHandler)
+	attachHandler(http.MethodPost, SuspendedCleanupPath, m.SuspendedCleanupPOSTHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
+	attachHandler(http.MethodPost, MediaPruneBytesPath, m.MediaPruneBytesPOSTHandler)
 	attachHandler(http.MethodPost, MediaRefetchPath, m.MediaRefetchPOSTHandler)
+	attachHandler(http.MethodGet, MediaErrorsPath, m.MediaErrorsGETHandler)
+	attachHandler(http.MethodPost, MediaErrorsRefetchPath, m.MediaErrorsRefetchPOSTHandler)
+	attachHandler(http.MethodGet, MediaUsagePath, m.MediaUsageGETHandler)
+	attachHandler(http.MethodPost, StorageGCPath, m.StorageGCPOSTHandler)
 
 	// reports stuff
 	attachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
@@ -97,4 +147,27 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	// email stuff
 	attachHandler(http.MethodPost, EmailTestPath, m.EmailTestPOSTHandler)
+	attachHandler(http.MethodGet, EmailTemplatesPath, m.EmailTemplatesGETHandler)
+	attachHandler(http.MethodGet, EmailTemplatePathName, m.EmailTemplateGETHandler)
+	attachHandler(http.MethodPut, EmailTemplatePathName, m.EmailTemplatePUTHandler)
+
+	// instance policy stuff
+	attachHandler(http.MethodPut, InstancePolicyPathType, m.InstancePolicyPUTHandler)
+
+	// sso stuff
+	attachHandler(http.MethodGet, SSOConfigPath, m.SSOConfigGETHandler)
+
+	// federation stuff
+	attachHandler(http.MethodGet, FederationStatePath, m.FederationStateGETHandler)
+	attachHandler(http.MethodGet, FederationActivityPath, m.FederationActivityGETHandler)
+	attachHandler(http.MethodPost, DomainCachePurgePath, m.DomainCachePurgePOSTHandler)
+	attachHandler(http.MethodGet, DomainStatsPath, m.DomainStatsGETHandler)
+	attachHandler(http.MethodGet, InstancesPathWithDomain, m.InstanceGETHandler)
+
+	// tag stuff
+	attachHandler(http.MethodGet, TagsPathWithName, m.TagGETHandler)
+	attachHandler(http.MethodPut, TagsPathWithName, m.TagPUTHandler)
+
+	// status stuff
+	attachHandler(http.MethodDelete, StatusesPathWithID, m.StatusDELETEHandler)
 }
@@ -47,6 +47,7 @@ type AdminStandardTestSuite struct {
 	storage      *storage.Driver
 	mediaManager *media.Manager
 	federator    federation.Federator
+	httpClient   *testrig.MockHTTPClient
 	processor    *processing.Processor
 	emailSender  email.Sender
 	sentEmails   map[string]string
@@ -100,7 +101,8 @@ func (suite *AdminStandardTestSuite) SetupTest() {
 	)
 
 	suite.mediaManager = testrig.NewTestMediaManager(&suite.state)
-	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(nil, "../../../../testrig/media")), suite.mediaManager)
+	suite.httpClient = testrig.NewMockHTTPClient(nil, "../../../../testrig/media")
+	suite.federator = testrig.NewTestFederator(&suite.state, testrig.NewTestTransportController(&suite.state, suite.httpClient), suite.mediaManager)
 	suite.sentEmails = make(map[string]string)
 	suite.emailSender = testrig.NewEmailSender("../../../../web/template/", suite.sentEmails)
 	suite.processor = testrig.NewTestProcessor(&suite.state, suite.federator, suite.emailSender, suite.mediaManager)
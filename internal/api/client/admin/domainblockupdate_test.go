@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type DomainBlockUpdateTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainBlockUpdateTestSuite) TestDomainBlockUpdate() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPut, []byte("obfuscate=true&public_comment=they+are+still+reply+guys"), admin.DomainBlocksPathWithID, "application/x-www-form-urlencoded")
+	ctx.AddParam(admin.IDKey, "01FF22EQM7X8E3RX1XGPN7S87D")
+
+	suite.adminModule.DomainBlockPUTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	domainBlock := &apimodel.DomainBlock{}
+	if err := json.Unmarshal(b, domainBlock); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("replyguys.com", domainBlock.Domain.Domain)
+	suite.True(domainBlock.Obfuscate)
+	suite.Equal("they are still reply guys", domainBlock.PublicComment)
+
+	dbBlock, err := suite.db.GetDomainBlock(context.Background(), "replyguys.com")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbBlock.Obfuscate)
+	suite.Equal("they are still reply guys", dbBlock.PublicComment)
+	suite.Equal("i blocked this domain because they keep replying with pushy + unwarranted linux advice", dbBlock.PrivateComment)
+}
+
+func (suite *DomainBlockUpdateTestSuite) TestDomainBlockUpdateNotFound() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPut, []byte("obfuscate=true"), admin.DomainBlocksPathWithID, "application/x-www-form-urlencoded")
+	ctx.AddParam(admin.IDKey, "01HF5XSQW6DAM4NXZ4PRZHD4NP")
+
+	suite.adminModule.DomainBlockPUTHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestDomainBlockUpdateTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainBlockUpdateTestSuite))
+}
@@ -0,0 +1,174 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainStatsGETHandler swagger:operation GET /api/v1/admin/domain_stats domainStatsGet
+//
+// View counts of known accounts, statuses, and cached media per remote domain.
+//
+// Domains are ordered by number of known accounts, most first.
+// Stats are cached for 30 minutes, so they may lag slightly behind.
+//
+// The next and previous queries can be parsed from the returned Link header.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: >-
+//			Number of domains to return.
+//			If more than 100 or less than 1, will be clamped to 100.
+//		default: 20
+//		in: query
+//	-
+//		name: offset
+//		type: integer
+//		description: Number of domains to skip, for paging.
+//		default: 0
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Per-domain stats.
+//			headers:
+//				Link:
+//					type: string
+//					description: Links to the next and previous queries.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminDomainStats"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) DomainStatsGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	limit := 20
+	if limitString := c.Query(LimitKey); limitString != "" {
+		i, err := strconv.Atoi(limitString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", LimitKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+
+		// normalize
+		if i < 1 || i > 100 {
+			i = 100
+		}
+		limit = i
+	}
+
+	offset := 0
+	if offsetString := c.Query(OffsetKey); offsetString != "" {
+		i, err := strconv.Atoi(offsetString)
+		if err != nil {
+			err := fmt.Errorf("error parsing %s: %s", OffsetKey, err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+		offset = i
+	}
+
+	stats, errWithCode := m.processor.Admin().DomainStatsGet(c.Request.Context(), limit, offset)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	var nextLink, prevLink string
+	if len(stats) == limit {
+		// There may be more domains after this page.
+		nextLink = domainStatsPageLink(limit, offset+limit)
+	}
+	if offset > 0 {
+		prevOffset := offset - limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		prevLink = domainStatsPageLink(limit, prevOffset)
+	}
+
+	if linkHeader := util.LinkHeaderFromPage(prevLink, nextLink); linkHeader != "" {
+		c.Header("Link", linkHeader)
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// domainStatsPageLink returns an absolute link
+// to the domain stats page at the given offset.
+func domainStatsPageLink(limit int, offset int) string {
+	u := &url.URL{
+		Scheme:   config.GetProtocol(),
+		Host:     config.GetHost(),
+		Path:     "/api/v1/admin/domain_stats",
+		RawQuery: fmt.Sprintf("%s=%d&%s=%d", LimitKey, limit, OffsetKey, offset),
+	}
+	return u.String()
+}
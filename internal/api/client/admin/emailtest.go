@@ -76,8 +76,9 @@ import (
 //			description: >-
 //				An smtp occurred while the email attempt was in progress.
 //				Check the returned json for more information. The smtp error
-//				will be included, to help you debug communication with the
-//				smtp server.
+//				will be included, along with a hint about which smtp config
+//				field(s) are likely misconfigured, to help you debug
+//				communication with the smtp server.
 //		'500':
 //			description: internal server error
 func (m *Module) EmailTestPOSTHandler(c *gin.Context) {
@@ -110,7 +111,7 @@ func (m *Module) EmailTestPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	errWithCode := m.processor.Admin().EmailTest(c.Request.Context(), authed.Account, email.Address)
+	errWithCode := m.processor.Admin().SendTestEmail(c.Request.Context(), authed.Account, email.Address)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type InstanceGetTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *InstanceGetTestSuite) getInstance(domain string, expectedCode int) *apimodel.RemoteInstance {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.InstancesPathWithDomain, "")
+	ctx.AddParam(admin.DomainKey, domain)
+
+	suite.adminModule.InstanceGETHandler(ctx)
+	suite.Equal(expectedCode, recorder.Code)
+
+	if expectedCode != http.StatusOK {
+		return nil
+	}
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instance := &apimodel.RemoteInstance{}
+	if err := json.Unmarshal(b, instance); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return instance
+}
+
+func (suite *InstanceGetTestSuite) TestInstanceGetFetchesNodeInfo() {
+	instance := suite.getInstance("fossbros-anonymous.io", http.StatusOK)
+	suite.Equal("fossbros-anonymous.io", instance.Domain)
+	suite.Equal("mastodon", instance.SoftwareName)
+	suite.Equal("4.1.2", instance.SoftwareVersion)
+	suite.Equal(1024, instance.UserCount)
+	suite.NotNil(instance.FetchedAt)
+
+	// The snapshot should have been stored.
+	dbInstance, err := suite.db.GetInstance(context.Background(), "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("mastodon", dbInstance.SoftwareName)
+	suite.Equal(1024, dbInstance.UserCount)
+	suite.False(dbInstance.NodeInfoFetchedAt.IsZero())
+}
+
+func (suite *InstanceGetTestSuite) TestInstanceGetServesFreshSnapshot() {
+	dbInstance, err := suite.db.GetInstance(context.Background(), "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbInstance.SoftwareName = "pleroma"
+	dbInstance.SoftwareVersion = "2.5.0"
+	dbInstance.UserCount = 12
+	dbInstance.NodeInfoFetchedAt = time.Now().Add(-time.Hour)
+	if err := suite.db.UpdateInstance(context.Background(), dbInstance,
+		"software_name",
+		"software_version",
+		"user_count",
+		"node_info_fetched_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Snapshot is less than a day old,
+	// so it should be served as-is.
+	instance := suite.getInstance("fossbros-anonymous.io", http.StatusOK)
+	suite.Equal("pleroma", instance.SoftwareName)
+	suite.Equal("2.5.0", instance.SoftwareVersion)
+	suite.Equal(12, instance.UserCount)
+}
+
+func (suite *InstanceGetTestSuite) TestInstanceGetRefreshesStaleSnapshot() {
+	dbInstance, err := suite.db.GetInstance(context.Background(), "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbInstance.SoftwareName = "pleroma"
+	dbInstance.NodeInfoFetchedAt = time.Now().Add(-48 * time.Hour)
+	if err := suite.db.UpdateInstance(context.Background(), dbInstance,
+		"software_name",
+		"node_info_fetched_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instance := suite.getInstance("fossbros-anonymous.io", http.StatusOK)
+	suite.Equal("mastodon", instance.SoftwareName)
+}
+
+func (suite *InstanceGetTestSuite) TestInstanceGetUnknown() {
+	suite.getInstance("not-a-known-instance.example", http.StatusNotFound)
+}
+
+func (suite *InstanceGetTestSuite) TestInstanceGetNotAdmin() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.InstancesPathWithDomain, "")
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.AddParam(admin.DomainKey, "fossbros-anonymous.io")
+
+	suite.adminModule.InstanceGETHandler(ctx)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func TestInstanceGetTestSuite(t *testing.T) {
+	suite.Run(t, &InstanceGetTestSuite{})
+}
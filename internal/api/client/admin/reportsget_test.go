@@ -242,6 +242,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       },
       "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
     },
+    "target_type": "account",
+    "target_tag": null,
     "assigned_account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
       "username": "admin",
@@ -444,6 +446,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "fields": []
       }
     },
+    "target_type": "account",
+    "target_tag": null,
     "assigned_account": null,
     "action_taken_by_account": null,
     "statuses": [
@@ -656,6 +660,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "fields": []
       }
     },
+    "target_type": "account",
+    "target_tag": null,
     "assigned_account": null,
     "action_taken_by_account": null,
     "statuses": [
@@ -868,6 +874,8 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "fields": []
       }
     },
+    "target_type": "account",
+    "target_tag": null,
     "assigned_account": null,
     "action_taken_by_account": null,
     "statuses": [
@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type StatusDeleteTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *StatusDeleteTestSuite) deleteStatus(statusID string, expectedCode int) *apimodel.Status {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, nil, admin.StatusesPathWithID, "application/json")
+	ctx.AddParam(admin.IDKey, statusID)
+
+	suite.adminModule.StatusDELETEHandler(ctx)
+	suite.Equal(expectedCode, recorder.Code)
+
+	if expectedCode != http.StatusOK {
+		return nil
+	}
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status := &apimodel.Status{}
+	if err := json.Unmarshal(b, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+func (suite *StatusDeleteTestSuite) TestDeleteRemoteStatus() {
+	ctx := context.Background()
+	testStatus := suite.testStatuses["remote_account_1_status_1"]
+	testAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	status := suite.deleteStatus(testStatus.ID, http.StatusOK)
+	suite.Equal(testStatus.ID, status.ID)
+
+	// Status should be gone once the purge is processed...
+	if !suite.Eventually(func() bool {
+		_, err := suite.db.GetStatusByID(ctx, testStatus.ID)
+		return errors.Is(err, db.ErrNoEntries)
+	}, 5*time.Second, 10*time.Millisecond) {
+		suite.FailNow("timed out waiting for status to be purged")
+	}
+
+	// ...along with its attachment and cached files.
+	_, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	for _, path := range []string{testAttachment.File.Path, testAttachment.Thumbnail.Path} {
+		hasKey, err := suite.storage.Has(ctx, path)
+		suite.NoError(err)
+		suite.False(hasKey)
+	}
+
+	// Nothing should have been federated, since
+	// this is purely a local purge of a remote
+	// status. Give the workers a moment first.
+	time.Sleep(1 * time.Second)
+	suite.httpClient.SentMessages.Range(func(key any, _ any) bool {
+		suite.Failf("unexpected federated message", "sent to %s", key)
+		return true
+	})
+}
+
+func (suite *StatusDeleteTestSuite) TestDeleteLocalStatus() {
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+
+	suite.deleteStatus(testStatus.ID, http.StatusBadRequest)
+
+	// Local status should still be there.
+	_, err := suite.db.GetStatusByID(context.Background(), testStatus.ID)
+	suite.NoError(err)
+}
+
+func (suite *StatusDeleteTestSuite) TestDeleteStatusNotFound() {
+	suite.deleteStatus("01H3EBV4XKQ8M2Z7N5W9R1T6PC", http.StatusNotFound)
+}
+
+func TestStatusDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &StatusDeleteTestSuite{})
+}
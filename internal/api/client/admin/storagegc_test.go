@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type StorageGCTestSuite struct {
+	AdminStandardTestSuite
+}
+
+const orphanPath = "01GJQJ1YD9QCHCE12GG0EYHVNW/attachment/original/01GJQJ2AYM1VKSRW96YVAJ3NK3.gif"
+
+func (suite *StorageGCTestSuite) storageGC(body string) *apimodel.AdminStorageGC {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(body), admin.StorageGCPath, "application/json")
+
+	suite.adminModule.StorageGCPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	resp := &apimodel.AdminStorageGC{}
+	if err := json.Unmarshal(b, resp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return resp
+}
+
+func (suite *StorageGCTestSuite) TestStorageGCNoConfirm() {
+	data := []byte("not a real gif")
+	if _, err := suite.storage.Put(context.Background(), orphanPath, data); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Without confirmation, only a dry run is performed.
+	resp := suite.storageGC("{}")
+	suite.True(resp.DryRun)
+	suite.Equal(1, resp.Objects)
+	suite.EqualValues(len(data), resp.Bytes)
+
+	hasKey, err := suite.storage.Has(context.Background(), orphanPath)
+	suite.NoError(err)
+	suite.True(hasKey)
+}
+
+func (suite *StorageGCTestSuite) TestStorageGCConfirm() {
+	data := []byte("not a real gif")
+	if _, err := suite.storage.Put(context.Background(), orphanPath, data); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	resp := suite.storageGC(`{"confirm":true}`)
+	suite.False(resp.DryRun)
+	suite.Equal(1, resp.Objects)
+	suite.EqualValues(len(data), resp.Bytes)
+
+	hasKey, err := suite.storage.Has(context.Background(), orphanPath)
+	suite.NoError(err)
+	suite.False(hasKey)
+
+	// Attachments that do have a db entry are left alone.
+	attachment := suite.testAttachments["local_account_1_status_1_attachment_1"]
+	hasKey, err = suite.storage.Has(context.Background(), attachment.File.Path)
+	suite.NoError(err)
+	suite.True(hasKey)
+}
+
+func TestStorageGCTestSuite(t *testing.T) {
+	suite.Run(t, &StorageGCTestSuite{})
+}
@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type TagsTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *TagsTestSuite) TestTagGet() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.TagsPathWithName, "")
+	ctx.AddParam(admin.NameKey, "Welcome")
+
+	suite.adminModule.TagGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tag := &apimodel.AdminTag{}
+	if err := json.Unmarshal(b, tag); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("welcome", tag.Name)
+	suite.True(tag.Usable)
+	suite.True(tag.Listable)
+	suite.True(tag.Trendable)
+	suite.False(tag.RequiresReview)
+}
+
+func (suite *TagsTestSuite) TestTagGetNotFound() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.TagsPathWithName, "")
+	ctx.AddParam(admin.NameKey, "thisisnotatag")
+
+	suite.adminModule.TagGETHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func (suite *TagsTestSuite) TestTagUpdate() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPut, []byte("usable=false&trendable=false"), admin.TagsPathWithName, "application/x-www-form-urlencoded")
+	ctx.AddParam(admin.NameKey, "welcome")
+
+	suite.adminModule.TagPUTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	dbTag, err := suite.db.GetTagByName(context.Background(), "welcome")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*dbTag.Useable)
+	suite.False(*dbTag.Trendable)
+	suite.True(*dbTag.Listable)
+}
+
+func (suite *TagsTestSuite) TestTagUpdateNothingSet() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPut, []byte{}, admin.TagsPathWithName, "application/x-www-form-urlencoded")
+	ctx.AddParam(admin.NameKey, "welcome")
+
+	suite.adminModule.TagPUTHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func TestTagsTestSuite(t *testing.T) {
+	suite.Run(t, new(TagsTestSuite))
+}
@@ -24,8 +24,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
-// BasePath is the base path for this api module, excluding the api prefix
-const BasePath = "/v1/apps"
+const (
+	// BasePath is the base path for this api module, excluding the api prefix
+	BasePath = "/v1/apps"
+	// VerifyPath is for verifying the credentials of the requesting app
+	VerifyPath = BasePath + "/verify_credentials"
+	// ScopesPath is for listing the OAuth scopes recognized by this instance
+	ScopesPath = BasePath + "/scopes"
+)
 
 type Module struct {
 	processor *processing.Processor
@@ -39,4 +45,6 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, BasePath, m.AppsPOSTHandler)
+	attachHandler(http.MethodGet, VerifyPath, m.AppVerifyGETHandler)
+	attachHandler(http.MethodGet, ScopesPath, m.AppScopesGETHandler)
 }
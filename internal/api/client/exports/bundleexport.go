@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// BundleExportGETHandler swagger:operation GET /api/v1/exports/bundle.zip bundleExport
+//
+// Export a zip of the requesting account's follows and blocks.
+//
+// The zip contains following_accounts.csv and blocked_accounts.csv, in the
+// same formats as the follows.csv and blocks.csv exports, so that a user can
+// take their social graph with them before deleting their account.
+//
+//	---
+//	tags:
+//	- exports
+//
+//	produces:
+//	- application/zip
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:follows
+//		- read:blocks
+//
+//	responses:
+//		'200':
+//			description: Zip of exports.
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) BundleExportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.AppZip); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	export, err := m.processor.Account().ExportAccountBundle(c.Request.Context(), authed.Account)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err), m.processor.InstanceGetV1)
+		return
+	}
+	defer export.Close()
+
+	c.DataFromReader(http.StatusOK, -1, string(apiutil.AppZip), export, map[string]string{
+		"Content-Disposition": `attachment; filename="export.zip"`,
+	})
+}
@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	// BasePath is the base URI path for serving exports, minus the api prefix.
+	BasePath = "/v1/exports"
+	// FollowsPath is the path for exporting follows as CSV.
+	FollowsPath = BasePath + "/follows.csv"
+	// BlocksPath is the path for exporting blocks as CSV.
+	BlocksPath = BasePath + "/blocks.csv"
+	// BundlePath is the path for exporting all of the above as one zip.
+	BundlePath = BasePath + "/bundle.zip"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, FollowsPath, m.FollowsExportGETHandler)
+	attachHandler(http.MethodGet, BlocksPath, m.BlocksExportGETHandler)
+	attachHandler(http.MethodGet, BundlePath, m.BundleExportGETHandler)
+}
@@ -25,7 +25,8 @@ import (
 )
 
 const (
-	BasePath = "/v1/featured_tags"
+	BasePath        = "/v1/featured_tags"
+	SuggestionsPath = BasePath + "/suggestions"
 )
 
 type Module struct {
@@ -40,4 +41,5 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.FeaturedTagsGETHandler)
+	attachHandler(http.MethodGet, SuggestionsPath, m.FeaturedTagSuggestionsGETHandler)
 }
@@ -36,6 +36,15 @@ const (
 	AuthorizePath = BasePathWithID + "/authorize"
 	// RejectPath is used for rejecting follow requests
 	RejectPath = BasePathWithID + "/reject"
+	// OutboundPath is used for viewing pending follow requests made by the requesting account
+	OutboundPath = BasePath + "/outbound"
+
+	// LimitKey is for setting the return amount limit
+	LimitKey = "limit"
+	// MaxIDKey is for specifying the maximum ID of the follow request to retrieve
+	MaxIDKey = "max_id"
+	// MinIDKey is for specifying the minimum ID of the follow request to retrieve
+	MinIDKey = "min_id"
 )
 
 type Module struct {
@@ -50,6 +59,7 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.FollowRequestGETHandler)
+	attachHandler(http.MethodGet, OutboundPath, m.FollowRequestOutboundGETHandler)
 	attachHandler(http.MethodPost, AuthorizePath, m.FollowRequestAuthorizePOSTHandler)
 	attachHandler(http.MethodPost, RejectPath, m.FollowRequestRejectPOSTHandler)
 }
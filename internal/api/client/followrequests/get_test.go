@@ -30,6 +30,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -99,6 +100,95 @@ func (suite *GetTestSuite) TestGet() {
 ]`, dst.String())
 }
 
+func (suite *GetTestSuite) TestGetOutbound() {
+	requestingAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	// put two follow requests in the database, one
+	// inbound and one outbound; we should only get
+	// the outbound one back
+	for _, fr := range []*gtsmodel.FollowRequest{
+		{
+			ID:              "01FJ1S8DX3STJJ6CEYPMZ1M0R3",
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+			URI:             fmt.Sprintf("%s/follow/01FJ1S8DX3STJJ6CEYPMZ1M0R3", requestingAccount.URI),
+			AccountID:       requestingAccount.ID,
+			TargetAccountID: suite.testAccounts["remote_account_2"].ID,
+		},
+		{
+			ID:              "01FJ1S8DX3STJJ6CEYPMZ1M0R4",
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+			URI:             fmt.Sprintf("%s/follow/01FJ1S8DX3STJJ6CEYPMZ1M0R4", suite.testAccounts["remote_account_1"].URI),
+			AccountID:       suite.testAccounts["remote_account_1"].ID,
+			TargetAccountID: requestingAccount.ID,
+		},
+	} {
+		if err := suite.db.Put(ctx, fr); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	ginCtx := suite.newContext(recorder, http.MethodGet, []byte{}, "/api/v1/follow_requests/outbound", "")
+
+	// call the handler
+	suite.followRequestModule.FollowRequestOutboundGETHandler(ginCtx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	accounts := []*apimodel.Account{}
+	if err := json.Unmarshal(b, &accounts); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 1)
+	suite.Equal(suite.testAccounts["remote_account_2"].ID, accounts[0].ID)
+	suite.Equal(`<http://localhost:8080/api/v1/follow_requests/outbound?limit=40&max_id=01FJ1S8DX3STJJ6CEYPMZ1M0R3>; rel="next", <http://localhost:8080/api/v1/follow_requests/outbound?limit=40&min_id=01FJ1S8DX3STJJ6CEYPMZ1M0R3>; rel="prev"`, result.Header.Get("Link"))
+}
+
+func (suite *GetTestSuite) TestGetOutboundNegativeLimit() {
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	fr := &gtsmodel.FollowRequest{
+		ID:              "01FJ1S8DX3STJJ6CEYPMZ1M0R3",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		URI:             fmt.Sprintf("%s/follow/01FJ1S8DX3STJJ6CEYPMZ1M0R3", requestingAccount.URI),
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: suite.testAccounts["remote_account_2"].ID,
+	}
+	if err := suite.db.Put(context.Background(), fr); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	recorder := httptest.NewRecorder()
+	ginCtx := suite.newContext(recorder, http.MethodGet, []byte{}, "/api/v1/follow_requests/outbound?limit=-5", "")
+
+	// call the handler; limit
+	// should be clamped to 1
+	suite.followRequestModule.FollowRequestOutboundGETHandler(ginCtx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	accounts := []*apimodel.Account{}
+	if err := json.Unmarshal(b, &accounts); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(accounts, 1)
+	suite.Equal(`<http://localhost:8080/api/v1/follow_requests/outbound?limit=1&max_id=01FJ1S8DX3STJJ6CEYPMZ1M0R3>; rel="next", <http://localhost:8080/api/v1/follow_requests/outbound?limit=1&min_id=01FJ1S8DX3STJJ6CEYPMZ1M0R3>; rel="prev"`, result.Header.Get("Link"))
+}
+
 func TestGetTestSuite(t *testing.T) {
 	suite.Run(t, &GetTestSuite{})
 }
@@ -28,6 +28,10 @@ const (
 	InstanceInformationPathV1 = "/v1/instance"
 	InstanceInformationPathV2 = "/v2/instance"
 	InstancePeersPath         = InstanceInformationPathV1 + "/peers"
+	InstanceActivityPath      = InstanceInformationPathV1 + "/activity"
+	InstanceConfigurationPath = InstanceInformationPathV1 + "/configuration"
+	InstancePrivacyPolicyPath = InstanceInformationPathV1 + "/privacy_policy"
+	InstanceTermsPath         = InstanceInformationPathV1 + "/terms_of_service"
 	PeersFilterKey            = "filter" // PeersFilterKey is used to provide filters to /api/v1/instance/peers
 )
 
@@ -47,4 +51,8 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	attachHandler(http.MethodPatch, InstanceInformationPathV1, m.InstanceUpdatePATCHHandler)
 	attachHandler(http.MethodGet, InstancePeersPath, m.InstancePeersGETHandler)
+	attachHandler(http.MethodGet, InstanceActivityPath, m.InstanceActivityGETHandler)
+	attachHandler(http.MethodGet, InstanceConfigurationPath, m.InstanceConfigurationGETHandler)
+	attachHandler(http.MethodGet, InstancePrivacyPolicyPath, m.InstancePrivacyPolicyGETHandler)
+	attachHandler(http.MethodGet, InstanceTermsPath, m.InstanceTermsOfServiceGETHandler)
 }
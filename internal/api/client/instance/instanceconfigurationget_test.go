@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package instance_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
+)
+
+type InstanceConfigurationGetTestSuite struct {
+	InstanceStandardTestSuite
+}
+
+func (suite *InstanceConfigurationGetTestSuite) TestInstanceConfigurationGet() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, instance.InstanceConfigurationPath, nil, "", false)
+
+	suite.instanceModule.InstanceConfigurationGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+	dst := new(bytes.Buffer)
+	err = json.Indent(dst, b, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "statuses": {
+    "max_characters": 5000,
+    "max_media_attachments": 6,
+    "characters_reserved_per_url": 25
+  },
+  "media_attachments": {
+    "supported_mime_types": [
+      "image/jpeg",
+      "image/gif",
+      "image/png",
+      "image/webp",
+      "video/mp4"
+    ],
+    "image_size_limit": 10485760,
+    "image_matrix_limit": 16777216,
+    "video_size_limit": 41943040,
+    "video_frame_rate_limit": 60,
+    "video_matrix_limit": 16777216
+  },
+  "polls": {
+    "max_options": 6,
+    "max_characters_per_option": 50,
+    "min_expiration": 300,
+    "max_expiration": 2629746
+  }
+}`, dst.String())
+}
+
+func TestInstanceConfigurationGetTestSuite(t *testing.T) {
+	suite.Run(t, new(InstanceConfigurationGetTestSuite))
+}
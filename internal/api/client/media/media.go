@@ -31,6 +31,11 @@ const (
 	APIv2            = "v2"                            // APIV2 corresponds to version 2 of the api
 	BasePath         = "/:" + APIVersionKey + "/media" // BasePath is the base API path for making media requests through v1 or v2 of the api (for mastodon API compatibility)
 	AttachmentWithID = BasePath + "/:" + IDKey         // BasePathWithID corresponds to a media attachment with the given ID
+
+	MaxIDKey               = "max_id"                          // MaxIDKey is the key for paging through attachments by ID
+	LimitKey               = "limit"                           // LimitKey is the key for limiting the number of attachments returned
+	MissingDescriptionPath = BasePath + "/missing_description" // MissingDescriptionPath is for listing attachments that have no alt-text
+	DescriptionsPath       = BasePath + "/descriptions"        // DescriptionsPath is for setting the alt-text of multiple attachments at once
 )
 
 type Module struct {
@@ -47,4 +52,6 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, BasePath, m.MediaCreatePOSTHandler)
 	attachHandler(http.MethodGet, AttachmentWithID, m.MediaGETHandler)
 	attachHandler(http.MethodPut, AttachmentWithID, m.MediaPUTHandler)
+	attachHandler(http.MethodGet, MissingDescriptionPath, m.MediaMissingDescriptionGETHandler)
+	attachHandler(http.MethodPost, DescriptionsPath, m.MediaDescriptionsPOSTHandler)
 }
@@ -31,6 +31,9 @@ const (
 	// BasePathV2 is the base path for serving v2 of the search API, minus the 'api' prefix
 	BasePathV2 = "/v2/search"
 
+	// HashtagSearchPath is the path for searching hashtags to autocomplete, minus the 'api' prefix
+	HashtagSearchPath = "/v1/hashtag_search"
+
 	// AccountIDKey -- If provided, statuses returned will be authored only by this account
 	AccountIDKey = "account_id"
 	// MaxIDKey -- Return results older than this id
@@ -49,14 +52,14 @@ const (
 	LimitKey = "limit"
 	// OffsetKey -- Offset in search results. Used for pagination. Defaults to 0.
 	OffsetKey = "offset"
-	// FollowingKey -- Only include accounts that the user is following. Defaults to false.
+	// FollowingKey -- Only include accounts (and statuses by accounts) that the user is following. Defaults to false.
 	FollowingKey = "following"
 
-	// TypeAccounts --
+	// TypeAccounts -- Include accounts in search results
 	TypeAccounts = "accounts"
-	// TypeHashtags --
+	// TypeHashtags -- Include hashtags in search results
 	TypeHashtags = "hashtags"
-	// TypeStatuses --
+	// TypeStatuses -- Include statuses in search results
 	TypeStatuses = "statuses"
 )
 
@@ -73,4 +76,5 @@ func New(processor *processing.Processor) *Module {
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePathV1, m.SearchGETHandler)
 	attachHandler(http.MethodGet, BasePathV2, m.SearchGETHandler)
+	attachHandler(http.MethodGet, HashtagSearchPath, m.HashtagSearchGETHandler)
 }
@@ -147,11 +147,23 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 		}
 	}
 
+	types := c.QueryArray(TypeKey)
+	for _, t := range types {
+		switch t {
+		case TypeAccounts, TypeHashtags, TypeStatuses:
+			// no problem
+		default:
+			err := fmt.Errorf("%s must be one of %s, %s, or %s, got %s", TypeKey, TypeAccounts, TypeHashtags, TypeStatuses, t)
+			apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+			return
+		}
+	}
+
 	searchQuery := &apimodel.SearchQuery{
 		AccountID:         c.Query(AccountIDKey),
 		MaxID:             c.Query(MaxIDKey),
 		MinID:             c.Query(MinIDKey),
-		Type:              c.Query(TypeKey),
+		Types:             types,
 		ExcludeUnreviewed: excludeUnreviewed,
 		Query:             query,
 		Resolve:           resolve,
@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -35,7 +36,11 @@ type SearchGetTestSuite struct {
 }
 
 func (suite *SearchGetTestSuite) testSearch(query string, resolve bool, expectedHTTPStatus int) (*apimodel.SearchResult, error) {
-	requestPath := fmt.Sprintf("%s?q=%s&resolve=%t", search.BasePathV1, query, resolve)
+	return suite.testSearchWithParams(query, fmt.Sprintf("resolve=%t", resolve), expectedHTTPStatus)
+}
+
+func (suite *SearchGetTestSuite) testSearchWithParams(query string, params string, expectedHTTPStatus int) (*apimodel.SearchResult, error) {
+	requestPath := fmt.Sprintf("%s?q=%s&%s", search.BasePathV1, url.QueryEscape(query), params)
 	recorder := httptest.NewRecorder()
 
 	ctx := suite.newContext(recorder, requestPath)
@@ -326,6 +331,121 @@ func (suite *SearchGetTestSuite) TestSearchBlockedDomainNamestring() {
 	suite.Len(searchResult.Hashtags, 0)
 }
 
+func (suite *SearchGetTestSuite) TestSearchHashtag() {
+	searchResult, err := suite.testSearchWithParams("#welcome", "", http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 0)
+	suite.Len(searchResult.Statuses, 0)
+	if !suite.Len(searchResult.Hashtags, 1) {
+		suite.FailNow("expected 1 hashtag in search results")
+	}
+	suite.Equal("welcome", searchResult.Hashtags[0].Name)
+}
+
+func (suite *SearchGetTestSuite) TestSearchHashtagNoLeadingHash() {
+	searchResult, err := suite.testSearchWithParams("Welcome", "", http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(searchResult.Hashtags, 1) {
+		suite.FailNow("expected 1 hashtag in search results")
+	}
+	suite.Equal("welcome", searchResult.Hashtags[0].Name)
+}
+
+func (suite *SearchGetTestSuite) TestSearchLocalAccountTypeHashtagsOnly() {
+	searchResult, err := suite.testSearchWithParams("@the_mighty_zork", "type=hashtags", http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 0)
+	suite.Len(searchResult.Statuses, 0)
+	suite.Len(searchResult.Hashtags, 0)
+}
+
+func (suite *SearchGetTestSuite) TestSearchLocalAccountMultipleTypes() {
+	searchResult, err := suite.testSearchWithParams("@the_mighty_zork", "type=hashtags&type=accounts", http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 1)
+}
+
+func (suite *SearchGetTestSuite) TestSearchInvalidType() {
+	_, err := suite.testSearchWithParams("@the_mighty_zork", "type=emojis", http.StatusBadRequest)
+	suite.NoError(err)
+}
+
+func (suite *SearchGetTestSuite) TestSearchLocalAccountFollowing() {
+	// local_account_1 follows local_account_2
+	searchResult, err := suite.testSearchWithParams("@1happyturtle", "following=true", http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 1)
+}
+
+func (suite *SearchGetTestSuite) TestSearchLocalAccountNotFollowing() {
+	// local_account_1 doesn't follow itself
+	searchResult, err := suite.testSearchWithParams("@the_mighty_zork", "following=true", http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 0)
+}
+
+func (suite *SearchGetTestSuite) TestSearchHashtagPrefix() {
+	searchResult, err := suite.testSearchWithParams("#wel", "type=hashtags", http.StatusOK)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(searchResult.Hashtags, 1) {
+		suite.FailNow("expected 1 hashtag in search results")
+	}
+	suite.Equal("welcome", searchResult.Hashtags[0].Name)
+	suite.Len(searchResult.Hashtags[0].History, 7)
+}
+
+func (suite *SearchGetTestSuite) TestHashtagSearch() {
+	requestPath := fmt.Sprintf("%s?q=%s", search.HashtagSearchPath, url.QueryEscape("#WEL"))
+	recorder := httptest.NewRecorder()
+
+	ctx := suite.newContext(recorder, requestPath)
+
+	suite.searchModule.HashtagSearchGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tags := []*apimodel.Tag{}
+	if err := json.Unmarshal(b, &tags); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(tags, 1) {
+		suite.FailNow("expected 1 hashtag")
+	}
+	suite.Equal("welcome", tags[0].Name)
+	suite.Equal("http://localhost:8080/tags/welcome", tags[0].URL)
+	suite.Len(tags[0].History, 7)
+}
+
 func TestSearchGetTestSuite(t *testing.T) {
 	suite.Run(t, &SearchGetTestSuite{})
 }
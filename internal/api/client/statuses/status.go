@@ -27,6 +27,10 @@ import (
 const (
 	// IDKey is for status UUIDs
 	IDKey = "id"
+	// MaxIDKey is for specifying the maximum ID of the item to retrieve when paging.
+	MaxIDKey = "max_id"
+	// MinIDKey is for specifying the minimum ID of the item to retrieve when paging.
+	MinIDKey = "min_id"
 	// BasePath is the base path for serving the statuses API, minus the 'api' prefix
 	BasePath = "/v1/statuses"
 	// BasePathWithID is just the base path with the ID key in it.
@@ -64,6 +68,15 @@ const (
 
 	// ContextPath is used for fetching context of posts
 	ContextPath = BasePathWithID + "/context"
+
+	// HistoryPath is used for fetching the edit history of posts
+	HistoryPath = BasePathWithID + "/history"
+
+	// VisibilityPath is used for explaining the visibility of posts
+	VisibilityPath = BasePathWithID + "/visibility"
+
+	// InteractionPolicyPath is used for viewing and changing who may interact with posts
+	InteractionPolicyPath = BasePathWithID + "/interaction_policy"
 )
 
 type Module struct {
@@ -100,4 +113,14 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 
 	// context / status thread
 	attachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
+
+	// edit history
+	attachHandler(http.MethodGet, HistoryPath, m.StatusHistoryGETHandler)
+
+	// visibility debugging
+	attachHandler(http.MethodGet, VisibilityPath, m.StatusVisibilityGETHandler)
+
+	// interaction policy
+	attachHandler(http.MethodGet, InteractionPolicyPath, m.StatusInteractionPolicyGETHandler)
+	attachHandler(http.MethodPut, InteractionPolicyPath, m.StatusInteractionPolicyPUTHandler)
 }
@@ -16,6 +16,7 @@
 package statuses_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -25,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
@@ -107,6 +109,82 @@ func (suite *StatusBoostedByTestSuite) TestRebloggedByUseBoostWrapperID() {
 	suite.Equal(accounts[0].ID, suite.testAccounts["admin_account"].ID)
 }
 
+func (suite *StatusBoostedByTestSuite) TestRebloggedByPaging() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+	boostingAccount := suite.testAccounts["local_account_2"]
+
+	// Have another account boost the status too.
+	boost := &gtsmodel.Status{
+		ID:                       "01H3BPV7YJ6QWQ3S8F2R9T5KXM",
+		URI:                      "http://localhost:8080/users/1happyturtle/statuses/01H3BPV7YJ6QWQ3S8F2R9T5KXM",
+		URL:                      "http://localhost:8080/@1happyturtle/statuses/01H3BPV7YJ6QWQ3S8F2R9T5KXM",
+		Local:                    testrig.TrueBool(),
+		AccountURI:               boostingAccount.URI,
+		AccountID:                boostingAccount.ID,
+		BoostOfID:                targetStatus.ID,
+		BoostOfAccountID:         targetStatus.AccountID,
+		Visibility:               gtsmodel.VisibilityPublic,
+		CreatedWithApplicationID: "01F8MGXQRHYF5QPMTMXP78QC2F",
+		Federated:                testrig.TrueBool(),
+		Boostable:                testrig.TrueBool(),
+		Replyable:                testrig.TrueBool(),
+		Likeable:                 testrig.TrueBool(),
+		ActivityStreamsType:      ap.ActivityAnnounce,
+	}
+	if err := suite.db.PutStatus(context.Background(), boost); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// First page should have just the newest boost...
+	accounts, link := suite.getRebloggedBy(targetStatus.ID, "?limit=1")
+	if !suite.Len(accounts, 1) {
+		suite.FailNow("should have had 1 account")
+	}
+	suite.Equal(boostingAccount.ID, accounts[0].ID)
+	suite.Contains(link, "max_id="+boost.ID)
+
+	// ...and the next page the older one.
+	accounts, _ = suite.getRebloggedBy(targetStatus.ID, "?limit=1&max_id="+boost.ID)
+	if !suite.Len(accounts, 1) {
+		suite.FailNow("should have had 1 account")
+	}
+	suite.Equal(suite.testAccounts["admin_account"].ID, accounts[0].ID)
+}
+
+// getRebloggedBy requests the accounts that boosted the given status as
+// local_account_1, returning the accounts and the Link header.
+func (suite *StatusBoostedByTestSuite) getRebloggedBy(statusID string, query string) ([]*gtsmodel.Account, string) {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s%s", strings.Replace(statuses.RebloggedPath, ":id", statusID, 1), query), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam("id", statusID)
+
+	suite.statusModule.StatusBoostedByGETHandler(ctx)
+
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	accounts := []*gtsmodel.Account{}
+	if err := json.Unmarshal(b, &accounts); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return accounts, result.Header.Get("Link")
+}
+
 func TestStatusBoostedByTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusBoostedByTestSuite))
 }
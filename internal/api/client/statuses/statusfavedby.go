@@ -45,6 +45,28 @@ import (
 //		description: Target status ID.
 //		in: path
 //		required: true
+//	-
+//		name: max_id
+//		type: string
+//		description: >-
+//			Return only accounts whose fave of the status has an ID *LOWER* than the given max ID.
+//			The account of the fave with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: min_id
+//		type: string
+//		description: >-
+//			Return only accounts whose fave of the status has an ID *HIGHER* than the given min ID.
+//			The account of the fave with the specified ID will not be included in the response.
+//		in: query
+//	-
+//		name: limit
+//		type: integer
+//		description: >-
+//			Number of accounts to return.
+//			If more than 80, 80 will be used. If less than 1, 1 will be used.
+//		default: 40
+//		in: query
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -52,6 +74,10 @@ import (
 //
 //	responses:
 //		'200':
+//			headers:
+//				Link:
+//					type: string
+//					description: Links to the next and previous queries.
 //			schema:
 //				type: array
 //				items:
@@ -87,11 +113,26 @@ func (m *Module) StatusFavedByGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiAccounts, errWithCode := m.processor.Status().FavedBy(c.Request.Context(), authed.Account, targetStatusID)
+	limit, errWithCode := apiutil.ParseLimit(c.Query(apiutil.LimitKey), 40)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
 	}
 
-	c.JSON(http.StatusOK, apiAccounts)
+	if limit > 80 {
+		limit = 80
+	} else if limit < 1 {
+		limit = 1
+	}
+
+	resp, errWithCode := m.processor.Status().FavedBy(c.Request.Context(), authed.Account, targetStatusID, c.Query(MaxIDKey), c.Query(MinIDKey), limit)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if resp.LinkHeader != "" {
+		c.Header("Link", resp.LinkHeader)
+	}
+	c.JSON(http.StatusOK, resp.Items)
 }
@@ -18,6 +18,7 @@
 package statuses_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -31,6 +32,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
@@ -82,6 +84,141 @@ func (suite *StatusFavedByTestSuite) TestGetFavedBy() {
 	assert.Equal(suite.T(), "the_mighty_zork", accts[0].Username)
 }
 
+func (suite *StatusFavedByTestSuite) TestGetFavedByBlocked() {
+	t := suite.testTokens["local_account_2"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	targetStatus := suite.testStatuses["admin_account_status_1"] // this status is faved by local_account_1
+
+	// local_account_1 blocks local_account_2,
+	// so their fave shouldn't be shown.
+	if err := suite.db.PutBlock(context.Background(), &gtsmodel.Block{
+		ID:              "01H3C2N1T8ZPB6R0Y4XKJ7WQ5E",
+		URI:             "http://localhost:8080/users/the_mighty_zork/blocks/01H3C2N1T8ZPB6R0Y4XKJ7WQ5E",
+		AccountID:       suite.testAccounts["local_account_1"].ID,
+		TargetAccountID: suite.testAccounts["local_account_2"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_2"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_2"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_2"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s", strings.Replace(statuses.FavouritedPath, ":id", targetStatus.ID, 1)), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatus.ID,
+		},
+	}
+
+	suite.statusModule.StatusFavedByGETHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	accts := []apimodel.Account{}
+	err = json.Unmarshal(b, &accts)
+	suite.NoError(err)
+	suite.Empty(accts)
+
+	// The page wasn't empty before filtering,
+	// so we should still be able to page on.
+	fave := testrig.NewTestFaves()["local_account_1_admin_account_status_1"]
+	link := result.Header.Get("Link")
+	suite.Contains(link, "max_id="+fave.ID)
+	suite.Contains(link, "min_id="+fave.ID)
+}
+
+func (suite *StatusFavedByTestSuite) TestGetFavedByPaging() {
+	t := suite.testTokens["local_account_2"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	targetStatus := suite.testStatuses["admin_account_status_1"] // this status is faved by local_account_1
+	fave := testrig.NewTestFaves()["local_account_1_admin_account_status_1"]
+
+	// Page past the only fave.
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_2"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_2"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_2"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s?max_id=%s", strings.Replace(statuses.FavouritedPath, ":id", targetStatus.ID, 1), fave.ID), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatus.ID,
+		},
+	}
+
+	suite.statusModule.StatusFavedByGETHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	accts := []apimodel.Account{}
+	err = json.Unmarshal(b, &accts)
+	suite.NoError(err)
+	suite.Empty(accts)
+	suite.Empty(result.Header.Get("Link"))
+}
+
+func (suite *StatusFavedByTestSuite) TestGetFavedByMinID() {
+	t := suite.testTokens["local_account_2"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	targetStatus := suite.testStatuses["admin_account_status_1"] // this status is faved by local_account_1
+
+	// Page up from before the only fave.
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_2"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_2"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_2"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080%s?min_id=%s", strings.Replace(statuses.FavouritedPath, ":id", targetStatus.ID, 1), "00000000000000000000000000"), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatus.ID,
+		},
+	}
+
+	suite.statusModule.StatusFavedByGETHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	accts := []apimodel.Account{}
+	err = json.Unmarshal(b, &accts)
+	suite.NoError(err)
+	suite.Len(accts, 1)
+	suite.Equal("the_mighty_zork", accts[0].Username)
+}
+
 func TestStatusFavedByTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusFavedByTestSuite))
 }
@@ -116,6 +116,26 @@ func (m *Module) ServeFile(c *gin.Context) {
 		return
 	}
 
+	if content.Placeholder {
+		// The real content will be ready soon,
+		// so make sure this doesn't stick around.
+		c.Header("Cache-Control", "no-store")
+	}
+
+	// Set validators so that clients can cache the content,
+	// and check whether they already have an up-to-date copy.
+	if content.ETag != "" {
+		c.Header("ETag", content.ETag)
+	}
+	if !content.ContentUpdated.IsZero() {
+		c.Header("Last-Modified", content.ContentUpdated.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request, content.ETag, content.ContentUpdated) {
+		c.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+
 	// if this is a head request, just return info + throw the reader away
 	if c.Request.Method == http.MethodHead {
 		c.Header("Content-Type", format)
@@ -143,6 +163,48 @@ func (m *Module) ServeFile(c *gin.Context) {
 	)
 }
 
+// notModified returns whether the given conditional request can be answered with
+// 304 Not Modified, given the current etag and last modified time of the content.
+// As per RFC 9110, If-None-Match takes precedence over If-Modified-Since.
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		// Only GET + HEAD are conditional.
+		return false
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if etag == "" {
+			return false
+		}
+
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" {
+				return true
+			}
+
+			// Weak comparison is used for If-None-Match.
+			if strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !modified.IsZero() {
+		since, err := http.ParseTime(ims)
+		if err != nil {
+			return false
+		}
+
+		// HTTP dates only have second precision.
+		return !modified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
 // serveFileRange serves the range of a file from a given source reader, without the
 // need for implementation of io.Seeker. Instead we read the first 'start' many bytes
 // into a discard reader. Code is adapted from https://codeberg.org/gruf/simplehttp.
@@ -43,12 +43,26 @@ func (suite *ServeFileTestSuite) GetFile(
 	mediaType media.Type,
 	mediaSize media.Size,
 	filename string,
+) (code int, headers http.Header, body []byte) {
+	return suite.GetFileWithHeaders(accountID, mediaType, mediaSize, filename, nil)
+}
+
+// GetFileWithHeaders is like GetFile, but sets the given extra request headers.
+func (suite *ServeFileTestSuite) GetFileWithHeaders(
+	accountID string,
+	mediaType media.Type,
+	mediaSize media.Size,
+	filename string,
+	reqHeaders map[string]string,
 ) (code int, headers http.Header, body []byte) {
 	recorder := httptest.NewRecorder()
 
 	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
 	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/whatever", nil)
 	ctx.Request.Header.Set("accept", "*/*")
+	for k, v := range reqHeaders {
+		ctx.Request.Header.Set(k, v)
+	}
 	ctx.AddParam(fileserver.AccountIDKey, accountID)
 	ctx.AddParam(fileserver.MediaTypeKey, string(mediaType))
 	ctx.AddParam(fileserver.MediaSizeKey, string(mediaSize))
@@ -106,6 +120,58 @@ func (suite *ServeFileTestSuite) TestServeOriginalLocalFileOK() {
 	suite.Equal(fileInStorage, body)
 }
 
+func (suite *ServeFileTestSuite) TestServeFileConditional() {
+	targetAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	fileName := targetAttachment.ID + ".jpg"
+
+	code, headers, _ := suite.GetFile(
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		fileName,
+	)
+	suite.Equal(http.StatusOK, code)
+
+	etag := headers.Get("ETag")
+	lastModified := headers.Get("Last-Modified")
+	suite.NotEmpty(etag)
+	suite.NotEmpty(lastModified)
+
+	// Matching etag should give not modified.
+	code, headers, body := suite.GetFileWithHeaders(
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		fileName,
+		map[string]string{"If-None-Match": etag},
+	)
+	suite.Equal(http.StatusNotModified, code)
+	suite.Equal(etag, headers.Get("ETag"))
+	suite.Empty(body)
+
+	// Unchanged since last modified should give not modified.
+	code, _, body = suite.GetFileWithHeaders(
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeOriginal,
+		fileName,
+		map[string]string{"If-Modified-Since": lastModified},
+	)
+	suite.Equal(http.StatusNotModified, code)
+	suite.Empty(body)
+
+	// Etag of a different size should not match.
+	code, _, body = suite.GetFileWithHeaders(
+		targetAttachment.AccountID,
+		media.TypeAttachment,
+		media.SizeSmall,
+		fileName,
+		map[string]string{"If-None-Match": etag},
+	)
+	suite.Equal(http.StatusOK, code)
+	suite.NotEmpty(body)
+}
+
 func (suite *ServeFileTestSuite) TestServeSmallLocalFileOK() {
 	targetAttachment := &gtsmodel.MediaAttachment{}
 	*targetAttachment = *suite.testAttachments["admin_account_status_1_attachment_1"]
@@ -211,6 +211,23 @@ type AccountFollowRequest struct {
 type AccountDeleteRequest struct {
 	// Password of the account's user, for confirmation.
 	Password string `form:"password" json:"password" xml:"password"`
+	// Optional ISO 8601 datetime at which to delete the account.
+	// If set, deletion will be scheduled for this time instead of
+	// happening immediately, and will be cancelled if the account's
+	// user signs in again before then.
+	ScheduledAt string `form:"scheduled_at" json:"scheduled_at" xml:"scheduled_at"`
+	// Delete the account even if it belongs to the last
+	// active admin of the instance, eg., for teardown.
+	Force bool `form:"force" json:"force" xml:"force"`
+}
+
+// AccountResendConfirmationRequest models a request
+// to resend an email address confirmation email.
+//
+// swagger:ignore
+type AccountResendConfirmationRequest struct {
+	// Email address that is awaiting confirmation.
+	Email string `form:"email" json:"email" xml:"email"`
 }
 
 // AccountRole models the role of an account.
@@ -107,7 +107,14 @@ type AdminReport struct {
 	// The account that created the report.
 	Account *AdminAccountInfo `json:"account"`
 	// Account that was reported.
+	// For a hashtag report, this is the instance account.
 	TargetAccount *AdminAccountInfo `json:"target_account"`
+	// What kind of thing was reported, either 'account' or 'hashtag'.
+	// example: account
+	TargetType string `json:"target_type"`
+	// Hashtag that was reported.
+	// Null unless target_type is 'hashtag'.
+	TargetTag *Tag `json:"target_tag"`
 	// The account assigned to handle the report.
 	// Null if no account assigned.
 	AssignedAccount *AdminAccountInfo `json:"assigned_account"`
@@ -184,8 +191,370 @@ type MediaCleanupRequest struct {
 	RemoteCacheDays *int `form:"remote_cache_days" json:"remote_cache_days" xml:"remote_cache_days"`
 }
 
+// MediaPruneBytesRequest models admin media prune by size parameters
+//
+// swagger:parameters mediaPruneBytes
+type MediaPruneBytesRequest struct {
+	// Amount of bytes of cached remote media storage to reclaim. Must be greater than 0.
+	// in: formData
+	// required: true
+	TargetBytes int64 `form:"target_bytes" json:"target_bytes" xml:"target_bytes"`
+}
+
+// AdminSignupRejectRequest models a request to reject a pending sign-up.
+//
+// swagger:ignore
+type AdminSignupRejectRequest struct {
+	// Reason for the rejection, included in the email to the applicant.
+	Reason string `form:"reason" json:"reason" xml:"reason"`
+	// Let the applicant know by email that their sign-up was rejected.
+	Notify bool `form:"notify" json:"notify" xml:"notify"`
+}
+
+// AdminSignupCount models the number of sign-ups awaiting approval.
+//
+// swagger:model adminSignupCount
+type AdminSignupCount struct {
+	// Number of sign-ups currently awaiting approval.
+	// example: 3
+	Count int `json:"count"`
+}
+
+// AdminMediaErrors models a summary of remote media
+// attachments which failed to be dereferenced.
+//
+// swagger:model adminMediaErrors
+type AdminMediaErrors struct {
+	// Number of remote media attachments currently in an error state.
+	// example: 12
+	Count int `json:"count"`
+}
+
+// AdminDomainMediaUsage models how much cached
+// remote media from one domain is stored locally.
+//
+// swagger:model adminDomainMediaUsage
+type AdminDomainMediaUsage struct {
+	// Domain the media originated from.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Number of cached media attachments from this domain (including avatars and headers).
+	// example: 120
+	Count int `json:"count"`
+	// Total size in bytes of cached media from this domain.
+	// example: 10485760
+	Bytes int64 `json:"bytes"`
+	// Whether this domain is over the configured media-per-domain-cache-limit,
+	// and will have some of its media uncached the next time the limit is enforced.
+	// example: false
+	OverLimit bool `json:"over_limit"`
+}
+
+// AdminStorageGCRequest models admin storage garbage collection parameters.
+//
+// swagger:parameters storageGarbageCollect
+type AdminStorageGCRequest struct {
+	// Actually remove orphaned files from storage. If not
+	// set to true, only a dry run will be performed.
+	// in: formData
+	Confirm bool `form:"confirm" json:"confirm" xml:"confirm"`
+}
+
+// AdminStorageGC models the result of an admin storage garbage
+// collection, ie., removal of stored files with no database entry.
+//
+// swagger:model adminStorageGC
+type AdminStorageGC struct {
+	// Number of orphaned files that were (or would be) removed.
+	// example: 12
+	Objects int `json:"objects"`
+	// Total size in bytes of orphaned files that were (or would be) removed.
+	// Files whose size is not known to the storage driver are not counted.
+	// example: 2048
+	Bytes int64 `json:"bytes"`
+	// Whether this was a dry run, ie., nothing was actually removed.
+	// example: true
+	DryRun bool `json:"dry_run"`
+}
+
+// AdminEmailTemplate models the admin view of a customizable email template.
+//
+// swagger:model adminEmailTemplate
+type AdminEmailTemplate struct {
+	// Name of the email template.
+	// example: confirm
+	Name string `json:"name"`
+	// Go text/template used to generate the email subject.
+	// example: GoToSocial Email Confirmation
+	SubjectTemplate string `json:"subject_template"`
+	// Go text/template used to generate the email body.
+	// example: Hello {{.Username}}!
+	BodyTemplate string `json:"body_template"`
+	// Whether this template has been customized by an admin.
+	// If false, the compiled-in default template is being used.
+	// example: false
+	Customized bool `json:"customized"`
+}
+
+// AdminEmailTemplateUpdateRequest models an email template update request.
+//
+// swagger:ignore
+type AdminEmailTemplateUpdateRequest struct {
+	// Go text/template used to generate the email subject.
+	// If this and body_template are both empty, the template will be reset to the default.
+	SubjectTemplate string `form:"subject_template" json:"subject_template" xml:"subject_template"`
+	// Go text/template used to generate the email body.
+	// If this and subject_template are both empty, the template will be reset to the default.
+	BodyTemplate string `form:"body_template" json:"body_template" xml:"body_template"`
+}
+
 // AdminSendTestEmailRequest models a test email send request (woah).
 type AdminSendTestEmailRequest struct {
 	// Email address to send the test email to.
 	Email string `form:"email" json:"email" xml:"email"`
 }
+
+// AdminSSOConfig models the admin view of this
+// instance's OIDC single sign-on configuration.
+// The client secret is never included.
+//
+// swagger:model adminSSOConfig
+type AdminSSOConfig struct {
+	// Whether OIDC login is enabled.
+	// example: true
+	Enabled bool `json:"enabled"`
+	// Name of the OIDC identity provider shown to users when logging in.
+	// example: Example Corp SSO
+	IdpName string `json:"idp_name"`
+	// Address of the OIDC issuer.
+	// example: https://sso.example.org/auth
+	Issuer string `json:"issuer"`
+	// ClientID of this instance, as registered with the OIDC provider.
+	// example: gotosocial
+	ClientID string `json:"client_id"`
+	// Scopes requested from the OIDC provider.
+	Scopes []string `json:"scopes"`
+	// Whether existing users are linked to OIDC logins by email address.
+	// example: false
+	LinkExisting bool `json:"link_existing"`
+	// Whether new accounts are created for OIDC logins that match no existing user.
+	// example: true
+	AutoProvision bool `json:"auto_provision"`
+	// OIDC groups whose members are made admins of this instance.
+	AdminGroups []string `json:"admin_groups"`
+}
+
+// AdminFederationState models an overview of this
+// instance's federation relationship with one domain.
+//
+// swagger:model adminFederationState
+type AdminFederationState struct {
+	// The domain this overview is for.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Domain block in place for this domain.
+	// Null if the domain is not blocked.
+	DomainBlock *DomainBlock `json:"domain_block"`
+	// Number of accepted follows from local accounts to accounts on the domain.
+	// example: 12
+	FollowsOutbound int `json:"follows_outbound"`
+	// Number of accepted follows from accounts on the domain to local accounts.
+	// example: 30
+	FollowsInbound int `json:"follows_inbound"`
+	// Pending follow requests from local accounts to accounts on the domain.
+	FollowRequestsOutbound []AdminFederationFollowRequest `json:"follow_requests_outbound"`
+	// Pending follow requests from accounts on the domain to local accounts.
+	FollowRequestsInbound []AdminFederationFollowRequest `json:"follow_requests_inbound"`
+}
+
+// AdminFederationFollowRequest models the admin
+// view of one pending follow request.
+//
+// swagger:model adminFederationFollowRequest
+type AdminFederationFollowRequest struct {
+	// The ID of the follow request.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// Full account name of the requesting account.
+	// example: someone@example.org
+	Account string `json:"account"`
+	// Full account name of the requested account.
+	// example: admin@example.org
+	TargetAccount string `json:"target_account"`
+	// When the follow request was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
+
+// AdminFederationActivity models one activity
+// recently received from a remote instance.
+//
+// swagger:model adminFederationActivity
+type AdminFederationActivity struct {
+	// When the activity was received (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	ReceivedAt string `json:"received_at"`
+	// Domain of the account that delivered the activity.
+	// example: example.org
+	Domain string `json:"domain"`
+	// ActivityStreams type of the activity.
+	// Empty if the activity could not be parsed.
+	// example: Create
+	ActivityType string `json:"activity_type"`
+	// ActivityStreams type of the activity's object.
+	// Empty if the object was given only as a URI.
+	// example: Note
+	ObjectType string `json:"object_type"`
+	// URI of the account that delivered the activity.
+	// example: https://example.org/users/someone
+	ActorURI string `json:"actor_uri"`
+	// Whether the activity was accepted for processing.
+	// example: false
+	Accepted bool `json:"accepted"`
+	// Why the activity was rejected. Empty if it was accepted.
+	// example: blocked
+	Reason string `json:"reason"`
+}
+
+// AdminDomainCachePurge models the result
+// of purging cache entries for one domain.
+//
+// swagger:model adminDomainCachePurge
+type AdminDomainCachePurge struct {
+	// The domain cache entries were purged for.
+	// example: example.org
+	Domain string `json:"domain"`
+}
+
+// AdminToken models the admin view of an
+// active OAuth access token belonging to a user.
+//
+// swagger:model adminToken
+type AdminToken struct {
+	// The ID of the token in the database.
+	// example: 01H2Z8D7B1G0V2ZMCHR0ZGA4YT
+	ID string `json:"id"`
+	// When the token was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// OAuth scopes granted to the token.
+	// example: read write
+	Scope string `json:"scope"`
+	// Name of the application the token was issued to.
+	// Empty if the application could not be found.
+	// example: Tusky
+	ClientName string `json:"client_name"`
+	// When the token was last used to authenticate a request (ISO 8601 Datetime).
+	// This is approximate, and only updated every few minutes.
+	// Null if the token has not been used since this was first recorded.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsedAt *string `json:"last_used_at"`
+	// The IP address the token was last used from.
+	// Null if not known.
+	// example: 192.0.2.1
+	IPAddress *string `json:"ip_address"`
+}
+
+// AdminDomainStats models a summary of what
+// this instance knows about from one remote domain.
+//
+// swagger:model adminDomainStats
+type AdminDomainStats struct {
+	// The domain these stats are for.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Number of known accounts from the domain.
+	// example: 12
+	AccountCount int `json:"account_count"`
+	// Number of known statuses from accounts on the domain.
+	// example: 345
+	StatusCount int `json:"status_count"`
+	// Total size in bytes of currently cached media from accounts on the domain.
+	// example: 1048576
+	MediaCountBytes int64 `json:"media_count_bytes"`
+	// When the most recent known status from the domain was created (ISO 8601 Datetime).
+	// Null if no statuses are known from the domain.
+	// example: 2021-07-30T09:20:25+00:00
+	LastActivity *string `json:"last_activity"`
+}
+
+// RemoteInstance models software information about a remote
+// instance, as reported by that instance's nodeinfo document.
+//
+// swagger:model remoteInstance
+type RemoteInstance struct {
+	// The domain of the instance.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Name of the software the instance runs.
+	// Empty if not reported.
+	// example: mastodon
+	SoftwareName string `json:"software_name"`
+	// Version of the software the instance runs.
+	// Empty if not reported.
+	// example: 4.1.2
+	SoftwareVersion string `json:"software_version"`
+	// Total number of users on the instance.
+	// example: 1024
+	UserCount int `json:"user_count"`
+	// When this information was fetched from the instance (ISO 8601 Datetime).
+	// Null if it has never been fetched successfully.
+	// example: 2021-07-30T09:20:25+00:00
+	FetchedAt *string `json:"fetched_at"`
+}
+
+// AdminTag models the admin view of a hashtag.
+//
+// swagger:model adminTag
+type AdminTag struct {
+	// The value of the hashtag after the # sign.
+	// example: helloworld
+	Name string `json:"name"`
+	// Web link to the hashtag.
+	// example: https://example.org/tags/helloworld
+	URL string `json:"url"`
+	// Whether local accounts can use this hashtag in statuses.
+	Usable bool `json:"usable"`
+	// Whether this hashtag can be looked up.
+	Listable bool `json:"listable"`
+	// Whether this hashtag can appear in trends.
+	Trendable bool `json:"trendable"`
+	// Whether this hashtag is awaiting review by an admin.
+	RequiresReview bool `json:"requires_review"`
+	// When this hashtag was last used in a status (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	LastStatusAt string `json:"last_status_at"`
+}
+
+// AdminTagUpdateRequest models a request to
+// update the moderation settings of a hashtag.
+// Fields which are not set are left unchanged.
+//
+// swagger:ignore
+type AdminTagUpdateRequest struct {
+	// Whether local accounts can use this hashtag in statuses.
+	Usable *bool `form:"usable" json:"usable" xml:"usable"`
+	// Whether this hashtag can be looked up.
+	Listable *bool `form:"listable" json:"listable" xml:"listable"`
+	// Whether this hashtag can appear in trends.
+	Trendable *bool `form:"trendable" json:"trendable" xml:"trendable"`
+	// Whether this hashtag is awaiting review by an admin.
+	RequiresReview *bool `form:"requires_review" json:"requires_review" xml:"requires_review"`
+}
+
+// AdminAccountDeleteCheck models the result of checking
+// whether deleting an account removed all of its data.
+//
+// swagger:model adminAccountDeleteCheck
+type AdminAccountDeleteCheck struct {
+	// ID of the checked account.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	AccountID string `json:"account_id"`
+	// Whether nothing was left behind by the delete.
+	Complete bool `json:"complete"`
+	// Counts of anything left behind, keyed by kind: statuses, media, follows,
+	// follow_requests, blocks, faves, bookmarks, notifications, saved_searches, or tokens.
+	// Only kinds with leftovers are included.
+	// example: {"media":2}
+	Leftovers map[string]int `json:"leftovers"`
+}
@@ -39,6 +39,10 @@ type Application struct {
 	ClientSecret string `json:"client_secret,omitempty"`
 	// Push API key for this application.
 	VapidKey string `json:"vapid_key,omitempty"`
+	// Scopes requested when this application was registered.
+	// Only included when verifying app credentials.
+	// example: ["read","write"]
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // ApplicationCreateRequest models app create parameters.
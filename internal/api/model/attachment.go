@@ -49,6 +49,27 @@ type AttachmentUpdateRequest struct {
 	Focus *string `form:"focus" json:"focus" xml:"focus"`
 }
 
+// AttachmentDescriptionsUpdateRequest models a request to set
+// the descriptions of multiple attachments at once.
+//
+// swagger:ignore
+type AttachmentDescriptionsUpdateRequest struct {
+	// Map of attachment ID to description to set for that attachment.
+	Descriptions map[string]string `json:"descriptions"`
+}
+
+// AttachmentsMissingDescription models a page of the requesting
+// account's media attachments which have no description (alt-text).
+//
+// swagger:model attachmentsMissingDescription
+type AttachmentsMissingDescription struct {
+	// Total number of the account's media attachments without a description.
+	// example: 12
+	Count int `json:"count"`
+	// Attachments without a description, newest first.
+	Attachments []*Attachment `json:"attachments"`
+}
+
 // Attachment models a media attachment.
 //
 // swagger:model attachment
@@ -32,10 +32,15 @@ type Content struct {
 	ContentLength int64
 	// Time when the content was last updated.
 	ContentUpdated time.Time
+	// Strong ETag for the content, derived from its storage metadata.
+	ETag string
 	// Actual content
 	Content io.ReadCloser
 	// Resource URL to forward to if the file can be fetched from the storage directly (e.g signed S3 URL)
 	URL *storage.PresignedURL
+	// Placeholder is set if Content is a stand-in for media
+	// that's still being fetched, which must not be cached.
+	Placeholder bool
 }
 
 // GetContentRequestForm describes a piece of content desired by the caller of the fileserver API.
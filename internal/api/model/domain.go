@@ -79,3 +79,16 @@ type DomainBlockCreateRequest struct {
 	// public comment on the reason for the domain block
 	PublicComment string `form:"public_comment" json:"public_comment" xml:"public_comment"`
 }
+
+// DomainBlockUpdateRequest is the form submitted as a PUT to /api/v1/admin/domain_blocks/:id to update an existing block.
+// Fields that aren't set are left unchanged.
+//
+// swagger:ignore
+type DomainBlockUpdateRequest struct {
+	// whether the domain should be obfuscated when being displayed publicly
+	Obfuscate *bool `form:"obfuscate" json:"obfuscate" xml:"obfuscate"`
+	// private comment for other admins on why the domain was blocked
+	PrivateComment *string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+	// public comment on the reason for the domain block
+	PublicComment *string `form:"public_comment" json:"public_comment" xml:"public_comment"`
+}
@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+import "mime/multipart"
+
+// FollowsImportRequest is the form submitted as a POST to /api/v1/imports/follows to import follows.
+//
+// swagger:ignore
+type FollowsImportRequest struct {
+	// CSV of accounts to follow, in the same format as the follows.csv export.
+	Data *multipart.FileHeader `form:"data" binding:"required"`
+}
+
+// FollowsImport models the progress of a follows import.
+//
+// swagger:model followsImport
+type FollowsImport struct {
+	// State of the import, one of `in_progress` or `finished`.
+	// example: in_progress
+	State string `json:"state"`
+	// Amount of accounts to follow in the import.
+	// example: 120
+	Total int `json:"total"`
+	// Amount of accounts followed successfully so far, including those already followed.
+	// example: 40
+	Succeeded int `json:"succeeded"`
+	// Amount of accounts that couldn't be followed so far, eg., because their instance is unreachable.
+	// example: 2
+	Failed int `json:"failed"`
+	// Time at which the import was started (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time at which the import finished (ISO 8601 Datetime), or null if it's still in progress.
+	// example: 2021-07-30T09:24:25+00:00
+	FinishedAt *string `json:"finished_at"`
+}
@@ -43,6 +43,19 @@ type InstanceSettingsUpdateRequest struct {
 	Header *multipart.FileHeader `form:"header" json:"header" xml:"header"`
 }
 
+// InstanceConfiguration models the limits of this instance, for
+// clients that need them without the rest of the instance object.
+//
+// swagger:model instanceConfiguration
+type InstanceConfiguration struct {
+	// Limits related to authoring statuses.
+	Statuses InstanceConfigurationStatuses `json:"statuses"`
+	// Hints for which attachments will be accepted.
+	MediaAttachments InstanceConfigurationMediaAttachments `json:"media_attachments"`
+	// Limits related to polls.
+	Polls InstanceConfigurationPolls `json:"polls"`
+}
+
 // InstanceConfigurationAccounts models instance account config parameters.
 //
 // swagger:model instanceConfigurationAccounts
@@ -143,3 +156,42 @@ type InstanceConfigurationEmojis struct {
 	// example: 51200
 	EmojiSizeLimit int `json:"emoji_size_limit"`
 }
+
+// InstanceActivity models one week of activity on this instance.
+//
+// swagger:model instanceActivity
+type InstanceActivity struct {
+	// Start of the week, as a Unix timestamp string.
+	//
+	// example: 1686528000
+	Week string `json:"week"`
+	// Number of statuses posted by local accounts during the week.
+	//
+	// example: 42
+	Statuses string `json:"statuses"`
+	// Number of distinct local users who signed in during the week.
+	//
+	// example: 7
+	Logins string `json:"logins"`
+	// Number of new local users who registered during the week.
+	//
+	// example: 1
+	Registrations string `json:"registrations"`
+	// Distinct local users who signed in during the week, by country.
+	// Only present for admins when log-geoip is enabled.
+	Countries []CountryActivity `json:"countries,omitempty"`
+}
+
+// CountryActivity models sign-in activity from one country.
+//
+// swagger:model countryActivity
+type CountryActivity struct {
+	// ISO 3166-1 alpha-2 country code.
+	//
+	// example: NL
+	Country string `json:"country"`
+	// Number of distinct local users who signed in from this country.
+	//
+	// example: 3
+	Logins int `json:"logins"`
+}
@@ -0,0 +1,40 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// InstancePolicy models one of this instance's policy documents,
+// eg., its privacy policy or terms of service.
+//
+// swagger:model instancePolicy
+type InstancePolicy struct {
+	// When the policy was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+	// The rendered HTML content of the policy.
+	// example: <p>We collect as little of your data as we can.</p>
+	Content string `json:"content"`
+}
+
+// InstancePolicyUpdateRequest models an instance policy update request.
+//
+// swagger:ignore
+type InstancePolicyUpdateRequest struct {
+	// Markdown content of the policy.
+	// If empty, the policy will be removed.
+	Content string `form:"content" json:"content" xml:"content"`
+}
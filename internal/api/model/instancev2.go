@@ -64,7 +64,24 @@ type InstanceV2 struct {
 	Contact InstanceV2Contact `json:"contact"`
 	// An itemized list of rules for this website.
 	// Currently not implemented (will always be empty array).
-	Rules []interface{} `json:"rules"`
+	Rules []InstanceV2Rule `json:"rules"`
+	// When the privacy policy of this instance was last updated (ISO 8601 Datetime),
+	// so that clients can prompt users to review it again.
+	// Null if the instance has no privacy policy set.
+	// example: 2021-07-30T09:20:25+00:00
+	PrivacyPolicyUpdatedAt *string `json:"privacy_policy_updated_at"`
+}
+
+// A rule that users of this instance are expected to follow.
+//
+// swagger:model instanceV2Rule
+type InstanceV2Rule struct {
+	// An identifier for the rule.
+	// example: 1
+	ID string `json:"id"`
+	// The rule to be followed.
+	// example: Don't be a jerk.
+	Text string `json:"text"`
 }
 
 // Usage data for this instance.
@@ -168,6 +185,9 @@ type InstanceV2Registrations struct {
 	// Whether registrations require moderator approval.
 	// example: true
 	ApprovalRequired bool `json:"approval_required"`
+	// Whether new signups must submit a reason for wanting to join.
+	// example: true
+	ReasonRequired bool `json:"reason_required"`
 	// A custom message (html string) to be shown when registrations are closed.
 	// Value will be null if no message is set.
 	// example: <p>Registrations are currently closed on example.org because of spam bots!</p>
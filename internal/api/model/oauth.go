@@ -17,6 +17,21 @@
 
 package model
 
+// OAuthScope describes one OAuth scope that can be requested by an application.
+//
+// swagger:model oauthScope
+type OAuthScope struct {
+	// Name of the scope.
+	// example: read:statuses
+	Scope string `json:"scope"`
+	// Description of what the scope grants.
+	// example: Read statuses and timelines.
+	Description string `json:"description"`
+	// Parent scope that includes this scope, if any.
+	// example: read
+	ParentScope string `json:"parent_scope,omitempty"`
+}
+
 // OAuthAuthorize represents a request sent to https://example.org/oauth/authorize
 type OAuthAuthorize struct {
 	// Forces the user to re-login, which is necessary for authorizing with multiple accounts from the same instance.
@@ -36,4 +51,10 @@ type OAuthAuthorize struct {
 	// The authorization server must return the unmodified state value back to the application.
 	// See https://www.oauth.com/oauth2-servers/authorization/the-authorization-request/
 	State string `form:"state" json:"state"`
+	// CodeChallenge is the PKCE code challenge derived from the client's code verifier.
+	// See https://datatracker.ietf.org/doc/html/rfc7636
+	CodeChallenge string `form:"code_challenge" json:"code_challenge"`
+	// CodeChallengeMethod is the method used to derive CodeChallenge.
+	// Only S256 is supported; plain is rejected.
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
 }
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// SavedSearch represents one search query saved in the user's search history.
+//
+// swagger:model savedSearch
+type SavedSearch struct {
+	// The ID of the saved search.
+	// example: 01H3K3ECQTXV1PV3RPD5QQT6MZ
+	ID string `json:"id"`
+	// The search query.
+	// example: @someone@example.org
+	Query string `json:"query"`
+	// When the search was saved (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
+
+// SavedSearchCreateRequest models saved search creation parameters.
+//
+// swagger:parameters savedSearchCreate
+type SavedSearchCreateRequest struct {
+	// The search query to save.
+	// example: @someone@example.org
+	// in: formData
+	// required: true
+	Query string `form:"query" json:"query" xml:"query"`
+}
@@ -35,17 +35,15 @@ type SearchQuery struct {
 	// The entry with this ID will not be included in the search results.
 	// in: query
 	MinID string `json:"min_id"`
-	// Type of the search query to perform.
+	// Types of result to include in the search response.
 	//
-	// Must be one of: `accounts`, `hashtags`, `statuses`.
+	// Each must be one of: `accounts`, `hashtags`, `statuses`.
+	// May be provided multiple times. If not provided, all types
+	// of result will be included.
 	//
-	// enum:
-	// - accounts
-	// - hashtags
-	// - statuses
-	// required: true
 	// in: query
-	Type string `json:"type"`
+	// collectionFormat: multi
+	Types []string `json:"type"`
 	// Filter out tags that haven't been reviewed and approved by an instance admin.
 	//
 	// default: false
@@ -74,7 +72,8 @@ type SearchQuery struct {
 	// default: 0
 	// in: query
 	Offset int `json:"offset"`
-	// Only include accounts that the searching account is following.
+	// Only include accounts that the searching account is following,
+	// and statuses authored by those accounts.
 	// default: false
 	// in: query
 	Following bool `json:"following"`
@@ -38,4 +38,6 @@ type Source struct {
 	Fields []Field `json:"fields"`
 	// The number of pending follow requests.
 	FollowRequestsCount int `json:"follow_requests_count"`
+	// If set, deletion of this account has been requested at this time, and is still pending. (ISO 8601 Datetime)
+	DeletionRequestedAt string `json:"deletion_requested_at,omitempty"`
 }
@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package model
+
+// StatusEdit models one version of a status, as part of its edit history.
+//
+// swagger:model statusEdit
+type StatusEdit struct {
+	// The content of the status at this version. Should be HTML, but might also be plaintext in some cases.
+	// example: <p>Hey this is a status!</p>
+	Content string `json:"content"`
+	// Subject, summary, or content warning for the status at this version.
+	// example: warning nsfw
+	SpoilerText string `json:"spoiler_text"`
+	// Status was marked sensitive at this version.
+	// example: false
+	Sensitive bool `json:"sensitive"`
+	// Visibility of the status at this version.
+	// example: unlisted
+	Visibility Visibility `json:"visibility"`
+	// The date when this version of the status was made (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The account that authored the status.
+	Account *Account `json:"account"`
+	// Media that was attached to the status at this version.
+	MediaAttachments []Attachment `json:"media_attachments"`
+	// Mentions in the status at this version.
+	Mentions []Mention `json:"mentions"`
+}
@@ -0,0 +1,49 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// StatusInteractionPolicy describes who may reply to, boost, or
+// like a status. The status author is always permitted.
+//
+// Each value is one of `everyone`, `followers_only`, `mentioned_only`, or `self_only`.
+//
+// swagger:model statusInteractionPolicy
+type StatusInteractionPolicy struct {
+	// Who may reply to the status.
+	// example: everyone
+	CanReplyTo string `json:"can_reply_to"`
+	// Who may boost the status.
+	// example: followers_only
+	CanBoost string `json:"can_boost"`
+	// Who may like the status.
+	// example: everyone
+	CanLike string `json:"can_like"`
+}
+
+// StatusInteractionPolicyUpdateRequest models a request to change
+// the interaction policy of a status. Only set fields are changed.
+//
+// swagger:ignore
+type StatusInteractionPolicyUpdateRequest struct {
+	// Who may reply to the status.
+	CanReplyTo *string `form:"can_reply_to" json:"can_reply_to" xml:"can_reply_to"`
+	// Who may boost the status.
+	CanBoost *string `form:"can_boost" json:"can_boost" xml:"can_boost"`
+	// Who may like the status.
+	CanLike *string `form:"can_like" json:"can_like" xml:"can_like"`
+}
@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// StatusVisibility explains who can see a status and how it can be
+// shared, and why it is or is not visible to the requesting account.
+//
+// swagger:model statusVisibility
+type StatusVisibility struct {
+	// Visibility level of the status.
+	// example: private
+	Visibility Visibility `json:"visibility"`
+	// Audiences who can see the status. Any of:
+	// `public`, `followers`, `mutuals`, `mentioned`, `self`.
+	// `mutuals` means only followers whom the author follows back.
+	// example: ["followers","mentioned","self"]
+	Audience []string `json:"audience"`
+	// Whether accounts other than the author can boost the status.
+	// example: false
+	CanBoost bool `json:"can_boost"`
+	// Whether the status can be quoted. GoToSocial
+	// doesn't support quote posts, so this is always false.
+	// example: false
+	CanQuote bool `json:"can_quote"`
+	// When the status expires, if ever. Statuses
+	// don't currently expire, so this is always unset.
+	// example: 2021-07-30T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at,omitempty"`
+	// IDs of accounts whose followers the status is limited to.
+	// example: ["01F8MH1H7YV1Z7D2C8K2730QBF"]
+	LimitedToFollowersOf []string `json:"limited_to_followers_of"`
+	// Whether the status is visible to the requesting account.
+	// example: false
+	Visible bool `json:"visible"`
+	// The visibility rule which determined whether the status
+	// is visible to the requesting account. One of:
+	// `account_not_visible`, `public`, `unlisted`,
+	// `author`, `mentioned`, `boost_mentioned`, `follower`,
+	// `not_follower`, `mutual`, `not_mutual`, `direct`.
+	// example: not_follower
+	Rule string `json:"rule"`
+	// Whether the status author blocks the requesting account.
+	// example: false
+	BlockedByAuthor bool `json:"blocked_by_author"`
+	// Whether the requesting account blocks the status author.
+	// example: false
+	BlockingAuthor bool `json:"blocking_author"`
+	// Whether the requesting account is mentioned in the status.
+	// example: false
+	Mentioned bool `json:"mentioned"`
+	// Whether this instance has a domain block
+	// in place affecting the status author.
+	// example: false
+	AuthorDomainBlocked bool `json:"author_domain_blocked"`
+}
@@ -27,4 +27,22 @@ type Tag struct {
 	// Web link to the hashtag.
 	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
+	// Daily usage of the hashtag, newest day first.
+	// Only included in search results and status tag lookups.
+	History []TagHistory `json:"history,omitempty"`
+}
+
+// TagHistory represents usage of a hashtag on one day.
+//
+// swagger:model tagHistory
+type TagHistory struct {
+	// UNIX timestamp of midnight (UTC) at the start of the day.
+	// example: 1574553600
+	Day string `json:"day"`
+	// Number of statuses using the hashtag that day.
+	// example: 200
+	Uses string `json:"uses"`
+	// Number of distinct accounts using the hashtag that day.
+	// example: 31
+	Accounts string `json:"accounts"`
 }
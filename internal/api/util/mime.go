@@ -25,6 +25,7 @@ const (
 	AppXML            MIME = `application/xml`
 	AppXMLXRD         MIME = `application/xrd+xml`
 	AppRSSXML         MIME = `application/rss+xml`
+	AppZip            MIME = `application/zip`
 	AppActivityJSON   MIME = `application/activity+json`
 	AppActivityLDJSON MIME = `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
 	AppJRDJSON        MIME = `application/jrd+json` // https://www.rfc-editor.org/rfc/rfc7033#section-10.2
@@ -33,4 +34,5 @@ const (
 	TextXML           MIME = `text/xml`
 	TextHTML          MIME = `text/html`
 	TextCSS           MIME = `text/css`
+	TextCSV           MIME = `text/csv`
 )
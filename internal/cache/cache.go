@@ -115,6 +115,12 @@ func (c *Caches) setuphooks() {
 		c.GTS.Follow().Invalidate("ID", followReq.ID)
 	})
 
+	c.GTS.Media().SetInvalidateCallback(func(media *gtsmodel.MediaAttachment) {
+		// Invalidate any stale copy of this media,
+		// so it can't be served after being changed.
+		c.GTS.MediaStale().Invalidate(media.ID)
+	})
+
 	c.GTS.Status().SetInvalidateCallback(func(status *gtsmodel.Status) {
 		// Invalidate status ID cached visibility.
 		c.Visibility.Invalidate("ItemID", status.ID)
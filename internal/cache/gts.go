@@ -18,6 +18,8 @@
 package cache
 
 import (
+	"time"
+
 	"codeberg.org/gruf/go-cache/v3/result"
 	"codeberg.org/gruf/go-cache/v3/ttl"
 	"github.com/superseriousbusiness/gotosocial/internal/cache/domain"
@@ -38,15 +40,32 @@ type GTSCaches struct {
 	list          *result.Cache[*gtsmodel.List]
 	listEntry     *result.Cache[*gtsmodel.ListEntry]
 	media         *result.Cache[*gtsmodel.MediaAttachment]
+	mediaStale    *ttl.Cache[string, *gtsmodel.MediaAttachment]
 	mention       *result.Cache[*gtsmodel.Mention]
 	notification  *result.Cache[*gtsmodel.Notification]
 	report        *result.Cache[*gtsmodel.Report]
 	status        *result.Cache[*gtsmodel.Status]
 	statusFave    *result.Cache[*gtsmodel.StatusFave]
+	tag           *result.Cache[*gtsmodel.Tag]
 	tombstone     *result.Cache[*gtsmodel.Tombstone]
 	user          *result.Cache[*gtsmodel.User]
 	// TODO: move out of GTS caches since not using database models.
-	webfinger *ttl.Cache[string, string]
+	webfinger         *ttl.Cache[string, string]
+	webfingerResponse *ttl.Cache[string, *Response]
+}
+
+// Response is a cached response body
+// from a remote instance, and the time
+// it was fetched at.
+type Response struct {
+	Body      []byte
+	FetchedAt time.Time
+}
+
+// Fresh returns true if the response
+// was fetched within the given ttl.
+func (r *Response) Fresh(ttl time.Duration) bool {
+	return time.Since(r.FetchedAt) < ttl
 }
 
 // Init will initialize all the gtsmodel caches in this collection.
@@ -62,14 +81,17 @@ func (c *GTSCaches) Init() {
 	c.initList()
 	c.initListEntry()
 	c.initMedia()
+	c.initMediaStale()
 	c.initMention()
 	c.initNotification()
 	c.initReport()
 	c.initStatus()
 	c.initStatusFave()
+	c.initTag()
 	c.initTombstone()
 	c.initUser()
 	c.initWebfinger()
+	c.initWebfingerResponse()
 }
 
 // Start will attempt to start all of the gtsmodel caches, or panic.
@@ -83,11 +105,18 @@ func (c *GTSCaches) Start() {
 	tryStart(c.list, config.GetCacheGTSListSweepFreq())
 	tryStart(c.listEntry, config.GetCacheGTSListEntrySweepFreq())
 	tryStart(c.media, config.GetCacheGTSMediaSweepFreq())
+	tryUntil("starting stale *gtsmodel.MediaAttachment cache", 5, func() bool {
+		if config.GetCacheGTSMediaStaleWindow() > 0 {
+			return c.mediaStale.Start(config.GetCacheGTSMediaSweepFreq())
+		}
+		return true
+	})
 	tryStart(c.mention, config.GetCacheGTSMentionSweepFreq())
 	tryStart(c.notification, config.GetCacheGTSNotificationSweepFreq())
 	tryStart(c.report, config.GetCacheGTSReportSweepFreq())
 	tryStart(c.status, config.GetCacheGTSStatusSweepFreq())
 	tryStart(c.statusFave, config.GetCacheGTSStatusFaveSweepFreq())
+	tryStart(c.tag, config.GetCacheGTSTagSweepFreq())
 	tryStart(c.tombstone, config.GetCacheGTSTombstoneSweepFreq())
 	tryStart(c.user, config.GetCacheGTSUserSweepFreq())
 	tryUntil("starting *gtsmodel.Webfinger cache", 5, func() bool {
@@ -96,6 +125,12 @@ func (c *GTSCaches) Start() {
 		}
 		return true
 	})
+	tryUntil("starting webfinger response cache", 5, func() bool {
+		if sweep := config.GetCacheGTSWebfingerSweepFreq(); sweep > 0 {
+			return c.webfingerResponse.Start(sweep)
+		}
+		return true
+	})
 }
 
 // Stop will attempt to stop all of the gtsmodel caches, or panic.
@@ -109,14 +144,22 @@ func (c *GTSCaches) Stop() {
 	tryStop(c.list, config.GetCacheGTSListSweepFreq())
 	tryStop(c.listEntry, config.GetCacheGTSListEntrySweepFreq())
 	tryStop(c.media, config.GetCacheGTSMediaSweepFreq())
+	tryUntil("stopping stale *gtsmodel.MediaAttachment cache", 5, func() bool {
+		if config.GetCacheGTSMediaStaleWindow() > 0 {
+			return c.mediaStale.Stop()
+		}
+		return true
+	})
 	tryStop(c.mention, config.GetCacheGTSNotificationSweepFreq())
 	tryStop(c.notification, config.GetCacheGTSNotificationSweepFreq())
 	tryStop(c.report, config.GetCacheGTSReportSweepFreq())
 	tryStop(c.status, config.GetCacheGTSStatusSweepFreq())
 	tryStop(c.statusFave, config.GetCacheGTSStatusFaveSweepFreq())
+	tryStop(c.tag, config.GetCacheGTSTagSweepFreq())
 	tryStop(c.tombstone, config.GetCacheGTSTombstoneSweepFreq())
 	tryStop(c.user, config.GetCacheGTSUserSweepFreq())
 	tryUntil("stopping *gtsmodel.Webfinger cache", 5, c.webfinger.Stop)
+	tryUntil("stopping webfinger response cache", 5, c.webfingerResponse.Stop)
 }
 
 // Account provides access to the gtsmodel Account database cache.
@@ -169,6 +212,13 @@ func (c *GTSCaches) Media() *result.Cache[*gtsmodel.MediaAttachment] {
 	return c.media
 }
 
+// MediaStale provides access to the stale-while-revalidate copies of
+// gtsmodel MediaAttachments, which outlive their entries in Media() by
+// the configured stale window. Only used when that window is non-zero.
+func (c *GTSCaches) MediaStale() *ttl.Cache[string, *gtsmodel.MediaAttachment] {
+	return c.mediaStale
+}
+
 // Mention provides access to the gtsmodel Mention database cache.
 func (c *GTSCaches) Mention() *result.Cache[*gtsmodel.Mention] {
 	return c.mention
@@ -194,6 +244,11 @@ func (c *GTSCaches) StatusFave() *result.Cache[*gtsmodel.StatusFave] {
 	return c.statusFave
 }
 
+// Tag provides access to the gtsmodel Tag database cache.
+func (c *GTSCaches) Tag() *result.Cache[*gtsmodel.Tag] {
+	return c.tag
+}
+
 // Tombstone provides access to the gtsmodel Tombstone database cache.
 func (c *GTSCaches) Tombstone() *result.Cache[*gtsmodel.Tombstone] {
 	return c.tombstone
@@ -209,6 +264,12 @@ func (c *GTSCaches) Webfinger() *ttl.Cache[string, string] {
 	return c.webfinger
 }
 
+// WebfingerResponse provides access to the cache of remote
+// webfinger responses, keyed by domain and resource.
+func (c *GTSCaches) WebfingerResponse() *ttl.Cache[string, *Response] {
+	return c.webfingerResponse
+}
+
 func (c *GTSCaches) initAccount() {
 	c.account = result.New([]result.Lookup{
 		{Name: "ID"},
@@ -337,6 +398,13 @@ func (c *GTSCaches) initMedia() {
 	c.media.IgnoreErrors(ignoreErrors)
 }
 
+func (c *GTSCaches) initMediaStale() {
+	c.mediaStale = ttl.New[string, *gtsmodel.MediaAttachment](
+		0,
+		config.GetCacheGTSMediaMaxSize(),
+		config.GetCacheGTSMediaTTL()+config.GetCacheGTSMediaStaleWindow())
+}
+
 func (c *GTSCaches) initMention() {
 	c.mention = result.New([]result.Lookup{
 		{Name: "ID"},
@@ -401,6 +469,19 @@ func (c *GTSCaches) initStatusFave() {
 	c.status.IgnoreErrors(ignoreErrors)
 }
 
+func (c *GTSCaches) initTag() {
+	c.tag = result.New([]result.Lookup{
+		{Name: "ID"},
+		{Name: "Name"},
+	}, func(t1 *gtsmodel.Tag) *gtsmodel.Tag {
+		t2 := new(gtsmodel.Tag)
+		*t2 = *t1
+		return t2
+	}, config.GetCacheGTSTagMaxSize())
+	c.tag.SetTTL(config.GetCacheGTSTagTTL(), true)
+	c.tag.IgnoreErrors(ignoreErrors)
+}
+
 func (c *GTSCaches) initTombstone() {
 	c.tombstone = result.New([]result.Lookup{
 		{Name: "ID"},
@@ -436,3 +517,12 @@ func (c *GTSCaches) initWebfinger() {
 		config.GetCacheGTSWebfingerMaxSize(),
 		config.GetCacheGTSWebfingerTTL())
 }
+
+func (c *GTSCaches) initWebfingerResponse() {
+	// Keep responses around for the stale
+	// window after they stop being fresh.
+	c.webfingerResponse = ttl.New[string, *Response](
+		0,
+		config.GetCacheGTSWebfingerResponseMaxSize(),
+		config.GetCacheGTSWebfingerResponseTTL()+config.GetCacheGTSWebfingerResponseStaleWindow())
+}
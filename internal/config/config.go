@@ -47,6 +47,8 @@ type Configuration struct {
 	LogLevel        string   `name:"log-level" usage:"Log level to run at: [trace, debug, info, warn, fatal]"`
 	LogDbQueries    bool     `name:"log-db-queries" usage:"Log database queries verbosely when log-level is trace or debug"`
 	LogClientIP     bool     `name:"log-client-ip" usage:"Include the client IP in logs"`
+	LogGeoIP        bool     `name:"log-geoip" usage:"Record the country of sign-ins, resolved using the GeoIP database at geoip-db-path, for instance activity statistics. Client IPs are never stored."`
+	GeoIPDBPath     string   `name:"geoip-db-path" usage:"Path to a MaxMind GeoLite2 / GeoIP2 country database (.mmdb) to use when log-geoip is enabled"`
 	ApplicationName string   `name:"application-name" usage:"Name of the application, used in various places internally"`
 	LandingPageUser string   `name:"landing-page-user" usage:"the user that should be shown on the instance's landing page"`
 	ConfigPath      string   `name:"config-path" usage:"Path to a file containing gotosocial configuration. Values set in this file will be overwritten by values set as env vars or arguments"`
@@ -75,11 +77,12 @@ type Configuration struct {
 	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
 	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
 
-	InstanceExposePeers            bool `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceExposePeers            bool   `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended        bool   `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb     bool   `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposePublicTimeline   bool   `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceDeliverToSharedInboxes bool   `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceContactAccountUsername string `name:"instance-contact-account-username" usage:"Username of the local account to show as the contact account for this instance. If not set, the contact account set via the settings panel will be used, falling back to the first admin account."`
 
 	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
 	AccountsApprovalRequired bool `name:"accounts-approval-required" usage:"Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved."`
@@ -87,6 +90,12 @@ type Configuration struct {
 	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
 	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
 
+	AccountsDeleteNotificationsBatchSize  int           `name:"accounts-delete-notifications-batch-size" usage:"When deleting an account, delete its notifications in batches of this many, rather than all at once. 0 disables batching."`
+	AccountsDeleteNotificationsBatchPause time.Duration `name:"accounts-delete-notifications-batch-pause" usage:"Time to pause between batches of notifications deleted when deleting an account, to let other database writers through."`
+
+	AccountsAdminDeleteLimit  int           `name:"accounts-admin-delete-limit" usage:"Maximum number of accounts a single admin can delete (suspend) within accounts-admin-delete-window. 0 or less turns the limit off."`
+	AccountsAdminDeleteWindow time.Duration `name:"accounts-admin-delete-window" usage:"Time window over which accounts-admin-delete-limit is counted."`
+
 	MediaImageMaxSize        bytesize.Size `name:"media-image-max-size" usage:"Max size of accepted images in bytes"`
 	MediaVideoMaxSize        bytesize.Size `name:"media-video-max-size" usage:"Max size of accepted videos in bytes"`
 	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
@@ -94,15 +103,24 @@ type Configuration struct {
 	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
 	MediaEmojiLocalMaxSize   bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
 	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
-
-	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
-	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
-	StorageS3Endpoint    string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
-	StorageS3AccessKey   string `name:"storage-s3-access-key" usage:"S3 Access Key"`
-	StorageS3SecretKey   string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
-	StorageS3UseSSL      bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
-	StorageS3BucketName  string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
-	StorageS3Proxy       bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	MediaStripMetadata       bool          `name:"media-strip-metadata" usage:"Strip EXIF and other metadata (including GPS location) from jpeg, png and webp images before storing them. Image orientation is preserved."`
+	MediaRefetchTimeout      time.Duration `name:"media-refetch-timeout" usage:"Maximum time an admin-triggered media refetch may run for before it is aborted."`
+	MediaRefetchEmojiTimeout time.Duration `name:"media-refetch-emoji-timeout" usage:"Maximum time to spend refetching a single remote emoji during a media refetch."`
+	MediaPerDomainCacheLimit bytesize.Size `name:"media-per-domain-cache-limit" usage:"Max size in bytes of cached remote media from any single domain. Least recently updated media over this limit will be uncached. If set to 0, there is no limit."`
+	MediaPruneDeadInstances  bool          `name:"media-prune-dead-instances" usage:"During media pruning, fully delete uncached remote media which has repeatedly failed to be fetched again, since it most likely belongs to an instance which is permanently gone."`
+	MediaRecacheTimeout      time.Duration `name:"media-recache-timeout" usage:"Maximum time to wait for uncached remote media to be fetched again when it is requested, before serving a placeholder instead. The fetch carries on in the background. If set to 0, wait until the fetch is done."`
+	MediaWarmAfterPrune      bool          `name:"media-warm-after-prune" usage:"After media pruning, fetch again uncached remote media from the statuses most recently faved or boosted, up to media-warm-budget bytes, so they load quickly when next viewed."`
+	MediaWarmBudget          bytesize.Size `name:"media-warm-budget" usage:"Max size in bytes of remote media to fetch again when warming the cache after media pruning."`
+
+	StorageBackend           string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
+	StorageLocalBasePath     string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
+	StorageMediaPathTemplate string `name:"storage-media-path-template" usage:"Template for the storage path of newly stored media attachments. Supported placeholders: {accountID}, {mediaType}, {mediaSize}, {mediaID}, {extension}, {yyyy}, {mm}, {dd}. Must include {mediaID} and {mediaSize}."`
+	StorageS3Endpoint        string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
+	StorageS3AccessKey       string `name:"storage-s3-access-key" usage:"S3 Access Key"`
+	StorageS3SecretKey       string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
+	StorageS3UseSSL          bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
+	StorageS3BucketName      string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
+	StorageS3Proxy           bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
 
 	StatusesMaxChars           int `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses"`
 	StatusesCWMaxChars         int `name:"statuses-cw-max-chars" usage:"Max permitted characters for content/spoiler warnings on statuses"`
@@ -127,6 +145,7 @@ type Configuration struct {
 	OIDCScopes           []string `name:"oidc-scopes" usage:"OIDC scopes."`
 	OIDCLinkExisting     bool     `name:"oidc-link-existing" usage:"link existing user accounts to OIDC logins based on the stored email value"`
 	OIDCAdminGroups      []string `name:"oidc-admin-groups" usage:"Membership of one of the listed groups makes someone a GtS admin"`
+	OIDCAutoProvision    bool     `name:"oidc-auto-provision" usage:"Create a new account for OIDC logins that don't match an existing user. If false, only existing users may log in via OIDC."`
 
 	TracingEnabled           bool   `name:"tracing-enabled" usage:"Enable OTLP Tracing"`
 	TracingTransport         string `name:"tracing-transport" usage:"grpc or jaeger"`
@@ -208,9 +227,10 @@ type GTSCacheConfiguration struct {
 	ListEntryTTL       time.Duration `name:"list-entry-ttl"`
 	ListEntrySweepFreq time.Duration `name:"list-entry-sweep-freq"`
 
-	MediaMaxSize   int           `name:"media-max-size"`
-	MediaTTL       time.Duration `name:"media-ttl"`
-	MediaSweepFreq time.Duration `name:"media-sweep-freq"`
+	MediaMaxSize     int           `name:"media-max-size"`
+	MediaTTL         time.Duration `name:"media-ttl"`
+	MediaSweepFreq   time.Duration `name:"media-sweep-freq"`
+	MediaStaleWindow time.Duration `name:"media-stale-window"`
 
 	MentionMaxSize   int           `name:"mention-max-size"`
 	MentionTTL       time.Duration `name:"mention-ttl"`
@@ -232,6 +252,10 @@ type GTSCacheConfiguration struct {
 	StatusFaveTTL       time.Duration `name:"status-fave-ttl"`
 	StatusFaveSweepFreq time.Duration `name:"status-fave-sweep-freq"`
 
+	TagMaxSize   int           `name:"tag-max-size"`
+	TagTTL       time.Duration `name:"tag-ttl"`
+	TagSweepFreq time.Duration `name:"tag-sweep-freq"`
+
 	TombstoneMaxSize   int           `name:"tombstone-max-size"`
 	TombstoneTTL       time.Duration `name:"tombstone-ttl"`
 	TombstoneSweepFreq time.Duration `name:"tombstone-sweep-freq"`
@@ -243,6 +267,10 @@ type GTSCacheConfiguration struct {
 	WebfingerMaxSize   int           `name:"webfinger-max-size"`
 	WebfingerTTL       time.Duration `name:"webfinger-ttl"`
 	WebfingerSweepFreq time.Duration `name:"webfinger-sweep-freq"`
+
+	WebfingerResponseMaxSize     int           `name:"webfinger-response-max-size"`
+	WebfingerResponseTTL         time.Duration `name:"webfinger-response-ttl"`
+	WebfingerResponseStaleWindow time.Duration `name:"webfinger-response-stale-window"`
 }
 
 // MarshalMap will marshal current Configuration into a map structure (useful for JSON/TOML/YAML).
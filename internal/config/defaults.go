@@ -60,6 +60,7 @@ var Defaults = Configuration{
 	InstanceExposeSuspended:        false,
 	InstanceExposeSuspendedWeb:     false,
 	InstanceDeliverToSharedInboxes: true,
+	InstanceContactAccountUsername: "",
 
 	AccountsRegistrationOpen: true,
 	AccountsApprovalRequired: true,
@@ -67,6 +68,12 @@ var Defaults = Configuration{
 	AccountsAllowCustomCSS:   false,
 	AccountsCustomCSSLength:  10000,
 
+	AccountsDeleteNotificationsBatchSize:  5000,
+	AccountsDeleteNotificationsBatchPause: 100 * time.Millisecond,
+
+	AccountsAdminDeleteLimit:  10,
+	AccountsAdminDeleteWindow: time.Hour,
+
 	MediaImageMaxSize:        10 * bytesize.MiB,
 	MediaVideoMaxSize:        40 * bytesize.MiB,
 	MediaDescriptionMinChars: 0,
@@ -74,11 +81,20 @@ var Defaults = Configuration{
 	MediaRemoteCacheDays:     30,
 	MediaEmojiLocalMaxSize:   50 * bytesize.KiB,
 	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
-
-	StorageBackend:       "local",
-	StorageLocalBasePath: "/gotosocial/storage",
-	StorageS3UseSSL:      true,
-	StorageS3Proxy:       false,
+	MediaStripMetadata:       true,
+	MediaRefetchTimeout:      time.Hour,
+	MediaRefetchEmojiTimeout: time.Minute,
+	MediaPerDomainCacheLimit: 0,
+	MediaPruneDeadInstances:  false,
+	MediaRecacheTimeout:      5 * time.Second,
+	MediaWarmAfterPrune:      false,
+	MediaWarmBudget:          100 * bytesize.MiB,
+
+	StorageBackend:           "local",
+	StorageLocalBasePath:     "/gotosocial/storage",
+	StorageMediaPathTemplate: "{accountID}/attachment/{mediaSize}/{mediaID}.{extension}",
+	StorageS3UseSSL:          true,
+	StorageS3Proxy:           false,
 
 	StatusesMaxChars:           5000,
 	StatusesCWMaxChars:         100,
@@ -102,6 +118,7 @@ var Defaults = Configuration{
 	OIDCClientSecret:     "",
 	OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
 	OIDCLinkExisting:     false,
+	OIDCAutoProvision:    true,
 
 	SMTPHost:               "",
 	SMTPPort:               0,
@@ -162,9 +179,10 @@ var Defaults = Configuration{
 			ListEntryTTL:       time.Minute * 30,
 			ListEntrySweepFreq: time.Minute,
 
-			MediaMaxSize:   1000,
-			MediaTTL:       time.Minute * 30,
-			MediaSweepFreq: time.Minute,
+			MediaMaxSize:     1000,
+			MediaTTL:         time.Minute * 30,
+			MediaSweepFreq:   time.Minute,
+			MediaStaleWindow: 0,
 
 			MentionMaxSize:   2000,
 			MentionTTL:       time.Minute * 30,
@@ -186,6 +204,10 @@ var Defaults = Configuration{
 			StatusFaveTTL:       time.Minute * 30,
 			StatusFaveSweepFreq: time.Minute,
 
+			TagMaxSize:   2000,
+			TagTTL:       time.Minute * 30,
+			TagSweepFreq: time.Minute,
+
 			TombstoneMaxSize:   500,
 			TombstoneTTL:       time.Minute * 30,
 			TombstoneSweepFreq: time.Minute,
@@ -197,6 +219,10 @@ var Defaults = Configuration{
 			WebfingerMaxSize:   250,
 			WebfingerTTL:       time.Hour * 24,
 			WebfingerSweepFreq: time.Minute * 15,
+
+			WebfingerResponseMaxSize:     250,
+			WebfingerResponseTTL:         time.Hour,
+			WebfingerResponseStaleWindow: time.Hour * 24,
 		},
 
 		VisibilityMaxSize:   2000,
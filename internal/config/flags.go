@@ -39,6 +39,8 @@ func (s *ConfigState) AddGlobalFlags(cmd *cobra.Command) {
 		cmd.PersistentFlags().String(ProtocolFlag(), cfg.Protocol, fieldtag("Protocol", "usage"))
 		cmd.PersistentFlags().String(LogLevelFlag(), cfg.LogLevel, fieldtag("LogLevel", "usage"))
 		cmd.PersistentFlags().Bool(LogDbQueriesFlag(), cfg.LogDbQueries, fieldtag("LogDbQueries", "usage"))
+		cmd.PersistentFlags().Bool(LogGeoIPFlag(), cfg.LogGeoIP, fieldtag("LogGeoIP", "usage"))
+		cmd.PersistentFlags().String(GeoIPDBPathFlag(), cfg.GeoIPDBPath, fieldtag("GeoIPDBPath", "usage"))
 		cmd.PersistentFlags().String(ConfigPathFlag(), cfg.ConfigPath, fieldtag("ConfigPath", "usage"))
 
 		// Database
@@ -80,12 +82,17 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
 		cmd.Flags().Bool(InstanceDeliverToSharedInboxesFlag(), cfg.InstanceDeliverToSharedInboxes, fieldtag("InstanceDeliverToSharedInboxes", "usage"))
+		cmd.Flags().String(InstanceContactAccountUsernameFlag(), cfg.InstanceContactAccountUsername, fieldtag("InstanceContactAccountUsername", "usage"))
 
 		// Accounts
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
 		cmd.Flags().Bool(AccountsApprovalRequiredFlag(), cfg.AccountsApprovalRequired, fieldtag("AccountsApprovalRequired", "usage"))
 		cmd.Flags().Bool(AccountsReasonRequiredFlag(), cfg.AccountsReasonRequired, fieldtag("AccountsReasonRequired", "usage"))
 		cmd.Flags().Bool(AccountsAllowCustomCSSFlag(), cfg.AccountsAllowCustomCSS, fieldtag("AccountsAllowCustomCSS", "usage"))
+		cmd.Flags().Int(AccountsDeleteNotificationsBatchSizeFlag(), cfg.AccountsDeleteNotificationsBatchSize, fieldtag("AccountsDeleteNotificationsBatchSize", "usage"))
+		cmd.Flags().Duration(AccountsDeleteNotificationsBatchPauseFlag(), cfg.AccountsDeleteNotificationsBatchPause, fieldtag("AccountsDeleteNotificationsBatchPause", "usage"))
+		cmd.Flags().Int(AccountsAdminDeleteLimitFlag(), cfg.AccountsAdminDeleteLimit, fieldtag("AccountsAdminDeleteLimit", "usage"))
+		cmd.Flags().Duration(AccountsAdminDeleteWindowFlag(), cfg.AccountsAdminDeleteWindow, fieldtag("AccountsAdminDeleteWindow", "usage"))
 
 		// Media
 		cmd.Flags().Uint64(MediaImageMaxSizeFlag(), uint64(cfg.MediaImageMaxSize), fieldtag("MediaImageMaxSize", "usage"))
@@ -95,10 +102,19 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Int(MediaRemoteCacheDaysFlag(), cfg.MediaRemoteCacheDays, fieldtag("MediaRemoteCacheDays", "usage"))
 		cmd.Flags().Uint64(MediaEmojiLocalMaxSizeFlag(), uint64(cfg.MediaEmojiLocalMaxSize), fieldtag("MediaEmojiLocalMaxSize", "usage"))
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
+		cmd.Flags().Bool(MediaStripMetadataFlag(), cfg.MediaStripMetadata, fieldtag("MediaStripMetadata", "usage"))
+		cmd.Flags().Duration(MediaRefetchTimeoutFlag(), cfg.MediaRefetchTimeout, fieldtag("MediaRefetchTimeout", "usage"))
+		cmd.Flags().Duration(MediaRefetchEmojiTimeoutFlag(), cfg.MediaRefetchEmojiTimeout, fieldtag("MediaRefetchEmojiTimeout", "usage"))
+		cmd.Flags().Uint64(MediaPerDomainCacheLimitFlag(), uint64(cfg.MediaPerDomainCacheLimit), fieldtag("MediaPerDomainCacheLimit", "usage"))
+		cmd.Flags().Bool(MediaPruneDeadInstancesFlag(), cfg.MediaPruneDeadInstances, fieldtag("MediaPruneDeadInstances", "usage"))
+		cmd.Flags().Duration(MediaRecacheTimeoutFlag(), cfg.MediaRecacheTimeout, fieldtag("MediaRecacheTimeout", "usage"))
+		cmd.Flags().Bool(MediaWarmAfterPruneFlag(), cfg.MediaWarmAfterPrune, fieldtag("MediaWarmAfterPrune", "usage"))
+		cmd.Flags().Uint64(MediaWarmBudgetFlag(), uint64(cfg.MediaWarmBudget), fieldtag("MediaWarmBudget", "usage"))
 
 		// Storage
 		cmd.Flags().String(StorageBackendFlag(), cfg.StorageBackend, fieldtag("StorageBackend", "usage"))
 		cmd.Flags().String(StorageLocalBasePathFlag(), cfg.StorageLocalBasePath, fieldtag("StorageLocalBasePath", "usage"))
+		cmd.Flags().String(StorageMediaPathTemplateFlag(), cfg.StorageMediaPathTemplate, fieldtag("StorageMediaPathTemplate", "usage"))
 
 		// Statuses
 		cmd.Flags().Int(StatusesMaxCharsFlag(), cfg.StatusesMaxChars, fieldtag("StatusesMaxChars", "usage"))
@@ -125,6 +141,7 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().String(OIDCClientIDFlag(), cfg.OIDCClientID, fieldtag("OIDCClientID", "usage"))
 		cmd.Flags().String(OIDCClientSecretFlag(), cfg.OIDCClientSecret, fieldtag("OIDCClientSecret", "usage"))
 		cmd.Flags().StringSlice(OIDCScopesFlag(), cfg.OIDCScopes, fieldtag("OIDCScopes", "usage"))
+		cmd.Flags().Bool(OIDCAutoProvisionFlag(), cfg.OIDCAutoProvision, fieldtag("OIDCAutoProvision", "usage"))
 
 		// SMTP
 		cmd.Flags().String(SMTPHostFlag(), cfg.SMTPHost, fieldtag("SMTPHost", "usage"))
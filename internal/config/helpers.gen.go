@@ -99,6 +99,56 @@ func GetLogClientIP() bool { return global.GetLogClientIP() }
 // SetLogClientIP safely sets the value for global configuration 'LogClientIP' field
 func SetLogClientIP(v bool) { global.SetLogClientIP(v) }
 
+// GetLogGeoIP safely fetches the Configuration value for state's 'LogGeoIP' field
+func (st *ConfigState) GetLogGeoIP() (v bool) {
+	st.mutex.Lock()
+	v = st.config.LogGeoIP
+	st.mutex.Unlock()
+	return
+}
+
+// SetLogGeoIP safely sets the Configuration value for state's 'LogGeoIP' field
+func (st *ConfigState) SetLogGeoIP(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.LogGeoIP = v
+	st.reloadToViper()
+}
+
+// LogGeoIPFlag returns the flag name for the 'LogGeoIP' field
+func LogGeoIPFlag() string { return "log-geoip" }
+
+// GetLogGeoIP safely fetches the value for global configuration 'LogGeoIP' field
+func GetLogGeoIP() bool { return global.GetLogGeoIP() }
+
+// SetLogGeoIP safely sets the value for global configuration 'LogGeoIP' field
+func SetLogGeoIP(v bool) { global.SetLogGeoIP(v) }
+
+// GetGeoIPDBPath safely fetches the Configuration value for state's 'GeoIPDBPath' field
+func (st *ConfigState) GetGeoIPDBPath() (v string) {
+	st.mutex.Lock()
+	v = st.config.GeoIPDBPath
+	st.mutex.Unlock()
+	return
+}
+
+// SetGeoIPDBPath safely sets the Configuration value for state's 'GeoIPDBPath' field
+func (st *ConfigState) SetGeoIPDBPath(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.GeoIPDBPath = v
+	st.reloadToViper()
+}
+
+// GeoIPDBPathFlag returns the flag name for the 'GeoIPDBPath' field
+func GeoIPDBPathFlag() string { return "geoip-db-path" }
+
+// GetGeoIPDBPath safely fetches the value for global configuration 'GeoIPDBPath' field
+func GetGeoIPDBPath() string { return global.GetGeoIPDBPath() }
+
+// SetGeoIPDBPath safely sets the value for global configuration 'GeoIPDBPath' field
+func SetGeoIPDBPath(v string) { global.SetGeoIPDBPath(v) }
+
 // GetApplicationName safely fetches the Configuration value for state's 'ApplicationName' field
 func (st *ConfigState) GetApplicationName() (v string) {
 	st.mutex.Lock()
@@ -849,6 +899,31 @@ func GetInstanceDeliverToSharedInboxes() bool { return global.GetInstanceDeliver
 // SetInstanceDeliverToSharedInboxes safely sets the value for global configuration 'InstanceDeliverToSharedInboxes' field
 func SetInstanceDeliverToSharedInboxes(v bool) { global.SetInstanceDeliverToSharedInboxes(v) }
 
+// GetInstanceContactAccountUsername safely fetches the Configuration value for state's 'InstanceContactAccountUsername' field
+func (st *ConfigState) GetInstanceContactAccountUsername() (v string) {
+	st.mutex.Lock()
+	v = st.config.InstanceContactAccountUsername
+	st.mutex.Unlock()
+	return
+}
+
+// SetInstanceContactAccountUsername safely sets the Configuration value for state's 'InstanceContactAccountUsername' field
+func (st *ConfigState) SetInstanceContactAccountUsername(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceContactAccountUsername = v
+	st.reloadToViper()
+}
+
+// InstanceContactAccountUsernameFlag returns the flag name for the 'InstanceContactAccountUsername' field
+func InstanceContactAccountUsernameFlag() string { return "instance-contact-account-username" }
+
+// GetInstanceContactAccountUsername safely fetches the value for global configuration 'InstanceContactAccountUsername' field
+func GetInstanceContactAccountUsername() string { return global.GetInstanceContactAccountUsername() }
+
+// SetInstanceContactAccountUsername safely sets the value for global configuration 'InstanceContactAccountUsername' field
+func SetInstanceContactAccountUsername(v string) { global.SetInstanceContactAccountUsername(v) }
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.Lock()
@@ -974,6 +1049,118 @@ func GetAccountsCustomCSSLength() int { return global.GetAccountsCustomCSSLength
 // SetAccountsCustomCSSLength safely sets the value for global configuration 'AccountsCustomCSSLength' field
 func SetAccountsCustomCSSLength(v int) { global.SetAccountsCustomCSSLength(v) }
 
+// GetAccountsDeleteNotificationsBatchSize safely fetches the Configuration value for state's 'AccountsDeleteNotificationsBatchSize' field
+func (st *ConfigState) GetAccountsDeleteNotificationsBatchSize() (v int) {
+	st.mutex.Lock()
+	v = st.config.AccountsDeleteNotificationsBatchSize
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsDeleteNotificationsBatchSize safely sets the Configuration value for state's 'AccountsDeleteNotificationsBatchSize' field
+func (st *ConfigState) SetAccountsDeleteNotificationsBatchSize(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsDeleteNotificationsBatchSize = v
+	st.reloadToViper()
+}
+
+// AccountsDeleteNotificationsBatchSizeFlag returns the flag name for the 'AccountsDeleteNotificationsBatchSize' field
+func AccountsDeleteNotificationsBatchSizeFlag() string {
+	return "accounts-delete-notifications-batch-size"
+}
+
+// GetAccountsDeleteNotificationsBatchSize safely fetches the value for global configuration 'AccountsDeleteNotificationsBatchSize' field
+func GetAccountsDeleteNotificationsBatchSize() int {
+	return global.GetAccountsDeleteNotificationsBatchSize()
+}
+
+// SetAccountsDeleteNotificationsBatchSize safely sets the value for global configuration 'AccountsDeleteNotificationsBatchSize' field
+func SetAccountsDeleteNotificationsBatchSize(v int) {
+	global.SetAccountsDeleteNotificationsBatchSize(v)
+}
+
+// GetAccountsDeleteNotificationsBatchPause safely fetches the Configuration value for state's 'AccountsDeleteNotificationsBatchPause' field
+func (st *ConfigState) GetAccountsDeleteNotificationsBatchPause() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.AccountsDeleteNotificationsBatchPause
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsDeleteNotificationsBatchPause safely sets the Configuration value for state's 'AccountsDeleteNotificationsBatchPause' field
+func (st *ConfigState) SetAccountsDeleteNotificationsBatchPause(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsDeleteNotificationsBatchPause = v
+	st.reloadToViper()
+}
+
+// AccountsDeleteNotificationsBatchPauseFlag returns the flag name for the 'AccountsDeleteNotificationsBatchPause' field
+func AccountsDeleteNotificationsBatchPauseFlag() string {
+	return "accounts-delete-notifications-batch-pause"
+}
+
+// GetAccountsDeleteNotificationsBatchPause safely fetches the value for global configuration 'AccountsDeleteNotificationsBatchPause' field
+func GetAccountsDeleteNotificationsBatchPause() time.Duration {
+	return global.GetAccountsDeleteNotificationsBatchPause()
+}
+
+// SetAccountsDeleteNotificationsBatchPause safely sets the value for global configuration 'AccountsDeleteNotificationsBatchPause' field
+func SetAccountsDeleteNotificationsBatchPause(v time.Duration) {
+	global.SetAccountsDeleteNotificationsBatchPause(v)
+}
+
+// GetAccountsAdminDeleteLimit safely fetches the Configuration value for state's 'AccountsAdminDeleteLimit' field
+func (st *ConfigState) GetAccountsAdminDeleteLimit() (v int) {
+	st.mutex.Lock()
+	v = st.config.AccountsAdminDeleteLimit
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsAdminDeleteLimit safely sets the Configuration value for state's 'AccountsAdminDeleteLimit' field
+func (st *ConfigState) SetAccountsAdminDeleteLimit(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsAdminDeleteLimit = v
+	st.reloadToViper()
+}
+
+// AccountsAdminDeleteLimitFlag returns the flag name for the 'AccountsAdminDeleteLimit' field
+func AccountsAdminDeleteLimitFlag() string { return "accounts-admin-delete-limit" }
+
+// GetAccountsAdminDeleteLimit safely fetches the value for global configuration 'AccountsAdminDeleteLimit' field
+func GetAccountsAdminDeleteLimit() int { return global.GetAccountsAdminDeleteLimit() }
+
+// SetAccountsAdminDeleteLimit safely sets the value for global configuration 'AccountsAdminDeleteLimit' field
+func SetAccountsAdminDeleteLimit(v int) { global.SetAccountsAdminDeleteLimit(v) }
+
+// GetAccountsAdminDeleteWindow safely fetches the Configuration value for state's 'AccountsAdminDeleteWindow' field
+func (st *ConfigState) GetAccountsAdminDeleteWindow() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.AccountsAdminDeleteWindow
+	st.mutex.Unlock()
+	return
+}
+
+// SetAccountsAdminDeleteWindow safely sets the Configuration value for state's 'AccountsAdminDeleteWindow' field
+func (st *ConfigState) SetAccountsAdminDeleteWindow(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsAdminDeleteWindow = v
+	st.reloadToViper()
+}
+
+// AccountsAdminDeleteWindowFlag returns the flag name for the 'AccountsAdminDeleteWindow' field
+func AccountsAdminDeleteWindowFlag() string { return "accounts-admin-delete-window" }
+
+// GetAccountsAdminDeleteWindow safely fetches the value for global configuration 'AccountsAdminDeleteWindow' field
+func GetAccountsAdminDeleteWindow() time.Duration { return global.GetAccountsAdminDeleteWindow() }
+
+// SetAccountsAdminDeleteWindow safely sets the value for global configuration 'AccountsAdminDeleteWindow' field
+func SetAccountsAdminDeleteWindow(v time.Duration) { global.SetAccountsAdminDeleteWindow(v) }
+
 // GetMediaImageMaxSize safely fetches the Configuration value for state's 'MediaImageMaxSize' field
 func (st *ConfigState) GetMediaImageMaxSize() (v bytesize.Size) {
 	st.mutex.Lock()
@@ -1149,6 +1336,206 @@ func GetMediaEmojiRemoteMaxSize() bytesize.Size { return global.GetMediaEmojiRem
 // SetMediaEmojiRemoteMaxSize safely sets the value for global configuration 'MediaEmojiRemoteMaxSize' field
 func SetMediaEmojiRemoteMaxSize(v bytesize.Size) { global.SetMediaEmojiRemoteMaxSize(v) }
 
+// GetMediaStripMetadata safely fetches the Configuration value for state's 'MediaStripMetadata' field
+func (st *ConfigState) GetMediaStripMetadata() (v bool) {
+	st.mutex.Lock()
+	v = st.config.MediaStripMetadata
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaStripMetadata safely sets the Configuration value for state's 'MediaStripMetadata' field
+func (st *ConfigState) SetMediaStripMetadata(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaStripMetadata = v
+	st.reloadToViper()
+}
+
+// MediaStripMetadataFlag returns the flag name for the 'MediaStripMetadata' field
+func MediaStripMetadataFlag() string { return "media-strip-metadata" }
+
+// GetMediaStripMetadata safely fetches the value for global configuration 'MediaStripMetadata' field
+func GetMediaStripMetadata() bool { return global.GetMediaStripMetadata() }
+
+// SetMediaStripMetadata safely sets the value for global configuration 'MediaStripMetadata' field
+func SetMediaStripMetadata(v bool) { global.SetMediaStripMetadata(v) }
+
+// GetMediaRefetchTimeout safely fetches the Configuration value for state's 'MediaRefetchTimeout' field
+func (st *ConfigState) GetMediaRefetchTimeout() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.MediaRefetchTimeout
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaRefetchTimeout safely sets the Configuration value for state's 'MediaRefetchTimeout' field
+func (st *ConfigState) SetMediaRefetchTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaRefetchTimeout = v
+	st.reloadToViper()
+}
+
+// MediaRefetchTimeoutFlag returns the flag name for the 'MediaRefetchTimeout' field
+func MediaRefetchTimeoutFlag() string { return "media-refetch-timeout" }
+
+// GetMediaRefetchTimeout safely fetches the value for global configuration 'MediaRefetchTimeout' field
+func GetMediaRefetchTimeout() time.Duration { return global.GetMediaRefetchTimeout() }
+
+// SetMediaRefetchTimeout safely sets the value for global configuration 'MediaRefetchTimeout' field
+func SetMediaRefetchTimeout(v time.Duration) { global.SetMediaRefetchTimeout(v) }
+
+// GetMediaRefetchEmojiTimeout safely fetches the Configuration value for state's 'MediaRefetchEmojiTimeout' field
+func (st *ConfigState) GetMediaRefetchEmojiTimeout() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.MediaRefetchEmojiTimeout
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaRefetchEmojiTimeout safely sets the Configuration value for state's 'MediaRefetchEmojiTimeout' field
+func (st *ConfigState) SetMediaRefetchEmojiTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaRefetchEmojiTimeout = v
+	st.reloadToViper()
+}
+
+// MediaRefetchEmojiTimeoutFlag returns the flag name for the 'MediaRefetchEmojiTimeout' field
+func MediaRefetchEmojiTimeoutFlag() string { return "media-refetch-emoji-timeout" }
+
+// GetMediaRefetchEmojiTimeout safely fetches the value for global configuration 'MediaRefetchEmojiTimeout' field
+func GetMediaRefetchEmojiTimeout() time.Duration { return global.GetMediaRefetchEmojiTimeout() }
+
+// SetMediaRefetchEmojiTimeout safely sets the value for global configuration 'MediaRefetchEmojiTimeout' field
+func SetMediaRefetchEmojiTimeout(v time.Duration) { global.SetMediaRefetchEmojiTimeout(v) }
+
+// GetMediaPerDomainCacheLimit safely fetches the Configuration value for state's 'MediaPerDomainCacheLimit' field
+func (st *ConfigState) GetMediaPerDomainCacheLimit() (v bytesize.Size) {
+	st.mutex.Lock()
+	v = st.config.MediaPerDomainCacheLimit
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaPerDomainCacheLimit safely sets the Configuration value for state's 'MediaPerDomainCacheLimit' field
+func (st *ConfigState) SetMediaPerDomainCacheLimit(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaPerDomainCacheLimit = v
+	st.reloadToViper()
+}
+
+// MediaPerDomainCacheLimitFlag returns the flag name for the 'MediaPerDomainCacheLimit' field
+func MediaPerDomainCacheLimitFlag() string { return "media-per-domain-cache-limit" }
+
+// GetMediaPerDomainCacheLimit safely fetches the value for global configuration 'MediaPerDomainCacheLimit' field
+func GetMediaPerDomainCacheLimit() bytesize.Size { return global.GetMediaPerDomainCacheLimit() }
+
+// SetMediaPerDomainCacheLimit safely sets the value for global configuration 'MediaPerDomainCacheLimit' field
+func SetMediaPerDomainCacheLimit(v bytesize.Size) { global.SetMediaPerDomainCacheLimit(v) }
+
+// GetMediaPruneDeadInstances safely fetches the Configuration value for state's 'MediaPruneDeadInstances' field
+func (st *ConfigState) GetMediaPruneDeadInstances() (v bool) {
+	st.mutex.Lock()
+	v = st.config.MediaPruneDeadInstances
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaPruneDeadInstances safely sets the Configuration value for state's 'MediaPruneDeadInstances' field
+func (st *ConfigState) SetMediaPruneDeadInstances(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaPruneDeadInstances = v
+	st.reloadToViper()
+}
+
+// MediaPruneDeadInstancesFlag returns the flag name for the 'MediaPruneDeadInstances' field
+func MediaPruneDeadInstancesFlag() string { return "media-prune-dead-instances" }
+
+// GetMediaPruneDeadInstances safely fetches the value for global configuration 'MediaPruneDeadInstances' field
+func GetMediaPruneDeadInstances() bool { return global.GetMediaPruneDeadInstances() }
+
+// SetMediaPruneDeadInstances safely sets the value for global configuration 'MediaPruneDeadInstances' field
+func SetMediaPruneDeadInstances(v bool) { global.SetMediaPruneDeadInstances(v) }
+
+// GetMediaRecacheTimeout safely fetches the Configuration value for state's 'MediaRecacheTimeout' field
+func (st *ConfigState) GetMediaRecacheTimeout() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.MediaRecacheTimeout
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaRecacheTimeout safely sets the Configuration value for state's 'MediaRecacheTimeout' field
+func (st *ConfigState) SetMediaRecacheTimeout(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaRecacheTimeout = v
+	st.reloadToViper()
+}
+
+// MediaRecacheTimeoutFlag returns the flag name for the 'MediaRecacheTimeout' field
+func MediaRecacheTimeoutFlag() string { return "media-recache-timeout" }
+
+// GetMediaRecacheTimeout safely fetches the value for global configuration 'MediaRecacheTimeout' field
+func GetMediaRecacheTimeout() time.Duration { return global.GetMediaRecacheTimeout() }
+
+// SetMediaRecacheTimeout safely sets the value for global configuration 'MediaRecacheTimeout' field
+func SetMediaRecacheTimeout(v time.Duration) { global.SetMediaRecacheTimeout(v) }
+
+// GetMediaWarmAfterPrune safely fetches the Configuration value for state's 'MediaWarmAfterPrune' field
+func (st *ConfigState) GetMediaWarmAfterPrune() (v bool) {
+	st.mutex.Lock()
+	v = st.config.MediaWarmAfterPrune
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaWarmAfterPrune safely sets the Configuration value for state's 'MediaWarmAfterPrune' field
+func (st *ConfigState) SetMediaWarmAfterPrune(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaWarmAfterPrune = v
+	st.reloadToViper()
+}
+
+// MediaWarmAfterPruneFlag returns the flag name for the 'MediaWarmAfterPrune' field
+func MediaWarmAfterPruneFlag() string { return "media-warm-after-prune" }
+
+// GetMediaWarmAfterPrune safely fetches the value for global configuration 'MediaWarmAfterPrune' field
+func GetMediaWarmAfterPrune() bool { return global.GetMediaWarmAfterPrune() }
+
+// SetMediaWarmAfterPrune safely sets the value for global configuration 'MediaWarmAfterPrune' field
+func SetMediaWarmAfterPrune(v bool) { global.SetMediaWarmAfterPrune(v) }
+
+// GetMediaWarmBudget safely fetches the Configuration value for state's 'MediaWarmBudget' field
+func (st *ConfigState) GetMediaWarmBudget() (v bytesize.Size) {
+	st.mutex.Lock()
+	v = st.config.MediaWarmBudget
+	st.mutex.Unlock()
+	return
+}
+
+// SetMediaWarmBudget safely sets the Configuration value for state's 'MediaWarmBudget' field
+func (st *ConfigState) SetMediaWarmBudget(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaWarmBudget = v
+	st.reloadToViper()
+}
+
+// MediaWarmBudgetFlag returns the flag name for the 'MediaWarmBudget' field
+func MediaWarmBudgetFlag() string { return "media-warm-budget" }
+
+// GetMediaWarmBudget safely fetches the value for global configuration 'MediaWarmBudget' field
+func GetMediaWarmBudget() bytesize.Size { return global.GetMediaWarmBudget() }
+
+// SetMediaWarmBudget safely sets the value for global configuration 'MediaWarmBudget' field
+func SetMediaWarmBudget(v bytesize.Size) { global.SetMediaWarmBudget(v) }
+
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
 	st.mutex.Lock()
@@ -1199,6 +1586,31 @@ func GetStorageLocalBasePath() string { return global.GetStorageLocalBasePath()
 // SetStorageLocalBasePath safely sets the value for global configuration 'StorageLocalBasePath' field
 func SetStorageLocalBasePath(v string) { global.SetStorageLocalBasePath(v) }
 
+// GetStorageMediaPathTemplate safely fetches the Configuration value for state's 'StorageMediaPathTemplate' field
+func (st *ConfigState) GetStorageMediaPathTemplate() (v string) {
+	st.mutex.Lock()
+	v = st.config.StorageMediaPathTemplate
+	st.mutex.Unlock()
+	return
+}
+
+// SetStorageMediaPathTemplate safely sets the Configuration value for state's 'StorageMediaPathTemplate' field
+func (st *ConfigState) SetStorageMediaPathTemplate(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageMediaPathTemplate = v
+	st.reloadToViper()
+}
+
+// StorageMediaPathTemplateFlag returns the flag name for the 'StorageMediaPathTemplate' field
+func StorageMediaPathTemplateFlag() string { return "storage-media-path-template" }
+
+// GetStorageMediaPathTemplate safely fetches the value for global configuration 'StorageMediaPathTemplate' field
+func GetStorageMediaPathTemplate() string { return global.GetStorageMediaPathTemplate() }
+
+// SetStorageMediaPathTemplate safely sets the value for global configuration 'StorageMediaPathTemplate' field
+func SetStorageMediaPathTemplate(v string) { global.SetStorageMediaPathTemplate(v) }
+
 // GetStorageS3Endpoint safely fetches the Configuration value for state's 'StorageS3Endpoint' field
 func (st *ConfigState) GetStorageS3Endpoint() (v string) {
 	st.mutex.Lock()
@@ -1849,6 +2261,31 @@ func GetOIDCAdminGroups() []string { return global.GetOIDCAdminGroups() }
 // SetOIDCAdminGroups safely sets the value for global configuration 'OIDCAdminGroups' field
 func SetOIDCAdminGroups(v []string) { global.SetOIDCAdminGroups(v) }
 
+// GetOIDCAutoProvision safely fetches the Configuration value for state's 'OIDCAutoProvision' field
+func (st *ConfigState) GetOIDCAutoProvision() (v bool) {
+	st.mutex.Lock()
+	v = st.config.OIDCAutoProvision
+	st.mutex.Unlock()
+	return
+}
+
+// SetOIDCAutoProvision safely sets the Configuration value for state's 'OIDCAutoProvision' field
+func (st *ConfigState) SetOIDCAutoProvision(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OIDCAutoProvision = v
+	st.reloadToViper()
+}
+
+// OIDCAutoProvisionFlag returns the flag name for the 'OIDCAutoProvision' field
+func OIDCAutoProvisionFlag() string { return "oidc-auto-provision" }
+
+// GetOIDCAutoProvision safely fetches the value for global configuration 'OIDCAutoProvision' field
+func GetOIDCAutoProvision() bool { return global.GetOIDCAutoProvision() }
+
+// SetOIDCAutoProvision safely sets the value for global configuration 'OIDCAutoProvision' field
+func SetOIDCAutoProvision(v bool) { global.SetOIDCAutoProvision(v) }
+
 // GetTracingEnabled safely fetches the Configuration value for state's 'TracingEnabled' field
 func (st *ConfigState) GetTracingEnabled() (v bool) {
 	st.mutex.Lock()
@@ -3053,6 +3490,31 @@ func GetCacheGTSMediaSweepFreq() time.Duration { return global.GetCacheGTSMediaS
 // SetCacheGTSMediaSweepFreq safely sets the value for global configuration 'Cache.GTS.MediaSweepFreq' field
 func SetCacheGTSMediaSweepFreq(v time.Duration) { global.SetCacheGTSMediaSweepFreq(v) }
 
+// GetCacheGTSMediaStaleWindow safely fetches the Configuration value for state's 'Cache.GTS.MediaStaleWindow' field
+func (st *ConfigState) GetCacheGTSMediaStaleWindow() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.Cache.GTS.MediaStaleWindow
+	st.mutex.Unlock()
+	return
+}
+
+// SetCacheGTSMediaStaleWindow safely sets the Configuration value for state's 'Cache.GTS.MediaStaleWindow' field
+func (st *ConfigState) SetCacheGTSMediaStaleWindow(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.GTS.MediaStaleWindow = v
+	st.reloadToViper()
+}
+
+// CacheGTSMediaStaleWindowFlag returns the flag name for the 'Cache.GTS.MediaStaleWindow' field
+func CacheGTSMediaStaleWindowFlag() string { return "cache-gts-media-stale-window" }
+
+// GetCacheGTSMediaStaleWindow safely fetches the value for global configuration 'Cache.GTS.MediaStaleWindow' field
+func GetCacheGTSMediaStaleWindow() time.Duration { return global.GetCacheGTSMediaStaleWindow() }
+
+// SetCacheGTSMediaStaleWindow safely sets the value for global configuration 'Cache.GTS.MediaStaleWindow' field
+func SetCacheGTSMediaStaleWindow(v time.Duration) { global.SetCacheGTSMediaStaleWindow(v) }
+
 // GetCacheGTSMentionMaxSize safely fetches the Configuration value for state's 'Cache.GTS.MentionMaxSize' field
 func (st *ConfigState) GetCacheGTSMentionMaxSize() (v int) {
 	st.mutex.Lock()
@@ -3430,6 +3892,81 @@ func GetCacheGTSStatusFaveSweepFreq() time.Duration { return global.GetCacheGTSS
 // SetCacheGTSStatusFaveSweepFreq safely sets the value for global configuration 'Cache.GTS.StatusFaveSweepFreq' field
 func SetCacheGTSStatusFaveSweepFreq(v time.Duration) { global.SetCacheGTSStatusFaveSweepFreq(v) }
 
+// GetCacheGTSTagMaxSize safely fetches the Configuration value for state's 'Cache.GTS.TagMaxSize' field
+func (st *ConfigState) GetCacheGTSTagMaxSize() (v int) {
+	st.mutex.Lock()
+	v = st.config.Cache.GTS.TagMaxSize
+	st.mutex.Unlock()
+	return
+}
+
+// SetCacheGTSTagMaxSize safely sets the Configuration value for state's 'Cache.GTS.TagMaxSize' field
+func (st *ConfigState) SetCacheGTSTagMaxSize(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.GTS.TagMaxSize = v
+	st.reloadToViper()
+}
+
+// CacheGTSTagMaxSizeFlag returns the flag name for the 'Cache.GTS.TagMaxSize' field
+func CacheGTSTagMaxSizeFlag() string { return "cache-gts-tag-max-size" }
+
+// GetCacheGTSTagMaxSize safely fetches the value for global configuration 'Cache.GTS.TagMaxSize' field
+func GetCacheGTSTagMaxSize() int { return global.GetCacheGTSTagMaxSize() }
+
+// SetCacheGTSTagMaxSize safely sets the value for global configuration 'Cache.GTS.TagMaxSize' field
+func SetCacheGTSTagMaxSize(v int) { global.SetCacheGTSTagMaxSize(v) }
+
+// GetCacheGTSTagTTL safely fetches the Configuration value for state's 'Cache.GTS.TagTTL' field
+func (st *ConfigState) GetCacheGTSTagTTL() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.Cache.GTS.TagTTL
+	st.mutex.Unlock()
+	return
+}
+
+// SetCacheGTSTagTTL safely sets the Configuration value for state's 'Cache.GTS.TagTTL' field
+func (st *ConfigState) SetCacheGTSTagTTL(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.GTS.TagTTL = v
+	st.reloadToViper()
+}
+
+// CacheGTSTagTTLFlag returns the flag name for the 'Cache.GTS.TagTTL' field
+func CacheGTSTagTTLFlag() string { return "cache-gts-tag-ttl" }
+
+// GetCacheGTSTagTTL safely fetches the value for global configuration 'Cache.GTS.TagTTL' field
+func GetCacheGTSTagTTL() time.Duration { return global.GetCacheGTSTagTTL() }
+
+// SetCacheGTSTagTTL safely sets the value for global configuration 'Cache.GTS.TagTTL' field
+func SetCacheGTSTagTTL(v time.Duration) { global.SetCacheGTSTagTTL(v) }
+
+// GetCacheGTSTagSweepFreq safely fetches the Configuration value for state's 'Cache.GTS.TagSweepFreq' field
+func (st *ConfigState) GetCacheGTSTagSweepFreq() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.Cache.GTS.TagSweepFreq
+	st.mutex.Unlock()
+	return
+}
+
+// SetCacheGTSTagSweepFreq safely sets the Configuration value for state's 'Cache.GTS.TagSweepFreq' field
+func (st *ConfigState) SetCacheGTSTagSweepFreq(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.GTS.TagSweepFreq = v
+	st.reloadToViper()
+}
+
+// CacheGTSTagSweepFreqFlag returns the flag name for the 'Cache.GTS.TagSweepFreq' field
+func CacheGTSTagSweepFreqFlag() string { return "cache-gts-tag-sweep-freq" }
+
+// GetCacheGTSTagSweepFreq safely fetches the value for global configuration 'Cache.GTS.TagSweepFreq' field
+func GetCacheGTSTagSweepFreq() time.Duration { return global.GetCacheGTSTagSweepFreq() }
+
+// SetCacheGTSTagSweepFreq safely sets the value for global configuration 'Cache.GTS.TagSweepFreq' field
+func SetCacheGTSTagSweepFreq(v time.Duration) { global.SetCacheGTSTagSweepFreq(v) }
+
 // GetCacheGTSTombstoneMaxSize safely fetches the Configuration value for state's 'Cache.GTS.TombstoneMaxSize' field
 func (st *ConfigState) GetCacheGTSTombstoneMaxSize() (v int) {
 	st.mutex.Lock()
@@ -3655,6 +4192,87 @@ func GetCacheGTSWebfingerSweepFreq() time.Duration { return global.GetCacheGTSWe
 // SetCacheGTSWebfingerSweepFreq safely sets the value for global configuration 'Cache.GTS.WebfingerSweepFreq' field
 func SetCacheGTSWebfingerSweepFreq(v time.Duration) { global.SetCacheGTSWebfingerSweepFreq(v) }
 
+// GetCacheGTSWebfingerResponseMaxSize safely fetches the Configuration value for state's 'Cache.GTS.WebfingerResponseMaxSize' field
+func (st *ConfigState) GetCacheGTSWebfingerResponseMaxSize() (v int) {
+	st.mutex.Lock()
+	v = st.config.Cache.GTS.WebfingerResponseMaxSize
+	st.mutex.Unlock()
+	return
+}
+
+// SetCacheGTSWebfingerResponseMaxSize safely sets the Configuration value for state's 'Cache.GTS.WebfingerResponseMaxSize' field
+func (st *ConfigState) SetCacheGTSWebfingerResponseMaxSize(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.GTS.WebfingerResponseMaxSize = v
+	st.reloadToViper()
+}
+
+// CacheGTSWebfingerResponseMaxSizeFlag returns the flag name for the 'Cache.GTS.WebfingerResponseMaxSize' field
+func CacheGTSWebfingerResponseMaxSizeFlag() string { return "cache-gts-webfinger-response-max-size" }
+
+// GetCacheGTSWebfingerResponseMaxSize safely fetches the value for global configuration 'Cache.GTS.WebfingerResponseMaxSize' field
+func GetCacheGTSWebfingerResponseMaxSize() int { return global.GetCacheGTSWebfingerResponseMaxSize() }
+
+// SetCacheGTSWebfingerResponseMaxSize safely sets the value for global configuration 'Cache.GTS.WebfingerResponseMaxSize' field
+func SetCacheGTSWebfingerResponseMaxSize(v int) { global.SetCacheGTSWebfingerResponseMaxSize(v) }
+
+// GetCacheGTSWebfingerResponseTTL safely fetches the Configuration value for state's 'Cache.GTS.WebfingerResponseTTL' field
+func (st *ConfigState) GetCacheGTSWebfingerResponseTTL() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.Cache.GTS.WebfingerResponseTTL
+	st.mutex.Unlock()
+	return
+}
+
+// SetCacheGTSWebfingerResponseTTL safely sets the Configuration value for state's 'Cache.GTS.WebfingerResponseTTL' field
+func (st *ConfigState) SetCacheGTSWebfingerResponseTTL(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.GTS.WebfingerResponseTTL = v
+	st.reloadToViper()
+}
+
+// CacheGTSWebfingerResponseTTLFlag returns the flag name for the 'Cache.GTS.WebfingerResponseTTL' field
+func CacheGTSWebfingerResponseTTLFlag() string { return "cache-gts-webfinger-response-ttl" }
+
+// GetCacheGTSWebfingerResponseTTL safely fetches the value for global configuration 'Cache.GTS.WebfingerResponseTTL' field
+func GetCacheGTSWebfingerResponseTTL() time.Duration { return global.GetCacheGTSWebfingerResponseTTL() }
+
+// SetCacheGTSWebfingerResponseTTL safely sets the value for global configuration 'Cache.GTS.WebfingerResponseTTL' field
+func SetCacheGTSWebfingerResponseTTL(v time.Duration) { global.SetCacheGTSWebfingerResponseTTL(v) }
+
+// GetCacheGTSWebfingerResponseStaleWindow safely fetches the Configuration value for state's 'Cache.GTS.WebfingerResponseStaleWindow' field
+func (st *ConfigState) GetCacheGTSWebfingerResponseStaleWindow() (v time.Duration) {
+	st.mutex.Lock()
+	v = st.config.Cache.GTS.WebfingerResponseStaleWindow
+	st.mutex.Unlock()
+	return
+}
+
+// SetCacheGTSWebfingerResponseStaleWindow safely sets the Configuration value for state's 'Cache.GTS.WebfingerResponseStaleWindow' field
+func (st *ConfigState) SetCacheGTSWebfingerResponseStaleWindow(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.GTS.WebfingerResponseStaleWindow = v
+	st.reloadToViper()
+}
+
+// CacheGTSWebfingerResponseStaleWindowFlag returns the flag name for the 'Cache.GTS.WebfingerResponseStaleWindow' field
+func CacheGTSWebfingerResponseStaleWindowFlag() string {
+	return "cache-gts-webfinger-response-stale-window"
+}
+
+// GetCacheGTSWebfingerResponseStaleWindow safely fetches the value for global configuration 'Cache.GTS.WebfingerResponseStaleWindow' field
+func GetCacheGTSWebfingerResponseStaleWindow() time.Duration {
+	return global.GetCacheGTSWebfingerResponseStaleWindow()
+}
+
+// SetCacheGTSWebfingerResponseStaleWindow safely sets the value for global configuration 'Cache.GTS.WebfingerResponseStaleWindow' field
+func SetCacheGTSWebfingerResponseStaleWindow(v time.Duration) {
+	global.SetCacheGTSWebfingerResponseStaleWindow(v)
+}
+
 // GetCacheVisibilityMaxSize safely fetches the Configuration value for state's 'Cache.VisibilityMaxSize' field
 func (st *ConfigState) GetCacheVisibilityMaxSize() (v int) {
 	st.mutex.Lock()
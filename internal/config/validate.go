@@ -20,6 +20,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/miekg/dns"
@@ -79,6 +80,16 @@ func Validate() error {
 		errs = append(errs, fmt.Errorf("%s and %s need to both be set or unset", tlsChainFlag, tlsKeyFlag))
 	}
 
+	// media path template
+	switch tmpl := GetStorageMediaPathTemplate(); tmpl {
+	case "":
+		SetStorageMediaPathTemplate(Defaults.StorageMediaPathTemplate)
+	default:
+		if err := validateMediaPathTemplate(tmpl); err != nil {
+			errs = append(errs, fmt.Errorf("%s was %s: %w", StorageMediaPathTemplateFlag(), tmpl, err))
+		}
+	}
+
 	if len(errs) > 0 {
 		errStrings := []string{}
 		for _, err := range errs {
@@ -89,3 +100,33 @@ func Validate() error {
 
 	return nil
 }
+
+// mediaPathPlaceholder matches one {placeholder} in a media path template.
+var mediaPathPlaceholder = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// validateMediaPathTemplate checks that the given storage media path
+// template only uses supported placeholders, contains enough of them
+// to give each stored file a unique path, and stays within storage.
+func validateMediaPathTemplate(tmpl string) error {
+	found := make(map[string]bool)
+	for _, match := range mediaPathPlaceholder.FindAllStringSubmatch(tmpl, -1) {
+		switch name := match[1]; name {
+		case "accountID", "mediaType", "mediaSize", "mediaID", "extension", "yyyy", "mm", "dd":
+			found[name] = true
+		default:
+			return fmt.Errorf("unsupported placeholder {%s}", name)
+		}
+	}
+
+	if !found["mediaID"] || !found["mediaSize"] {
+		return errors.New("template must include both {mediaID} and {mediaSize}")
+	}
+
+	for _, segment := range strings.Split(tmpl, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return errors.New("template must be a relative path with no empty, . or .. segments")
+		}
+	}
+
+	return nil
+}
@@ -140,6 +140,52 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigBadProtocolNoHost() {
 	suite.EqualError(err, "host must be set; protocol must be set to either http or https, provided value was foo")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateMediaPathTemplateOK() {
+	testrig.InitTestConfig()
+
+	config.SetStorageMediaPathTemplate("{yyyy}/{mm}/{accountID}/{mediaType}/{mediaSize}/{mediaID}.{extension}")
+
+	err := config.Validate()
+	suite.NoError(err)
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateMediaPathTemplateEmpty() {
+	testrig.InitTestConfig()
+
+	config.SetStorageMediaPathTemplate("")
+
+	err := config.Validate()
+	suite.NoError(err)
+	suite.Equal(config.Defaults.StorageMediaPathTemplate, config.GetStorageMediaPathTemplate())
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateMediaPathTemplateUnknownPlaceholder() {
+	testrig.InitTestConfig()
+
+	config.SetStorageMediaPathTemplate("{accountID}/{mediaSize}/{mediaID}.{ext}")
+
+	err := config.Validate()
+	suite.EqualError(err, "storage-media-path-template was {accountID}/{mediaSize}/{mediaID}.{ext}: unsupported placeholder {ext}")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateMediaPathTemplateNotUnique() {
+	testrig.InitTestConfig()
+
+	config.SetStorageMediaPathTemplate("{accountID}/{mediaID}.{extension}")
+
+	err := config.Validate()
+	suite.EqualError(err, "storage-media-path-template was {accountID}/{mediaID}.{extension}: template must include both {mediaID} and {mediaSize}")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateMediaPathTemplateEscapes() {
+	testrig.InitTestConfig()
+
+	config.SetStorageMediaPathTemplate("../{mediaSize}/{mediaID}.{extension}")
+
+	err := config.Validate()
+	suite.EqualError(err, "storage-media-path-template was ../{mediaSize}/{mediaID}.{extension}: template must be a relative path with no empty, . or .. segments")
+}
+
 func TestConfigValidateTestSuite(t *testing.T) {
 	suite.Run(t, &ConfigValidateTestSuite{})
 }
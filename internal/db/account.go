@@ -29,6 +29,10 @@ type Account interface {
 	// GetAccountByID returns one account with the given ID, or an error if something goes wrong.
 	GetAccountByID(ctx context.Context, id string) (*gtsmodel.Account, Error)
 
+	// GetAccountsByIDs returns accounts with the given IDs, in the same order. Accounts not yet
+	// cached are selected from the database in one query. Accounts that can't be fetched are skipped.
+	GetAccountsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Account, error)
+
 	// GetAccountByURI returns one account with the given URI, or an error if something goes wrong.
 	GetAccountByURI(ctx context.Context, uri string) (*gtsmodel.Account, Error)
 
@@ -67,9 +71,47 @@ type Account interface {
 	// account as suspended instead, rather than deleting from the db entirely.
 	DeleteAccount(ctx context.Context, id string) Error
 
+	// MergeAccounts moves statuses (along with their media, boosts and replies), follows,
+	// follow requests, faves, and bookmarks of source across to target, in one transaction.
+	// Anything which would become a duplicate of target's own, or relate target to itself,
+	// is dropped, as are follows and follow requests of remote accounts by source, which
+	// the caller should federate the undo of and refollow as target. Source is left as is.
+	MergeAccounts(ctx context.Context, source *gtsmodel.Account, target *gtsmodel.Account) Error
+
 	// GetAccountCustomCSSByUsername returns the custom css of an account on this instance with the given username.
 	GetAccountCustomCSSByUsername(ctx context.Context, username string) (string, Error)
 
+	// GetAccountsBySuspensionOrigin fetches up to limit accounts whose suspension was caused by the
+	// database entry with the given ID, eg., a domain block, or an account that suspended itself.
+	// Accounts are returned in descending ID order, starting below maxID, if set.
+	//
+	// In the case of no accounts, this function will return db.ErrNoEntries.
+	GetAccountsBySuspensionOrigin(ctx context.Context, origin string, maxID string, limit int) ([]*gtsmodel.Account, Error)
+
+	// GetSuspendedAccountIDs fetches the IDs of up to limit suspended accounts,
+	// in descending ID order, starting below maxID (if set). This is useful
+	// for paging through all suspended accounts.
+	GetSuspendedAccountIDs(ctx context.Context, maxID string, limit int) ([]string, Error)
+
+	// SearchAccountIDs returns the IDs of up to limit non-suspended accounts
+	// whose username or display name starts with the given query
+	// (case-insensitively), skipping the first offset results. Accounts
+	// are ranked by exact username match, then username prefix match, then
+	// display name match, with local accounts before remote ones at each rank.
+	//
+	// If followedBy is set, only accounts followed by that account ID are returned.
+	SearchAccountIDs(ctx context.Context, query string, followedBy string, limit int, offset int) ([]string, Error)
+
+	// CountAccountPeripheral counts the status faves, bookmarks and mutes
+	// which were created by, or which target, the given accountID.
+	CountAccountPeripheral(ctx context.Context, accountID string) (int, Error)
+
+	// CountAccountLeftovers counts the statuses, media, follows, follow requests,
+	// blocks, faves, bookmarks, notifications, saved searches and tokens which still exist for
+	// the given accountID, keyed by kind. Kinds with no rows are omitted, so an
+	// empty map means nothing was left behind by deleting the account.
+	CountAccountLeftovers(ctx context.Context, accountID string) (map[string]int, Error)
+
 	// GetAccountFaves fetches faves/likes created by the target accountID.
 	GetAccountFaves(ctx context.Context, accountID string) ([]*gtsmodel.StatusFave, Error)
 
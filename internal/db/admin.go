@@ -41,6 +41,10 @@ type Admin interface {
 	// By the time this function is called, it should be assumed that all the parameters have passed validation!
 	NewSignup(ctx context.Context, username string, reason string, requireApproval bool, email string, password string, signUpIP net.IP, locale string, appID string, emailVerified bool, externalID string, admin bool) (*gtsmodel.User, Error)
 
+	// CountActiveAdmins returns the number of local users with admin privileges
+	// whose user is not disabled, and whose account is not suspended.
+	CountActiveAdmins(ctx context.Context) (int, Error)
+
 	// CreateInstanceAccount creates an account in the database with the same username as the instance host value.
 	// Ie., if the instance is hosted at 'example.org' the instance user will have a username of 'example.org'.
 	// This is needed for things like serving files that belong to the instance and not an individual user/account.
@@ -56,6 +56,63 @@ func (a *accountDB) GetAccountByID(ctx context.Context, id string) (*gtsmodel.Ac
 	)
 }
 
+func (a *accountDB) GetAccountsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Account, error) {
+	// Gather IDs of accounts
+	// not already cached.
+	uncached := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !a.state.Caches.GTS.Account().Has("ID", id) {
+			uncached = append(uncached, id)
+		}
+	}
+
+	// Select all uncached accounts in one go,
+	// rather than one query per account below.
+	selected := make(map[string]*gtsmodel.Account, len(uncached))
+	if len(uncached) > 0 {
+		var accounts []*gtsmodel.Account
+		if err := a.conn.NewSelect().
+			Model(&accounts).
+			Where("? IN (?)", bun.Ident("account.id"), bun.In(uncached)).
+			Scan(ctx); err != nil {
+			return nil, a.conn.ProcessError(err)
+		}
+
+		for _, account := range accounts {
+			selected[account.ID] = account
+		}
+	}
+
+	accounts := make([]*gtsmodel.Account, 0, len(ids))
+	for _, id := range ids {
+		account, err := a.getAccount(
+			ctx,
+			"ID",
+			func(account *gtsmodel.Account) error {
+				if s, ok := selected[id]; ok {
+					// Already selected above.
+					*account = *s
+					return nil
+				}
+
+				return a.conn.NewSelect().
+					Model(account).
+					Where("? = ?", bun.Ident("account.id"), id).
+					Scan(ctx)
+			},
+			id,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error getting account %q: %v", id, err)
+			continue
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
+
 func (a *accountDB) GetAccountByURI(ctx context.Context, uri string) (*gtsmodel.Account, db.Error) {
 	return a.getAccount(
 		ctx,
@@ -444,6 +501,48 @@ func (a *accountDB) GetAccountCustomCSSByUsername(ctx context.Context, username
 	return account.CustomCSS, nil
 }
 
+func (a *accountDB) GetAccountsBySuspensionOrigin(ctx context.Context, origin string, maxID string, limit int) ([]*gtsmodel.Account, db.Error) {
+	var accountIDs []string
+
+	q := a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? = ?", bun.Ident("account.suspension_origin"), origin).
+		Order("account.id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("account.id"), maxID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	if len(accountIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	accounts := make([]*gtsmodel.Account, 0, len(accountIDs))
+
+	for _, id := range accountIDs {
+		// Fetch account model for ID via the cache.
+		account, err := a.GetAccountByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting account %q: %v", id, err)
+			continue
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
+
 func (a *accountDB) GetAccountFaves(ctx context.Context, accountID string) ([]*gtsmodel.StatusFave, db.Error) {
 	faves := new([]*gtsmodel.StatusFave)
 
@@ -458,6 +557,174 @@ func (a *accountDB) GetAccountFaves(ctx context.Context, accountID string) ([]*g
 	return *faves, nil
 }
 
+func (a *accountDB) GetSuspendedAccountIDs(ctx context.Context, maxID string, limit int) ([]string, db.Error) {
+	var accountIDs []string
+
+	q := a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? IS NOT NULL", bun.Ident("account.suspended_at")).
+		Order("account.id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("account.id"), maxID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return accountIDs, nil
+}
+
+func (a *accountDB) SearchAccountIDs(ctx context.Context, query string, followedBy string, limit int, offset int) ([]string, db.Error) {
+	var (
+		accountIDs []string
+		lowerQuery = strings.ToLower(query)
+		prefix     = escapeLike(lowerQuery) + "%"
+	)
+
+	q := a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		Column("account.id").
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("LOWER(?) LIKE ? ESCAPE '\\'", bun.Ident("account.username"), prefix).
+				WhereOr("LOWER(?) LIKE ? ESCAPE '\\'", bun.Ident("account.display_name"), prefix)
+		}).
+		// Rank exact username matches first, then
+		// username prefix matches, then the rest
+		// (which must be display name matches).
+		OrderExpr(
+			"CASE WHEN LOWER(?) = ? THEN 0 WHEN LOWER(?) LIKE ? ESCAPE '\\' THEN 1 ELSE 2 END ASC",
+			bun.Ident("account.username"), lowerQuery,
+			bun.Ident("account.username"), prefix,
+		).
+		// Local accounts (no domain) before remote.
+		OrderExpr("CASE WHEN ? IS NULL THEN 0 ELSE 1 END ASC", bun.Ident("account.domain")).
+		Order("account.username ASC", "account.id ASC")
+
+	if followedBy != "" {
+		q = q.Where("? IN (?)",
+			bun.Ident("account.id"),
+			a.conn.
+				NewSelect().
+				Table("follows").
+				Column("target_account_id").
+				Where("? = ?", bun.Ident("account_id"), followedBy),
+		)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if offset > 0 {
+		q = q.Offset(offset)
+	}
+
+	if err := q.Scan(ctx, &accountIDs); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	return accountIDs, nil
+}
+
+func (a *accountDB) CountAccountPeripheral(ctx context.Context, accountID string) (int, db.Error) {
+	var total int
+
+	for _, table := range []string{
+		"status_faves",
+		"status_bookmarks",
+		"status_mutes",
+	} {
+		count, err := a.conn.
+			NewSelect().
+			Table(table).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? = ?", bun.Ident("account_id"), accountID).
+					WhereOr("? = ?", bun.Ident("target_account_id"), accountID)
+			}).
+			Count(ctx)
+		if err != nil {
+			return 0, a.conn.ProcessError(err)
+		}
+
+		total += count
+	}
+
+	return total, nil
+}
+
+func (a *accountDB) CountAccountLeftovers(ctx context.Context, accountID string) (map[string]int, db.Error) {
+	leftovers := make(map[string]int)
+
+	for _, check := range []struct {
+		kind    string
+		table   string
+		columns []string
+	}{
+		{"statuses", "statuses", []string{"account_id"}},
+		{"media", "media_attachments", []string{"account_id"}},
+		{"follows", "follows", []string{"account_id", "target_account_id"}},
+		{"follow_requests", "follow_requests", []string{"account_id", "target_account_id"}},
+		{"blocks", "blocks", []string{"account_id", "target_account_id"}},
+		{"faves", "status_faves", []string{"account_id", "target_account_id"}},
+		{"bookmarks", "status_bookmarks", []string{"account_id", "target_account_id"}},
+		{"notifications", "notifications", []string{"origin_account_id", "target_account_id"}},
+		{"saved_searches", "saved_searches", []string{"account_id"}},
+	} {
+		columns := check.columns
+		count, err := a.conn.
+			NewSelect().
+			Table(check.table).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				for _, column := range columns {
+					q = q.WhereOr("? = ?", bun.Ident(column), accountID)
+				}
+				return q
+			}).
+			Count(ctx)
+		if err != nil {
+			return nil, a.conn.ProcessError(err)
+		}
+
+		if count != 0 {
+			leftovers[check.kind] = count
+		}
+	}
+
+	// Tokens belong to the account's user, if any.
+	userIDs := a.conn.
+		NewSelect().
+		Table("users").
+		Column("id").
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	count, err := a.conn.
+		NewSelect().
+		Table("tokens").
+		Where("? IN (?)", bun.Ident("user_id"), userIDs).
+		Count(ctx)
+	if err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	if count != 0 {
+		leftovers["tokens"] = count
+	}
+
+	return leftovers, nil
+}
+
 func (a *accountDB) CountAccountStatuses(ctx context.Context, accountID string) (int, db.Error) {
 	return a.conn.
 		NewSelect().
@@ -0,0 +1,332 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+func (a *accountDB) MergeAccounts(ctx context.Context, source *gtsmodel.Account, target *gtsmodel.Account) db.Error {
+	var (
+		// IDs of statuses and media touched
+		// by the merge, to invalidate after.
+		statusIDs     []string
+		attachmentIDs []string
+	)
+
+	defer func() {
+		// Follows, follow requests and faves are cached under the account
+		// IDs being swapped around, including cached misses for target, so
+		// just drop them all. Merges are rare enough for this to be cheaper
+		// than working out every key that might have been affected.
+		a.state.Caches.GTS.Follow().Clear()
+		a.state.Caches.GTS.FollowRequest().Clear()
+		a.state.Caches.GTS.ListEntry().Clear()
+		a.state.Caches.GTS.StatusFave().Clear()
+		a.state.Caches.Visibility.Clear()
+
+		for _, id := range statusIDs {
+			a.state.Caches.GTS.Status().Invalidate("ID", id)
+		}
+
+		for _, id := range attachmentIDs {
+			a.state.Caches.GTS.Media().Invalidate("ID", id)
+		}
+	}()
+
+	return a.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// Follows (and follow requests) of remote accounts can't just
+		// be moved, as the remote has to accept a follow from target
+		// first, so drop them and leave it to the caller to refollow.
+		for _, table := range []string{"follows", "follow_requests"} {
+			if _, err := tx.
+				NewDelete().
+				Table(table).
+				Where("? = ?", bun.Ident("account_id"), source.ID).
+				Where("? IN (?)", bun.Ident("target_account_id"), tx.
+					NewSelect().
+					Table("accounts").
+					Column("id").
+					WhereGroup(" AND ", whereNotEmptyAndNotNull("domain")),
+				).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		if err := mergeAccountRelationships(ctx, tx, "follows", []string{"follows"}, source.ID, target.ID); err != nil {
+			return err
+		}
+
+		if err := mergeAccountRelationships(ctx, tx, "follow_requests", []string{"follows", "follow_requests"}, source.ID, target.ID); err != nil {
+			return err
+		}
+
+		// Boosts by source of statuses which target has already
+		// boosted would be duplicates once moved, so drop them.
+		var dupeBoostIDs []string
+		if err := tx.
+			NewSelect().
+			Table("statuses").
+			Column("id").
+			Where("? = ?", bun.Ident("account_id"), source.ID).
+			Where("? IN (?)", bun.Ident("boost_of_id"), tx.
+				NewSelect().
+				Table("statuses").
+				Column("boost_of_id").
+				Where("? = ?", bun.Ident("account_id"), target.ID),
+			).
+			Scan(ctx, &dupeBoostIDs); err != nil {
+			return err
+		}
+
+		if len(dupeBoostIDs) > 0 {
+			statusIDs = append(statusIDs, dupeBoostIDs...)
+
+			if _, err := tx.
+				NewDelete().
+				Table("notifications").
+				Where("? IN (?)", bun.Ident("status_id"), bun.In(dupeBoostIDs)).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			if _, err := tx.
+				NewDelete().
+				Table("statuses").
+				Where("? IN (?)", bun.Ident("id"), bun.In(dupeBoostIDs)).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Move across statuses owned by source, boosts of
+		// them, and replies to them. Status URIs are left
+		// untouched, so they still resolve for remotes.
+		for _, column := range []string{"account_id", "boost_of_account_id", "in_reply_to_account_id"} {
+			var ids []string
+			if err := tx.
+				NewSelect().
+				Table("statuses").
+				Column("id").
+				Where("? = ?", bun.Ident(column), source.ID).
+				Scan(ctx, &ids); err != nil {
+				return err
+			}
+
+			if len(ids) == 0 {
+				continue
+			}
+			statusIDs = append(statusIDs, ids...)
+
+			q := tx.
+				NewUpdate().
+				Table("statuses").
+				Set("? = ?", bun.Ident(column), target.ID).
+				Where("? IN (?)", bun.Ident("id"), bun.In(ids))
+
+			if column == "account_id" {
+				q = q.Set("? = ?", bun.Ident("account_uri"), target.URI)
+			}
+
+			if _, err := q.Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		// Move across media attached to those statuses. Media
+		// without a status (eg., avatar + header) stays put.
+		if err := tx.
+			NewSelect().
+			Table("media_attachments").
+			Column("id").
+			Where("? = ?", bun.Ident("account_id"), source.ID).
+			WhereGroup(" AND ", whereNotEmptyAndNotNull("status_id")).
+			Scan(ctx, &attachmentIDs); err != nil {
+			return err
+		}
+
+		if len(attachmentIDs) > 0 {
+			if _, err := tx.
+				NewUpdate().
+				Table("media_attachments").
+				Set("? = ?", bun.Ident("account_id"), target.ID).
+				Where("? IN (?)", bun.Ident("id"), bun.In(attachmentIDs)).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		for _, table := range []string{"status_faves", "status_bookmarks"} {
+			if err := mergeAccountStatusRelationships(ctx, tx, table, source.ID, target.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// mergeAccountRelationships moves rows of the given account relationship table (ie., follows
+// or follow_requests) from sourceID to targetID, on either side of the relationship. Rows which
+// would relate targetID to itself, or duplicate a row in any of the given existing tables, are
+// deleted instead, along with any list entries of deleted follows.
+func mergeAccountRelationships(ctx context.Context, tx bun.Tx, table string, existing []string, sourceID string, targetID string) error {
+	var rows []struct {
+		ID              string
+		AccountID       string
+		TargetAccountID string
+	}
+
+	if err := tx.
+		NewSelect().
+		Table(table).
+		Column("id", "account_id", "target_account_id").
+		WhereOr("? = ?", bun.Ident("account_id"), sourceID).
+		WhereOr("? = ?", bun.Ident("target_account_id"), sourceID).
+		Scan(ctx, &rows); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		accountID, targetAccountID := row.AccountID, row.TargetAccountID
+		if accountID == sourceID {
+			accountID = targetID
+		}
+		if targetAccountID == sourceID {
+			targetAccountID = targetID
+		}
+
+		drop := accountID == targetAccountID
+		for _, existingTable := range existing {
+			if drop {
+				break
+			}
+
+			exists, err := tx.
+				NewSelect().
+				Table(existingTable).
+				Where("? = ?", bun.Ident("account_id"), accountID).
+				Where("? = ?", bun.Ident("target_account_id"), targetAccountID).
+				Exists(ctx)
+			if err != nil {
+				return err
+			}
+			drop = exists
+		}
+
+		if !drop {
+			if _, err := tx.
+				NewUpdate().
+				Table(table).
+				Set("? = ?", bun.Ident("account_id"), accountID).
+				Set("? = ?", bun.Ident("target_account_id"), targetAccountID).
+				Where("? = ?", bun.Ident("id"), row.ID).
+				Exec(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := tx.
+			NewDelete().
+			Table(table).
+			Where("? = ?", bun.Ident("id"), row.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if table == "follows" {
+			// List entries hang off follows.
+			if _, err := tx.
+				NewDelete().
+				Table("list_entries").
+				Where("? = ?", bun.Ident("follow_id"), row.ID).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// mergeAccountStatusRelationships moves rows of the given account to status relationship table
+// (ie., status_faves or status_bookmarks) from sourceID to targetID, on either side of the
+// relationship. Rows owned by sourceID for statuses which targetID already has a row for are
+// deleted instead.
+func mergeAccountStatusRelationships(ctx context.Context, tx bun.Tx, table string, sourceID string, targetID string) error {
+	var rows []struct {
+		ID       string
+		StatusID string
+	}
+
+	if err := tx.
+		NewSelect().
+		Table(table).
+		Column("id", "status_id").
+		Where("? = ?", bun.Ident("account_id"), sourceID).
+		Scan(ctx, &rows); err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		exists, err := tx.
+			NewSelect().
+			Table(table).
+			Where("? = ?", bun.Ident("account_id"), targetID).
+			Where("? = ?", bun.Ident("status_id"), row.StatusID).
+			Exists(ctx)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			if _, err := tx.
+				NewDelete().
+				Table(table).
+				Where("? = ?", bun.Ident("id"), row.ID).
+				Exec(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := tx.
+			NewUpdate().
+			Table(table).
+			Set("? = ?", bun.Ident("account_id"), targetID).
+			Where("? = ?", bun.Ident("id"), row.ID).
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Rows targeting source are now about statuses owned by target.
+	_, err := tx.
+		NewUpdate().
+		Table(table).
+		Set("? = ?", bun.Ident("target_account_id"), targetID).
+		Where("? = ?", bun.Ident("target_account_id"), sourceID).
+		Exec(ctx)
+	return err
+}
@@ -32,6 +32,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 	"github.com/uptrace/bun"
 )
 
@@ -45,6 +47,108 @@ func (suite *AccountTestSuite) TestGetAccountStatuses() {
 	suite.Len(statuses, 5)
 }
 
+func (suite *AccountTestSuite) TestGetAccountsBySuspensionOrigin() {
+	ctx := context.Background()
+	origin := "01H2N8J8RVFVM4P0Z6K8C1M3QA"
+
+	// Nothing suspended by this origin yet.
+	accounts, err := suite.db.GetAccountsBySuspensionOrigin(ctx, origin, "", 0)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Empty(accounts)
+
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["remote_account_1"]
+	testAccount.SuspendedAt = time.Now()
+	testAccount.SuspensionOrigin = origin
+	if err := suite.db.UpdateAccount(ctx, testAccount, "suspended_at", "suspension_origin"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err = suite.db.GetAccountsBySuspensionOrigin(ctx, origin, "", 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+	suite.Equal(testAccount.ID, accounts[0].ID)
+
+	// Nothing left below the last account.
+	accounts, err = suite.db.GetAccountsBySuspensionOrigin(ctx, origin, testAccount.ID, 20)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Empty(accounts)
+}
+
+func (suite *AccountTestSuite) TestGetSuspendedAccountIDs() {
+	ctx := context.Background()
+
+	// Nothing suspended yet.
+	accountIDs, err := suite.db.GetSuspendedAccountIDs(ctx, "", 0)
+	suite.NoError(err)
+	suite.Empty(accountIDs)
+
+	for _, key := range []string{"remote_account_1", "local_account_2"} {
+		testAccount := &gtsmodel.Account{}
+		*testAccount = *suite.testAccounts[key]
+		testAccount.SuspendedAt = time.Now()
+		if err := suite.db.UpdateAccount(ctx, testAccount, "suspended_at"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	accountIDs, err = suite.db.GetSuspendedAccountIDs(ctx, "", 0)
+	suite.NoError(err)
+	suite.Equal([]string{
+		suite.testAccounts["local_account_2"].ID,
+		suite.testAccounts["remote_account_1"].ID,
+	}, accountIDs)
+
+	// Page down from the first.
+	accountIDs, err = suite.db.GetSuspendedAccountIDs(ctx, accountIDs[0], 1)
+	suite.NoError(err)
+	suite.Equal([]string{suite.testAccounts["remote_account_1"].ID}, accountIDs)
+}
+
+func (suite *AccountTestSuite) TestCountAccountPeripheral() {
+	ctx := context.Background()
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	var expected int
+	for _, fave := range suite.testFaves {
+		if fave.AccountID == accountID || fave.TargetAccountID == accountID {
+			expected++
+		}
+	}
+	for _, bookmark := range suite.testBookmarks {
+		if bookmark.AccountID == accountID || bookmark.TargetAccountID == accountID {
+			expected++
+		}
+	}
+
+	count, err := suite.db.CountAccountPeripheral(ctx, accountID)
+	suite.NoError(err)
+	suite.Equal(expected, count)
+
+	// Status mutes should be counted too.
+	status := suite.testStatuses["admin_account_status_1"]
+	if err := suite.db.Put(ctx, &gtsmodel.StatusMute{
+		ID:              "01H9CGSN3YSGC8RPRP9X5YS8ZZ",
+		AccountID:       accountID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	count, err = suite.db.CountAccountPeripheral(ctx, accountID)
+	suite.NoError(err)
+	suite.Equal(expected+1, count)
+
+	if err := suite.db.DeleteStatusMutes(ctx, "", accountID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	count, err = suite.db.CountAccountPeripheral(ctx, accountID)
+	suite.NoError(err)
+	suite.Equal(expected, count)
+}
+
 func (suite *AccountTestSuite) TestGetAccountStatusesPageDown() {
 	// get the first page
 	statuses, err := suite.db.GetAccountStatuses(context.Background(), suite.testAccounts["local_account_1"].ID, 2, false, false, "", "", false, false)
@@ -375,6 +479,102 @@ func (suite *AccountTestSuite) TestCountAccountPinnedNothingPinned() {
 	suite.Equal(pinned, 0) // This account has nothing pinned.
 }
 
+func (suite *AccountTestSuite) TestCountAccountLeftovers() {
+	ctx := context.Background()
+	accountID := suite.testAccounts["local_account_1"].ID
+
+	var statuses, faves, bookmarks int
+	for _, status := range suite.testStatuses {
+		if status.AccountID == accountID {
+			statuses++
+		}
+	}
+	for _, fave := range suite.testFaves {
+		if fave.AccountID == accountID || fave.TargetAccountID == accountID {
+			faves++
+		}
+	}
+	for _, bookmark := range suite.testBookmarks {
+		if bookmark.AccountID == accountID || bookmark.TargetAccountID == accountID {
+			bookmarks++
+		}
+	}
+
+	leftovers, err := suite.db.CountAccountLeftovers(ctx, accountID)
+	suite.NoError(err)
+	suite.Equal(statuses, leftovers["statuses"])
+	suite.Equal(faves, leftovers["faves"])
+	suite.Equal(bookmarks, leftovers["bookmarks"])
+	suite.NotZero(leftovers["tokens"])
+
+	// Nothing exists for an unknown account.
+	leftovers, err = suite.db.CountAccountLeftovers(ctx, "01H3J6C3ZNNWB8YF0HFK3X5C3M")
+	suite.NoError(err)
+	suite.Empty(leftovers)
+}
+
+func (suite *AccountTestSuite) TestGetAccountsByIDs() {
+	ctx := context.Background()
+	zork := suite.testAccounts["local_account_1"]
+	admin := suite.testAccounts["admin_account"]
+
+	// Cache one of the accounts
+	// before fetching them all.
+	if _, err := suite.db.GetAccountByID(ctx, admin.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err := suite.db.GetAccountsByIDs(ctx, []string{
+		zork.ID,
+		"01H3EFQ0S0V7M6D5AKZB2W4N8X", // doesn't exist
+		admin.ID,
+	})
+	suite.NoError(err)
+	suite.Len(accounts, 2)
+
+	// Should be in the requested order.
+	suite.Equal(zork.ID, accounts[0].ID)
+	suite.Equal(admin.ID, accounts[1].ID)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
+
+func BenchmarkGetAccountsByIDs(b *testing.B) {
+	var testState state.State
+
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	testDB := testrig.NewTestDB(&testState)
+	testrig.StandardDBSetup(testDB, nil)
+	defer testrig.StandardDBTeardown(testDB)
+
+	ctx := context.Background()
+	testAccounts := testrig.NewTestAccounts()
+	ids := make([]string, 0, len(testAccounts))
+	for _, account := range testAccounts {
+		ids = append(ids, account.ID)
+	}
+
+	b.Run("one by one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			testState.Caches.GTS.Account().Clear()
+			for _, id := range ids {
+				if _, err := testDB.GetAccountByID(ctx, id); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+
+	b.Run("batched", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			testState.Caches.GTS.Account().Clear()
+			if _, err := testDB.GetAccountsByIDs(ctx, ids); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
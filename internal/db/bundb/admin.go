@@ -191,6 +191,21 @@ func (a *adminDB) NewSignup(ctx context.Context, username string, reason string,
 	return u, nil
 }
 
+func (a *adminDB) CountActiveAdmins(ctx context.Context) (int, db.Error) {
+	count, err := a.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("account"), bun.Ident("account.id"), bun.Ident("user.account_id")).
+		Where("? = ?", bun.Ident("user.admin"), true).
+		Where("? = ?", bun.Ident("user.disabled"), false).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Count(ctx)
+	if err != nil {
+		return 0, a.conn.ProcessError(err)
+	}
+	return count, nil
+}
+
 func (a *adminDB) CreateInstanceAccount(ctx context.Context) db.Error {
 	username := config.GetHost()
 
@@ -221,6 +236,7 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) db.Error {
 		return err
 	}
 
+	system := true
 	newAccountURIs := uris.GenerateURIsForAccount(username)
 	acct := &gtsmodel.Account{
 		ID:                    aID,
@@ -237,6 +253,7 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) db.Error {
 		FollowersURI:          newAccountURIs.FollowersURI,
 		FollowingURI:          newAccountURIs.FollowingURI,
 		FeaturedCollectionURI: newAccountURIs.FeaturedCollectionURI,
+		System:                &system,
 	}
 
 	// insert the new account!
@@ -68,6 +68,35 @@ func (suite *AdminTestSuite) TestIsEmailAvailableDomainBlocked() {
 	suite.False(available)
 }
 
+func (suite *AdminTestSuite) TestCountActiveAdmins() {
+	ctx := context.Background()
+
+	count, err := suite.db.CountActiveAdmins(ctx)
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	// Promote another user to admin.
+	user := *suite.testUsers["local_account_1"]
+	user.Admin = testrig.TrueBool()
+	if err := suite.db.UpdateUser(ctx, &user, "admin"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	count, err = suite.db.CountActiveAdmins(ctx)
+	suite.NoError(err)
+	suite.Equal(2, count)
+
+	// Disabled admins don't count.
+	user.Disabled = testrig.TrueBool()
+	if err := suite.db.UpdateUser(ctx, &user, "disabled"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	count, err = suite.db.CountActiveAdmins(ctx)
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
 func (suite *AdminTestSuite) TestCreateInstanceAccount() {
 	// reinitialize db caches to clear
 	suite.state.Caches.Init()
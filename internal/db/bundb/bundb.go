@@ -43,6 +43,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/tracing"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/dialect/sqlitedialect"
@@ -63,18 +64,24 @@ type DBService struct {
 	db.Admin
 	db.Basic
 	db.Domain
+	db.EmailTemplate
 	db.Emoji
 	db.Instance
+	db.InstancePolicy
 	db.List
 	db.Media
 	db.Mention
 	db.Notification
+	db.PreviewCard
 	db.Relationship
 	db.Report
+	db.SavedSearch
 	db.Session
+	db.SignIn
 	db.Status
 	db.StatusBookmark
 	db.StatusFave
+	db.Tag
 	db.Timeline
 	db.User
 	db.Tombstone
@@ -173,6 +180,9 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		EmailTemplate: &emailTemplateDB{
+			conn: conn,
+		},
 		Emoji: &emojiDB{
 			conn:  conn,
 			state: state,
@@ -180,13 +190,17 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 		Instance: &instanceDB{
 			conn: conn,
 		},
+		InstancePolicy: &instancePolicyDB{
+			conn: conn,
+		},
 		List: &listDB{
 			conn:  conn,
 			state: state,
 		},
 		Media: &mediaDB{
-			conn:  conn,
-			state: state,
+			conn:       conn,
+			state:      state,
+			refreshing: make(map[string]struct{}),
 		},
 		Mention: &mentionDB{
 			conn:  conn,
@@ -196,6 +210,9 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		PreviewCard: &previewCardDB{
+			conn: conn,
+		},
 		Relationship: &relationshipDB{
 			conn:  conn,
 			state: state,
@@ -204,9 +221,15 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		SavedSearch: &savedSearchDB{
+			conn: conn,
+		},
 		Session: &sessionDB{
 			conn: conn,
 		},
+		SignIn: &signInDB{
+			conn: conn,
+		},
 		Status: &statusDB{
 			conn:  conn,
 			state: state,
@@ -227,6 +250,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			conn:  conn,
 			state: state,
 		},
+		Tag: &tagDB{
+			conn:  conn,
+			state: state,
+		},
 		Tombstone: &tombstoneDB{
 			conn:  conn,
 			state: state,
@@ -492,23 +519,25 @@ func (dbService *DBService) TagStringToTag(ctx context.Context, t string, origin
 	protocol := config.GetProtocol()
 	host := config.GetHost()
 	now := time.Now()
+	name := util.NormalizeHashtag(t)
 
-	tag := &gtsmodel.Tag{}
-	// we can use selectorinsert here to create the new tag if it doesn't exist already
-	// inserted will be true if this is a new tag we just created
-	if err := dbService.conn.NewSelect().Model(tag).Where("LOWER(?) = LOWER(?)", bun.Ident("name"), t).Scan(ctx); err != nil && err != sql.ErrNoRows {
-		return nil, fmt.Errorf("error getting tag with name %s: %s", t, err)
+	// look the tag up through the cache, so that we don't
+	// trip over any cached result for this name later on
+	tag, err := dbService.GetTagByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("error getting tag with name %s: %w", name, err)
 	}
 
-	if tag.ID == "" {
+	if tag == nil {
 		// tag doesn't exist yet so populate it
 		newID, err := id.NewRandomULID()
 		if err != nil {
 			return nil, err
 		}
+		tag = &gtsmodel.Tag{}
 		tag.ID = newID
-		tag.URL = protocol + "://" + host + "/tags/" + t
-		tag.Name = t
+		tag.URL = protocol + "://" + host + "/tags/" + name
+		tag.Name = name
 		tag.FirstSeenFromAccountID = originAccountID
 		tag.CreatedAt = now
 		tag.UpdatedAt = now
@@ -516,12 +545,17 @@ func (dbService *DBService) TagStringToTag(ctx context.Context, t string, origin
 		tag.Useable = &useable
 		listable := true
 		tag.Listable = &listable
+		trendable := true
+		tag.Trendable = &trendable
+		requiresReview := false
+		tag.RequiresReview = &requiresReview
 	}
 
 	// bail already if the tag isn't useable
 	if !*tag.Useable {
-		return nil, fmt.Errorf("tag %s is not useable", t)
+		return nil, fmt.Errorf("tag %s: %w", name, db.ErrTagNotUseable)
 	}
+
 	tag.LastStatusAt = now
 	return tag, nil
 }
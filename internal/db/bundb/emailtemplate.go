@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type emailTemplateDB struct {
+	conn *DBConn
+}
+
+func (e *emailTemplateDB) GetEmailTemplateByName(ctx context.Context, name string) (*gtsmodel.EmailTemplate, db.Error) {
+	template := new(gtsmodel.EmailTemplate)
+
+	if err := e.conn.
+		NewSelect().
+		Model(template).
+		Where("? = ?", bun.Ident("email_template.name"), name).
+		Scan(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return template, nil
+}
+
+func (e *emailTemplateDB) GetEmailTemplates(ctx context.Context) ([]*gtsmodel.EmailTemplate, db.Error) {
+	templates := []*gtsmodel.EmailTemplate{}
+
+	if err := e.conn.
+		NewSelect().
+		Model(&templates).
+		Order("email_template.name ASC").
+		Scan(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return templates, nil
+}
+
+func (e *emailTemplateDB) PutEmailTemplate(ctx context.Context, template *gtsmodel.EmailTemplate) db.Error {
+	_, err := e.conn.
+		NewInsert().
+		Model(template).
+		Exec(ctx)
+	return e.conn.ProcessError(err)
+}
+
+func (e *emailTemplateDB) UpdateEmailTemplate(ctx context.Context, template *gtsmodel.EmailTemplate, columns ...string) db.Error {
+	template.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := e.conn.
+		NewUpdate().
+		Model(template).
+		Where("? = ?", bun.Ident("email_template.id"), template.ID).
+		Column(columns...).
+		Exec(ctx)
+	return e.conn.ProcessError(err)
+}
+
+func (e *emailTemplateDB) DeleteEmailTemplateByName(ctx context.Context, name string) db.Error {
+	_, err := e.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("email_templates"), bun.Ident("email_template")).
+		Where("? = ?", bun.Ident("email_template.name"), name).
+		Exec(ctx)
+	return e.conn.ProcessError(err)
+}
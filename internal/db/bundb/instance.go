@@ -19,6 +19,7 @@ package bundb
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -74,6 +75,33 @@ func (i *instanceDB) CountInstanceStatuses(ctx context.Context, domain string) (
 	return count, nil
 }
 
+func (i *instanceDB) CountLocalStatusesBetween(ctx context.Context, from time.Time, to time.Time) (int, db.Error) {
+	count, err := i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Where("? = ?", bun.Ident("status.local"), true).
+		Where("? >= ?", bun.Ident("status.created_at"), from).
+		Where("? < ?", bun.Ident("status.created_at"), to).
+		Count(ctx)
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+	return count, nil
+}
+
+func (i *instanceDB) CountLocalUsersCreatedBetween(ctx context.Context, from time.Time, to time.Time) (int, db.Error) {
+	count, err := i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Where("? >= ?", bun.Ident("user.created_at"), from).
+		Where("? < ?", bun.Ident("user.created_at"), to).
+		Count(ctx)
+	if err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+	return count, nil
+}
+
 func (i *instanceDB) CountInstanceDomains(ctx context.Context, domain string) (int, db.Error) {
 	q := i.conn.
 		NewSelect().
@@ -111,6 +139,22 @@ func (i *instanceDB) GetInstance(ctx context.Context, domain string) (*gtsmodel.
 	return instance, nil
 }
 
+func (i *instanceDB) UpdateInstance(ctx context.Context, instance *gtsmodel.Instance, columns ...string) db.Error {
+	instance.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.conn.
+		NewUpdate().
+		Model(instance).
+		Where("? = ?", bun.Ident("instance.id"), instance.ID).
+		Column(columns...).
+		Exec(ctx)
+	return i.conn.ProcessError(err)
+}
+
 func (i *instanceDB) GetInstancePeers(ctx context.Context, includeSuspended bool) ([]*gtsmodel.Instance, db.Error) {
 	instances := []*gtsmodel.Instance{}
 
@@ -157,6 +201,111 @@ func (i *instanceDB) GetInstanceAccounts(ctx context.Context, domain string, max
 	return accounts, nil
 }
 
+func (i *instanceDB) GetDomainStats(ctx context.Context, limit int, offset int) ([]*gtsmodel.DomainStats, db.Error) {
+	// Select the page of domains
+	// with their account counts.
+	var accountRows []struct {
+		Domain       string
+		AccountCount int
+	}
+
+	q := i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+		ColumnExpr("? AS ?", bun.Ident("account.domain"), bun.Ident("domain")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("account_count")).
+		Where("? IS NOT NULL", bun.Ident("account.domain")).
+		Group("account.domain").
+		OrderExpr("? DESC", bun.Ident("account_count")).
+		OrderExpr("? ASC", bun.Ident("domain"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if offset > 0 {
+		q = q.Offset(offset)
+	}
+
+	if err := q.Scan(ctx, &accountRows); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	if len(accountRows) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	domains := make([]string, 0, len(accountRows))
+	stats := make([]*gtsmodel.DomainStats, 0, len(accountRows))
+	byDomain := make(map[string]*gtsmodel.DomainStats, len(accountRows))
+
+	for _, row := range accountRows {
+		domainStats := &gtsmodel.DomainStats{
+			Domain:       row.Domain,
+			AccountCount: row.AccountCount,
+		}
+		domains = append(domains, row.Domain)
+		stats = append(stats, domainStats)
+		byDomain[row.Domain] = domainStats
+	}
+
+	// Fill in status counts and last
+	// activity for this page of domains.
+	var statusRows []struct {
+		Domain       string
+		StatusCount  int
+		LastActivity time.Time
+	}
+
+	if err := i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("account"), bun.Ident("account.id"), bun.Ident("status.account_id")).
+		ColumnExpr("? AS ?", bun.Ident("account.domain"), bun.Ident("domain")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("status_count")).
+		ColumnExpr("MAX(?) AS ?", bun.Ident("status.created_at"), bun.Ident("last_activity")).
+		Where("? IN (?)", bun.Ident("account.domain"), bun.In(domains)).
+		Group("account.domain").
+		Scan(ctx, &statusRows); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	for _, row := range statusRows {
+		if domainStats, ok := byDomain[row.Domain]; ok {
+			domainStats.StatusCount = row.StatusCount
+			domainStats.LastActivity = row.LastActivity
+		}
+	}
+
+	// Fill in the total size of cached
+	// media for this page of domains.
+	var mediaRows []struct {
+		Domain     string
+		MediaBytes int64
+	}
+
+	if err := i.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("account"), bun.Ident("account.id"), bun.Ident("media_attachment.account_id")).
+		ColumnExpr("? AS ?", bun.Ident("account.domain"), bun.Ident("domain")).
+		ColumnExpr("SUM(? + ?) AS ?", bun.Ident("media_attachment.file_file_size"), bun.Ident("media_attachment.thumbnail_file_size"), bun.Ident("media_bytes")).
+		Where("? IN (?)", bun.Ident("account.domain"), bun.In(domains)).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		Group("account.domain").
+		Scan(ctx, &mediaRows); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	for _, row := range mediaRows {
+		if domainStats, ok := byDomain[row.Domain]; ok {
+			domainStats.MediaBytes = row.MediaBytes
+		}
+	}
+
+	return stats, nil
+}
+
 func (i *instanceDB) GetInstanceModeratorAddresses(ctx context.Context) ([]string, db.Error) {
 	addresses := []string{}
 
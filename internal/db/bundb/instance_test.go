@@ -92,6 +92,41 @@ func (suite *InstanceTestSuite) TestGetInstanceAccounts() {
 	suite.Len(accounts, 1)
 }
 
+func (suite *InstanceTestSuite) TestGetDomainStats() {
+	ctx := context.Background()
+
+	stats, err := suite.db.GetDomainStats(ctx, 0, 0)
+	suite.NoError(err)
+	suite.NotEmpty(stats)
+
+	for i, domainStats := range stats {
+		if i > 0 {
+			// Ordered by account count, most first.
+			suite.LessOrEqual(domainStats.AccountCount, stats[i-1].AccountCount)
+		}
+
+		accounts, err := suite.db.GetInstanceAccounts(ctx, domainStats.Domain, "", 0)
+		suite.NoError(err)
+		suite.Equal(len(accounts), domainStats.AccountCount)
+
+		statusCount, err := suite.db.CountInstanceStatuses(ctx, domainStats.Domain)
+		suite.NoError(err)
+		suite.Equal(statusCount, domainStats.StatusCount)
+
+		if domainStats.StatusCount == 0 {
+			suite.Zero(domainStats.LastActivity)
+		} else {
+			suite.NotZero(domainStats.LastActivity)
+		}
+	}
+
+	// Paging should pick up where the last page left off.
+	page, err := suite.db.GetDomainStats(ctx, 1, 1)
+	suite.NoError(err)
+	suite.Len(page, 1)
+	suite.Equal(stats[1].Domain, page[0].Domain)
+}
+
 func (suite *InstanceTestSuite) TestGetInstanceModeratorAddressesOK() {
 	// We have one admin user by default.
 	addresses, err := suite.db.GetInstanceModeratorAddresses(context.Background())
@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type instancePolicyDB struct {
+	conn *DBConn
+}
+
+func (i *instancePolicyDB) GetInstancePolicy(ctx context.Context, policyType gtsmodel.InstancePolicyType) (*gtsmodel.InstancePolicy, db.Error) {
+	policy := new(gtsmodel.InstancePolicy)
+
+	if err := i.conn.
+		NewSelect().
+		Model(policy).
+		Where("? = ?", bun.Ident("instance_policy.type"), policyType).
+		Scan(ctx); err != nil {
+		return nil, i.conn.ProcessError(err)
+	}
+
+	return policy, nil
+}
+
+func (i *instancePolicyDB) PutInstancePolicy(ctx context.Context, policy *gtsmodel.InstancePolicy) db.Error {
+	_, err := i.conn.
+		NewInsert().
+		Model(policy).
+		Exec(ctx)
+	return i.conn.ProcessError(err)
+}
+
+func (i *instancePolicyDB) UpdateInstancePolicy(ctx context.Context, policy *gtsmodel.InstancePolicy, columns ...string) db.Error {
+	policy.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.conn.
+		NewUpdate().
+		Model(policy).
+		Where("? = ?", bun.Ident("instance_policy.id"), policy.ID).
+		Column(columns...).
+		Exec(ctx)
+	return i.conn.ProcessError(err)
+}
+
+func (i *instancePolicyDB) DeleteInstancePolicy(ctx context.Context, policyType gtsmodel.InstancePolicyType) db.Error {
+	_, err := i.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("instance_policies"), bun.Ident("instance_policy")).
+		Where("? = ?", bun.Ident("instance_policy.type"), policyType).
+		Exec(ctx)
+	return i.conn.ProcessError(err)
+}
@@ -20,8 +20,12 @@ package bundb
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -33,10 +37,15 @@ import (
 type mediaDB struct {
 	conn  *DBConn
 	state *state.State
+
+	// IDs of stale attachments currently
+	// being refreshed in the background.
+	refreshing   map[string]struct{}
+	refreshingMu sync.Mutex
 }
 
 func (m *mediaDB) GetAttachmentByID(ctx context.Context, id string) (*gtsmodel.MediaAttachment, db.Error) {
-	return m.getAttachment(
+	attachment, err := m.getAttachment(
 		ctx,
 		"ID",
 		func(attachment *gtsmodel.MediaAttachment) error {
@@ -47,26 +56,210 @@ func (m *mediaDB) GetAttachmentByID(ctx context.Context, id string) (*gtsmodel.M
 		},
 		id,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.CachedMediaOnly(ctx) && !*attachment.Cached {
+		// Caller only wants media
+		// that can be served.
+		return nil, db.ErrMediaNotCached
+	}
+
+	return attachment, nil
+}
+
+func (m *mediaDB) GetAttachmentWithContext(ctx context.Context, id string) (*gtsmodel.MediaAttachmentContext, db.Error) {
+	attachment, err := m.GetAttachmentByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Status and account both have their own caches, so
+	// going via those means repeat lookups for the same
+	// attachment (eg., serving thumbnails for a thread)
+	// won't hit the database at all.
+	mediaCtx := &gtsmodel.MediaAttachmentContext{
+		Attachment: attachment,
+	}
+
+	mediaCtx.Account, err = m.state.DB.GetAccountByID(ctx, attachment.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting account %s owning attachment %s: %w", attachment.AccountID, id, err)
+	}
+
+	if attachment.StatusID == "" {
+		// Unattached, nothing more to do.
+		return mediaCtx, nil
+	}
+
+	mediaCtx.Status, err = m.state.DB.GetStatusByID(ctx, attachment.StatusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("error getting status %s owning attachment %s: %w", attachment.StatusID, id, err)
+	}
+
+	return mediaCtx, nil
 }
 
 func (m *mediaDB) GetAttachmentsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.MediaAttachment, error) {
 	attachments := make([]*gtsmodel.MediaAttachment, 0, len(ids))
+	m.getAttachmentsByIDs(ctx, ids, func(attachment *gtsmodel.MediaAttachment) {
+		attachments = append(attachments, attachment)
+	})
+	return attachments, nil
+}
+
+func (m *mediaDB) GetAttachmentsByIDsMap(ctx context.Context, ids []string) (map[string]*gtsmodel.MediaAttachment, error) {
+	attachments := make(map[string]*gtsmodel.MediaAttachment, len(ids))
+	m.getAttachmentsByIDs(ctx, ids, func(attachment *gtsmodel.MediaAttachment) {
+		attachments[attachment.ID] = attachment
+	})
+	return attachments, nil
+}
+
+// getAttachmentsByIDs fetches attachments with the given IDs, serving stale
+// copies where allowed, and passes each one in turn to the given function.
+// Attachments that can't be fetched are logged and skipped.
+func (m *mediaDB) getAttachmentsByIDs(ctx context.Context, ids []string, add func(*gtsmodel.MediaAttachment)) {
+	staleWindow := config.GetCacheGTSMediaStaleWindow()
 
 	for _, id := range ids {
+		if staleWindow > 0 && !m.state.Caches.GTS.Media().Has("ID", id) {
+			// Not in the main cache, but we may still have a
+			// recent enough copy to serve while we refresh it.
+			if stale, ok := m.state.Caches.GTS.MediaStale().Get(id); ok {
+				m.refreshStaleAttachment(id)
+
+				// Pass on a copy, so the stale
+				// one can't be changed by callers.
+				attachment := new(gtsmodel.MediaAttachment)
+				*attachment = *stale
+				add(attachment)
+				continue
+			}
+		}
+
 		// Attempt fetch from DB
 		attachment, err := m.GetAttachmentByID(ctx, id)
 		if err != nil {
-			log.Errorf(ctx, "error getting attachment %q: %v", id, err)
+			if !errors.Is(err, db.ErrMediaNotCached) {
+				log.Errorf(ctx, "error getting attachment %q: %v", id, err)
+			}
 			continue
 		}
 
-		// Append attachment
-		attachments = append(attachments, attachment)
+		// Add attachment
+		add(attachment)
+	}
+}
+
+// setStaleAttachment stores a copy of the given attachment, freshly
+// loaded from the database, in the stale media cache for use by
+// GetAttachmentsByIDs.
+func (m *mediaDB) setStaleAttachment(attachment *gtsmodel.MediaAttachment) {
+	stale := new(gtsmodel.MediaAttachment)
+	*stale = *attachment
+	m.state.Caches.GTS.MediaStale().Set(stale.ID, stale)
+}
+
+// refreshStaleAttachment asynchronously reloads the attachment
+// with given ID from the database, which updates its stale copy.
+//
+// If the attachment is gone, the stale copy is dropped. On any
+// other error the stale copy is left alone, as it's likely still
+// valid, and will expire at the end of the stale window anyway.
+func (m *mediaDB) refreshStaleAttachment(id string) {
+	m.refreshingMu.Lock()
+	if _, ok := m.refreshing[id]; ok {
+		// Already being refreshed.
+		m.refreshingMu.Unlock()
+		return
+	}
+	m.refreshing[id] = struct{}{}
+	m.refreshingMu.Unlock()
+
+	m.state.Workers.Media.Enqueue(func(ctx context.Context) {
+		defer func() {
+			m.refreshingMu.Lock()
+			delete(m.refreshing, id)
+			m.refreshingMu.Unlock()
+		}()
+
+		_, err := m.GetAttachmentByID(ctx, id)
+		if errors.Is(err, db.ErrNoEntries) {
+			m.state.Caches.GTS.MediaStale().Invalidate(id)
+		} else if err != nil {
+			log.Warnf(ctx, "error refreshing stale attachment %q: %v", id, err)
+		}
+	})
+}
+
+func (m *mediaDB) GetAttachmentsByStatusID(ctx context.Context, statusID string) ([]*gtsmodel.MediaAttachment, error) {
+	var attachments []*gtsmodel.MediaAttachment
+
+	// Select all attachments for this status in one go.
+	if err := m.conn.
+		NewSelect().
+		Model(&attachments).
+		Where("? = ?", bun.Ident("media_attachment.status_id"), statusID).
+		Order("media_attachment.id ASC").
+		Scan(ctx); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	for i, attachment := range attachments {
+		// Populate the cache with each attachment, preferring
+		// any copy which has already been cached to the fresh one.
+		cached, err := m.state.Caches.GTS.Media().Load("ID", func() (*gtsmodel.MediaAttachment, error) {
+			return attachment, nil
+		}, attachment.ID)
+		if err != nil {
+			// Should never happen.
+			return nil, err
+		}
+		attachments[i] = cached
 	}
 
+	// Return attachments in the order declared
+	// by the status, rather than in ID order.
+	status := &gtsmodel.Status{}
+	if err := m.conn.
+		NewSelect().
+		Model(status).
+		Column("status.attachments").
+		Where("? = ?", bun.Ident("status.id"), statusID).
+		Scan(ctx); err != nil {
+		err = m.conn.ProcessError(err)
+		if !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+	}
+
+	sortAttachmentsByIDs(attachments, status.AttachmentIDs)
+
 	return attachments, nil
 }
 
+// sortAttachmentsByIDs sorts attachments into the order of the given IDs.
+// Attachments not in ids are moved to the end, keeping their relative order.
+func sortAttachmentsByIDs(attachments []*gtsmodel.MediaAttachment, ids []string) {
+	idx := make(map[string]int, len(ids))
+	for i, id := range ids {
+		idx[id] = i
+	}
+
+	position := func(attachment *gtsmodel.MediaAttachment) int {
+		if i, ok := idx[attachment.ID]; ok {
+			return i
+		}
+		return len(ids)
+	}
+
+	sort.SliceStable(attachments, func(i, j int) bool {
+		return position(attachments[i]) < position(attachments[j])
+	})
+}
+
 func (m *mediaDB) getAttachment(ctx context.Context, lookup string, dbQuery func(*gtsmodel.MediaAttachment) error, keyParts ...any) (*gtsmodel.MediaAttachment, db.Error) {
 	return m.state.Caches.GTS.Media().Load(lookup, func() (*gtsmodel.MediaAttachment, error) {
 		var attachment gtsmodel.MediaAttachment
@@ -76,6 +269,11 @@ func (m *mediaDB) getAttachment(ctx context.Context, lookup string, dbQuery func
 			return nil, m.conn.ProcessError(err)
 		}
 
+		if config.GetCacheGTSMediaStaleWindow() > 0 {
+			// Keep a copy around for serving stale.
+			m.setStaleAttachment(&attachment)
+		}
+
 		return &attachment, nil
 	}, keyParts...)
 }
@@ -104,6 +302,46 @@ func (m *mediaDB) UpdateAttachment(ctx context.Context, media *gtsmodel.MediaAtt
 	})
 }
 
+func (m *mediaDB) GetAttachmentProcessingState(ctx context.Context, id string) (gtsmodel.ProcessingStatus, error) {
+	media, err := m.GetAttachmentByID(gtscontext.SetBarebones(ctx), id)
+	if err != nil {
+		return 0, err
+	}
+	return media.Processing, nil
+}
+
+func (m *mediaDB) PutAttachmentVariant(ctx context.Context, variant *gtsmodel.MediaAttachmentVariant) error {
+	_, err := m.conn.
+		NewInsert().
+		Model(variant).
+		Exec(ctx)
+	return m.conn.ProcessError(err)
+}
+
+func (m *mediaDB) GetAttachmentVariants(ctx context.Context, attachmentID string) ([]*gtsmodel.MediaAttachmentVariant, error) {
+	variants := []*gtsmodel.MediaAttachmentVariant{}
+
+	if err := m.conn.
+		NewSelect().
+		Model(&variants).
+		Where("? = ?", bun.Ident("media_attachment_variant.attachment_id"), attachmentID).
+		Order("media_attachment_variant.id ASC").
+		Scan(ctx); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	return variants, nil
+}
+
+func (m *mediaDB) DeleteAttachmentVariants(ctx context.Context, attachmentID string) error {
+	_, err := m.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("media_attachment_variants"), bun.Ident("media_attachment_variant")).
+		Where("? = ?", bun.Ident("media_attachment_variant.attachment_id"), attachmentID).
+		Exec(ctx)
+	return m.conn.ProcessError(err)
+}
+
 func (m *mediaDB) DeleteAttachment(ctx context.Context, id string) error {
 	defer m.state.Caches.GTS.Media().Invalidate("ID", id)
 
@@ -119,15 +357,38 @@ func (m *mediaDB) DeleteAttachment(ctx context.Context, id string) error {
 		return err
 	}
 
-	// Finally delete media from DB.
-	_, err = m.conn.NewDelete().
-		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
-		Where("? = ?", bun.Ident("media_attachment.id"), id).
-		Exec(ctx)
-	return m.conn.ProcessError(err)
+	// Finally delete media and its variants from DB.
+	if err := m.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			TableExpr("? AS ?", bun.Ident("media_attachment_variants"), bun.Ident("media_attachment_variant")).
+			Where("? = ?", bun.Ident("media_attachment_variant.attachment_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.NewDelete().
+			TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+			Where("? = ?", bun.Ident("media_attachment.id"), id).
+			Exec(ctx)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *mediaDB) SetAttachmentInstanceAsset(ctx context.Context, id string, instanceAsset bool) error {
+	attachment, err := m.GetAttachmentByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	attachment.InstanceAsset = &instanceAsset
+	return m.UpdateAttachment(ctx, attachment, "instance_asset")
 }
 
-func (m *mediaDB) GetRemoteOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, db.Error) {
+func (m *mediaDB) GetRemoteOlderThanAscending(ctx context.Context, olderThan time.Time, sinceCreatedAt time.Time, sinceID string, limit int) ([]*gtsmodel.MediaAttachment, db.Error) {
 	attachmentIDs := []string{}
 
 	q := m.conn.
@@ -136,17 +397,34 @@ func (m *mediaDB) GetRemoteOlderThan(ctx context.Context, olderThan time.Time, l
 		Column("media_attachment.id").
 		Where("? = ?", bun.Ident("media_attachment.cached"), true).
 		Where("? < ?", bun.Ident("media_attachment.created_at"), olderThan).
+		Where("? = ?", bun.Ident("media_attachment.instance_asset"), false).
 		WhereGroup(" AND ", whereNotEmptyAndNotNull("media_attachment.remote_url")).
-		Order("media_attachment.created_at DESC")
-
-	if limit != 0 {
-		q = q.Limit(limit)
+		OrderExpr("? ASC", bun.Ident("media_attachment.created_at")).
+		OrderExpr("? ASC", bun.Ident("media_attachment.id")).
+		Limit(limit)
+
+	if sinceID != "" {
+		// Only select attachments ordered after
+		// the one with sinceID and sinceCreatedAt.
+		q = q.WhereGroup(" AND ", func(innerQ *bun.SelectQuery) *bun.SelectQuery {
+			return innerQ.
+				WhereOr("? > ?", bun.Ident("media_attachment.created_at"), sinceCreatedAt).
+				WhereGroup(" OR ", func(innerQ *bun.SelectQuery) *bun.SelectQuery {
+					return innerQ.
+						Where("? = ?", bun.Ident("media_attachment.created_at"), sinceCreatedAt).
+						Where("? > ?", bun.Ident("media_attachment.id"), sinceID)
+				})
+		})
 	}
 
 	if err := q.Scan(ctx, &attachmentIDs); err != nil {
 		return nil, m.conn.ProcessError(err)
 	}
 
+	if len(attachmentIDs) == 0 {
+		return nil, nil
+	}
+
 	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
 }
 
@@ -157,6 +435,7 @@ func (m *mediaDB) CountRemoteOlderThan(ctx context.Context, olderThan time.Time)
 		Column("media_attachment.id").
 		Where("? = ?", bun.Ident("media_attachment.cached"), true).
 		Where("? < ?", bun.Ident("media_attachment.created_at"), olderThan).
+		Where("? = ?", bun.Ident("media_attachment.instance_asset"), false).
 		WhereGroup(" AND ", whereNotEmptyAndNotNull("media_attachment.remote_url"))
 
 	count, err := q.Count(ctx)
@@ -167,6 +446,135 @@ func (m *mediaDB) CountRemoteOlderThan(ctx context.Context, olderThan time.Time)
 	return count, nil
 }
 
+func (m *mediaDB) GetRemoteDead(ctx context.Context, minFailures int, failingSince time.Time, limit int) ([]*gtsmodel.MediaAttachment, db.Error) {
+	attachmentIDs := []string{}
+
+	q := m.newRemoteDeadQ(minFailures, failingSince).
+		Column("media_attachment.id").
+		Order("media_attachment.id ASC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &attachmentIDs); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
+
+func (m *mediaDB) CountRemoteDead(ctx context.Context, minFailures int, failingSince time.Time) (int, db.Error) {
+	count, err := m.newRemoteDeadQ(minFailures, failingSince).Count(ctx)
+	if err != nil {
+		return 0, m.conn.ProcessError(err)
+	}
+
+	return count, nil
+}
+
+// newRemoteDeadQ returns a select query for uncached remote media which
+// has failed to be fetched at least minFailures times, with the first
+// of those failures before failingSince.
+func (m *mediaDB) newRemoteDeadQ(minFailures int, failingSince time.Time) *bun.SelectQuery {
+	return m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Where("? = ?", bun.Ident("media_attachment.cached"), false).
+		Where("? >= ?", bun.Ident("media_attachment.fetch_failures"), minFailures).
+		Where("? < ?", bun.Ident("media_attachment.fetch_failing_since"), failingSince).
+		Where("? = ?", bun.Ident("media_attachment.avatar"), false).
+		Where("? = ?", bun.Ident("media_attachment.header"), false).
+		Where("? = ?", bun.Ident("media_attachment.instance_asset"), false).
+		WhereGroup(" AND ", whereNotEmptyAndNotNull("media_attachment.remote_url"))
+}
+
+func (m *mediaDB) GetUncachedRecentlyInteracted(ctx context.Context, since time.Time, limit int) ([]*gtsmodel.MediaAttachment, db.Error) {
+	// Time of the most recent interaction with each status.
+	interactedAt := make(map[string]time.Time, limit)
+
+	for _, q := range []*bun.SelectQuery{
+		// Faves of statuses.
+		m.conn.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+			ColumnExpr("? AS ?", bun.Ident("status_fave.status_id"), bun.Ident("status_id")).
+			ColumnExpr("MAX(?) AS ?", bun.Ident("status_fave.created_at"), bun.Ident("interacted_at")).
+			Where("? > ?", bun.Ident("status_fave.created_at"), since).
+			Group("status_fave.status_id"),
+
+		// Boosts of statuses.
+		m.conn.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+			ColumnExpr("? AS ?", bun.Ident("status.boost_of_id"), bun.Ident("status_id")).
+			ColumnExpr("MAX(?) AS ?", bun.Ident("status.created_at"), bun.Ident("interacted_at")).
+			Where("? IS NOT NULL", bun.Ident("status.boost_of_id")).
+			Where("? > ?", bun.Ident("status.created_at"), since).
+			Group("status.boost_of_id"),
+	} {
+		var rows []struct {
+			StatusID     string
+			InteractedAt time.Time
+		}
+
+		if err := q.
+			OrderExpr("? DESC", bun.Ident("interacted_at")).
+			Limit(limit).
+			Scan(ctx, &rows); err != nil {
+			return nil, m.conn.ProcessError(err)
+		}
+
+		for _, row := range rows {
+			if row.InteractedAt.After(interactedAt[row.StatusID]) {
+				interactedAt[row.StatusID] = row.InteractedAt
+			}
+		}
+	}
+
+	if len(interactedAt) == 0 {
+		return nil, nil
+	}
+
+	statusIDs := make([]string, 0, len(interactedAt))
+	for statusID := range interactedAt {
+		statusIDs = append(statusIDs, statusID)
+	}
+
+	var rows []struct {
+		ID       string
+		StatusID string
+	}
+
+	if err := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.id", "media_attachment.status_id").
+		Where("? IN (?)", bun.Ident("media_attachment.status_id"), bun.In(statusIDs)).
+		Where("? = ?", bun.Ident("media_attachment.cached"), false).
+		Where("? = ?", bun.Ident("media_attachment.fetch_failures"), 0).
+		WhereGroup(" AND ", whereNotEmptyAndNotNull("media_attachment.remote_url")).
+		Scan(ctx, &rows); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	// Most recently interacted with first.
+	sort.SliceStable(rows, func(i, j int) bool {
+		return interactedAt[rows[i].StatusID].After(interactedAt[rows[j].StatusID])
+	})
+
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+
+	attachmentIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		attachmentIDs = append(attachmentIDs, row.ID)
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
+
 func (m *mediaDB) GetAvatarsAndHeaders(ctx context.Context, maxID string, limit int) ([]*gtsmodel.MediaAttachment, db.Error) {
 	attachmentIDs := []string{}
 
@@ -206,8 +614,10 @@ func (m *mediaDB) GetLocalUnattachedOlderThan(ctx context.Context, olderThan tim
 		Where("? = ?", bun.Ident("media_attachment.avatar"), false).
 		Where("? = ?", bun.Ident("media_attachment.header"), false).
 		Where("? < ?", bun.Ident("media_attachment.created_at"), olderThan).
+		Where("? = ?", bun.Ident("media_attachment.instance_asset"), false).
 		Where("? IS NULL", bun.Ident("media_attachment.remote_url")).
 		Where("? IS NULL", bun.Ident("media_attachment.status_id")).
+		Where("? IS NULL", bun.Ident("media_attachment.scheduled_status_id")).
 		Order("media_attachment.created_at DESC")
 
 	if limit != 0 {
@@ -230,8 +640,102 @@ func (m *mediaDB) CountLocalUnattachedOlderThan(ctx context.Context, olderThan t
 		Where("? = ?", bun.Ident("media_attachment.avatar"), false).
 		Where("? = ?", bun.Ident("media_attachment.header"), false).
 		Where("? < ?", bun.Ident("media_attachment.created_at"), olderThan).
+		Where("? = ?", bun.Ident("media_attachment.instance_asset"), false).
 		Where("? IS NULL", bun.Ident("media_attachment.remote_url")).
-		Where("? IS NULL", bun.Ident("media_attachment.status_id"))
+		Where("? IS NULL", bun.Ident("media_attachment.status_id")).
+		Where("? IS NULL", bun.Ident("media_attachment.scheduled_status_id"))
+
+	count, err := q.Count(ctx)
+	if err != nil {
+		return 0, m.conn.ProcessError(err)
+	}
+
+	return count, nil
+}
+
+func (m *mediaDB) GetAttachmentsInErrorState(ctx context.Context, limit int) ([]*gtsmodel.MediaAttachment, db.Error) {
+	attachmentIDs := []string{}
+
+	q := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.id").
+		WhereGroup(" AND ", whereNotEmptyAndNotNull("media_attachment.processing_error")).
+		WhereGroup(" AND ", whereNotEmptyAndNotNull("media_attachment.remote_url")).
+		Order("media_attachment.created_at DESC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &attachmentIDs); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
+
+func (m *mediaDB) CountAttachmentsInErrorState(ctx context.Context) (int, db.Error) {
+	q := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.id").
+		WhereGroup(" AND ", whereNotEmptyAndNotNull("media_attachment.processing_error")).
+		WhereGroup(" AND ", whereNotEmptyAndNotNull("media_attachment.remote_url"))
+
+	count, err := q.Count(ctx)
+	if err != nil {
+		return 0, m.conn.ProcessError(err)
+	}
+
+	return count, nil
+}
+
+func (m *mediaDB) GetAttachmentsMissingDescription(ctx context.Context, accountID string, maxID string, limit int) ([]*gtsmodel.MediaAttachment, db.Error) {
+	attachmentIDs := []string{}
+
+	q := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.id").
+		Where("? = ?", bun.Ident("media_attachment.account_id"), accountID).
+		Where("? = ?", bun.Ident("media_attachment.avatar"), false).
+		Where("? = ?", bun.Ident("media_attachment.header"), false).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("media_attachment.description")).
+				WhereOr("? = ''", bun.Ident("media_attachment.description"))
+		}).
+		Order("media_attachment.id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("media_attachment.id"), maxID)
+	}
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &attachmentIDs); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
+
+func (m *mediaDB) CountAttachmentsMissingDescription(ctx context.Context, accountID string) (int, db.Error) {
+	q := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Column("media_attachment.id").
+		Where("? = ?", bun.Ident("media_attachment.account_id"), accountID).
+		Where("? = ?", bun.Ident("media_attachment.avatar"), false).
+		Where("? = ?", bun.Ident("media_attachment.header"), false).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("media_attachment.description")).
+				WhereOr("? = ''", bun.Ident("media_attachment.description"))
+		})
 
 	count, err := q.Count(ctx)
 	if err != nil {
@@ -240,3 +744,86 @@ func (m *mediaDB) CountLocalUnattachedOlderThan(ctx context.Context, olderThan t
 
 	return count, nil
 }
+
+func (m *mediaDB) GetCachedMediaUsageByDomain(ctx context.Context) ([]*gtsmodel.DomainMediaUsage, db.Error) {
+	var rows []struct {
+		Domain string
+		Count  int
+		Bytes  int64
+	}
+
+	q := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("media_attachment.account_id"),
+		).
+		ColumnExpr("? AS ?", bun.Ident("account.domain"), bun.Ident("domain")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		ColumnExpr("SUM(? + ?) AS ?",
+			bun.Ident("media_attachment.file_file_size"),
+			bun.Ident("media_attachment.thumbnail_file_size"),
+			bun.Ident("bytes"),
+		).
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		WhereGroup(" AND ", whereNotEmptyAndNotNull("media_attachment.remote_url")).
+		WhereGroup(" AND ", whereNotEmptyAndNotNull("account.domain")).
+		Group("account.domain").
+		OrderExpr("? DESC", bun.Ident("bytes")).
+		OrderExpr("? ASC", bun.Ident("domain"))
+
+	if err := q.Scan(ctx, &rows); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	usages := make([]*gtsmodel.DomainMediaUsage, 0, len(rows))
+	for _, row := range rows {
+		usages = append(usages, &gtsmodel.DomainMediaUsage{
+			Domain: row.Domain,
+			Count:  row.Count,
+			Bytes:  row.Bytes,
+		})
+	}
+
+	return usages, nil
+}
+
+func (m *mediaDB) GetCachedAttachmentsForDomain(ctx context.Context, domain string, limit int) ([]*gtsmodel.MediaAttachment, db.Error) {
+	attachmentIDs := []string{}
+
+	q := m.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("media_attachments"), bun.Ident("media_attachment")).
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("media_attachment.account_id"),
+		).
+		Column("media_attachment.id").
+		Where("? = ?", bun.Ident("media_attachment.cached"), true).
+		WhereGroup(" AND ", whereNotEmptyAndNotNull("media_attachment.remote_url")).
+		Where("? = ?", bun.Ident("account.domain"), domain).
+		Order("media_attachment.updated_at ASC", "media_attachment.id ASC")
+
+	if limit != 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &attachmentIDs); err != nil {
+		return nil, m.conn.ProcessError(err)
+	}
+
+	attachments := make([]*gtsmodel.MediaAttachment, 0, len(attachmentIDs))
+	for _, id := range attachmentIDs {
+		// Fetch directly rather than via GetAttachmentsByIDs,
+		// so we never get a stale copy with outdated cached flag.
+		attachment, err := m.GetAttachmentByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting attachment %q: %v", id, err)
+			continue
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
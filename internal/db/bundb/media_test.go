@@ -23,6 +23,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -37,12 +42,246 @@ func (suite *MediaTestSuite) TestGetAttachmentByID() {
 	suite.NotNil(attachment)
 }
 
+func (suite *MediaTestSuite) TestGetAttachmentByIDCachedOnly() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	// Cached attachments are returned as normal.
+	attachment, err := suite.db.GetAttachmentByID(gtscontext.SetCachedMediaOnly(ctx), testAttachment.ID)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// Uncache the attachment.
+	attachment.Cached = testrig.FalseBool()
+	if err := suite.db.UpdateAttachment(ctx, attachment, "cached"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now it's only returned without the flag.
+	attachment, err = suite.db.GetAttachmentByID(gtscontext.SetCachedMediaOnly(ctx), testAttachment.ID)
+	suite.ErrorIs(err, db.ErrMediaNotCached)
+	suite.Nil(attachment)
+
+	attachment, err = suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.False(*attachment.Cached)
+}
+
+func (suite *MediaTestSuite) TestGetAttachmentWithContext() {
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+	mediaCtx, err := suite.db.GetAttachmentWithContext(context.Background(), testAttachment.ID)
+	suite.NoError(err)
+	suite.Equal(testAttachment.ID, mediaCtx.Attachment.ID)
+	suite.Equal(testAttachment.AccountID, mediaCtx.Account.ID)
+	suite.NotNil(mediaCtx.Status)
+	suite.Equal(testAttachment.StatusID, mediaCtx.Status.ID)
+}
+
+func (suite *MediaTestSuite) TestGetAttachmentWithContextUnattached() {
+	testAttachment := suite.testAttachments["local_account_1_unattached_1"]
+	mediaCtx, err := suite.db.GetAttachmentWithContext(context.Background(), testAttachment.ID)
+	suite.NoError(err)
+	suite.Equal(testAttachment.ID, mediaCtx.Attachment.ID)
+	suite.Equal(testAttachment.AccountID, mediaCtx.Account.ID)
+	suite.Nil(mediaCtx.Status)
+}
+
+func (suite *MediaTestSuite) TestGetAttachmentsByStatusID() {
+	testStatus := suite.testStatuses["local_account_1_status_4"]
+	attachments, err := suite.db.GetAttachmentsByStatusID(context.Background(), testStatus.ID)
+	suite.NoError(err)
+	suite.Len(attachments, 2)
+	for i, attachment := range attachments {
+		suite.Equal(testStatus.ID, attachment.StatusID)
+		// Declared order, not ID order.
+		suite.Equal(testStatus.AttachmentIDs[i], attachment.ID)
+	}
+}
+
+func (suite *MediaTestSuite) TestGetAttachmentsByIDsServeStale() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	config.SetCacheGTSMediaStaleWindow(time.Minute)
+	defer config.SetCacheGTSMediaStaleWindow(0)
+
+	testrig.StartWorkers(&suite.state)
+	defer testrig.StopWorkers(&suite.state)
+
+	// Put an outdated copy of the attachment in the
+	// stale cache, while the main cache doesn't have it.
+	stale := &gtsmodel.MediaAttachment{}
+	*stale = *testAttachment
+	stale.Description = "outdated description"
+	suite.state.Caches.GTS.MediaStale().Set(stale.ID, stale)
+
+	// The stale copy should be served straight away...
+	attachments, err := suite.db.GetAttachmentsByIDs(ctx, []string{testAttachment.ID})
+	suite.NoError(err)
+	suite.Len(attachments, 1)
+	suite.Equal("outdated description", attachments[0].Description)
+	suite.NotSame(stale, attachments[0])
+
+	// ...and then refreshed from the db in the background.
+	if !testrig.WaitFor(func() bool {
+		refreshed, ok := suite.state.Caches.GTS.MediaStale().Get(testAttachment.ID)
+		return ok && refreshed.Description == testAttachment.Description
+	}) {
+		suite.FailNow("timed out waiting for stale attachment refresh")
+	}
+
+	attachments, err = suite.db.GetAttachmentsByIDs(ctx, []string{testAttachment.ID})
+	suite.NoError(err)
+	suite.Len(attachments, 1)
+	suite.Equal(testAttachment.Description, attachments[0].Description)
+}
+
+func (suite *MediaTestSuite) TestGetAttachmentStaleOnlyOnLoad() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	config.SetCacheGTSMediaStaleWindow(time.Minute)
+	defer config.SetCacheGTSMediaStaleWindow(0)
+
+	// Loading from the db keeps a stale copy.
+	attachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	stale, ok := suite.state.Caches.GTS.MediaStale().Get(testAttachment.ID)
+	suite.True(ok)
+	suite.NotSame(attachment, stale)
+
+	// But a main cache hit doesn't touch it.
+	suite.state.Caches.GTS.MediaStale().Invalidate(testAttachment.ID)
+	_, err = suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.False(suite.state.Caches.GTS.MediaStale().Has(testAttachment.ID))
+}
+
+func (suite *MediaTestSuite) TestGetAttachmentsByIDsStaleDisabled() {
+	testAttachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	// With no stale window (the default),
+	// stale copies are never served.
+	stale := &gtsmodel.MediaAttachment{}
+	*stale = *testAttachment
+	stale.Description = "outdated description"
+	suite.state.Caches.GTS.MediaStale().Set(stale.ID, stale)
+
+	attachments, err := suite.db.GetAttachmentsByIDs(context.Background(), []string{testAttachment.ID})
+	suite.NoError(err)
+	suite.Len(attachments, 1)
+	suite.Equal(testAttachment.Description, attachments[0].Description)
+}
+
+func (suite *MediaTestSuite) TestGetAttachmentsByIDsMap() {
+	attachment1 := suite.testAttachments["admin_account_status_1_attachment_1"]
+	attachment2 := suite.testAttachments["local_account_1_status_4_attachment_1"]
+
+	attachments, err := suite.db.GetAttachmentsByIDsMap(context.Background(), []string{
+		attachment1.ID,
+		"01H3EFQ0S0V7M6D5AKZB2W4N8X", // doesn't exist
+		attachment2.ID,
+	})
+	suite.NoError(err)
+	suite.Len(attachments, 2)
+	suite.Equal(attachment1.StatusID, attachments[attachment1.ID].StatusID)
+	suite.Equal(attachment2.StatusID, attachments[attachment2.ID].StatusID)
+}
+
 func (suite *MediaTestSuite) TestGetOlder() {
-	attachments, err := suite.db.GetRemoteOlderThan(context.Background(), time.Now(), 20)
+	attachments, err := suite.db.GetRemoteOlderThanAscending(context.Background(), time.Now(), time.Time{}, "", 20)
 	suite.NoError(err)
 	suite.Len(attachments, 2)
 }
 
+func (suite *MediaTestSuite) TestGetRemoteOlderThanAscending() {
+	ctx := context.Background()
+
+	// Oldest to newest.
+	all, err := suite.db.GetRemoteOlderThanAscending(ctx, time.Now(), time.Time{}, "", 20)
+	suite.NoError(err)
+	suite.Len(all, 2)
+	suite.False(all[1].CreatedAt.Before(all[0].CreatedAt))
+
+	// Page through oldest to newest, one at a time.
+	var (
+		paged          []string
+		sinceCreatedAt time.Time
+		sinceID        string
+	)
+
+	for {
+		page, err := suite.db.GetRemoteOlderThanAscending(ctx, time.Now(), sinceCreatedAt, sinceID, 1)
+		suite.NoError(err)
+		if len(page) == 0 {
+			break
+		}
+		suite.Len(page, 1)
+		paged = append(paged, page[0].ID)
+		sinceCreatedAt, sinceID = page[0].CreatedAt, page[0].ID
+	}
+
+	suite.Equal([]string{all[0].ID, all[1].ID}, paged)
+}
+
+func (suite *MediaTestSuite) TestGetRemoteOlderThanAscendingSameCreatedAt() {
+	ctx := context.Background()
+
+	fixtures, err := suite.db.GetRemoteOlderThanAscending(ctx, time.Now(), time.Time{}, "", 20)
+	suite.NoError(err)
+	suite.Len(fixtures, 2)
+
+	// Add enough candidates to need more than one page, all
+	// with the same created_at, so that every page after the
+	// first has to be keyed on ID as well as created_at.
+	createdAt := fixtures[0].CreatedAt.Add(-time.Hour)
+	for i := 0; i < 45; i++ {
+		attachment := new(gtsmodel.MediaAttachment)
+		*attachment = *fixtures[0]
+		attachment.ID = id.NewULID()
+		attachment.CreatedAt = createdAt
+		if err := suite.db.PutAttachment(ctx, attachment); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	var (
+		all            []*gtsmodel.MediaAttachment
+		sinceCreatedAt time.Time
+		sinceID        string
+	)
+
+	for {
+		page, err := suite.db.GetRemoteOlderThanAscending(ctx, time.Now(), sinceCreatedAt, sinceID, 20)
+		suite.NoError(err)
+		if len(page) == 0 {
+			break
+		}
+		all = append(all, page...)
+		last := page[len(page)-1]
+		sinceCreatedAt, sinceID = last.CreatedAt, last.ID
+	}
+	suite.Len(all, 47)
+
+	// Every candidate once, oldest to newest,
+	// and lowest to highest ID for equal times.
+	seen := make(map[string]bool, len(all))
+	for i, attachment := range all {
+		suite.False(seen[attachment.ID])
+		seen[attachment.ID] = true
+
+		if i == 0 {
+			continue
+		}
+
+		prev := all[i-1]
+		suite.False(attachment.CreatedAt.Before(prev.CreatedAt))
+		if attachment.CreatedAt.Equal(prev.CreatedAt) {
+			suite.Greater(attachment.ID, prev.ID)
+		}
+	}
+}
+
 func (suite *MediaTestSuite) TestGetAvisAndHeaders() {
 	ctx := context.Background()
 
@@ -59,6 +298,120 @@ func (suite *MediaTestSuite) TestGetLocalUnattachedOlderThan() {
 	suite.Len(attachments, 1)
 }
 
+func (suite *MediaTestSuite) TestGetAttachmentsInErrorState() {
+	ctx := context.Background()
+
+	attachments, err := suite.db.GetAttachmentsInErrorState(ctx, 10)
+	suite.NoError(err)
+	suite.Empty(attachments)
+
+	// Mark a remote attachment as errored.
+	testAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	testAttachment.ProcessingError = "error executing data function: 404 Not Found"
+	if err := suite.db.UpdateAttachment(ctx, testAttachment, "processing_error"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachments, err = suite.db.GetAttachmentsInErrorState(ctx, 10)
+	suite.NoError(err)
+	suite.Len(attachments, 1)
+	suite.Equal(testAttachment.ID, attachments[0].ID)
+
+	count, err := suite.db.CountAttachmentsInErrorState(ctx)
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
+// expectedDomainMediaUsage calculates cached remote
+// media usage per domain from the test fixtures.
+func (suite *MediaTestSuite) expectedDomainMediaUsage() map[string]*gtsmodel.DomainMediaUsage {
+	accounts := make(map[string]*gtsmodel.Account, len(suite.testAccounts))
+	for _, account := range suite.testAccounts {
+		accounts[account.ID] = account
+	}
+
+	usages := make(map[string]*gtsmodel.DomainMediaUsage)
+	for _, attachment := range suite.testAttachments {
+		account, ok := accounts[attachment.AccountID]
+		if !ok || account.Domain == "" || attachment.RemoteURL == "" || !*attachment.Cached {
+			continue
+		}
+
+		usage, ok := usages[account.Domain]
+		if !ok {
+			usage = &gtsmodel.DomainMediaUsage{Domain: account.Domain}
+			usages[account.Domain] = usage
+		}
+
+		usage.Count++
+		usage.Bytes += int64(attachment.File.FileSize + attachment.Thumbnail.FileSize)
+	}
+
+	return usages
+}
+
+func (suite *MediaTestSuite) TestGetCachedMediaUsageByDomain() {
+	expected := suite.expectedDomainMediaUsage()
+	suite.NotEmpty(expected)
+
+	usages, err := suite.db.GetCachedMediaUsageByDomain(context.Background())
+	suite.NoError(err)
+	suite.Len(usages, len(expected))
+
+	for i, usage := range usages {
+		suite.Equal(expected[usage.Domain], usage)
+
+		if i > 0 {
+			// Should be ordered largest first.
+			suite.LessOrEqual(usage.Bytes, usages[i-1].Bytes)
+		}
+	}
+}
+
+func (suite *MediaTestSuite) TestGetCachedAttachmentsForDomain() {
+	ctx := context.Background()
+
+	var domain string
+	for d := range suite.expectedDomainMediaUsage() {
+		domain = d
+		break
+	}
+
+	attachments, err := suite.db.GetCachedAttachmentsForDomain(ctx, domain, 0)
+	suite.NoError(err)
+	suite.NotEmpty(attachments)
+
+	for i, attachment := range attachments {
+		suite.True(*attachment.Cached)
+		suite.NotEmpty(attachment.RemoteURL)
+
+		account, err := suite.db.GetAccountByID(ctx, attachment.AccountID)
+		suite.NoError(err)
+		suite.Equal(domain, account.Domain)
+
+		if i > 0 {
+			// Should be ordered least recently updated first.
+			suite.False(attachment.UpdatedAt.Before(attachments[i-1].UpdatedAt))
+		}
+	}
+
+	// Uncached attachments should no longer be returned.
+	attachment := attachments[0]
+	attachment.Cached = testrig.FalseBool()
+	if err := suite.db.UpdateAttachment(ctx, attachment, "cached"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	uncachedOne, err := suite.db.GetCachedAttachmentsForDomain(ctx, domain, 0)
+	suite.NoError(err)
+	suite.Len(uncachedOne, len(attachments)-1)
+
+	// Limit should be respected.
+	limited, err := suite.db.GetCachedAttachmentsForDomain(ctx, domain, 1)
+	suite.NoError(err)
+	suite.Len(limited, 1)
+}
+
 func TestMediaTestSuite(t *testing.T) {
 	suite.Run(t, new(MediaTestSuite))
 }
@@ -54,6 +54,11 @@ func (m *mentionDB) GetMention(ctx context.Context, id string) (*gtsmodel.Mentio
 		return nil, err
 	}
 
+	if gtscontext.Barebones(ctx) {
+		// Only a barebones model was requested.
+		return mention, nil
+	}
+
 	// Set the mention originating status.
 	mention.Status, err = m.state.DB.GetStatusByID(
 		gtscontext.SetBarebones(ctx),
@@ -88,8 +93,9 @@ func (m *mentionDB) GetMentions(ctx context.Context, ids []string) ([]*gtsmodel.
 	mentions := make([]*gtsmodel.Mention, 0, len(ids))
 
 	for _, id := range ids {
-		// Attempt fetch from DB
-		mention, err := m.GetMention(ctx, id)
+		// Attempt fetch from DB, populating
+		// all the mentions together below.
+		mention, err := m.GetMention(gtscontext.SetBarebones(ctx), id)
 		if err != nil {
 			log.Errorf(ctx, "error getting mention %q: %v", id, err)
 			continue
@@ -99,7 +105,62 @@ func (m *mentionDB) GetMentions(ctx context.Context, ids []string) ([]*gtsmodel.
 		mentions = append(mentions, mention)
 	}
 
-	return mentions, nil
+	if gtscontext.Barebones(ctx) {
+		// Only barebones models were requested.
+		return mentions, nil
+	}
+
+	return m.populateMentions(ctx, mentions)
+}
+
+// populateMentions populates the statuses and accounts of the given mentions,
+// loading all the accounts in one go rather than one by one. Mentions which
+// can't be populated are left out of the returned slice.
+func (m *mentionDB) populateMentions(ctx context.Context, mentions []*gtsmodel.Mention) ([]*gtsmodel.Mention, error) {
+	accountIDs := make([]string, 0, 2*len(mentions))
+	for _, mention := range mentions {
+		accountIDs = append(accountIDs, mention.OriginAccountID, mention.TargetAccountID)
+	}
+
+	accounts, err := m.state.DB.GetAccountsByIDs(gtscontext.SetBarebones(ctx), accountIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error populating mention accounts: %w", err)
+	}
+
+	accountsByID := make(map[string]*gtsmodel.Account, len(accounts))
+	for _, account := range accounts {
+		accountsByID[account.ID] = account
+	}
+
+	populated := make([]*gtsmodel.Mention, 0, len(mentions))
+	for _, mention := range mentions {
+		// Set the mention originating status. Mentions
+		// usually share one status, which will be cached.
+		mention.Status, err = m.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			mention.StatusID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error populating mention %s status: %v", mention.ID, err)
+			continue
+		}
+
+		mention.OriginAccount = accountsByID[mention.OriginAccountID]
+		if mention.OriginAccount == nil {
+			log.Errorf(ctx, "error populating mention %s origin account: not found", mention.ID)
+			continue
+		}
+
+		mention.TargetAccount = accountsByID[mention.TargetAccountID]
+		if mention.TargetAccount == nil {
+			log.Errorf(ctx, "error populating mention %s target account: not found", mention.ID)
+			continue
+		}
+
+		populated = append(populated, mention)
+	}
+
+	return populated, nil
 }
 
 func (m *mentionDB) PutMention(ctx context.Context, mention *gtsmodel.Mention) error {
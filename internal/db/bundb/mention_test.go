@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 )
 
 type MentionTestSuite struct {
@@ -59,6 +60,19 @@ func (suite *MentionTestSuite) TestGetMentions() {
 	suite.NotNil(dbMention.Status)
 }
 
+func (suite *MentionTestSuite) TestGetMentionsBarebones() {
+	m := suite.testMentions["local_user_2_mention_zork"]
+
+	dbMentions, err := suite.db.GetMentions(gtscontext.SetBarebones(context.Background()), []string{m.ID})
+	suite.NoError(err)
+	suite.Len(dbMentions, 1)
+	dbMention := dbMentions[0]
+	suite.Equal(m.ID, dbMention.ID)
+	suite.Nil(dbMention.OriginAccount)
+	suite.Nil(dbMention.TargetAccount)
+	suite.Nil(dbMention.Status)
+}
+
 func TestMentionTestSuite(t *testing.T) {
 	suite.Run(t, new(MentionTestSuite))
 }
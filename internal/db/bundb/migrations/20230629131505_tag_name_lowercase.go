@@ -0,0 +1,195 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Tags are now stored and looked up by normalized
+			// name, so normalize the names of existing tags.
+			// Where several tags normalize to the same name,
+			// merge them into the one with the lowest ID (the
+			// oldest), moving everything that refers to the
+			// others over to it.
+			var tags []struct {
+				ID   string
+				Name string
+				URL  string
+			}
+
+			if err := tx.NewSelect().
+				Table("tags").
+				Column("id", "name", "url").
+				Order("id ASC").
+				Scan(ctx, &tags); err != nil {
+				return err
+			}
+
+			// Group tag indices by normalized name,
+			// keeping the order they were selected in.
+			var names []string
+			groups := make(map[string][]int)
+			for i, tag := range tags {
+				name := util.NormalizeHashtag(tag.Name)
+				if _, ok := groups[name]; !ok {
+					names = append(names, name)
+				}
+				groups[name] = append(groups[name], i)
+			}
+
+			for _, name := range names {
+				group := groups[name]
+				keep := tags[group[0]]
+
+				for _, i := range group[1:] {
+					if err := mergeTag(ctx, tx, tags[i].ID, keep.ID); err != nil {
+						return err
+					}
+				}
+
+				if keep.Name == name {
+					// Already normalized.
+					continue
+				}
+
+				// Tag URLs end with the tag name.
+				url := keep.URL
+				if strings.HasSuffix(url, "/"+keep.Name) {
+					url = strings.TrimSuffix(url, keep.Name) + name
+				}
+
+				if _, err := tx.NewUpdate().
+					Table("tags").
+					Set("? = ?", bun.Ident("name"), name).
+					Set("? = ?", bun.Ident("url"), url).
+					Where("? = ?", bun.Ident("id"), keep.ID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// mergeTag repoints statuses and reports using the tag
+// with ID fromID to the tag with ID toID, then deletes
+// the tag with ID fromID.
+func mergeTag(ctx context.Context, tx bun.Tx, fromID string, toID string) error {
+	var statuses []struct {
+		bun.BaseModel `bun:"table:statuses"`
+		ID            string   `bun:",pk"`
+		TagIDs        []string `bun:"tags,array"`
+	}
+
+	if err := tx.NewSelect().
+		Model(&statuses).
+		Column("id", "tags").
+		Where("? IN (?)",
+			bun.Ident("id"),
+			tx.NewSelect().
+				Table("status_to_tags").
+				Column("status_id").
+				Where("? = ?", bun.Ident("tag_id"), fromID),
+		).
+		Scan(ctx); err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		// Swap the old tag ID for the
+		// new one, without duplicating.
+		tagIDs := make([]string, 0, len(status.TagIDs))
+		seen := make(map[string]bool, len(status.TagIDs))
+		for _, id := range status.TagIDs {
+			if id == fromID {
+				id = toID
+			}
+			if !seen[id] {
+				seen[id] = true
+				tagIDs = append(tagIDs, id)
+			}
+		}
+		status.TagIDs = tagIDs
+
+		if _, err := tx.NewUpdate().
+			Model(&status).
+			Column("tags").
+			WherePK().
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	// Drop links that would be duplicated
+	// by moving, then move the rest over.
+	if _, err := tx.NewDelete().
+		Table("status_to_tags").
+		Where("? = ?", bun.Ident("tag_id"), fromID).
+		Where("? IN (?)",
+			bun.Ident("status_id"),
+			tx.NewSelect().
+				Table("status_to_tags").
+				Column("status_id").
+				Where("? = ?", bun.Ident("tag_id"), toID),
+		).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err := tx.NewUpdate().
+		Table("status_to_tags").
+		Set("? = ?", bun.Ident("tag_id"), toID).
+		Where("? = ?", bun.Ident("tag_id"), fromID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err := tx.NewUpdate().
+		Table("reports").
+		Set("? = ?", bun.Ident("target_tag_id"), toID).
+		Where("? = ?", bun.Ident("target_tag_id"), fromID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	_, err := tx.NewDelete().
+		Table("tags").
+		Where("? = ?", bun.Ident("id"), fromID).
+		Exec(ctx)
+	return err
+}
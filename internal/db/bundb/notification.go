@@ -20,6 +20,7 @@ package bundb
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
@@ -202,9 +203,9 @@ func (n *notificationDB) DeleteNotificationByID(ctx context.Context, id string)
 	return n.conn.ProcessError(err)
 }
 
-func (n *notificationDB) DeleteNotifications(ctx context.Context, types []string, targetAccountID string, originAccountID string) db.Error {
-	if targetAccountID == "" && originAccountID == "" {
-		return errors.New("DeleteNotifications: one of targetAccountID or originAccountID must be set")
+func (n *notificationDB) DeleteNotifications(ctx context.Context, types []string, targetAccountID string, originAccountID string, createdBefore time.Time) db.Error {
+	if targetAccountID == "" && originAccountID == "" && createdBefore.IsZero() {
+		return errors.New("DeleteNotifications: one of targetAccountID, originAccountID or createdBefore must be set")
 	}
 
 	var notifIDs []string
@@ -226,6 +227,10 @@ func (n *notificationDB) DeleteNotifications(ctx context.Context, types []string
 		q = q.Where("? = ?", bun.Ident("origin_account_id"), originAccountID)
 	}
 
+	if !createdBefore.IsZero() {
+		q = q.Where("? < ?", bun.Ident("created_at"), createdBefore)
+	}
+
 	if _, err := q.Exec(ctx, &notifIDs); err != nil {
 		return n.conn.ProcessError(err)
 	}
@@ -255,6 +260,85 @@ func (n *notificationDB) DeleteNotifications(ctx context.Context, types []string
 	return n.conn.ProcessError(err)
 }
 
+func (n *notificationDB) DeleteNotificationsBatch(ctx context.Context, targetAccountID string, originAccountID string, createdBefore time.Time, limit int) (int, error) {
+	if targetAccountID == "" && originAccountID == "" && createdBefore.IsZero() {
+		return 0, errors.New("DeleteNotificationsBatch: one of targetAccountID, originAccountID or createdBefore must be set")
+	}
+
+	var notifIDs []string
+
+	q := n.conn.
+		NewSelect().
+		Column("id").
+		Table("notifications").
+		Order("id ASC").
+		Limit(limit)
+
+	if targetAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("target_account_id"), targetAccountID)
+	}
+
+	if originAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("origin_account_id"), originAccountID)
+	}
+
+	if !createdBefore.IsZero() {
+		q = q.Where("? < ?", bun.Ident("created_at"), createdBefore)
+	}
+
+	if _, err := q.Exec(ctx, &notifIDs); err != nil {
+		return 0, n.conn.ProcessError(err)
+	}
+
+	if len(notifIDs) == 0 {
+		// Nothing
+		// left to do.
+		return 0, nil
+	}
+
+	defer func() {
+		// Invalidate all IDs on return.
+		for _, id := range notifIDs {
+			n.state.Caches.GTS.Notification().Invalidate("ID", id)
+		}
+	}()
+
+	// Load all notif into cache, this *really* isn't great
+	// but it is the only way we can ensure we invalidate all
+	// related caches correctly (e.g. visibility).
+	for _, id := range notifIDs {
+		_, err := n.GetNotificationByID(ctx, id)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return 0, err
+		}
+	}
+
+	// Delete this batch from DB. The IDs are contiguous
+	// for the given filters, so delete by ID range.
+	dq := n.conn.NewDelete().
+		Table("notifications").
+		Where("? >= ?", bun.Ident("id"), notifIDs[0]).
+		Where("? <= ?", bun.Ident("id"), notifIDs[len(notifIDs)-1])
+
+	if targetAccountID != "" {
+		dq = dq.Where("? = ?", bun.Ident("target_account_id"), targetAccountID)
+	}
+
+	if originAccountID != "" {
+		dq = dq.Where("? = ?", bun.Ident("origin_account_id"), originAccountID)
+	}
+
+	if !createdBefore.IsZero() {
+		dq = dq.Where("? < ?", bun.Ident("created_at"), createdBefore)
+	}
+
+	if _, err := dq.Exec(ctx); err != nil {
+		return 0, n.conn.ProcessError(err)
+	}
+
+	return len(notifIDs), nil
+}
+
 func (n *notificationDB) DeleteNotificationsForStatus(ctx context.Context, statusID string) db.Error {
 	var notifIDs []string
 
@@ -117,7 +117,7 @@ func (suite *NotificationTestSuite) TestGetAccountNotificationsWithoutSpam() {
 func (suite *NotificationTestSuite) TestDeleteNotificationsWithSpam() {
 	suite.spamNotifs()
 	testAccount := suite.testAccounts["local_account_1"]
-	err := suite.db.DeleteNotifications(context.Background(), nil, testAccount.ID, "")
+	err := suite.db.DeleteNotifications(context.Background(), nil, testAccount.ID, "", time.Time{})
 	suite.NoError(err)
 
 	notifications, err := suite.db.GetAccountNotifications(context.Background(), testAccount.ID, id.Highest, id.Lowest, "", 20, nil)
@@ -129,7 +129,7 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsWithSpam() {
 func (suite *NotificationTestSuite) TestDeleteNotificationsWithTwoAccounts() {
 	suite.spamNotifs()
 	testAccount := suite.testAccounts["local_account_1"]
-	err := suite.db.DeleteNotifications(context.Background(), nil, testAccount.ID, "")
+	err := suite.db.DeleteNotifications(context.Background(), nil, testAccount.ID, "", time.Time{})
 	suite.NoError(err)
 
 	notifications, err := suite.db.GetAccountNotifications(context.Background(), testAccount.ID, id.Highest, id.Lowest, "", 20, nil)
@@ -146,7 +146,7 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsWithTwoAccounts() {
 func (suite *NotificationTestSuite) TestDeleteNotificationsOriginatingFromAccount() {
 	testAccount := suite.testAccounts["local_account_2"]
 
-	if err := suite.db.DeleteNotifications(context.Background(), nil, "", testAccount.ID); err != nil {
+	if err := suite.db.DeleteNotifications(context.Background(), nil, "", testAccount.ID, time.Time{}); err != nil {
 		suite.FailNow(err.Error())
 	}
 
@@ -166,7 +166,7 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsOriginatingFromAndTar
 	originAccount := suite.testAccounts["local_account_2"]
 	targetAccount := suite.testAccounts["admin_account"]
 
-	if err := suite.db.DeleteNotifications(context.Background(), nil, targetAccount.ID, originAccount.ID); err != nil {
+	if err := suite.db.DeleteNotifications(context.Background(), nil, targetAccount.ID, originAccount.ID, time.Time{}); err != nil {
 		suite.FailNow(err.Error())
 	}
 
@@ -187,6 +187,178 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsOriginatingFromAndTar
 	}
 }
 
+func (suite *NotificationTestSuite) TestDeleteNotificationsCreatedBefore() {
+	ctx := context.Background()
+	testNotifications := testrig.NewTestNotifications()
+	newer := testNotifications["local_account_1_like"]
+	older := testNotifications["local_account_2_like"]
+
+	// Notifications created exactly at
+	// createdBefore should not be deleted.
+	if err := suite.db.DeleteNotifications(ctx, nil, "", "", newer.CreatedAt); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetNotificationByID(ctx, older.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.db.GetNotificationByID(ctx, newer.ID)
+	suite.NoError(err)
+
+	// Now move createdBefore just past it.
+	if err := suite.db.DeleteNotifications(ctx, nil, "", "", newer.CreatedAt.Add(time.Second)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetNotificationByID(ctx, newer.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *NotificationTestSuite) TestDeleteNotificationsNoParams() {
+	err := suite.db.DeleteNotifications(context.Background(), nil, "", "", time.Time{})
+	suite.Error(err)
+}
+
+// putNotifsTargeting puts count fave notifications
+// targeting the given account ID in the database.
+func (suite *NotificationTestSuite) putNotifsTargeting(targetAccountID string, count int) {
+	for i := 0; i < count; i++ {
+		notif := &gtsmodel.Notification{
+			ID:               id.NewULID(),
+			NotificationType: gtsmodel.NotificationFave,
+			CreatedAt:        time.Now(),
+			TargetAccountID:  targetAccountID,
+			OriginAccountID:  suite.testAccounts["local_account_2"].ID,
+			StatusID:         suite.testStatuses["local_account_1_status_1"].ID,
+			Read:             testrig.FalseBool(),
+		}
+
+		if err := suite.db.Put(context.Background(), notif); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+}
+
+func (suite *NotificationTestSuite) TestDeleteNotificationsBatch() {
+	ctx := context.Background()
+
+	for _, test := range []struct {
+		count   int
+		limit   int
+		batches []int
+	}{
+		{count: 4, limit: 2, batches: []int{2, 2, 0}}, // exact multiple, needs an empty batch to be sure
+		{count: 5, limit: 2, batches: []int{2, 2, 1}}, // last batch smaller than limit
+		{count: 4, limit: 4, batches: []int{4, 0}},    // exactly one batch
+		{count: 3, limit: 4, batches: []int{3}},       // small set, one go
+		{count: 0, limit: 4, batches: []int{0}},       // nothing to delete
+	} {
+		targetAccountID := id.NewULID()
+		suite.putNotifsTargeting(targetAccountID, test.count)
+
+		batches := []int{}
+		for {
+			deleted, err := suite.db.DeleteNotificationsBatch(ctx, targetAccountID, "", time.Time{}, test.limit)
+			if err != nil {
+				suite.FailNow(err.Error())
+			}
+
+			batches = append(batches, deleted)
+			if deleted < test.limit {
+				break
+			}
+		}
+		suite.Equal(test.batches, batches)
+
+		notifications, err := suite.db.GetAccountNotifications(ctx, targetAccountID, id.Highest, id.Lowest, "", 20, nil)
+		suite.NoError(err)
+		suite.Empty(notifications)
+	}
+}
+
+func (suite *NotificationTestSuite) TestDeleteNotificationsBatchOtherAccountsUntouched() {
+	ctx := context.Background()
+	zork := suite.testAccounts["local_account_1"]
+
+	before := []*gtsmodel.Notification{}
+	if err := suite.db.GetAll(ctx, &before); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	targetAccountID := id.NewULID()
+	suite.putNotifsTargeting(targetAccountID, 3)
+
+	// IDs of these notifs sit between
+	// those of the other account's.
+	suite.putNotifsTargeting(zork.ID, 1)
+	suite.putNotifsTargeting(targetAccountID, 3)
+
+	deleted, err := suite.db.DeleteNotificationsBatch(ctx, targetAccountID, "", time.Time{}, 10)
+	suite.NoError(err)
+	suite.Equal(6, deleted)
+
+	after := []*gtsmodel.Notification{}
+	if err := suite.db.GetAll(ctx, &after); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(after, len(before)+1)
+}
+
+func (suite *NotificationTestSuite) TestDeleteNotificationsBatchCreatedBefore() {
+	ctx := context.Background()
+	targetAccountID := id.NewULID()
+
+	// Three old notifs, then one new
+	// one (in ID order too), then two
+	// more old ones.
+	old := time.Now().Add(-48 * time.Hour)
+	var newID string
+	for i := 0; i < 6; i++ {
+		notif := &gtsmodel.Notification{
+			ID:               id.NewULID(),
+			NotificationType: gtsmodel.NotificationFave,
+			CreatedAt:        old,
+			TargetAccountID:  targetAccountID,
+			OriginAccountID:  suite.testAccounts["local_account_2"].ID,
+			StatusID:         suite.testStatuses["local_account_1_status_1"].ID,
+			Read:             testrig.FalseBool(),
+		}
+
+		if i == 3 {
+			notif.CreatedAt = time.Now()
+			newID = notif.ID
+		}
+
+		if err := suite.db.Put(ctx, notif); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	createdBefore := time.Now().Add(-24 * time.Hour)
+	batches := 0
+	for {
+		deleted, err := suite.db.DeleteNotificationsBatch(ctx, "", "", createdBefore, 2)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		batches++
+		if deleted < 2 {
+			break
+		}
+	}
+
+	// Testrig notifications may be old too, so
+	// only check that it took several batches.
+	suite.GreaterOrEqual(batches, 3)
+
+	notifications, err := suite.db.GetAccountNotifications(ctx, targetAccountID, id.Highest, id.Lowest, "", 20, nil)
+	suite.NoError(err)
+	if suite.Len(notifications, 1) {
+		suite.Equal(newID, notifications[0].ID)
+	}
+}
+
 func (suite *NotificationTestSuite) TestDeleteNotificationsPertainingToStatusID() {
 	testStatus := suite.testStatuses["local_account_1_status_1"]
 
@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type previewCardDB struct {
+	conn *DBConn
+}
+
+func (p *previewCardDB) GetPreviewCardByURL(ctx context.Context, url string) (*gtsmodel.PreviewCard, db.Error) {
+	card := &gtsmodel.PreviewCard{}
+
+	if err := p.conn.
+		NewSelect().
+		Model(card).
+		Where("? = ?", bun.Ident("preview_card.url"), url).
+		Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+
+	return card, nil
+}
+
+func (p *previewCardDB) PutPreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) error {
+	_, err := p.conn.
+		NewInsert().
+		Model(card).
+		Exec(ctx)
+	return p.conn.ProcessError(err)
+}
+
+func (p *previewCardDB) UpdatePreviewCard(ctx context.Context, card *gtsmodel.PreviewCard) error {
+	card.UpdatedAt = time.Now()
+	_, err := p.conn.
+		NewUpdate().
+		Model(card).
+		Where("? = ?", bun.Ident("preview_card.id"), card.ID).
+		Exec(ctx)
+	return p.conn.ProcessError(err)
+}
@@ -88,6 +88,117 @@ func (r *relationshipDB) GetRelationship(ctx context.Context, requestingAccount
 	return &rel, nil
 }
 
+func (r *relationshipDB) GetRelationships(ctx context.Context, requestingAccount string, targetAccounts []string) ([]*gtsmodel.Relationship, error) {
+	rels := make([]*gtsmodel.Relationship, 0, len(targetAccounts))
+	if len(targetAccounts) == 0 {
+		return rels, nil
+	}
+
+	byID := make(map[string]*gtsmodel.Relationship, len(targetAccounts))
+	for _, targetAccount := range targetAccounts {
+		rel, ok := byID[targetAccount]
+		if !ok {
+			rel = &gtsmodel.Relationship{ID: targetAccount}
+			byID[targetAccount] = rel
+		}
+		rels = append(rels, rel)
+	}
+
+	// Follows in either direction.
+	var follows []*gtsmodel.Follow
+	if err := r.conn.
+		NewSelect().
+		Model(&follows).
+		Column("account_id", "target_account_id", "show_reblogs", "notify").
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("follow.account_id"), requestingAccount).
+				Where("? IN (?)", bun.Ident("follow.target_account_id"), bun.In(targetAccounts))
+		}).
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IN (?)", bun.Ident("follow.account_id"), bun.In(targetAccounts)).
+				Where("? = ?", bun.Ident("follow.target_account_id"), requestingAccount)
+		}).
+		Scan(ctx); err != nil {
+		if err := r.conn.ProcessError(err); !errors.Is(err, db.ErrNoEntries) {
+			return nil, fmt.Errorf("GetRelationships: error fetching follows: %w", err)
+		}
+	}
+
+	for _, follow := range follows {
+		if follow.AccountID == requestingAccount {
+			if rel, ok := byID[follow.TargetAccountID]; ok {
+				rel.Following = true
+				rel.ShowingReblogs = *follow.ShowReblogs
+				rel.Notifying = *follow.Notify
+			}
+		}
+		if follow.TargetAccountID == requestingAccount {
+			if rel, ok := byID[follow.AccountID]; ok {
+				rel.FollowedBy = true
+			}
+		}
+	}
+
+	// Follow requests from requesting account.
+	var requestedIDs []string
+	if err := r.conn.
+		NewSelect().
+		Table("follow_requests").
+		Column("target_account_id").
+		Where("? = ?", bun.Ident("account_id"), requestingAccount).
+		Where("? IN (?)", bun.Ident("target_account_id"), bun.In(targetAccounts)).
+		Scan(ctx, &requestedIDs); err != nil {
+		if err := r.conn.ProcessError(err); !errors.Is(err, db.ErrNoEntries) {
+			return nil, fmt.Errorf("GetRelationships: error fetching follow requests: %w", err)
+		}
+	}
+
+	for _, id := range requestedIDs {
+		if rel, ok := byID[id]; ok {
+			rel.Requested = true
+		}
+	}
+
+	// Blocks in either direction.
+	var blocks []*gtsmodel.Block
+	if err := r.conn.
+		NewSelect().
+		Model(&blocks).
+		Column("account_id", "target_account_id").
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("block.account_id"), requestingAccount).
+				Where("? IN (?)", bun.Ident("block.target_account_id"), bun.In(targetAccounts))
+		}).
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IN (?)", bun.Ident("block.account_id"), bun.In(targetAccounts)).
+				Where("? = ?", bun.Ident("block.target_account_id"), requestingAccount)
+		}).
+		Scan(ctx); err != nil {
+		if err := r.conn.ProcessError(err); !errors.Is(err, db.ErrNoEntries) {
+			return nil, fmt.Errorf("GetRelationships: error fetching blocks: %w", err)
+		}
+	}
+
+	for _, block := range blocks {
+		if block.AccountID == requestingAccount {
+			if rel, ok := byID[block.TargetAccountID]; ok {
+				rel.Blocking = true
+			}
+		}
+		if block.TargetAccountID == requestingAccount {
+			if rel, ok := byID[block.AccountID]; ok {
+				rel.BlockedBy = true
+			}
+		}
+	}
+
+	return rels, nil
+}
+
 func (r *relationshipDB) GetAccountFollows(ctx context.Context, accountID string) ([]*gtsmodel.Follow, error) {
 	var followIDs []string
 	if err := newSelectFollows(r.conn, accountID).
@@ -162,6 +273,64 @@ func (r *relationshipDB) GetAccountFollowRequesting(ctx context.Context, account
 	return r.GetFollowRequestsByIDs(ctx, followReqIDs)
 }
 
+func (r *relationshipDB) GetAccountFollowRequestingPage(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.FollowRequest, error) {
+	// Ensure reasonable
+	if limit < 0 {
+		limit = 0
+	}
+
+	// Make educated guess for slice size
+	var (
+		followReqIDs = make([]string, 0, limit)
+		frontToBack  = true
+	)
+
+	q := r.conn.NewSelect().
+		TableExpr("?", bun.Ident("follow_requests")).
+		ColumnExpr("?", bun.Ident("id")).
+		Where("? = ?", bun.Ident("account_id"), accountID)
+
+	if maxID != "" {
+		// return only requests LOWER (ie., older) than maxID
+		q = q.Where("? < ?", bun.Ident("id"), maxID)
+	}
+
+	if minID != "" {
+		// return only requests HIGHER (ie., newer) than minID
+		q = q.Where("? > ?", bun.Ident("id"), minID)
+
+		// page up
+		frontToBack = false
+	}
+
+	if limit > 0 {
+		// limit amount of requests returned
+		q = q.Limit(limit)
+	}
+
+	if frontToBack {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("id"))
+	} else {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("id"))
+	}
+
+	if err := q.Scan(ctx, &followReqIDs); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	// If we're paging up, we still want requests
+	// to be sorted by ID desc, so reverse ids slice.
+	if !frontToBack {
+		for l, r := 0, len(followReqIDs)-1; l < r; l, r = l+1, r-1 {
+			followReqIDs[l], followReqIDs[r] = followReqIDs[r], followReqIDs[l]
+		}
+	}
+
+	return r.GetFollowRequestsByIDs(ctx, followReqIDs)
+}
+
 func (r *relationshipDB) CountAccountFollowRequests(ctx context.Context, accountID string) (int, error) {
 	n, err := newSelectFollowRequests(r.conn, accountID).Count(ctx)
 	return n, r.conn.ProcessError(err)
@@ -172,6 +341,41 @@ func (r *relationshipDB) CountAccountFollowRequesting(ctx context.Context, accou
 	return n, r.conn.ProcessError(err)
 }
 
+func (r *relationshipDB) CountDomainFollows(ctx context.Context, domain string, outbound bool) (int, error) {
+	n, err := newSelectDomainRelationships(r.conn, "follows", domain, outbound).Count(ctx)
+	return n, r.conn.ProcessError(err)
+}
+
+func (r *relationshipDB) GetDomainFollowRequests(ctx context.Context, domain string, outbound bool) ([]*gtsmodel.FollowRequest, error) {
+	var followReqIDs []string
+	if err := newSelectDomainRelationships(r.conn, "follow_requests", domain, outbound).
+		Scan(ctx, &followReqIDs); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+	return r.GetFollowRequestsByIDs(ctx, followReqIDs)
+}
+
+// newSelectDomainRelationships returns a new select query for all rows in the given follows-like table
+// with target_account_id (if outbound) or account_id (if not outbound) belonging to an account on domain.
+func newSelectDomainRelationships(conn *DBConn, table string, domain string, outbound bool) *bun.SelectQuery {
+	column := "account_id"
+	if outbound {
+		column = "target_account_id"
+	}
+
+	return conn.NewSelect().
+		TableExpr("?", bun.Ident(table)).
+		ColumnExpr("?", bun.Ident("id")).
+		Where("? IN (?)",
+			bun.Ident(column),
+			conn.NewSelect().
+				Table("accounts").
+				Column("id").
+				Where("? = ?", bun.Ident("domain"), domain),
+		).
+		OrderExpr("? DESC", bun.Ident("updated_at"))
+}
+
 // newSelectFollowRequests returns a new select query for all rows in the follow_requests table with target_account_id = accountID.
 func newSelectFollowRequests(conn *DBConn, accountID string) *bun.SelectQuery {
 	return conn.NewSelect().
@@ -186,7 +390,7 @@ func newSelectFollowRequesting(conn *DBConn, accountID string) *bun.SelectQuery
 	return conn.NewSelect().
 		TableExpr("?", bun.Ident("follow_requests")).
 		ColumnExpr("?", bun.Ident("id")).
-		Where("? = ?", bun.Ident("target_account_id"), accountID).
+		Where("? = ?", bun.Ident("account_id"), accountID).
 		OrderExpr("? DESC", bun.Ident("updated_at"))
 }
 
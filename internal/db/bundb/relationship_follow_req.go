@@ -223,7 +223,7 @@ func (r *relationshipDB) AcceptFollowRequest(ctx context.Context, sourceAccountI
 	// Delete original follow request notification
 	if err := r.state.DB.DeleteNotifications(ctx, []string{
 		string(gtsmodel.NotificationFollowRequest),
-	}, targetAccountID, sourceAccountID); err != nil {
+	}, targetAccountID, sourceAccountID, time.Time{}); err != nil {
 		return nil, err
 	}
 
@@ -260,7 +260,7 @@ func (r *relationshipDB) RejectFollowRequest(ctx context.Context, sourceAccountI
 	// Delete original follow request notification
 	return r.state.DB.DeleteNotifications(ctx, []string{
 		string(gtsmodel.NotificationFollowRequest),
-	}, targetAccountID, sourceAccountID)
+	}, targetAccountID, sourceAccountID, time.Time{})
 }
 
 func (r *relationshipDB) DeleteFollowRequestByID(ctx context.Context, id string) error {
@@ -532,6 +532,32 @@ func (suite *RelationshipTestSuite) TestGetRelationship() {
 	suite.Empty(relationship.Note)
 }
 
+func (suite *RelationshipTestSuite) TestGetRelationships() {
+	requestingAccount := suite.testAccounts["local_account_2"]
+	targetAccountIDs := []string{
+		suite.testAccounts["local_account_1"].ID,
+		suite.testAccounts["remote_account_1"].ID,
+		suite.testAccounts["admin_account"].ID,
+	}
+
+	relationships, err := suite.db.GetRelationships(context.Background(), requestingAccount.ID, targetAccountIDs)
+	suite.NoError(err)
+	suite.Len(relationships, len(targetAccountIDs))
+
+	// Each relationship from the bulk
+	// get should be the same as if it was
+	// fetched for that target on its own.
+	for i, targetAccountID := range targetAccountIDs {
+		relationship, err := suite.db.GetRelationship(context.Background(), requestingAccount.ID, targetAccountID)
+		suite.NoError(err)
+		suite.Equal(relationship, relationships[i])
+	}
+
+	// local_account_2 blocks remote_account_1.
+	suite.True(relationships[1].Blocking)
+	suite.False(relationships[1].Following)
+}
+
 func (suite *RelationshipTestSuite) TestIsFollowingYes() {
 	requestingAccount := suite.testAccounts["local_account_1"]
 	targetAccount := suite.testAccounts["admin_account"]
@@ -758,6 +784,83 @@ func (suite *RelationshipTestSuite) TestGetAccountFollowRequests() {
 	suite.Len(followRequests, 1)
 }
 
+func (suite *RelationshipTestSuite) TestGetAccountFollowRequesting() {
+	ctx := context.Background()
+	account := suite.testAccounts["admin_account"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	followRequest := &gtsmodel.FollowRequest{
+		ID:              "01GEF753FWHCHRDWR0QEHBXM8W",
+		URI:             "http://localhost:8080/weeeeeeeeeeeeeeeee",
+		AccountID:       account.ID,
+		TargetAccountID: targetAccount.ID,
+	}
+
+	if err := suite.db.Put(ctx, followRequest); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	followRequests, err := suite.db.GetAccountFollowRequesting(ctx, account.ID)
+	suite.NoError(err)
+	suite.Len(followRequests, 1)
+
+	// The target should have no outbound requests.
+	followRequests, err = suite.db.GetAccountFollowRequesting(ctx, targetAccount.ID)
+	suite.NoError(err)
+	suite.Empty(followRequests)
+}
+
+func (suite *RelationshipTestSuite) TestGetAccountFollowRequestingPage() {
+	ctx := context.Background()
+	account := suite.testAccounts["admin_account"]
+
+	ids := []string{
+		"01GEF753FWHCHRDWR0QEHBXM8A",
+		"01GEF753FWHCHRDWR0QEHBXM8B",
+		"01GEF753FWHCHRDWR0QEHBXM8C",
+	}
+
+	for i, targetAccount := range []*gtsmodel.Account{
+		suite.testAccounts["local_account_1"],
+		suite.testAccounts["local_account_2"],
+		suite.testAccounts["remote_account_1"],
+	} {
+		followRequest := &gtsmodel.FollowRequest{
+			ID:              ids[i],
+			URI:             "http://localhost:8080/weeeeeeeeeeeeeeeee/" + ids[i],
+			AccountID:       account.ID,
+			TargetAccountID: targetAccount.ID,
+		}
+
+		if err := suite.db.Put(ctx, followRequest); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	pageIDs := func(followRequests []*gtsmodel.FollowRequest) []string {
+		ids := make([]string, 0, len(followRequests))
+		for _, followRequest := range followRequests {
+			ids = append(ids, followRequest.ID)
+		}
+		return ids
+	}
+
+	// First page, newest first.
+	followRequests, err := suite.db.GetAccountFollowRequestingPage(ctx, account.ID, "", "", 2)
+	suite.NoError(err)
+	suite.Equal([]string{ids[2], ids[1]}, pageIDs(followRequests))
+
+	// Page down from there.
+	followRequests, err = suite.db.GetAccountFollowRequestingPage(ctx, account.ID, ids[1], "", 2)
+	suite.NoError(err)
+	suite.Equal([]string{ids[0]}, pageIDs(followRequests))
+
+	// Page up from the oldest, still sorted newest first.
+	followRequests, err = suite.db.GetAccountFollowRequestingPage(ctx, account.ID, "", ids[0], 1)
+	suite.NoError(err)
+	suite.Equal([]string{ids[1]}, pageIDs(followRequests))
+}
+
 func (suite *RelationshipTestSuite) TestGetAccountFollows() {
 	account := suite.testAccounts["local_account_1"]
 	follows, err := suite.db.GetAccountFollows(context.Background(), account.ID)
@@ -800,6 +903,49 @@ func (suite *RelationshipTestSuite) TestCountAccountFollowersLocalOnly() {
 	suite.Equal(2, followsCount)
 }
 
+func (suite *RelationshipTestSuite) TestDomainFollowState() {
+	ctx := context.Background()
+	localAccount := suite.testAccounts["local_account_1"]
+	remoteAccount := suite.testAccounts["remote_account_1"]
+
+	// Remote account requests to follow local account.
+	followReq := &gtsmodel.FollowRequest{
+		ID:              "01H2YZQ4S7JKB0KX2VH0QZ6N3D",
+		URI:             "http://fossbros-anonymous.io/users/foss_satan/follow/1",
+		AccountID:       remoteAccount.ID,
+		TargetAccountID: localAccount.ID,
+	}
+	if err := suite.db.PutFollowRequest(ctx, followReq); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	inbound, err := suite.db.GetDomainFollowRequests(ctx, remoteAccount.Domain, false)
+	suite.NoError(err)
+	suite.Len(inbound, 1)
+	suite.Equal(followReq.ID, inbound[0].ID)
+
+	outbound, err := suite.db.GetDomainFollowRequests(ctx, remoteAccount.Domain, true)
+	suite.NoError(err)
+	suite.Empty(outbound)
+
+	// Accepting the request turns it into an inbound follow.
+	if _, err := suite.db.AcceptFollowRequest(ctx, remoteAccount.ID, localAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	inbound, err = suite.db.GetDomainFollowRequests(ctx, remoteAccount.Domain, false)
+	suite.NoError(err)
+	suite.Empty(inbound)
+
+	followsCount, err := suite.db.CountDomainFollows(ctx, remoteAccount.Domain, false)
+	suite.NoError(err)
+	suite.Equal(1, followsCount)
+
+	followsCount, err = suite.db.CountDomainFollows(ctx, remoteAccount.Domain, true)
+	suite.NoError(err)
+	suite.Zero(followsCount)
+}
+
 func (suite *RelationshipTestSuite) TestUnfollowExisting() {
 	originAccount := suite.testAccounts["local_account_1"]
 	targetAccount := suite.testAccounts["admin_account"]
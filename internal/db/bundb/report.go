@@ -147,6 +147,14 @@ func (r *reportDB) getReport(ctx context.Context, lookup string, dbQuery func(*g
 		return nil, fmt.Errorf("error getting report target account: %w", err)
 	}
 
+	if report.TargetTagID != "" {
+		// Set the report target tag
+		report.TargetTag, err = r.state.DB.GetTagByID(ctx, report.TargetTagID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting report target tag: %w", err)
+		}
+	}
+
 	if len(report.StatusIDs) > 0 {
 		// Fetch reported statuses
 		report.Statuses, err = r.state.DB.GetStatuses(ctx, report.StatusIDs)
@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type savedSearchDB struct {
+	conn *DBConn
+}
+
+func (s *savedSearchDB) GetSavedSearchByID(ctx context.Context, id string) (*gtsmodel.SavedSearch, db.Error) {
+	savedSearch := &gtsmodel.SavedSearch{}
+
+	if err := s.conn.
+		NewSelect().
+		Model(savedSearch).
+		Where("? = ?", bun.Ident("saved_search.id"), id).
+		Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	return savedSearch, nil
+}
+
+func (s *savedSearchDB) GetAccountSavedSearches(ctx context.Context, accountID string) ([]*gtsmodel.SavedSearch, db.Error) {
+	savedSearches := []*gtsmodel.SavedSearch{}
+
+	if err := s.conn.
+		NewSelect().
+		Model(&savedSearches).
+		Where("? = ?", bun.Ident("saved_search.account_id"), accountID).
+		Order("saved_search.created_at DESC", "saved_search.id DESC").
+		Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	return savedSearches, nil
+}
+
+func (s *savedSearchDB) PutSavedSearch(ctx context.Context, savedSearch *gtsmodel.SavedSearch) error {
+	_, err := s.conn.
+		NewInsert().
+		Model(savedSearch).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *savedSearchDB) DeleteSavedSearchByID(ctx context.Context, id string) error {
+	_, err := s.conn.
+		NewDelete().
+		Table("saved_searches").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *savedSearchDB) DeleteAccountSavedSearches(ctx context.Context, accountID string) error {
+	_, err := s.conn.
+		NewDelete().
+		Table("saved_searches").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
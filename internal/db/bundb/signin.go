@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type signInDB struct {
+	conn *DBConn
+}
+
+func (s *signInDB) PutSignIn(ctx context.Context, signIn *gtsmodel.SignIn) error {
+	_, err := s.conn.
+		NewInsert().
+		Model(signIn).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *signInDB) CountSignInUsers(ctx context.Context, from time.Time, to time.Time) (int, db.Error) {
+	var count int
+
+	if err := s.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("sign_ins"), bun.Ident("sign_in")).
+		ColumnExpr("COUNT(DISTINCT ?)", bun.Ident("sign_in.user_id")).
+		Where("? >= ?", bun.Ident("sign_in.created_at"), from).
+		Where("? < ?", bun.Ident("sign_in.created_at"), to).
+		Scan(ctx, &count); err != nil {
+		return 0, s.conn.ProcessError(err)
+	}
+
+	return count, nil
+}
+
+func (s *signInDB) CountSignInCountries(ctx context.Context, from time.Time, to time.Time) (map[string]int, db.Error) {
+	var rows []struct {
+		CountryCode string
+		Count       int
+	}
+
+	if err := s.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("sign_ins"), bun.Ident("sign_in")).
+		ColumnExpr("? AS ?", bun.Ident("sign_in.country_code"), bun.Ident("country_code")).
+		ColumnExpr("COUNT(DISTINCT ?) AS ?", bun.Ident("sign_in.user_id"), bun.Ident("count")).
+		Where("? >= ?", bun.Ident("sign_in.created_at"), from).
+		Where("? < ?", bun.Ident("sign_in.created_at"), to).
+		Where("? IS NOT NULL", bun.Ident("sign_in.country_code")).
+		Group("sign_in.country_code").
+		Scan(ctx, &rows); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	countries := make(map[string]int, len(rows))
+	for _, row := range rows {
+		countries[row.CountryCode] = row.Count
+	}
+
+	return countries, nil
+}
+
+func (s *signInDB) DeleteSignInsOlderThan(ctx context.Context, olderThan time.Time) (int, db.Error) {
+	res, err := s.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("sign_ins"), bun.Ident("sign_in")).
+		Where("? < ?", bun.Ident("sign_in.created_at"), olderThan).
+		Exec(ctx)
+	if err != nil {
+		return 0, s.conn.ProcessError(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, s.conn.ProcessError(err)
+	}
+
+	return int(deleted), nil
+}
+
+func (s *signInDB) DeleteSignInsForUser(ctx context.Context, userID string) db.Error {
+	_, err := s.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("sign_ins"), bun.Ident("sign_in")).
+		Where("? = ?", bun.Ident("sign_in.user_id"), userID).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
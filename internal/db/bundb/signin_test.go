@@ -0,0 +1,104 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type SignInTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *SignInTestSuite) TestCountSignIns() {
+	ctx := context.Background()
+
+	for _, signIn := range []*gtsmodel.SignIn{
+		{UserID: suite.testUsers["local_account_1"].ID, CountryCode: "NL"},
+		{UserID: suite.testUsers["local_account_1"].ID, CountryCode: "NL"},
+		{UserID: suite.testUsers["local_account_2"].ID, CountryCode: "DE"},
+		{UserID: suite.testUsers["admin_account"].ID},
+	} {
+		signIn.ID = id.NewULID()
+		if err := suite.db.PutSignIn(ctx, signIn); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	from := time.Now().Add(-1 * time.Hour)
+	to := time.Now().Add(1 * time.Hour)
+
+	// Repeated sign-ins by the same user are only counted once.
+	users, err := suite.db.CountSignInUsers(ctx, from, to)
+	suite.NoError(err)
+	suite.Equal(3, users)
+
+	// Sign-ins without a country are left out.
+	countries, err := suite.db.CountSignInCountries(ctx, from, to)
+	suite.NoError(err)
+	suite.Equal(map[string]int{"NL": 1, "DE": 1}, countries)
+
+	// Nothing outside the given range.
+	users, err = suite.db.CountSignInUsers(ctx, from.Add(-24*time.Hour), from)
+	suite.NoError(err)
+	suite.Zero(users)
+}
+
+func (suite *SignInTestSuite) TestDeleteSignIns() {
+	ctx := context.Background()
+
+	for _, signIn := range []*gtsmodel.SignIn{
+		{UserID: suite.testUsers["local_account_1"].ID, CreatedAt: time.Now().AddDate(0, 0, -100)},
+		{UserID: suite.testUsers["local_account_1"].ID, CreatedAt: time.Now()},
+		{UserID: suite.testUsers["local_account_2"].ID, CreatedAt: time.Now()},
+	} {
+		signIn.ID = id.NewULID()
+		if err := suite.db.PutSignIn(ctx, signIn); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	from := time.Now().AddDate(0, 0, -200)
+	to := time.Now().Add(1 * time.Hour)
+
+	// Only the old sign-in should be pruned.
+	pruned, err := suite.db.DeleteSignInsOlderThan(ctx, time.Now().AddDate(0, 0, -91))
+	suite.NoError(err)
+	suite.Equal(1, pruned)
+
+	users, err := suite.db.CountSignInUsers(ctx, from, to)
+	suite.NoError(err)
+	suite.Equal(2, users)
+
+	// Deleting for a user leaves other users' sign-ins.
+	err = suite.db.DeleteSignInsForUser(ctx, suite.testUsers["local_account_1"].ID)
+	suite.NoError(err)
+
+	users, err = suite.db.CountSignInUsers(ctx, from, to)
+	suite.NoError(err)
+	suite.Equal(1, users)
+}
+
+func TestSignInTestSuite(t *testing.T) {
+	suite.Run(t, new(SignInTestSuite))
+}
@@ -380,6 +380,29 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 	})
 }
 
+func (s *statusDB) PutStatusRevision(ctx context.Context, revision *gtsmodel.StatusRevision) error {
+	_, err := s.conn.
+		NewInsert().
+		Model(revision).
+		Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
+func (s *statusDB) GetStatusRevisions(ctx context.Context, statusID string) ([]*gtsmodel.StatusRevision, error) {
+	revisions := []*gtsmodel.StatusRevision{}
+
+	if err := s.conn.
+		NewSelect().
+		Model(&revisions).
+		Where("? = ?", bun.Ident("status_revision.status_id"), statusID).
+		Order("status_revision.id ASC").
+		Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	return revisions, nil
+}
+
 func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) db.Error {
 	defer s.state.Caches.GTS.Status().Invalidate("ID", id)
 
@@ -416,6 +439,15 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) db.Error {
 			return err
 		}
 
+		// delete earlier versions of this status
+		if _, err := tx.
+			NewDelete().
+			TableExpr("? AS ?", bun.Ident("status_revisions"), bun.Ident("status_revision")).
+			Where("? = ?", bun.Ident("status_revision.status_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
 		// delete the status itself
 		if _, err := tx.
 			NewDelete().
@@ -580,6 +612,27 @@ func (s *statusDB) IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status,
 	return s.conn.Exists(ctx, q)
 }
 
+func (s *statusDB) DeleteStatusMutes(ctx context.Context, targetAccountID string, originAccountID string) db.Error {
+	if targetAccountID == "" && originAccountID == "" {
+		return errors.New("DeleteStatusMutes: one of targetAccountID or originAccountID must be set")
+	}
+
+	q := s.conn.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("status_mutes"), bun.Ident("status_mute"))
+
+	if targetAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("status_mute.target_account_id"), targetAccountID)
+	}
+
+	if originAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("status_mute.account_id"), originAccountID)
+	}
+
+	_, err := q.Exec(ctx)
+	return s.conn.ProcessError(err)
+}
+
 func (s *statusDB) IsStatusBookmarkedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, db.Error) {
 	q := s.conn.
 		NewSelect().
@@ -602,3 +655,138 @@ func (s *statusDB) GetStatusReblogs(ctx context.Context, status *gtsmodel.Status
 	}
 	return reblogs, nil
 }
+
+func (s *statusDB) GetStatusReblogsPage(ctx context.Context, statusID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, db.Error) {
+	var (
+		statusIDs   []string
+		frontToBack = true
+	)
+
+	q := s.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		// Join on the boosting account so that
+		// suspended accounts can be left out.
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("status.account_id"),
+		).
+		Where("? = ?", bun.Ident("status.boost_of_id"), statusID).
+		Where("? IS NULL", bun.Ident("account.suspended_at"))
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("status.id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("status.id"), minID)
+
+		// page up
+		frontToBack = false
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if frontToBack {
+		// Page down.
+		q = q.Order("status.id DESC")
+	} else {
+		// Page up.
+		q = q.Order("status.id ASC")
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	// If we're paging up, we still want boosts
+	// to be sorted by ID desc, so reverse ids slice.
+	if !frontToBack {
+		for l, r := 0, len(statusIDs)-1; l < r; l, r = l+1, r-1 {
+			statusIDs[l], statusIDs[r] = statusIDs[r], statusIDs[l]
+		}
+	}
+
+	reblogs := make([]*gtsmodel.Status, 0, len(statusIDs))
+	for _, id := range statusIDs {
+		reblog, err := s.GetStatusByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting status %q: %v", id, err)
+			continue
+		}
+		reblogs = append(reblogs, reblog)
+	}
+
+	return reblogs, nil
+}
+
+func (s *statusDB) GetDirectStatusesForAccount(ctx context.Context, accountID string, maxID string, limit int) ([]*gtsmodel.Status, error) {
+	var statusIDs []string
+
+	q := s.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		Column("status.id").
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityDirect).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("status.account_id"), accountID).
+				WhereOr("? IN (?)", bun.Ident("status.id"), s.conn.
+					NewSelect().
+					TableExpr("? AS ?", bun.Ident("mentions"), bun.Ident("mention")).
+					Column("mention.status_id").
+					Where("? = ?", bun.Ident("mention.target_account_id"), accountID))
+		}).
+		Order("status.id DESC")
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("status.id"), maxID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &statusIDs); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	statuses := make([]*gtsmodel.Status, 0, len(statusIDs))
+	for _, id := range statusIDs {
+		status, err := s.GetStatusByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting status %q: %v", id, err)
+			continue
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (s *statusDB) GetReblogsForStatusIDs(ctx context.Context, statusIDs []string) (map[string][]*gtsmodel.Status, db.Error) {
+	reblogsByID := make(map[string][]*gtsmodel.Status)
+	if len(statusIDs) == 0 {
+		// Nothing to look for.
+		return reblogsByID, nil
+	}
+
+	reblogs := []*gtsmodel.Status{}
+
+	q := s.
+		newStatusQ(&reblogs).
+		Where("? IN (?)", bun.Ident("status.boost_of_id"), bun.In(statusIDs))
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	for _, reblog := range reblogs {
+		reblogsByID[reblog.BoostOfID] = append(reblogsByID[reblog.BoostOfID], reblog)
+	}
+	return reblogsByID, nil
+}
@@ -212,6 +212,24 @@ func (suite *StatusTestSuite) TestUpdateStatus() {
 	suite.True(updated.PinnedAt.IsZero())
 }
 
+func (suite *StatusTestSuite) TestGetReblogsForStatusIDs() {
+	boostedStatus := suite.testStatuses["local_account_1_status_1"]
+	unboostedStatus := suite.testStatuses["local_account_1_status_2"]
+
+	reblogs, err := suite.db.GetReblogsForStatusIDs(context.Background(), []string{boostedStatus.ID, unboostedStatus.ID})
+	suite.NoError(err)
+	suite.Len(reblogs, 1)
+	suite.Len(reblogs[boostedStatus.ID], 1)
+	suite.Equal(suite.testStatuses["admin_account_status_4"].ID, reblogs[boostedStatus.ID][0].ID)
+	suite.Empty(reblogs[unboostedStatus.ID])
+}
+
+func (suite *StatusTestSuite) TestGetReblogsForStatusIDsEmpty() {
+	reblogs, err := suite.db.GetReblogsForStatusIDs(context.Background(), nil)
+	suite.NoError(err)
+	suite.Empty(reblogs)
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
@@ -145,6 +145,68 @@ func (s *statusFaveDB) GetStatusFavesForStatus(ctx context.Context, statusID str
 	return faves, nil
 }
 
+func (s *statusFaveDB) GetStatusFavesPage(ctx context.Context, statusID string, maxID string, minID string, limit int) ([]*gtsmodel.StatusFave, db.Error) {
+	var (
+		ids         = []string{}
+		frontToBack = true
+	)
+
+	q := s.conn.
+		NewSelect().
+		Table("status_faves").
+		Column("id").
+		Where("? = ?", bun.Ident("status_id"), statusID)
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("id"), minID)
+
+		// page up
+		frontToBack = false
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if frontToBack {
+		// Page down.
+		q = q.Order("id DESC")
+	} else {
+		// Page up.
+		q = q.Order("id ASC")
+	}
+
+	if err := q.Scan(ctx, &ids); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+
+	// If we're paging up, we still want faves
+	// to be sorted by ID desc, so reverse ids slice.
+	if !frontToBack {
+		for l, r := 0, len(ids)-1; l < r; l, r = l+1, r-1 {
+			ids[l], ids[r] = ids[r], ids[l]
+		}
+	}
+
+	faves := make([]*gtsmodel.StatusFave, 0, len(ids))
+
+	for _, id := range ids {
+		fave, err := s.GetStatusFaveByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting status fave %q: %v", id, err)
+			continue
+		}
+
+		faves = append(faves, fave)
+	}
+
+	return faves, nil
+}
+
 func (s *statusFaveDB) PutStatusFave(ctx context.Context, fave *gtsmodel.StatusFave) db.Error {
 	return s.state.Caches.GTS.StatusFave().Store(fave, func() error {
 		_, err := s.conn.
@@ -0,0 +1,300 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type tagDB struct {
+	conn  *DBConn
+	state *state.State
+}
+
+func (t *tagDB) GetTagByID(ctx context.Context, id string) (*gtsmodel.Tag, db.Error) {
+	return t.state.Caches.GTS.Tag().Load("ID", func() (*gtsmodel.Tag, error) {
+		var tag gtsmodel.Tag
+
+		q := t.conn.
+			NewSelect().
+			Model(&tag).
+			Where("? = ?", bun.Ident("tag.id"), id)
+
+		if err := q.Scan(ctx); err != nil {
+			return nil, t.conn.ProcessError(err)
+		}
+
+		return &tag, nil
+	}, id)
+}
+
+func (t *tagDB) GetTagByName(ctx context.Context, name string) (*gtsmodel.Tag, db.Error) {
+	// Tags are stored by normalized
+	// name, so look them up that way.
+	name = util.NormalizeHashtag(name)
+
+	return t.state.Caches.GTS.Tag().Load("Name", func() (*gtsmodel.Tag, error) {
+		var tag gtsmodel.Tag
+
+		q := t.conn.
+			NewSelect().
+			Model(&tag).
+			Where("? = ?", bun.Ident("tag.name"), name)
+
+		if err := q.Scan(ctx); err != nil {
+			return nil, t.conn.ProcessError(err)
+		}
+
+		return &tag, nil
+	}, name)
+}
+
+func (t *tagDB) PutTag(ctx context.Context, tag *gtsmodel.Tag) db.Error {
+	return t.state.Caches.GTS.Tag().Store(tag, func() error {
+		_, err := t.conn.
+			NewInsert().
+			Model(tag).
+			Exec(ctx)
+		return t.conn.ProcessError(err)
+	})
+}
+
+func (t *tagDB) UpdateTag(ctx context.Context, tag *gtsmodel.Tag, columns ...string) db.Error {
+	tag.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column, ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return t.state.Caches.GTS.Tag().Store(tag, func() error {
+		_, err := t.conn.
+			NewUpdate().
+			Model(tag).
+			Where("? = ?", bun.Ident("tag.id"), tag.ID).
+			Column(columns...).
+			Exec(ctx)
+		return t.conn.ProcessError(err)
+	})
+}
+
+func (t *tagDB) GetListableTagsByNamePrefix(ctx context.Context, prefix string, limit int) ([]*gtsmodel.Tag, db.Error) {
+	var (
+		tagIDs      []string
+		lowerPrefix = util.NormalizeHashtag(prefix)
+	)
+
+	q := t.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("tags"), bun.Ident("tag")).
+		Column("tag.id").
+		Where("? = ?", bun.Ident("tag.listable"), true).
+		Where("? LIKE ? ESCAPE '\\'", bun.Ident("tag.name"), escapeLike(lowerPrefix)+"%").
+		// Exact match first.
+		OrderExpr("CASE WHEN ? = ? THEN 0 ELSE 1 END ASC", bun.Ident("tag.name"), lowerPrefix).
+		Order("tag.name ASC")
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &tagIDs); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	tags := make([]*gtsmodel.Tag, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		tag, err := t.GetTagByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Tag was removed
+				// in the meantime.
+				continue
+			}
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func (t *tagDB) GetTagsByStatusID(ctx context.Context, statusID string) ([]*gtsmodel.Tag, db.Error) {
+	var tagIDs []string
+
+	if err := t.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Column("status_to_tag.tag_id").
+		Where("? = ?", bun.Ident("status_to_tag.status_id"), statusID).
+		Order("status_to_tag.tag_id ASC").
+		Scan(ctx, &tagIDs); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	tags := make([]*gtsmodel.Tag, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		tag, err := t.GetTagByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Tag was removed
+				// in the meantime.
+				continue
+			}
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func (t *tagDB) GetTagHistory(ctx context.Context, tagID string, days int) ([]*gtsmodel.TagHistory, db.Error) {
+	if days < 1 {
+		return []*gtsmodel.TagHistory{}, nil
+	}
+
+	var (
+		today = time.Now().UTC().Truncate(24 * time.Hour)
+		rows  []struct {
+			Day      int
+			Uses     int
+			Accounts int
+		}
+	)
+
+	// Bucket statuses by how many days ago they were
+	// created, so the database can do the grouping for
+	// us in one go: CASE WHEN created_at >= today THEN 0
+	// WHEN created_at >= yesterday THEN 1 ... END.
+	var (
+		dayExpr strings.Builder
+		dayArgs = make([]any, 0, days*3)
+	)
+
+	dayExpr.WriteString("CASE")
+	for i := 0; i < days; i++ {
+		dayExpr.WriteString(" WHEN ? >= ? THEN ?")
+		dayArgs = append(dayArgs, bun.Ident("status.created_at"), today.AddDate(0, 0, -i), i)
+	}
+	dayExpr.WriteString(" END")
+
+	if err := t.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("statuses"), bun.Ident("status"), bun.Ident("status.id"), bun.Ident("status_to_tag.status_id")).
+		ColumnExpr(dayExpr.String()+" AS ?", append(dayArgs, bun.Ident("day"))...).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("uses")).
+		ColumnExpr("COUNT(DISTINCT ?) AS ?", bun.Ident("status.account_id"), bun.Ident("accounts")).
+		Where("? = ?", bun.Ident("status_to_tag.tag_id"), tagID).
+		Where("? >= ?", bun.Ident("status.created_at"), today.AddDate(0, 0, -(days-1))).
+		// Only count statuses that
+		// are publicly visible anyway.
+		Where("? IN (?)", bun.Ident("status.visibility"), bun.In([]gtsmodel.Visibility{
+			gtsmodel.VisibilityPublic,
+			gtsmodel.VisibilityUnlocked,
+		})).
+		GroupExpr("?", bun.Ident("day")).
+		Scan(ctx, &rows); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	// Prepare one entry per
+	// day, newest first.
+	history := make([]*gtsmodel.TagHistory, days)
+	for i := range history {
+		history[i] = &gtsmodel.TagHistory{Day: today.AddDate(0, 0, -i)}
+	}
+
+	for _, row := range rows {
+		if row.Day < 0 || row.Day >= days {
+			// Shouldn't happen, but
+			// don't panic if it does.
+			continue
+		}
+
+		history[row.Day].Uses = row.Uses
+		history[row.Day].Accounts = row.Accounts
+	}
+
+	return history, nil
+}
+
+func (t *tagDB) GetAccountTagUsage(ctx context.Context, accountID string, since time.Time, limit int) ([]*gtsmodel.TagUsage, db.Error) {
+	var rows []struct {
+		TagID        string
+		StatusCount  int
+		LastStatusAt time.Time
+	}
+
+	q := t.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("statuses"), bun.Ident("status"), bun.Ident("status.id"), bun.Ident("status_to_tag.status_id")).
+		ColumnExpr("? AS ?", bun.Ident("status_to_tag.tag_id"), bun.Ident("tag_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("status_count")).
+		ColumnExpr("MAX(?) AS ?", bun.Ident("status.created_at"), bun.Ident("last_status_at")).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Where("? > ?", bun.Ident("status.created_at"), since).
+		Group("status_to_tag.tag_id").
+		OrderExpr("? DESC", bun.Ident("status_count")).
+		OrderExpr("? DESC", bun.Ident("last_status_at"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &rows); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	if len(rows) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	usages := make([]*gtsmodel.TagUsage, 0, len(rows))
+	for _, row := range rows {
+		tag, err := t.GetTagByID(ctx, row.TagID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// Tag was removed
+				// in the meantime.
+				continue
+			}
+			return nil, err
+		}
+
+		usages = append(usages, &gtsmodel.TagUsage{
+			Tag:          tag,
+			StatusCount:  row.StatusCount,
+			LastStatusAt: row.LastStatusAt,
+		})
+	}
+
+	return usages, nil
+}
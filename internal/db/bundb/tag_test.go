@@ -0,0 +1,257 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type TagTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *TagTestSuite) TestGetTagByName() {
+	testTag := suite.testTags["welcome"]
+
+	tag, err := suite.db.GetTagByName(context.Background(), testTag.Name)
+	suite.NoError(err)
+	suite.Equal(testTag.ID, tag.ID)
+}
+
+func (suite *TagTestSuite) TestGetTagByNameCaseInsensitive() {
+	testTag := suite.testTags["welcome"]
+
+	tag, err := suite.db.GetTagByName(context.Background(), "WeLcOmE")
+	suite.NoError(err)
+	suite.Equal(testTag.ID, tag.ID)
+}
+
+func (suite *TagTestSuite) TestGetTagByNameNonexisting() {
+	tag, err := suite.db.GetTagByName(context.Background(), "thisisnotatag")
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Nil(tag)
+}
+
+func (suite *TagTestSuite) TestGetListableTagsByNamePrefix() {
+	ctx := context.Background()
+
+	tags, err := suite.db.GetListableTagsByNamePrefix(ctx, "WEL", 10)
+	suite.NoError(err)
+	if suite.Len(tags, 1) {
+		suite.Equal("welcome", tags[0].Name)
+	}
+
+	tags, err = suite.db.GetListableTagsByNamePrefix(ctx, "elcome", 10)
+	suite.NoError(err)
+	suite.Empty(tags)
+
+	// Wildcards in the prefix should be taken literally.
+	tags, err = suite.db.GetListableTagsByNamePrefix(ctx, "%", 10)
+	suite.NoError(err)
+	suite.Empty(tags)
+}
+
+func (suite *TagTestSuite) TestGetListableTagsByNamePrefixUnlistable() {
+	ctx := context.Background()
+	testTag := suite.testTags["welcome"]
+
+	tag, err := suite.db.GetTagByID(ctx, testTag.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	*tag.Listable = false
+	if err := suite.db.UpdateTag(ctx, tag, "listable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tags, err := suite.db.GetListableTagsByNamePrefix(ctx, "wel", 10)
+	suite.NoError(err)
+	suite.Empty(tags)
+}
+
+func (suite *TagTestSuite) TestGetTagHistory() {
+	ctx := context.Background()
+	testTag := suite.testTags["welcome"]
+
+	// Use the tag in a new status today.
+	status := &gtsmodel.Status{}
+	*status = *suite.testStatuses["admin_account_status_1"]
+	status.ID = "01H3P7Y2J5E0K9R6T1V4B8N3QX"
+	status.URI = "http://localhost:8080/users/admin/statuses/01H3P7Y2J5E0K9R6T1V4B8N3QX"
+	status.URL = "http://localhost:8080/@admin/statuses/01H3P7Y2J5E0K9R6T1V4B8N3QX"
+	status.CreatedAt = time.Now()
+	status.AttachmentIDs = nil
+	status.Attachments = nil
+	status.TagIDs = []string{testTag.ID}
+	status.Tags = nil
+	status.Visibility = gtsmodel.VisibilityPublic
+	if err := suite.db.PutStatus(ctx, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Use it again in a followers-only
+	// status, which shouldn't be counted.
+	private := &gtsmodel.Status{}
+	*private = *status
+	private.ID = "01H3P7Y2J5E0K9R6T1V4B8N3QY"
+	private.URI = "http://localhost:8080/users/admin/statuses/01H3P7Y2J5E0K9R6T1V4B8N3QY"
+	private.URL = "http://localhost:8080/@admin/statuses/01H3P7Y2J5E0K9R6T1V4B8N3QY"
+	private.Visibility = gtsmodel.VisibilityFollowersOnly
+	if err := suite.db.PutStatus(ctx, private); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	history, err := suite.db.GetTagHistory(ctx, testTag.ID, 7)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(history, 7) {
+		suite.FailNow("expected 7 days of history")
+	}
+
+	// Today is first, and has the new status. The older
+	// test status using the tag is out of range.
+	suite.Equal(time.Now().UTC().Truncate(24*time.Hour), history[0].Day)
+	suite.Equal(1, history[0].Uses)
+	suite.Equal(1, history[0].Accounts)
+
+	for i, day := range history[1:] {
+		suite.Equal(history[i].Day.AddDate(0, 0, -1), day.Day)
+		suite.Zero(day.Uses)
+		suite.Zero(day.Accounts)
+	}
+}
+
+func (suite *TagTestSuite) TestUpdateTag() {
+	ctx := context.Background()
+	testTag := suite.testTags["welcome"]
+
+	// Prime the cache.
+	tag, err := suite.db.GetTagByName(ctx, testTag.Name)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	*tag.Useable = false
+	*tag.Trendable = false
+	if err := suite.db.UpdateTag(ctx, tag, "useable", "trendable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbTag, err := suite.db.GetTagByName(ctx, testTag.Name)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*dbTag.Useable)
+	suite.False(*dbTag.Trendable)
+	suite.True(*dbTag.Listable)
+	suite.False(*dbTag.RequiresReview)
+}
+
+func (suite *TagTestSuite) TestGetAccountTagUsage() {
+	testAccount := suite.testAccounts["admin_account"]
+	testTag := suite.testTags["welcome"]
+	testStatus := suite.testStatuses["admin_account_status_1"]
+
+	usages, err := suite.db.GetAccountTagUsage(context.Background(), testAccount.ID, time.Time{}, 10)
+	suite.NoError(err)
+	suite.Len(usages, 1)
+	suite.Equal(testTag.ID, usages[0].Tag.ID)
+	suite.Equal(1, usages[0].StatusCount)
+	suite.True(testStatus.CreatedAt.Equal(usages[0].LastStatusAt))
+}
+
+func (suite *TagTestSuite) TestGetAccountTagUsageSince() {
+	testAccount := suite.testAccounts["admin_account"]
+	testStatus := suite.testStatuses["admin_account_status_1"]
+
+	// The only tagged status was created before this.
+	usages, err := suite.db.GetAccountTagUsage(context.Background(), testAccount.ID, testStatus.CreatedAt.Add(time.Second), 10)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Empty(usages)
+}
+
+func (suite *TagTestSuite) TestGetTagsByStatusID() {
+	testTag := suite.testTags["welcome"]
+	testStatus := suite.testStatuses["admin_account_status_1"]
+
+	tags, err := suite.db.GetTagsByStatusID(context.Background(), testStatus.ID)
+	suite.NoError(err)
+	if suite.Len(tags, 1) {
+		suite.Equal(testTag.ID, tags[0].ID)
+	}
+
+	// A status without tags.
+	tags, err = suite.db.GetTagsByStatusID(context.Background(), suite.testStatuses["admin_account_status_2"].ID)
+	suite.NoError(err)
+	suite.Empty(tags)
+}
+
+func (suite *TagTestSuite) TestTagStringToTagAfterMiss() {
+	ctx := context.Background()
+
+	// Cache a miss for this tag.
+	_, err := suite.db.GetTagByName(ctx, "BrandNewTag")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	tag, err := suite.db.TagStringToTag(ctx, "BrandNewTag", suite.testAccounts["local_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("brandnewtag", tag.Name)
+	suite.Equal("http://localhost:8080/tags/brandnewtag", tag.URL)
+
+	if err := suite.db.PutTag(ctx, tag); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The cached miss must not stick around.
+	dbTag, err := suite.db.GetTagByName(ctx, "BRANDNEWTAG")
+	suite.NoError(err)
+	suite.Equal(tag.ID, dbTag.ID)
+}
+
+func (suite *TagTestSuite) TestTagStringToTagNotUseable() {
+	ctx := context.Background()
+
+	tag, err := suite.db.GetTagByName(ctx, "welcome")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	*tag.Useable = false
+	if err := suite.db.UpdateTag(ctx, tag, "useable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tag, err = suite.db.TagStringToTag(ctx, "Welcome", suite.testAccounts["local_account_1"].ID)
+	suite.ErrorIs(err, db.ErrTagNotUseable)
+	suite.Nil(tag)
+}
+
+func TestTagTestSuite(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
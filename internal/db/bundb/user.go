@@ -25,6 +25,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/uptrace/bun"
 )
@@ -138,6 +139,131 @@ func (u *userDB) GetAllUsers(ctx context.Context) ([]*gtsmodel.User, db.Error) {
 	return users, nil
 }
 
+func (u *userDB) GetUsersScheduledForDeletion(ctx context.Context, before time.Time) ([]*gtsmodel.User, db.Error) {
+	var userIDs []string
+
+	if err := u.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.id").
+		Where("? IS NOT NULL", bun.Ident("user.delete_scheduled_at")).
+		Where("? <= ?", bun.Ident("user.delete_scheduled_at"), before).
+		Scan(ctx, &userIDs); err != nil {
+		return nil, u.conn.ProcessError(err)
+	}
+
+	users := make([]*gtsmodel.User, 0, len(userIDs))
+	for _, id := range userIDs {
+		user, err := u.GetUserByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting user %q: %v", id, err)
+			continue
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (u *userDB) GetUsersPendingDeletion(ctx context.Context, requestedBefore time.Time) ([]*gtsmodel.User, db.Error) {
+	var userIDs []string
+
+	if err := u.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.id").
+		Where("? IS NOT NULL", bun.Ident("user.delete_requested_at")).
+		Where("? <= ?", bun.Ident("user.delete_requested_at"), requestedBefore).
+		Scan(ctx, &userIDs); err != nil {
+		return nil, u.conn.ProcessError(err)
+	}
+
+	users := make([]*gtsmodel.User, 0, len(userIDs))
+	for _, id := range userIDs {
+		user, err := u.GetUserByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting user %q: %v", id, err)
+			continue
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (u *userDB) GetPendingSignups(ctx context.Context, maxID string, minID string, limit int) ([]*gtsmodel.User, db.Error) {
+	var (
+		userIDs     []string
+		frontToBack = true
+	)
+
+	q := u.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Column("user.id").
+		Where("? = ?", bun.Ident("user.approved"), false)
+
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("user.account_id"), maxID)
+	}
+
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("user.account_id"), minID)
+
+		// page up
+		frontToBack = false
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if frontToBack {
+		// Page down.
+		q = q.Order("user.account_id DESC")
+	} else {
+		// Page up.
+		q = q.Order("user.account_id ASC")
+	}
+
+	if err := q.Scan(ctx, &userIDs); err != nil {
+		return nil, u.conn.ProcessError(err)
+	}
+
+	// If we're paging up, we still want users
+	// to be sorted by account ID desc, so reverse.
+	if !frontToBack {
+		for l, r := 0, len(userIDs)-1; l < r; l, r = l+1, r-1 {
+			userIDs[l], userIDs[r] = userIDs[r], userIDs[l]
+		}
+	}
+
+	users := make([]*gtsmodel.User, 0, len(userIDs))
+	for _, id := range userIDs {
+		user, err := u.GetUserByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting user %q: %v", id, err)
+			continue
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+func (u *userDB) CountPendingSignups(ctx context.Context) (int, db.Error) {
+	count, err := u.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("users"), bun.Ident("user")).
+		Where("? = ?", bun.Ident("user.approved"), false).
+		Count(ctx)
+	if err != nil {
+		return 0, u.conn.ProcessError(err)
+	}
+
+	return count, nil
+}
+
 func (u *userDB) PutUser(ctx context.Context, user *gtsmodel.User) db.Error {
 	return u.state.Caches.GTS.User().Store(user, func() error {
 		_, err := u.conn.
@@ -53,6 +53,24 @@ func (suite *UserTestSuite) TestGetUserByAccountID() {
 	suite.NotNil(user)
 }
 
+func (suite *UserTestSuite) TestGetPendingSignups() {
+	pending := suite.testUsers["unconfirmed_account"]
+
+	users, err := suite.db.GetPendingSignups(context.Background(), "", "", 0)
+	suite.NoError(err)
+	suite.Len(users, 1)
+	suite.Equal(pending.ID, users[0].ID)
+
+	// Nothing older than the only pending sign-up.
+	users, err = suite.db.GetPendingSignups(context.Background(), pending.AccountID, "", 0)
+	suite.NoError(err)
+	suite.Empty(users)
+
+	count, err := suite.db.CountPendingSignups(context.Background())
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
 func (suite *UserTestSuite) TestUpdateUserSelectedColumns() {
 	testUser := suite.testUsers["local_account_1"]
 
@@ -18,6 +18,8 @@
 package bundb
 
 import (
+	"strings"
+
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/uptrace/bun"
 )
@@ -50,6 +52,16 @@ func whereNotEmptyAndNotNull(column string) func(*bun.SelectQuery) *bun.SelectQu
 	}
 }
 
+// escapeLike escapes the LIKE wildcard characters
+// in s, for use in a LIKE pattern with ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\",
+		"%", "\\%",
+		"_", "\\_",
+	).Replace(s)
+}
+
 // updateWhere parses []db.Where and adds it to the given update query.
 func updateWhere(q *bun.UpdateQuery, where []db.Where) {
 	for _, w := range where {
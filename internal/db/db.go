@@ -34,18 +34,24 @@ type DB interface {
 	Admin
 	Basic
 	Domain
+	EmailTemplate
 	Emoji
 	Instance
+	InstancePolicy
 	List
 	Media
 	Mention
 	Notification
+	PreviewCard
 	Relationship
 	Report
+	SavedSearch
 	Session
+	SignIn
 	Status
 	StatusBookmark
 	StatusFave
+	Tag
 	Timeline
 	User
 	Tombstone
@@ -54,10 +60,11 @@ type DB interface {
 		USEFUL CONVERSION FUNCTIONS
 	*/
 
-	// TagStringToTag takes a lowercase tag in the form "somehashtag", which has been
-	// used in a status. It takes the id of the account that wrote the status, and the id of the status itself, and then
-	// returns an *apimodel.Tag corresponding to the given tags. If the tag already exists in database, that tag
-	// will be returned. Otherwise a pointer to a new tag struct will be created and returned.
+	// TagStringToTag takes a tag in the form "somehashtag", which has been used in a status,
+	// and normalizes it with util.NormalizeHashtag. It takes the id of the account that wrote the status,
+	// and then returns a *gtsmodel.Tag corresponding to the given tag. If the tag already exists in database,
+	// that tag will be returned. Otherwise a pointer to a new tag struct will be created and returned.
+	// If the tag exists but an admin has made it unusable, ErrTagNotUseable is returned.
 	//
 	// Note: this func doesn't/shouldn't do any manipulation of tags in the DB, it's just for checking
 	// if they exist in the db already, and conveniently returning them, or creating new tag structs.
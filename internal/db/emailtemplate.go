@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// EmailTemplate contains functions for getting/putting admin-customized email templates.
+type EmailTemplate interface {
+	// GetEmailTemplateByName gets the email template override with the given name.
+	GetEmailTemplateByName(ctx context.Context, name string) (*gtsmodel.EmailTemplate, Error)
+
+	// GetEmailTemplates gets all stored email template overrides.
+	GetEmailTemplates(ctx context.Context) ([]*gtsmodel.EmailTemplate, Error)
+
+	// PutEmailTemplate inserts the given email template override into the database.
+	PutEmailTemplate(ctx context.Context, template *gtsmodel.EmailTemplate) Error
+
+	// UpdateEmailTemplate updates the given email template override in the database.
+	UpdateEmailTemplate(ctx context.Context, template *gtsmodel.EmailTemplate, columns ...string) Error
+
+	// DeleteEmailTemplateByName deletes the email template override with the given name.
+	DeleteEmailTemplateByName(ctx context.Context, name string) Error
+}
@@ -29,6 +29,12 @@ var (
 	ErrMultipleEntries Error = fmt.Errorf("multiple entries")
 	// ErrAlreadyExists is returned when a conflict was encountered in the db when doing an insert.
 	ErrAlreadyExists Error = fmt.Errorf("already exists")
+	// ErrMediaNotCached is returned when a caller asked for a media attachment only if
+	// it's cached (see gtscontext.SetCachedMediaOnly), but the attachment isn't cached.
+	ErrMediaNotCached Error = fmt.Errorf("media not cached")
+	// ErrTagNotUseable is returned when a caller asked for a tag to use
+	// in a status, but an admin has made the tag unusable.
+	ErrTagNotUseable Error = fmt.Errorf("tag not useable")
 	// ErrUnknown denotes an unknown database error.
 	ErrUnknown Error = fmt.Errorf("unknown error")
 )
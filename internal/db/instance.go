@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -34,12 +35,27 @@ type Instance interface {
 	// CountInstanceDomains returns the number of known instances known that the given domain federates with.
 	CountInstanceDomains(ctx context.Context, domain string) (int, Error)
 
+	// CountLocalStatusesBetween returns the number of statuses posted
+	// by local accounts between the given times (inclusive, exclusive).
+	CountLocalStatusesBetween(ctx context.Context, from time.Time, to time.Time) (int, Error)
+
+	// CountLocalUsersCreatedBetween returns the number of local users
+	// created between the given times (inclusive, exclusive).
+	CountLocalUsersCreatedBetween(ctx context.Context, from time.Time, to time.Time) (int, Error)
+
 	// GetInstance returns the instance entry for the given domain, if it exists.
 	GetInstance(ctx context.Context, domain string) (*gtsmodel.Instance, Error)
 
+	// UpdateInstance updates the given instance entry. If any columns are provided, only those will be updated.
+	UpdateInstance(ctx context.Context, instance *gtsmodel.Instance, columns ...string) Error
+
 	// GetInstanceAccounts returns a slice of accounts from the given instance, arranged by ID.
 	GetInstanceAccounts(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, Error)
 
+	// GetDomainStats returns account, status and media stats for known remote
+	// domains, ordered by number of accounts (descending) and then by domain.
+	GetDomainStats(ctx context.Context, limit int, offset int) ([]*gtsmodel.DomainStats, Error)
+
 	// GetInstancePeers returns a slice of instances that the host instance knows about.
 	GetInstancePeers(ctx context.Context, includeSuspended bool) ([]*gtsmodel.Instance, Error)
 
@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// InstancePolicy contains functions for getting/putting this instance's policy documents.
+type InstancePolicy interface {
+	// GetInstancePolicy gets the instance policy of the given type.
+	GetInstancePolicy(ctx context.Context, policyType gtsmodel.InstancePolicyType) (*gtsmodel.InstancePolicy, Error)
+
+	// PutInstancePolicy inserts the given instance policy into the database.
+	PutInstancePolicy(ctx context.Context, policy *gtsmodel.InstancePolicy) Error
+
+	// UpdateInstancePolicy updates the given instance policy in the database.
+	UpdateInstancePolicy(ctx context.Context, policy *gtsmodel.InstancePolicy, columns ...string) Error
+
+	// DeleteInstancePolicy deletes the instance policy of the given type.
+	DeleteInstancePolicy(ctx context.Context, policyType gtsmodel.InstancePolicyType) Error
+}
@@ -26,33 +26,87 @@ import (
 
 // Media contains functions related to creating/getting/removing media attachments.
 type Media interface {
-	// GetAttachmentByID gets a single attachment by its ID.
+	// GetAttachmentByID gets a single attachment by its ID. If the context has the
+	// gtscontext.CachedMediaOnly flag set, and the attachment isn't currently cached,
+	// ErrMediaNotCached is returned instead, so that the caller can trigger a recache.
 	GetAttachmentByID(ctx context.Context, id string) (*gtsmodel.MediaAttachment, Error)
 
+	// GetAttachmentWithContext gets a single attachment by its ID, along with the status and account that own it.
+	// If the attachment isn't attached to a status, or that status no longer exists, the returned Status will be nil.
+	GetAttachmentWithContext(ctx context.Context, id string) (*gtsmodel.MediaAttachmentContext, Error)
+
 	// GetAttachmentsByIDs fetches a list of media attachments for given IDs.
 	GetAttachmentsByIDs(ctx context.Context, ids []string) ([]*gtsmodel.MediaAttachment, error)
 
+	// GetAttachmentsByIDsMap is like GetAttachmentsByIDs, but returns the
+	// attachments keyed by ID, for callers that need to look them up by ID.
+	// Since it's a map, no ordering of the returned attachments is guaranteed.
+	GetAttachmentsByIDsMap(ctx context.Context, ids []string) (map[string]*gtsmodel.MediaAttachment, error)
+
+	// GetAttachmentsByStatusID fetches all media attachments belonging to the given status ID in a single query.
+	// Attachments are returned in the order declared by the status' AttachmentIDs.
+	GetAttachmentsByStatusID(ctx context.Context, statusID string) ([]*gtsmodel.MediaAttachment, error)
+
 	// PutAttachment inserts the given attachment into the database.
 	PutAttachment(ctx context.Context, media *gtsmodel.MediaAttachment) error
 
 	// UpdateAttachment will update the given attachment in the database.
 	UpdateAttachment(ctx context.Context, media *gtsmodel.MediaAttachment, columns ...string) error
 
-	// DeleteAttachment deletes the attachment with given ID from the database.
+	// GetAttachmentProcessingState returns the processing state of the attachment with the given ID.
+	// Attachments being processed for the first time are only stored once processing has finished
+	// or errored, so until then this will return ErrNoEntries; recached attachments go through
+	// each state in turn.
+	GetAttachmentProcessingState(ctx context.Context, id string) (gtsmodel.ProcessingStatus, error)
+
+	// PutAttachmentVariant records the given generated variant of an attachment in the database.
+	PutAttachmentVariant(ctx context.Context, variant *gtsmodel.MediaAttachmentVariant) error
+
+	// GetAttachmentVariants gets all recorded variants of the attachment with the given ID.
+	GetAttachmentVariants(ctx context.Context, attachmentID string) ([]*gtsmodel.MediaAttachmentVariant, error)
+
+	// DeleteAttachmentVariants deletes all recorded variants of the attachment with the given ID.
+	DeleteAttachmentVariants(ctx context.Context, attachmentID string) error
+
+	// DeleteAttachment deletes the attachment with given ID, and any variants recorded
+	// for it, from the database. Stored files are left as-is for the caller to remove.
+	// Any status the attachment belonged to is left as-is for the caller to update.
 	DeleteAttachment(ctx context.Context, id string) error
 
-	// GetRemoteOlderThan gets limit n remote media attachments (including avatars and headers) older than the given
-	// olderThan time. These will be returned in order of attachment.created_at descending (newest to oldest in other words).
+	// SetAttachmentInstanceAsset marks or unmarks the attachment with the given ID as an instance asset,
+	// ie., media used by the instance itself for branding. Instance assets are never selected for pruning.
+	SetAttachmentInstanceAsset(ctx context.Context, id string, instanceAsset bool) error
+
+	// GetRemoteOlderThanAscending gets limit n remote media attachments (including avatars and headers) older than
+	// the given olderThan time. These will be returned in order of attachment.created_at ascending (oldest to newest
+	// in other words). If sinceID is set, only attachments ordered after the attachment with sinceID and
+	// sinceCreatedAt are returned, so callers can page through candidates.
 	//
 	// The selected media attachments will be those with both a URL and a RemoteURL filled in.
 	// In other words, media attachments that originated remotely, and that we currently have cached locally.
-	GetRemoteOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, Error)
+	// Instance assets are never selected.
+	GetRemoteOlderThanAscending(ctx context.Context, olderThan time.Time, sinceCreatedAt time.Time, sinceID string, limit int) ([]*gtsmodel.MediaAttachment, Error)
 
-	// CountRemoteOlderThan is like GetRemoteOlderThan, except instead of getting limit n attachments,
+	// CountRemoteOlderThan is like GetRemoteOlderThanAscending, except instead of getting limit n attachments,
 	// it just counts how many remote attachments in the database (including avatars and headers) meet
 	// the olderThan criteria.
 	CountRemoteOlderThan(ctx context.Context, olderThan time.Time) (int, Error)
 
+	// GetRemoteDead gets limit n uncached remote media attachments which have failed to be fetched
+	// at least minFailures times in a row, with the first of those failures before failingSince, and
+	// so most likely belong to an instance that's gone. Avatars, headers, and instance assets are
+	// never selected.
+	GetRemoteDead(ctx context.Context, minFailures int, failingSince time.Time, limit int) ([]*gtsmodel.MediaAttachment, Error)
+
+	// CountRemoteDead is like GetRemoteDead, except instead of getting limit n attachments,
+	// it just counts how many remote attachments in the database meet the criteria.
+	CountRemoteDead(ctx context.Context, minFailures int, failingSince time.Time) (int, Error)
+
+	// GetUncachedRecentlyInteracted gets limit n uncached remote media attachments belonging to statuses
+	// which have been faved or boosted since the given time, ordered by most recent interaction first.
+	// Media which has failed to be fetched again since it was uncached is not selected.
+	GetUncachedRecentlyInteracted(ctx context.Context, since time.Time, limit int) ([]*gtsmodel.MediaAttachment, Error)
+
 	// GetAvatarsAndHeaders fetches limit n avatars and headers with an id < maxID. These headers
 	// and avis may be in use or not; the caller should check this if it's important.
 	GetAvatarsAndHeaders(ctx context.Context, maxID string, limit int) ([]*gtsmodel.MediaAttachment, Error)
@@ -60,11 +114,39 @@ type Media interface {
 	// GetLocalUnattachedOlderThan fetches limit n local media attachments (including avatars and headers), older than
 	// the given time, which aren't header or avatars, and aren't attached to a status. In other words, attachments which were
 	// uploaded but never used for whatever reason, or attachments that were attached to a status which was subsequently deleted.
+	// Instance assets, and attachments waiting on a scheduled status to be posted, are never selected.
 	//
 	// These will be returned in order of attachment.created_at descending (newest to oldest in other words).
 	GetLocalUnattachedOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, Error)
 
+	// GetAttachmentsInErrorState fetches limit n remote media attachments which failed to be dereferenced
+	// or processed, ie., those with a processing error recorded. These will be returned in order of
+	// attachment.created_at descending (newest to oldest in other words).
+	GetAttachmentsInErrorState(ctx context.Context, limit int) ([]*gtsmodel.MediaAttachment, Error)
+
+	// CountAttachmentsInErrorState is like GetAttachmentsInErrorState, except instead of getting limit n
+	// attachments, it just counts how many attachments in the database have a processing error recorded.
+	CountAttachmentsInErrorState(ctx context.Context) (int, Error)
+
 	// CountLocalUnattachedOlderThan is like GetLocalUnattachedOlderThan, except instead of getting limit n attachments,
 	// it just counts how many local attachments in the database meet the olderThan criteria.
 	CountLocalUnattachedOlderThan(ctx context.Context, olderThan time.Time) (int, Error)
+
+	// GetAttachmentsMissingDescription fetches limit n media attachments owned by the given account, with an
+	// id < maxID, which don't have a description (alt-text) set. Avatars and headers are not included.
+	// These will be returned in order of attachment.id descending (newest to oldest in other words).
+	GetAttachmentsMissingDescription(ctx context.Context, accountID string, maxID string, limit int) ([]*gtsmodel.MediaAttachment, Error)
+
+	// CountAttachmentsMissingDescription is like GetAttachmentsMissingDescription, except instead of getting
+	// limit n attachments, it just counts how many of the account's attachments are missing a description.
+	CountAttachmentsMissingDescription(ctx context.Context, accountID string) (int, Error)
+
+	// GetCachedMediaUsageByDomain returns the number and total size of cached remote media attachments
+	// (including avatars and headers) per remote domain, in order of total size descending.
+	GetCachedMediaUsageByDomain(ctx context.Context) ([]*gtsmodel.DomainMediaUsage, Error)
+
+	// GetCachedAttachmentsForDomain fetches limit n cached remote media attachments (including avatars
+	// and headers) belonging to accounts on the given domain. These will be returned in order of
+	// attachment.updated_at ascending (least recently updated first, in other words).
+	GetCachedAttachmentsForDomain(ctx context.Context, domain string, limit int) ([]*gtsmodel.MediaAttachment, Error)
 }
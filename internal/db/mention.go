@@ -28,7 +28,8 @@ type Mention interface {
 	// GetMention gets a single mention by ID
 	GetMention(ctx context.Context, id string) (*gtsmodel.Mention, Error)
 
-	// GetMentions gets multiple mentions.
+	// GetMentions gets multiple mentions, loading their
+	// accounts in one go rather than one mention at a time.
 	GetMentions(ctx context.Context, ids []string) ([]*gtsmodel.Mention, Error)
 
 	// PutMention will insert the given mention into the database.
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -56,8 +57,20 @@ type Notification interface {
 	// If both are set, then notifications that target targetAccountID and
 	// originate from originAccountID will be deleted.
 	//
-	// At least one parameter must not be an empty string.
-	DeleteNotifications(ctx context.Context, types []string, targetAccountID string, originAccountID string) Error
+	// If createdBefore is not zero, only notifications created before that
+	// time will be deleted. If neither account ID is set, this deletes old
+	// notifications instance-wide.
+	//
+	// At least one of targetAccountID, originAccountID or createdBefore must be set.
+	DeleteNotifications(ctx context.Context, types []string, targetAccountID string, originAccountID string, createdBefore time.Time) Error
+
+	// DeleteNotificationsBatch is like DeleteNotifications for all types, but deletes
+	// only up to limit of the oldest matching notifications (by ID), so that huge sets
+	// can be deleted a bit at a time. It returns the amount of notifications deleted;
+	// when this is less than limit, there are no matching notifications left.
+	//
+	// At least one of targetAccountID, originAccountID or createdBefore must be set.
+	DeleteNotificationsBatch(ctx context.Context, targetAccountID string, originAccountID string, createdBefore time.Time, limit int) (int, error)
 
 	// DeleteNotificationsForStatus deletes all notifications that relate to
 	// the given statusID. This function is useful when a status has been deleted,
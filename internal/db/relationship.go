@@ -55,6 +55,11 @@ type Relationship interface {
 	// GetRelationship retrieves the relationship of the targetAccount to the requestingAccount.
 	GetRelationship(ctx context.Context, requestingAccount string, targetAccount string) (*gtsmodel.Relationship, Error)
 
+	// GetRelationships is like GetRelationship, but for many target accounts at once. Follows, follow
+	// requests and blocks are each fetched for all targets in one query, rather than one per target.
+	// Relationships are returned in the same order as targetAccounts.
+	GetRelationships(ctx context.Context, requestingAccount string, targetAccounts []string) ([]*gtsmodel.Relationship, error)
+
 	// GetFollowByID fetches follow with given ID from the database.
 	GetFollowByID(ctx context.Context, id string) (*gtsmodel.Follow, error)
 
@@ -154,9 +159,21 @@ type Relationship interface {
 	// GetAccountFollowRequesting returns all follow requests originating from the given account.
 	GetAccountFollowRequesting(ctx context.Context, accountID string) ([]*gtsmodel.FollowRequest, error)
 
+	// GetAccountFollowRequestingPage returns up to limit follow requests originating from the given
+	// account, sorted by ID descending (newest first), and optionally paged with maxID and/or minID.
+	GetAccountFollowRequestingPage(ctx context.Context, accountID string, maxID string, minID string, limit int) ([]*gtsmodel.FollowRequest, error)
+
 	// CountAccountFollowRequests returns number of follow requests targeting the given account.
 	CountAccountFollowRequests(ctx context.Context, accountID string) (int, error)
 
 	// CountAccountFollowerRequests returns number of follow requests originating from the given account.
 	CountAccountFollowRequesting(ctx context.Context, accountID string) (int, error)
+
+	// CountDomainFollows returns the number of follows between local accounts and accounts on the given domain.
+	// If outbound is true, follows from local accounts are counted, else follows from the domain are counted.
+	CountDomainFollows(ctx context.Context, domain string, outbound bool) (int, error)
+
+	// GetDomainFollowRequests returns pending follow requests between local accounts and accounts on the given domain.
+	// If outbound is true, requests from local accounts are returned, else requests from the domain are returned.
+	GetDomainFollowRequests(ctx context.Context, domain string, outbound bool) ([]*gtsmodel.FollowRequest, error)
 }
@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// SavedSearch contains functions for getting, creating and deleting saved searches.
+type SavedSearch interface {
+	// GetSavedSearchByID gets one saved search with the given id.
+	GetSavedSearchByID(ctx context.Context, id string) (*gtsmodel.SavedSearch, Error)
+
+	// GetAccountSavedSearches gets all saved searches of the given accountID, newest first.
+	GetAccountSavedSearches(ctx context.Context, accountID string) ([]*gtsmodel.SavedSearch, Error)
+
+	// PutSavedSearch puts the given saved search in the database.
+	PutSavedSearch(ctx context.Context, savedSearch *gtsmodel.SavedSearch) error
+
+	// DeleteSavedSearchByID deletes one saved search with the given id.
+	DeleteSavedSearchByID(ctx context.Context, id string) error
+
+	// DeleteAccountSavedSearches deletes all saved searches of the given accountID.
+	DeleteAccountSavedSearches(ctx context.Context, accountID string) error
+}
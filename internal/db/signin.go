@@ -0,0 +1,47 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// SignIn contains functions for recording and counting local user sign-ins.
+type SignIn interface {
+	// PutSignIn stores one sign-in.
+	PutSignIn(ctx context.Context, signIn *gtsmodel.SignIn) error
+
+	// CountSignInUsers returns the number of distinct users
+	// who signed in between the given times (inclusive, exclusive).
+	CountSignInUsers(ctx context.Context, from time.Time, to time.Time) (int, Error)
+
+	// CountSignInCountries returns the number of distinct users who signed in
+	// between the given times (inclusive, exclusive), keyed by country code.
+	// Sign-ins without a resolved country are not included.
+	CountSignInCountries(ctx context.Context, from time.Time, to time.Time) (map[string]int, Error)
+
+	// DeleteSignInsOlderThan deletes all sign-ins created before the
+	// given time, returning the number of sign-ins that were deleted.
+	DeleteSignInsOlderThan(ctx context.Context, olderThan time.Time) (int, Error)
+
+	// DeleteSignInsForUser deletes all sign-ins by the user with the given ID.
+	DeleteSignInsForUser(ctx context.Context, userID string) Error
+}
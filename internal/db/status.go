@@ -46,7 +46,13 @@ type Status interface {
 	// UpdateStatus updates one status in the database.
 	UpdateStatus(ctx context.Context, status *gtsmodel.Status, columns ...string) Error
 
-	// DeleteStatusByID deletes one status from the database.
+	// PutStatusRevision stores one earlier version of a status in the database.
+	PutStatusRevision(ctx context.Context, revision *gtsmodel.StatusRevision) error
+
+	// GetStatusRevisions returns the stored earlier versions of the given status, oldest first.
+	GetStatusRevisions(ctx context.Context, statusID string) ([]*gtsmodel.StatusRevision, error)
+
+	// DeleteStatusByID deletes one status, and any stored revisions of it, from the database.
 	DeleteStatusByID(ctx context.Context, id string) Error
 
 	// CountStatusReplies returns the amount of replies recorded for a status, or an error if something goes wrong
@@ -77,10 +83,28 @@ type Status interface {
 	// IsStatusMutedBy checks if a given status has been muted by a given account ID
 	IsStatusMutedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
 
+	// DeleteStatusMutes mass deletes status mutes targeting targetAccountID
+	// and/or originating from originAccountID, in the same way as DeleteStatusFaves.
+	//
+	// At least one parameter must not be an empty string.
+	DeleteStatusMutes(ctx context.Context, targetAccountID string, originAccountID string) Error
+
 	// IsStatusBookmarkedBy checks if a given status has been bookmarked by a given account ID
 	IsStatusBookmarkedBy(ctx context.Context, status *gtsmodel.Status, accountID string) (bool, Error)
 
 	// GetStatusReblogs returns a slice of statuses that are a boost/reblog of the given status.
 	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
 	GetStatusReblogs(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, Error)
+
+	// GetStatusReblogsPage returns up to limit boosts/reblogs of the status with the given ID, with an ID lower than
+	// maxID and higher than minID if set, newest first. Boosts by suspended accounts are left out, but this is otherwise unfiltered.
+	GetStatusReblogsPage(ctx context.Context, statusID string, maxID string, minID string, limit int) ([]*gtsmodel.Status, Error)
+
+	// GetReblogsForStatusIDs returns the boosts/reblogs of each of the given status IDs, keyed by the ID of the boosted status,
+	// using a single query. Statuses with no boosts will not be present in the returned map. Like GetStatusReblogs, this is unfiltered.
+	GetReblogsForStatusIDs(ctx context.Context, statusIDs []string) (map[string][]*gtsmodel.Status, Error)
+
+	// GetDirectStatusesForAccount returns up to limit statuses with direct visibility which were either
+	// authored by the given account or mention it, with an ID lower than maxID if set, newest first.
+	GetDirectStatusesForAccount(ctx context.Context, accountID string, maxID string, limit int) ([]*gtsmodel.Status, error)
 }
@@ -35,6 +35,10 @@ type StatusFave interface {
 	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
 	GetStatusFavesForStatus(ctx context.Context, statusID string) ([]*gtsmodel.StatusFave, Error)
 
+	// GetStatusFavesPage returns up to limit faves/likes of the given status, with an ID
+	// lower than maxID and higher than minID if set, newest first. This slice will be unfiltered.
+	GetStatusFavesPage(ctx context.Context, statusID string, maxID string, minID string, limit int) ([]*gtsmodel.StatusFave, Error)
+
 	// PutStatusFave inserts the given statusFave into the database.
 	PutStatusFave(ctx context.Context, statusFave *gtsmodel.StatusFave) Error
 
@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Tag contains functions for getting and setting hashtags.
+type Tag interface {
+	// GetTagByID gets the tag with the given ID.
+	GetTagByID(ctx context.Context, id string) (*gtsmodel.Tag, Error)
+
+	// GetTagByName gets the tag with the given name. The name is normalized
+	// with util.NormalizeHashtag first, so the lookup is case-insensitive.
+	GetTagByName(ctx context.Context, name string) (*gtsmodel.Tag, Error)
+
+	// PutTag inserts the given tag into the database. The tag's
+	// name should already be normalized with util.NormalizeHashtag.
+	PutTag(ctx context.Context, tag *gtsmodel.Tag) Error
+
+	// UpdateTag updates the given tag in the database. If columns
+	// is empty then all columns will be updated.
+	UpdateTag(ctx context.Context, tag *gtsmodel.Tag, columns ...string) Error
+
+	// GetListableTagsByNamePrefix returns up to limit listable tags whose name starts
+	// with the given prefix, case-insensitively. A tag whose name is exactly the prefix
+	// comes first, followed by the rest in name order.
+	GetListableTagsByNamePrefix(ctx context.Context, prefix string, limit int) ([]*gtsmodel.Tag, Error)
+
+	// GetTagsByStatusID returns the tags attached to the status with the given ID,
+	// in ascending tag ID order. If the status has no tags, the slice will be empty.
+	GetTagsByStatusID(ctx context.Context, statusID string) ([]*gtsmodel.Tag, Error)
+
+	// GetTagHistory returns the daily use history of the tag with the given ID over
+	// the given number of days, one entry per day (including days with no uses),
+	// starting with today (UTC) and going back in time. Only public and unlisted
+	// statuses are counted.
+	GetTagHistory(ctx context.Context, tagID string, days int) ([]*gtsmodel.TagHistory, Error)
+
+	// GetAccountTagUsage returns up to limit of the hashtags most used by the given
+	// account in statuses created after since, sorted by number of uses descending.
+	GetAccountTagUsage(ctx context.Context, accountID string, since time.Time, limit int) ([]*gtsmodel.TagUsage, Error)
+}
@@ -19,6 +19,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -37,6 +38,15 @@ type User interface {
 	GetUserByExternalID(ctx context.Context, id string) (*gtsmodel.User, Error)
 	// GetUserByConfirmationToken returns one user by its confirmation token, or an error if something goes wrong.
 	GetUserByConfirmationToken(ctx context.Context, confirmationToken string) (*gtsmodel.User, Error)
+	// GetUsersScheduledForDeletion returns all users who have scheduled deletion of their account at or before the given time.
+	GetUsersScheduledForDeletion(ctx context.Context, before time.Time) ([]*gtsmodel.User, Error)
+	// GetUsersPendingDeletion returns all users who requested deletion of their account at or before the given time, and whose deletion hasn't completed yet.
+	GetUsersPendingDeletion(ctx context.Context, requestedBefore time.Time) ([]*gtsmodel.User, Error)
+	// GetPendingSignups returns users whose sign-up is still awaiting approval, newest first, paged by account ID
+	// with an account ID lower than maxID and higher than minID if set.
+	GetPendingSignups(ctx context.Context, maxID string, minID string, limit int) ([]*gtsmodel.User, Error)
+	// CountPendingSignups returns the number of users whose sign-up is still awaiting approval.
+	CountPendingSignups(ctx context.Context) (int, Error)
 	// PutUser will attempt to place user in the database
 	PutUser(ctx context.Context, user *gtsmodel.User) Error
 	// UpdateUser updates one user by its primary key, updating either only the specified columns, or all of them.
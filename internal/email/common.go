@@ -32,12 +32,12 @@ import (
 )
 
 func (s *sender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
-	buf := &bytes.Buffer{}
-	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
+	subject, body, err := s.render(template, subject, data)
+	if err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), s.from, toAddresses...)
+	msg, err := assembleMessage(subject, body, s.from, toAddresses...)
 	if err != nil {
 		return err
 	}
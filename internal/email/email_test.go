@@ -185,6 +185,90 @@ func (suite *EmailTestSuite) TestTemplateReportClosedLocalAccountNoComment() {
 	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\n\r\nHello !\r\n\r\nYou recently reported the account @1happyturtle to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report did not leave a comment.\r\n\r\n", suite.sentEmails["user@example.org"])
 }
 
+func (suite *EmailTestSuite) TestTemplateSignupApproved() {
+	signupApprovedData := email.SignupApprovedData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+	}
+
+	if err := suite.sender.SendSignupApprovedEmail("user@example.org", signupApprovedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Sign-Up Approved\r\n\r\nHello test!\r\n\r\nYour request to sign up for an account on Test Instance (https://example.org) has been approved by a moderator.\r\n\r\nOnce you've confirmed your email address, you can log in at https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateSignupRejected() {
+	signupRejectedData := email.SignupRejectedData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Reason:       "This instance is for cat owners only.",
+	}
+
+	if err := suite.sender.SendSignupRejectedEmail("user@example.org", signupRejectedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Sign-Up Rejected\r\n\r\nHello test!\r\n\r\nYour request to sign up for an account on Test Instance (https://example.org) has been rejected by a moderator.\r\n\r\nThe moderator who rejected your sign-up gave the following reason: This instance is for cat owners only.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateSignupRejectedNoReason() {
+	signupRejectedData := email.SignupRejectedData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+	}
+
+	if err := suite.sender.SendSignupRejectedEmail("user@example.org", signupRejectedData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Sign-Up Rejected\r\n\r\nHello test!\r\n\r\nYour request to sign up for an account on Test Instance (https://example.org) has been rejected by a moderator.\r\n\r\nThe moderator who rejected your sign-up did not give a reason.\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateConfirmCustomized() {
+	if err := suite.sender.SetTemplate(email.TemplateNameConfirm, "Welcome to {{.InstanceName}}", "Hi {{.Username}}, click {{.ConfirmLink}}"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	subject, body, customized, err := suite.sender.GetTemplate(email.TemplateNameConfirm)
+	suite.NoError(err)
+	suite.True(customized)
+	suite.Equal("Welcome to {{.InstanceName}}", subject)
+	suite.Equal("Hi {{.Username}}, click {{.ConfirmLink}}", body)
+
+	confirmData := email.ConfirmData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		ConfirmLink:  "https://example.org/confirm_email?token=ee24f71d-e615-43f9-afae-385c0799b7fa",
+	}
+
+	suite.sender.SendConfirmEmail("user@example.org", confirmData)
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: Welcome to Test Instance\r\n\r\nHi test, click https://example.org/confirm_email?token=ee24f71d-e615-43f9-afae-385c0799b7fa\r\n", suite.sentEmails["user@example.org"])
+
+	// Reset back to the default.
+	if err := suite.sender.SetTemplate(email.TemplateNameConfirm, "", ""); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	subject, _, customized, err = suite.sender.GetTemplate(email.TemplateNameConfirm)
+	suite.NoError(err)
+	suite.False(customized)
+	suite.Equal("GoToSocial Email Confirmation", subject)
+}
+
+func (suite *EmailTestSuite) TestValidateTemplate() {
+	suite.NoError(email.ValidateTemplate(email.TemplateNameReset, "Reset for {{.Username}}", "{{.ResetLink}}"))
+	suite.Error(email.ValidateTemplate(email.TemplateNameReset, "Reset for {{.Username}}", "{{.ConfirmLink}}"))
+	suite.Error(email.ValidateTemplate(email.TemplateNameReset, "Reset for {{.Username", "{{.ResetLink}}"))
+	suite.Error(email.ValidateTemplate(email.TemplateNameReset, "Reset\nfor {{.Username}}", "{{.ResetLink}}"))
+	suite.Error(email.ValidateTemplate("not_a_template", "subject", "body"))
+}
+
 func TestEmailTestSuite(t *testing.T) {
 	suite.Run(t, new(EmailTestSuite))
 }
@@ -18,9 +18,6 @@
 package email
 
 import (
-	"bytes"
-	"text/template"
-
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
@@ -38,14 +35,14 @@ func NewNoopSender(sendCallback func(toAddress string, message string)) (Sender,
 	}
 
 	return &noopSender{
+		templates:    newTemplates(t),
 		sendCallback: sendCallback,
-		template:     t,
 	}, nil
 }
 
 type noopSender struct {
+	*templates
 	sendCallback func(toAddress string, message string)
-	template     *template.Template
 }
 
 func (s *noopSender) SendConfirmEmail(toAddress string, data ConfirmData) error {
@@ -68,13 +65,21 @@ func (s *noopSender) SendReportClosedEmail(toAddress string, data ReportClosedDa
 	return s.sendTemplate(reportClosedTemplate, reportClosedSubject, data, toAddress)
 }
 
+func (s *noopSender) SendSignupApprovedEmail(toAddress string, data SignupApprovedData) error {
+	return s.sendTemplate(signupApprovedTemplate, signupApprovedSubject, data, toAddress)
+}
+
+func (s *noopSender) SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error {
+	return s.sendTemplate(signupRejectedTemplate, signupRejectedSubject, data, toAddress)
+}
+
 func (s *noopSender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
-	buf := &bytes.Buffer{}
-	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
+	subject, body, err := s.render(template, subject, data)
+	if err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), "test@example.org", toAddresses...)
+	msg, err := assembleMessage(subject, body, "test@example.org", toAddresses...)
 	if err != nil {
 		return err
 	}
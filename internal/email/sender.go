@@ -20,7 +20,6 @@ package email
 import (
 	"fmt"
 	"net/smtp"
-	"text/template"
 
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
@@ -46,6 +45,24 @@ type Sender interface {
 	// SendReportClosedEmail sends an email notification to the given address, letting them
 	// know that a report that they created has been closed / resolved by an admin.
 	SendReportClosedEmail(toAddress string, data ReportClosedData) error
+
+	// SendSignupApprovedEmail sends an email notification to the given address,
+	// letting them know that their sign-up has been approved by an admin.
+	SendSignupApprovedEmail(toAddress string, data SignupApprovedData) error
+
+	// SendSignupRejectedEmail sends an email notification to the given address,
+	// letting them know that their sign-up has been rejected by an admin.
+	SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error
+
+	// GetTemplate returns the currently effective subject and body templates for
+	// the customizable email template with the given name (see TemplateNames),
+	// and whether these templates have been customized by an admin.
+	GetTemplate(name string) (subject string, body string, customized bool, err error)
+
+	// SetTemplate overrides the compiled-in subject and body templates for the
+	// customizable email template with the given name. Passing an empty subject
+	// and body will restore the compiled-in defaults.
+	SetTemplate(name string, subject string, body string) error
 }
 
 // NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
@@ -66,13 +83,13 @@ func NewSender() (Sender, error) {
 		hostAddress: fmt.Sprintf("%s:%d", host, port),
 		from:        from,
 		auth:        smtp.PlainAuth("", username, password, host),
-		template:    t,
+		templates:   newTemplates(t),
 	}, nil
 }
 
 type sender struct {
+	*templates
 	hostAddress string
 	from        string
 	auth        smtp.Auth
-	template    *template.Template
 }
@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/textproto"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// SMTPErrorKind describes the broad category
+// of a failure during SMTP communication.
+type SMTPErrorKind string
+
+const (
+	SMTPErrorUnknown        SMTPErrorKind = "unknown"
+	SMTPErrorDNS            SMTPErrorKind = "dns_lookup_failed"
+	SMTPErrorConnection     SMTPErrorKind = "connection_failed"
+	SMTPErrorTLS            SMTPErrorKind = "tls_failed"
+	SMTPErrorAuthentication SMTPErrorKind = "authentication_failed"
+	SMTPErrorSender         SMTPErrorKind = "sender_rejected"
+	SMTPErrorRecipient      SMTPErrorKind = "recipient_rejected"
+)
+
+// SMTPErrorInfo contains a classification of an SMTP
+// error, and the config fields likely responsible for it.
+type SMTPErrorInfo struct {
+	Kind   SMTPErrorKind
+	Fields []string
+}
+
+// HelpText returns a human-readable explanation
+// of the SMTP error, suitable for showing to an admin.
+func (i SMTPErrorInfo) HelpText(err error) string {
+	var msg string
+
+	switch i.Kind {
+	case SMTPErrorDNS:
+		msg = "smtp host could not be resolved"
+	case SMTPErrorConnection:
+		msg = "could not connect to smtp server"
+	case SMTPErrorTLS:
+		msg = "tls negotiation with smtp server failed"
+	case SMTPErrorAuthentication:
+		msg = "smtp server rejected authentication"
+	case SMTPErrorSender:
+		msg = "smtp server rejected the sending address"
+	case SMTPErrorRecipient:
+		msg = "smtp server rejected the recipient address"
+	default:
+		msg = "smtp error"
+	}
+
+	if len(i.Fields) > 0 {
+		msg += "; check config field(s): " + strings.Join(i.Fields, ", ")
+	}
+
+	if err != nil {
+		msg += ": " + err.Error()
+	}
+
+	return msg
+}
+
+// ClassifySMTPError inspects the given error returned during
+// SMTP communication, and attempts to determine which part of
+// the exchange failed, and so which config fields are at fault.
+func ClassifySMTPError(err error) SMTPErrorInfo {
+	var (
+		dnsErr   *net.DNSError
+		opErr    *net.OpError
+		protoErr *textproto.Error
+		certErr  *tls.CertificateVerificationError
+		hostErr  x509.HostnameError
+		authErr  x509.UnknownAuthorityError
+		recErr   tls.RecordHeaderError
+	)
+
+	switch {
+	case err == nil:
+		return SMTPErrorInfo{Kind: SMTPErrorUnknown}
+
+	case errors.As(err, &dnsErr):
+		return SMTPErrorInfo{
+			Kind:   SMTPErrorDNS,
+			Fields: []string{config.SMTPHostFlag()},
+		}
+
+	case errors.As(err, &certErr),
+		errors.As(err, &hostErr),
+		errors.As(err, &authErr),
+		errors.As(err, &recErr):
+		return SMTPErrorInfo{
+			Kind:   SMTPErrorTLS,
+			Fields: []string{config.SMTPHostFlag(), config.SMTPPortFlag()},
+		}
+
+	case errors.As(err, &opErr) && opErr.Op == "dial":
+		return SMTPErrorInfo{
+			Kind:   SMTPErrorConnection,
+			Fields: []string{config.SMTPHostFlag(), config.SMTPPortFlag()},
+		}
+
+	case errors.As(err, &protoErr):
+		return classifySMTPCode(protoErr)
+	}
+
+	// net/smtp returns some plain string errors
+	// from its auth implementations, check these.
+	switch msg := err.Error(); {
+	case strings.Contains(msg, "unencrypted connection"):
+		return SMTPErrorInfo{
+			Kind:   SMTPErrorTLS,
+			Fields: []string{config.SMTPPortFlag()},
+		}
+
+	case strings.Contains(msg, "wrong host name"):
+		return SMTPErrorInfo{
+			Kind:   SMTPErrorTLS,
+			Fields: []string{config.SMTPHostFlag()},
+		}
+
+	case strings.Contains(msg, "server doesn't support AUTH"):
+		return SMTPErrorInfo{
+			Kind:   SMTPErrorAuthentication,
+			Fields: []string{config.SMTPUsernameFlag(), config.SMTPPasswordFlag()},
+		}
+	}
+
+	return SMTPErrorInfo{Kind: SMTPErrorUnknown}
+}
+
+// classifySMTPCode classifies an SMTP error
+// reply based on the returned status code.
+func classifySMTPCode(err *textproto.Error) SMTPErrorInfo {
+	switch err.Code {
+	// Authentication required / invalid / mechanism too weak.
+	case 530, 534, 535, 538:
+		return SMTPErrorInfo{
+			Kind:   SMTPErrorAuthentication,
+			Fields: []string{config.SMTPUsernameFlag(), config.SMTPPasswordFlag()},
+		}
+
+	// Mailbox unavailable / not local / name not allowed.
+	case 550, 551, 553:
+		if strings.Contains(strings.ToLower(err.Msg), "sender") ||
+			strings.Contains(strings.ToLower(err.Msg), "from") {
+			return SMTPErrorInfo{
+				Kind:   SMTPErrorSender,
+				Fields: []string{config.SMTPFromFlag()},
+			}
+		}
+		return SMTPErrorInfo{Kind: SMTPErrorRecipient}
+
+	// Parameters not recognized / not implemented.
+	case 555:
+		return SMTPErrorInfo{
+			Kind:   SMTPErrorSender,
+			Fields: []string{config.SMTPFromFlag()},
+		}
+	}
+
+	return SMTPErrorInfo{Kind: SMTPErrorUnknown}
+}
@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+)
+
+func TestClassifySMTPError(t *testing.T) {
+	for _, test := range []struct {
+		err    error
+		kind   email.SMTPErrorKind
+		fields []string
+	}{
+		{
+			err:    &net.DNSError{Err: "no such host", Name: "smtp.example.org"},
+			kind:   email.SMTPErrorDNS,
+			fields: []string{"smtp-host"},
+		},
+		{
+			err:    &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			kind:   email.SMTPErrorConnection,
+			fields: []string{"smtp-host", "smtp-port"},
+		},
+		{
+			err:    fmt.Errorf("wrapped: %w", &textproto.Error{Code: 535, Msg: "5.7.8 Authentication credentials invalid"}),
+			kind:   email.SMTPErrorAuthentication,
+			fields: []string{"smtp-username", "smtp-password"},
+		},
+		{
+			err:    &textproto.Error{Code: 553, Msg: "5.7.1 Sender address rejected"},
+			kind:   email.SMTPErrorSender,
+			fields: []string{"smtp-from"},
+		},
+		{
+			err:  &textproto.Error{Code: 550, Msg: "5.1.1 No such user"},
+			kind: email.SMTPErrorRecipient,
+		},
+		{
+			err:    errors.New("unencrypted connection"),
+			kind:   email.SMTPErrorTLS,
+			fields: []string{"smtp-port"},
+		},
+		{
+			err:  errors.New("something else entirely"),
+			kind: email.SMTPErrorUnknown,
+		},
+	} {
+		info := email.ClassifySMTPError(test.err)
+		if info.Kind != test.kind {
+			t.Errorf("%v: expected kind %q, got %q", test.err, test.kind, info.Kind)
+		}
+		if fmt.Sprint(info.Fields) != fmt.Sprint(test.fields) {
+			t.Errorf("%v: expected fields %v, got %v", test.err, test.fields, info.Fields)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package email
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// Names of email templates which can be customized by an admin.
+const (
+	TemplateNameConfirm        = "confirm"
+	TemplateNameReset          = "reset"
+	TemplateNameTest           = "test"
+	TemplateNameNewReport      = "new_report"
+	TemplateNameReportClosed   = "report_closed"
+	TemplateNameSignupApproved = "signup_approved"
+	TemplateNameSignupRejected = "signup_rejected"
+)
+
+// templateDefault describes the compiled-in defaults
+// for an email template which can be customized.
+type templateDefault struct {
+	file    string // template file name
+	subject string // default email subject
+	dummy   any    // dummy data to validate against
+}
+
+var templateDefaults = map[string]templateDefault{
+	TemplateNameConfirm: {
+		file:    confirmTemplate,
+		subject: confirmSubject,
+		dummy: ConfirmData{
+			Username:     "example",
+			InstanceURL:  "https://example.org",
+			InstanceName: "Example Instance",
+			ConfirmLink:  "https://example.org/confirm_email?token=example",
+		},
+	},
+	TemplateNameReset: {
+		file:    resetTemplate,
+		subject: resetSubject,
+		dummy: ResetData{
+			Username:     "example",
+			InstanceURL:  "https://example.org",
+			InstanceName: "Example Instance",
+			ResetLink:    "https://example.org/reset_email?token=example",
+		},
+	},
+	TemplateNameTest: {
+		file:    testTemplate,
+		subject: testSubject,
+		dummy: TestData{
+			SendingUsername: "example",
+			InstanceURL:     "https://example.org",
+			InstanceName:    "Example Instance",
+		},
+	},
+	TemplateNameNewReport: {
+		file:    newReportTemplate,
+		subject: newReportSubject,
+		dummy: NewReportData{
+			InstanceURL:        "https://example.org",
+			InstanceName:       "Example Instance",
+			ReportURL:          "https://example.org/settings/admin/reports/01GVJHN1RTYZCZTCXVPPPKBX6R",
+			ReportDomain:       "example.com",
+			ReportTargetDomain: "",
+		},
+	},
+	TemplateNameReportClosed: {
+		file:    reportClosedTemplate,
+		subject: reportClosedSubject,
+		dummy: ReportClosedData{
+			Username:             "example",
+			InstanceURL:          "https://example.org",
+			InstanceName:         "Example Instance",
+			ReportTargetUsername: "someone",
+			ReportTargetDomain:   "example.com",
+			ActionTakenComment:   "Example comment.",
+		},
+	},
+	TemplateNameSignupApproved: {
+		file:    signupApprovedTemplate,
+		subject: signupApprovedSubject,
+		dummy: SignupApprovedData{
+			Username:     "example",
+			InstanceURL:  "https://example.org",
+			InstanceName: "Example Instance",
+		},
+	},
+	TemplateNameSignupRejected: {
+		file:    signupRejectedTemplate,
+		subject: signupRejectedSubject,
+		dummy: SignupRejectedData{
+			Username:     "example",
+			InstanceURL:  "https://example.org",
+			InstanceName: "Example Instance",
+			Reason:       "Example reason.",
+		},
+	},
+}
+
+// TemplateNames returns the names of all
+// email templates which can be customized.
+func TemplateNames() []string {
+	names := make([]string, 0, len(templateDefaults))
+	for name := range templateDefaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateTemplate parses the given subject and body templates, and executes them
+// against dummy data for the email template with given name, returning any error.
+func ValidateTemplate(name string, subject string, body string) error {
+	def, ok := templateDefaults[name]
+	if !ok {
+		return fmt.Errorf("no email template with name %s", name)
+	}
+
+	override, err := parseOverride(name, subject, body)
+	if err != nil {
+		return err
+	}
+
+	if _, err := renderSubject(override.subject, def.dummy); err != nil {
+		return err
+	}
+
+	if err := override.body.Execute(io.Discard, def.dummy); err != nil {
+		return fmt.Errorf("error executing body template: %w", err)
+	}
+
+	return nil
+}
+
+// templates wraps the compiled-in email templates,
+// along with any admin-provided template overrides.
+type templates struct {
+	base      *template.Template
+	overrides map[string]*templateOverride // keyed by template file
+	mu        sync.RWMutex
+}
+
+// templateOverride contains parsed
+// subject and body template overrides.
+type templateOverride struct {
+	subjectText string
+	bodyText    string
+	subject     *template.Template
+	body        *template.Template
+}
+
+func newTemplates(base *template.Template) *templates {
+	return &templates{
+		base:      base,
+		overrides: make(map[string]*templateOverride),
+	}
+}
+
+func parseOverride(name string, subject string, body string) (*templateOverride, error) {
+	subjectTmpl, err := template.New(name + "_subject").Parse(subject)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing subject template: %w", err)
+	}
+
+	bodyTmpl, err := template.New(name + "_body").Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing body template: %w", err)
+	}
+
+	return &templateOverride{
+		subjectText: subject,
+		bodyText:    body,
+		subject:     subjectTmpl,
+		body:        bodyTmpl,
+	}, nil
+}
+
+func renderSubject(t *template.Template, data any) (string, error) {
+	buf := &bytes.Buffer{}
+	if err := t.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("error executing subject template: %w", err)
+	}
+
+	subject := buf.String()
+	if strings.ContainsAny(subject, "\r\n") {
+		return "", errors.New("email subject must not contain newline characters")
+	}
+
+	return subject, nil
+}
+
+// render renders the subject and body for the given template
+// file, using an admin-provided override if one is set.
+func (t *templates) render(file string, subject string, data any) (string, string, error) {
+	t.mu.RLock()
+	override := t.overrides[file]
+	t.mu.RUnlock()
+
+	buf := &bytes.Buffer{}
+
+	if override == nil {
+		// No override, use the compiled-in defaults.
+		if err := t.base.ExecuteTemplate(buf, file, data); err != nil {
+			return "", "", err
+		}
+		return subject, buf.String(), nil
+	}
+
+	subject, err := renderSubject(override.subject, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := override.body.Execute(buf, data); err != nil {
+		return "", "", fmt.Errorf("error executing body template: %w", err)
+	}
+
+	return subject, buf.String(), nil
+}
+
+func (t *templates) GetTemplate(name string) (string, string, bool, error) {
+	def, ok := templateDefaults[name]
+	if !ok {
+		return "", "", false, fmt.Errorf("no email template with name %s", name)
+	}
+
+	t.mu.RLock()
+	override := t.overrides[def.file]
+	t.mu.RUnlock()
+
+	if override != nil {
+		return override.subjectText, override.bodyText, true, nil
+	}
+
+	tmpl := t.base.Lookup(def.file)
+	if tmpl == nil || tmpl.Tree == nil {
+		return "", "", false, fmt.Errorf("email template %s not loaded", def.file)
+	}
+
+	// Reconstruct the default body template text from
+	// its parse tree, this drops comments + whitespace.
+	return def.subject, tmpl.Tree.Root.String(), false, nil
+}
+
+func (t *templates) SetTemplate(name string, subject string, body string) error {
+	def, ok := templateDefaults[name]
+	if !ok {
+		return fmt.Errorf("no email template with name %s", name)
+	}
+
+	if subject == "" && body == "" {
+		// Restore the defaults.
+		t.mu.Lock()
+		delete(t.overrides, def.file)
+		t.mu.Unlock()
+		return nil
+	}
+
+	override, err := parseOverride(name, subject, body)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	t.overrides[def.file] = override
+	t.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// activityLogDomainSize is the amount of most recent
+	// incoming activities kept per domain by an ActivityLog.
+	activityLogDomainSize = 50
+
+	// activityLogMaxDomains is the amount of domains an
+	// ActivityLog keeps entries for. When a new domain
+	// arrives and the log is full, the domain that was
+	// least recently heard from is dropped.
+	activityLogMaxDomains = 500
+)
+
+// ActivityLogEntry records the outcome of one activity posted to
+// an inbox on this instance. Activity bodies are never recorded,
+// as they may contain personal information.
+type ActivityLogEntry struct {
+	// ReceivedAt is when the activity was received.
+	ReceivedAt time.Time
+	// Domain of the account that delivered the activity.
+	Domain string
+	// ActivityType is the ActivityStreams type of the
+	// activity, eg., Create. Empty if it couldn't be parsed.
+	ActivityType string
+	// ObjectType is the ActivityStreams type of the activity
+	// object, eg., Note. Empty if the object was just an IRI.
+	ObjectType string
+	// ActorURI is the URI of the account that delivered the
+	// activity. Empty if the request couldn't be authenticated.
+	ActorURI string
+	// Accepted is true if the activity was accepted for processing.
+	Accepted bool
+	// Reason the activity was rejected, if it was.
+	Reason string
+}
+
+// ActivityLog keeps the most recently received activities from
+// each delivering domain, so admins can see what remote instances
+// are sending when debugging federation. Entries are kept per
+// domain, so one busy instance can't push out everyone else's.
+type ActivityLog struct {
+	domains map[string]*activityLogRing
+	seq     uint64 // incremented on every Record
+	mu      sync.Mutex
+}
+
+// activityLogRing is a fixed size circular
+// buffer of one domain's most recent entries.
+type activityLogRing struct {
+	entries [activityLogDomainSize]ActivityLogEntry
+	next    int    // index of the next entry to write
+	full    bool   // whether entries has wrapped around
+	last    uint64 // log seq when the newest entry was recorded
+}
+
+// Record adds the given entry to the log, overwriting the
+// oldest entry for its domain if that domain's buffer is full.
+func (l *ActivityLog) Record(entry ActivityLogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.domains == nil {
+		l.domains = make(map[string]*activityLogRing)
+	}
+
+	ring, ok := l.domains[entry.Domain]
+	if !ok {
+		if len(l.domains) >= activityLogMaxDomains {
+			l.dropStalestDomain()
+		}
+
+		ring = &activityLogRing{}
+		l.domains[entry.Domain] = ring
+	}
+
+	ring.entries[ring.next] = entry
+	ring.next = (ring.next + 1) % activityLogDomainSize
+	if ring.next == 0 {
+		ring.full = true
+	}
+	l.seq++
+	ring.last = l.seq
+}
+
+// dropStalestDomain removes the domain that was least
+// recently recorded. The caller must hold the lock.
+func (l *ActivityLog) dropStalestDomain() {
+	var (
+		stalest     string
+		stalestLast uint64
+	)
+
+	for domain, ring := range l.domains {
+		if stalestLast == 0 || ring.last < stalestLast {
+			stalest = domain
+			stalestLast = ring.last
+		}
+	}
+
+	delete(l.domains, stalest)
+}
+
+// Entries returns logged entries for the given domain, newest
+// first. If domain is empty, entries for all domains are returned.
+func (l *ActivityLog) Entries(domain string) []ActivityLogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if domain != "" {
+		ring, ok := l.domains[domain]
+		if !ok {
+			return []ActivityLogEntry{}
+		}
+		return ring.newestFirst(nil)
+	}
+
+	var entries []ActivityLogEntry
+	for _, ring := range l.domains {
+		entries = ring.newestFirst(entries)
+	}
+
+	// Interleave the domains.
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].ReceivedAt.After(entries[j].ReceivedAt)
+	})
+
+	return entries
+}
+
+// newestFirst appends the ring's
+// entries to dst, newest first.
+func (r *activityLogRing) newestFirst(dst []ActivityLogEntry) []ActivityLogEntry {
+	count := r.next
+	if r.full {
+		count = activityLogDomainSize
+	}
+
+	for i := 1; i <= count; i++ {
+		// Walk backwards from the
+		// most recently written entry.
+		dst = append(dst, r.entries[(r.next-i+activityLogDomainSize)%activityLogDomainSize])
+	}
+
+	return dst
+}
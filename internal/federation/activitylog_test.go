@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federation_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+)
+
+type ActivityLogTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ActivityLogTestSuite) TestEntriesNewestFirst() {
+	log := &federation.ActivityLog{}
+	start := time.Now()
+	log.Record(federation.ActivityLogEntry{ReceivedAt: start, Domain: "example.org", ActivityType: "Follow"})
+	log.Record(federation.ActivityLogEntry{ReceivedAt: start.Add(time.Second), Domain: "fossbros-anonymous.io", ActivityType: "Create"})
+	log.Record(federation.ActivityLogEntry{ReceivedAt: start.Add(2 * time.Second), Domain: "example.org", ActivityType: "Undo"})
+
+	entries := log.Entries("")
+	suite.Len(entries, 3)
+	suite.Equal("Undo", entries[0].ActivityType)
+	suite.Equal("Create", entries[1].ActivityType)
+	suite.Equal("Follow", entries[2].ActivityType)
+
+	entries = log.Entries("example.org")
+	suite.Len(entries, 2)
+	suite.Equal("Undo", entries[0].ActivityType)
+	suite.Equal("Follow", entries[1].ActivityType)
+
+	suite.Empty(log.Entries("not.a.domain"))
+}
+
+func (suite *ActivityLogTestSuite) TestOldestEntriesDropped() {
+	log := &federation.ActivityLog{}
+	start := time.Now()
+
+	// One entry from a quiet domain...
+	log.Record(federation.ActivityLogEntry{
+		ReceivedAt: start,
+		Domain:     "fossbros-anonymous.io",
+	})
+
+	// ...then more entries from a busy domain than fit
+	// in its buffer; only the last 50 should remain.
+	for i := 0; i < 75; i++ {
+		log.Record(federation.ActivityLogEntry{
+			ReceivedAt: start.Add(time.Duration(i+1) * time.Second),
+			Domain:     "example.org",
+			ActorURI:   fmt.Sprintf("http://example.org/users/%d", i),
+		})
+	}
+
+	entries := log.Entries("example.org")
+	suite.Len(entries, 50)
+	suite.Equal("http://example.org/users/74", entries[0].ActorURI)
+	suite.Equal("http://example.org/users/25", entries[49].ActorURI)
+
+	// The busy domain didn't push out the quiet one.
+	suite.Len(log.Entries("fossbros-anonymous.io"), 1)
+	suite.Len(log.Entries(""), 51)
+}
+
+func (suite *ActivityLogTestSuite) TestStalestDomainDropped() {
+	log := &federation.ActivityLog{}
+
+	// Fill the log with as many domains as it
+	// can hold, then hear from one more.
+	for i := 0; i <= 500; i++ {
+		log.Record(federation.ActivityLogEntry{
+			ReceivedAt: time.Now(),
+			Domain:     fmt.Sprintf("%d.example.org", i),
+		})
+	}
+
+	// The first domain was least recently heard from.
+	suite.Empty(log.Entries("0.example.org"))
+	suite.Len(log.Entries("1.example.org"), 1)
+	suite.Len(log.Entries("500.example.org"), 1)
+	suite.Len(log.Entries(""), 500)
+}
+
+func TestActivityLogTestSuite(t *testing.T) {
+	suite.Run(t, &ActivityLogTestSuite{})
+}
@@ -318,6 +318,16 @@ func (d *deref) enrichStatus(ctx context.Context, requestUser string, uri *url.U
 			return nil, nil, gtserror.Newf("error putting in database: %w", err)
 		}
 	} else {
+		if statusEdited(status, latestStatus) {
+			// Keep the version of the status we had before,
+			// so that it's still there in the edit history.
+			revision := gtsmodel.NewStatusRevision(status)
+			revision.ID = id.NewULID()
+			if err := d.state.DB.PutStatusRevision(ctx, revision); err != nil {
+				return nil, nil, gtserror.Newf("error putting revision of status %s: %w", uri, err)
+			}
+		}
+
 		// This is an existing status, update the model in the database.
 		if err := d.state.DB.UpdateStatus(ctx, latestStatus); err != nil {
 			return nil, nil, gtserror.Newf("error updating database: %w", err)
@@ -480,3 +490,35 @@ func (d *deref) fetchStatusEmojis(ctx context.Context, requestUser string, exist
 
 	return nil
 }
+
+// statusEdited returns whether the text or attachments of latest differ
+// from those of existing, ie., whether the author edited the status in between.
+func statusEdited(existing *gtsmodel.Status, latest *gtsmodel.Status) bool {
+	if existing == latest {
+		// Same model,
+		// nothing new.
+		return false
+	}
+
+	if existing.Content != latest.Content ||
+		existing.ContentWarning != latest.ContentWarning ||
+		(existing.Sensitive != nil && latest.Sensitive != nil &&
+			*existing.Sensitive != *latest.Sensitive) {
+		return true
+	}
+
+	// Unchanged attachments keep their IDs, as
+	// they're looked up by remote URL, so any
+	// difference here means media was edited.
+	if len(existing.AttachmentIDs) != len(latest.AttachmentIDs) {
+		return true
+	}
+
+	for i := range existing.AttachmentIDs {
+		if existing.AttachmentIDs[i] != latest.AttachmentIDs[i] {
+			return true
+		}
+	}
+
+	return false
+}
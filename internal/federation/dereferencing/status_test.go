@@ -20,6 +20,7 @@ package dereferencing_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -72,6 +73,55 @@ func (suite *StatusTestSuite) TestDereferenceSimpleStatus() {
 	suite.Nil(account.PrivateKey)
 }
 
+func (suite *StatusTestSuite) TestRefreshEditedStatusStoresRevision() {
+	ctx := context.Background()
+	fetchingAccount := suite.testAccounts["local_account_1"]
+
+	statusURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839")
+	status, _, err := suite.dereferencer.GetStatusByURI(ctx, fetchingAccount.Username, statusURL)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Pretend we last saw the status a while ago,
+	// before the author fixed a typo in it.
+	status.Content = "Hello wrld!"
+	status.FetchedAt = time.Now().Add(-24 * time.Hour)
+	if err := suite.db.UpdateStatus(ctx, status, "content", "fetched_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	refreshed, _, err := suite.dereferencer.RefreshStatus(ctx, fetchingAccount.Username, status, nil, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("Hello world!", refreshed.Content)
+
+	// The old version should be in the edit history.
+	revisions, err := suite.db.GetStatusRevisions(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(revisions, 1) {
+		suite.FailNow("expected 1 revision")
+	}
+	suite.Equal("Hello wrld!", revisions[0].Content)
+
+	// Refreshing again without changes
+	// shouldn't store another revision.
+	refreshed.FetchedAt = time.Now().Add(-24 * time.Hour)
+	if _, _, err := suite.dereferencer.RefreshStatus(ctx, fetchingAccount.Username, refreshed, nil, false); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	revisions, err = suite.db.GetStatusRevisions(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(revisions, 1)
+}
+
 func (suite *StatusTestSuite) TestDereferenceStatusWithMention() {
 	fetchingAccount := suite.testAccounts["local_account_1"]
 
@@ -26,14 +26,17 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"codeberg.org/gruf/go-kv"
+	"github.com/go-fed/httpsig"
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
@@ -89,17 +92,19 @@ func IsASMediaType(ct string) bool {
 type federatingActor struct {
 	sideEffectActor pub.DelegateActor
 	wrapped         pub.FederatingActor
+	activityLog     *ActivityLog
 }
 
 // newFederatingProtocol returns a new federatingActor, which
 // implements the pub.FederatingActor interface.
-func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub.Database, clock pub.Clock) pub.FederatingActor {
+func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub.Database, clock pub.Clock, activityLog *ActivityLog) pub.FederatingActor {
 	sideEffectActor := pub.NewSideEffectActor(c, s2s, nil, db, clock)
 	sideEffectActor.Serialize = ap.Serialize // hook in our own custom Serialize function
 
 	return &federatingActor{
 		sideEffectActor: sideEffectActor,
 		wrapped:         pub.NewCustomActor(sideEffectActor, false, true, clock),
+		activityLog:     activityLog,
 	}
 }
 
@@ -113,7 +118,26 @@ func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub
 //     provide more helpful messages to remote callers.
 //   - Return code 202 instead of 200 on successful POST, to reflect
 //     that we process most side effects asynchronously.
-func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+//   - Record the outcome of requests in the activity log.
+func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWriter, r *http.Request, scheme string) (accepted bool, err error) {
+	var (
+		receivedAt = time.Now()
+		requestCtx = ctx
+		activity   pub.Activity
+	)
+
+	// Record whatever happens to this request, including
+	// rejections before and during authentication. Some
+	// auth failures return a nil context, so fall back
+	// to the request context if we have to.
+	defer func() {
+		recordCtx := ctx
+		if recordCtx == nil {
+			recordCtx = requestCtx
+		}
+		f.recordActivity(recordCtx, receivedAt, activity, accepted, err)
+	}()
+
 	l := log.WithContext(ctx).
 		WithFields([]kv.Field{
 			{"userAgent", r.UserAgent()},
@@ -221,6 +245,50 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 	return true, nil
 }
 
+// recordActivity adds the outcome of an inbox POST to the activity
+// log. The activity will be nil if it could not be resolved from the
+// request body. If the request wasn't authenticated, the domain is
+// taken from the http signature key ID; requests we can't attribute
+// to any domain at all aren't recorded.
+func (f *federatingActor) recordActivity(ctx context.Context, receivedAt time.Time, activity pub.Activity, accepted bool, err error) {
+	entry := ActivityLogEntry{
+		ReceivedAt: receivedAt,
+		Accepted:   accepted && err == nil,
+	}
+
+	if requestingAccount, ok := ctx.Value(ap.ContextRequestingAccount).(*gtsmodel.Account); ok {
+		entry.Domain = requestingAccount.Domain
+		entry.ActorURI = requestingAccount.URI
+	} else if verifier, ok := ctx.Value(ap.ContextRequestingPublicKeyVerifier).(httpsig.Verifier); ok {
+		if keyID, err := url.Parse(verifier.KeyId()); err == nil {
+			entry.Domain = strings.ToLower(keyID.Host)
+		}
+	}
+
+	if entry.Domain == "" {
+		// Nobody to attribute it to.
+		return
+	}
+
+	if activity != nil {
+		entry.ActivityType = activity.GetTypeName()
+
+		// Only the type of the first
+		// object is recorded, if any.
+		if objectProp := activity.GetActivityStreamsObject(); objectProp != nil && objectProp.Len() != 0 {
+			if t := objectProp.At(0).GetType(); t != nil {
+				entry.ObjectType = t.GetTypeName()
+			}
+		}
+	}
+
+	if err != nil {
+		entry.Reason = err.Error()
+	}
+
+	f.activityLog.Record(entry)
+}
+
 // resolveActivity is a util function for pulling a
 // pub.Activity type out of an incoming POST request.
 func resolveActivity(ctx context.Context, r *http.Request) (pub.Activity, gtserror.WithCode) {
@@ -32,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 )
 
 // Create adds a new entry to the database which must be able to be
@@ -219,6 +220,19 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 		return fmt.Errorf("createNote: error converting note to status: %s", err)
 	}
 
+	if status.InReplyTo != nil && *status.InReplyTo.Local {
+		// Replies to our statuses must be permitted by their interaction policy.
+		permitted, err := f.filter.StatusInteractionPermitted(ctx, requestingAccount, status.InReplyTo, visibility.InteractionReply)
+		if err != nil {
+			return fmt.Errorf("createNote: error checking interaction policy of status %s: %w", status.InReplyTo.ID, err)
+		}
+
+		if !permitted {
+			l.Debugf("reply to %s not permitted by its interaction policy, dropping it", status.InReplyTo.URI)
+			return nil
+		}
+	}
+
 	// id the status based on the time it was created
 	statusID, err := id.NewULIDFromTime(status.CreatedAt)
 	if err != nil {
@@ -293,6 +307,19 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		return fmt.Errorf("activityLike: could not convert Like to fave: %w", err)
 	}
 
+	if *fave.Status.Local {
+		// Likes of our statuses must be permitted by their interaction policy.
+		permitted, err := f.filter.StatusInteractionPermitted(ctx, fave.Account, fave.Status, visibility.InteractionLike)
+		if err != nil {
+			return fmt.Errorf("activityLike: error checking interaction policy of status %s: %w", fave.Status.ID, err)
+		}
+
+		if !permitted {
+			log.Debugf(ctx, "like of %s not permitted by its interaction policy, dropping it", fave.Status.URI)
+			return nil
+		}
+	}
+
 	fave.ID = id.NewULID()
 
 	if err := f.state.DB.PutStatusFave(ctx, fave); err != nil {
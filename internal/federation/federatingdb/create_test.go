@@ -21,10 +21,12 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -128,6 +130,53 @@ func (suite *CreateTestSuite) TestCreateFlag1() {
 	}
 }
 
+func (suite *CreateTestSuite) TestCreateLikeNotPermitted() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	targetStatus := &gtsmodel.Status{}
+	*targetStatus = *suite.testStatuses["local_account_1_status_1"]
+
+	// Only the author may like this status.
+	targetStatus.InteractionPolicy.CanLike = gtsmodel.InteractionPolicySelfOnly
+	if err := suite.db.UpdateStatus(context.Background(), targetStatus, "interaction_policy_can_like"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + requestingAccount.URI + `",
+  "id": "http://fossbros-anonymous.io/users/foss_satan/liked/01H3QDHPAK6Y8YJ8P9FNBZ4VAN",
+  "object": "` + targetStatus.URI + `",
+  "type": "Like"
+}`
+
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx := createTestContext(receivingAccount, requestingAccount)
+	if err := suite.federatingDB.Create(ctx, t); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The like should have been dropped
+	// without any further processing.
+	select {
+	case msg := <-suite.fromFederator:
+		suite.FailNow("unexpected message", "%+v", msg)
+	case <-time.After(time.Second):
+	}
+
+	_, err = suite.db.GetStatusFave(context.Background(), requestingAccount.ID, targetStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestCreateTestSuite(t *testing.T) {
 	suite.Run(t, &CreateTestSuite{})
 }
@@ -171,6 +171,48 @@ func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInbox() {
 	suite.Equal(sendingAccount.Username, requestingAccount.Username)
 }
 
+func (suite *FederatingProtocolTestSuite) TestPostInboxUnauthenticatedRecorded() {
+	activity := suite.testActivities["dm_for_zork"]
+
+	httpClient := testrig.NewMockHTTPClient(nil, "../../testrig/media")
+	tc := testrig.NewTestTransportController(&suite.state, httpClient)
+	federator := federation.NewFederator(&suite.state, testrig.NewTestFederatingDB(&suite.state), tc, suite.tc, testrig.NewTestMediaManager(&suite.state))
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/users/the_mighty_zork/inbox", nil)
+	request.Header.Set("Content-Type", "application/activity+json")
+	request.Header.Set("Signature", activity.SignatureHeader)
+
+	verifier, err := httpsig.NewVerifier(request)
+	suite.NoError(err)
+
+	// Set the verifier but not the signature, so
+	// authentication fails after we know the key ID.
+	ctx := context.WithValue(context.Background(), ap.ContextRequestingPublicKeyVerifier, verifier)
+
+	accepted, err := federator.FederatingActor().PostInboxScheme(ctx, httptest.NewRecorder(), request, "http")
+	suite.False(accepted)
+	suite.Error(err)
+
+	// The rejection is recorded against the key's domain.
+	entries := federator.ActivityLog().Entries("fossbros-anonymous.io")
+	if !suite.Len(entries, 1) {
+		suite.FailNow("expected 1 activity log entry")
+	}
+	suite.False(entries[0].Accepted)
+	suite.Empty(entries[0].ActorURI)
+	suite.NotEmpty(entries[0].Reason)
+
+	// A request with no signature at all can't be
+	// attributed to a domain, so it isn't recorded.
+	request = httptest.NewRequest(http.MethodPost, "http://localhost:8080/users/the_mighty_zork/inbox", nil)
+	request.Header.Set("Content-Type", "application/activity+json")
+
+	accepted, err = federator.FederatingActor().PostInboxScheme(context.Background(), httptest.NewRecorder(), request, "http")
+	suite.False(accepted)
+	suite.Error(err)
+	suite.Len(federator.ActivityLog().Entries(""), 1)
+}
+
 func (suite *FederatingProtocolTestSuite) TestAuthenticatePostGone() {
 	// the activity we're gonna use
 	activity := suite.testActivities["delete_https://somewhere.mysterious/users/rest_in_piss#main-key"]
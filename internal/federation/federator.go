@@ -40,6 +40,8 @@ type Federator interface {
 	FederatingDB() federatingdb.DB
 	// TransportController returns the underlying transport controller.
 	TransportController() transport.Controller
+	// ActivityLog returns the log of recently received activities.
+	ActivityLog() *ActivityLog
 
 	// AuthenticateFederatedRequest can be used to check the authenticity of incoming http-signed requests for federating resources.
 	// The given username will be used to create a transport for making outgoing requests. See the implementation for more detailed comments.
@@ -64,6 +66,7 @@ type federator struct {
 	transportController transport.Controller
 	mediaManager        *media.Manager
 	actor               pub.FederatingActor
+	activityLog         *ActivityLog
 	dereferencing.Dereferencer
 }
 
@@ -79,9 +82,10 @@ func NewFederator(state *state.State, federatingDB federatingdb.DB, transportCon
 		typeConverter:       typeConverter,
 		transportController: transportController,
 		mediaManager:        mediaManager,
+		activityLog:         &ActivityLog{},
 		Dereferencer:        dereferencer,
 	}
-	actor := newFederatingActor(f, f, federatingDB, clock)
+	actor := newFederatingActor(f, f, federatingDB, clock, f.activityLog)
 	f.actor = actor
 	return f
 }
@@ -97,3 +101,7 @@ func (f *federator) FederatingDB() federatingdb.DB {
 func (f *federator) TransportController() transport.Controller {
 	return f.transportController
 }
+
+func (f *federator) ActivityLog() *ActivityLog {
+	return f.activityLog
+}
@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package geoip provides country-level resolution
+// of client IP addresses, for sign-in activity
+// statistics.
+package geoip
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// Resolver resolves IP addresses to ISO 3166-1
+// country codes using a MaxMind country database.
+type Resolver struct {
+	reader *maxminddb.Reader
+}
+
+// NewResolver opens the MaxMind GeoLite2 / GeoIP2
+// country database at the given path for lookups.
+func NewResolver(path string) (*Resolver, error) {
+	if path == "" {
+		return nil, fmt.Errorf("NewResolver: no geoip database path set")
+	}
+
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("NewResolver: error opening geoip database %s: %w", path, err)
+	}
+
+	return &Resolver{reader: reader}, nil
+}
+
+// Country returns the ISO 3166-1 alpha-2 country code for the given IP address,
+// or an empty string if the address couldn't be found in the database.
+func (r *Resolver) Country(ip net.IP) (string, error) {
+	var record struct {
+		Country struct {
+			ISOCode string `maxminddb:"iso_code"`
+		} `maxminddb:"country"`
+	}
+
+	if err := r.reader.Lookup(ip, &record); err != nil {
+		return "", fmt.Errorf("Country: error looking up %s: %w", ip, err)
+	}
+
+	return record.Country.ISOCode, nil
+}
+
+// Close closes the underlying database.
+func (r *Resolver) Close() error {
+	return r.reader.Close()
+}
@@ -28,6 +28,7 @@ const (
 	// context keys.
 	_ ctxkey = iota
 	barebonesKey
+	cachedMediaOnlyKey
 	fastFailKey
 	pubKeyIDKey
 	requestIDKey
@@ -89,3 +90,17 @@ func Barebones(ctx context.Context) bool {
 func SetBarebones(ctx context.Context) context.Context {
 	return context.WithValue(ctx, barebonesKey, struct{}{})
 }
+
+// CachedMediaOnly returns whether the "cachedmediaonly" context key has been set.
+// This can be used to indicate to the database, for example, that a media attachment
+// is only wanted if it's currently cached, ie., it can actually be served.
+func CachedMediaOnly(ctx context.Context) bool {
+	_, ok := ctx.Value(cachedMediaOnlyKey).(struct{})
+	return ok
+}
+
+// SetCachedMediaOnly sets the "cachedmediaonly" context flag and returns this wrapped
+// context. See CachedMediaOnly() for further information on the "cachedmediaonly" flag.
+func SetCachedMediaOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cachedMediaOnlyKey, struct{}{})
+}
@@ -81,6 +81,7 @@ type Account struct {
 	HideCollections         *bool            `validate:"-" bun:",default:false"`                                                                                     // Hide this account's collections
 	SuspensionOrigin        string           `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                                // id of the database entry that caused this account to become suspended -- can be an account ID or a domain block ID
 	EnableRSS               *bool            `validate:"-" bun:",default:false"`                                                                                     // enable RSS feed subscription for this account's public posts at [URL]/feed
+	System                  *bool            `validate:"-" bun:",default:false"`                                                                                     // Is this a system account (eg., the instance actor) that must never be deleted?
 }
 
 // IsLocal returns whether account is a local user account.
@@ -39,4 +39,19 @@ type Instance struct {
 	ContactAccount         *Account     `validate:"-" bun:"rel:belongs-to"`                                                           // account corresponding to contactAccountID
 	Reputation             int64        `validate:"-" bun:",notnull,default:0"`                                                       // Reputation score of this instance
 	Version                string       `validate:"-" bun:",nullzero"`                                                                // Version of the software used on this instance
+	SoftwareName           string       `validate:"-" bun:",nullzero"`                                                                // Software name reported by this instance's nodeinfo
+	SoftwareVersion        string       `validate:"-" bun:",nullzero"`                                                                // Software version reported by this instance's nodeinfo
+	UserCount              int          `validate:"-" bun:",nullzero"`                                                                // Total user count reported by this instance's nodeinfo
+	NodeInfoFetchedAt      time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                                // When was nodeinfo last fetched for this instance, if ever?
+}
+
+// DomainStats is a summary of what this instance knows about
+// from one remote domain. It's not stored in the database, but
+// rather aggregated from accounts, statuses and media attachments.
+type DomainStats struct {
+	Domain       string    // Domain the stats are for
+	AccountCount int       // Number of known accounts from the domain
+	StatusCount  int       // Number of known statuses from accounts on the domain
+	MediaBytes   int64     // Total size in bytes of cached media from accounts on the domain
+	LastActivity time.Time // When the most recent known status from the domain was created
 }
@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// InstancePolicyType denotes which of this
+// instance's policy documents a policy is.
+type InstancePolicyType string
+
+// InstancePolicyType values.
+const (
+	InstancePolicyTypePrivacyPolicy InstancePolicyType = "privacy_policy"
+)
+
+// InstancePolicy represents one of this instance's policy
+// documents, eg., its privacy policy, as set by an admin.
+type InstancePolicy struct {
+	ID        string             `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time          `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time          `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Type      InstancePolicyType `validate:"oneof=privacy_policy" bun:",nullzero,notnull,unique"`                 // Which policy document this is.
+	Content   string             `validate:"required" bun:",nullzero,notnull"`                                    // Markdown content of the policy.
+}
@@ -37,11 +37,16 @@ type MediaAttachment struct {
 	ScheduledStatusID string           `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                        // To which scheduled status does this attachment belong
 	Blurhash          string           `validate:"required_if=Type Image,required_if=Type Gif,required_if=Type Video" bun:",nullzero"` // What is the generated blurhash of this attachment
 	Processing        ProcessingStatus `validate:"oneof=0 1 2 666" bun:",notnull,default:2"`                                           // What is the processing status of this attachment
+	ProcessingError   string           `validate:"-" bun:",nullzero"`                                                                  // Error encountered during the most recent failed processing of this attachment, if any
+	FetchFailures     int              `validate:"-" bun:",notnull,default:0"`                                                         // Number of consecutive failed attempts to fetch + process this remote attachment
+	FetchFailingSince time.Time        `validate:"-" bun:"type:timestamptz,nullzero"`                                                  // When the first of the current run of consecutive failed fetches happened, if any
 	File              File             `validate:"required" bun:",embed:file_,notnull,nullzero"`                                       // metadata for the whole file
 	Thumbnail         Thumbnail        `validate:"required" bun:",embed:thumbnail_,notnull,nullzero"`                                  // small image thumbnail derived from a larger image, video, or audio file.
 	Avatar            *bool            `validate:"-" bun:",nullzero,notnull,default:false"`                                            // Is this attachment being used as an avatar?
 	Header            *bool            `validate:"-" bun:",nullzero,notnull,default:false"`                                            // Is this attachment being used as a header?
 	Cached            *bool            `validate:"-" bun:",nullzero,notnull,default:false"`                                            // Is this attachment currently cached by our instance?
+	MetadataStripped  *bool            `validate:"-" bun:",nullzero,notnull,default:false"`                                            // Was EXIF and other metadata stripped from the file before it was stored?
+	InstanceAsset     *bool            `validate:"-" bun:",nullzero,notnull,default:false"`                                            // Is this attachment used by the instance itself (eg., for branding)? If so, it's never pruned.
 }
 
 // File refers to the metadata for the whole file
@@ -62,6 +67,19 @@ type Thumbnail struct {
 	RemoteURL   string    `validate:"required_without=URL,omitempty,url" bun:",nullzero"`                  // What is the remote URL of the thumbnail (empty for local media)
 }
 
+// MediaAttachmentVariant refers to an extra version of an attachment's file
+// generated from the original, eg., a re-encoded or resized copy. Variants
+// are stored under the attachment's own ID, and are removed along with it.
+type MediaAttachmentVariant struct {
+	ID           string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AttachmentID string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // ID of the attachment this is a variant of
+	Name         string    `validate:"required" bun:",nullzero,notnull"`                                    // Name of the variant, eg., "webp" or "720p"
+	Path         string    `validate:"required,file" bun:",nullzero,notnull,unique"`                        // Path of the file in storage.
+	ContentType  string    `validate:"required" bun:",nullzero,notnull"`                                    // MIME content type of the file.
+	FileSize     int       `validate:"required" bun:",notnull"`                                             // File size in bytes
+}
+
 // ProcessingStatus refers to how far along in the processing stage the attachment is.
 type ProcessingStatus int
 
@@ -117,3 +135,21 @@ type Focus struct {
 	X float32 `validate:"omitempty,max=1,min=-1"`
 	Y float32 `validate:"omitempty,max=1,min=-1"`
 }
+
+// DomainMediaUsage summarises how much remote media from one domain
+// is currently cached. It's not stored in the database, but rather
+// aggregated from the cached media attachments of the domain's accounts.
+type DomainMediaUsage struct {
+	Domain string // Domain the media originated from
+	Count  int    // Number of cached media attachments
+	Bytes  int64  // Total size of cached files and thumbnails in bytes
+}
+
+// MediaAttachmentContext wraps a media attachment together
+// with the status and account that own it. It's not stored in
+// the database, but rather assembled from the cached models.
+type MediaAttachmentContext struct {
+	Attachment *MediaAttachment // The attachment itself
+	Status     *Status          // Status the attachment belongs to, nil if unattached
+	Account    *Account         // Account the attachment belongs to
+}
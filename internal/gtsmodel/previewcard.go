@@ -0,0 +1,38 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package gtsmodel
+
+import "time"
+
+// PreviewCard represents a rich preview of a web page linked
+// from a status, generated from the page's OpenGraph tags.
+// Cards are keyed by URL, so that statuses linking to the
+// same page can share one card.
+type PreviewCard struct {
+	ID           string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	URL          string    `validate:"required,url" bun:",nullzero,notnull,unique"`                         // URL of the linked page.
+	Title        string    `validate:"-" bun:""`                                                            // og:title of the page.
+	Description  string    `validate:"-" bun:""`                                                            // og:description of the page.
+	Type         string    `validate:"-" bun:",nullzero"`                                                   // Type of card: link, photo, video or rich.
+	ProviderName string    `validate:"-" bun:""`                                                            // og:site_name of the page.
+	ProviderURL  string    `validate:"-" bun:""`                                                            // Scheme + host of the page.
+	Image        string    `validate:"-" bun:""`                                                            // og:image of the page.
+	Width        int       `validate:"-" bun:",nullzero"`                                                   // og:image:width of the page.
+	Height       int       `validate:"-" bun:",nullzero"`                                                   // og:image:height of the page.
+}
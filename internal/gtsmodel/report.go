@@ -25,6 +25,9 @@ import "time"
 // This can be either a report created locally (on this instance) about a user on this
 // or another instance, OR a report that was created remotely (on another instance)
 // about a user on this instance, and received via the federated (s2s) API.
+//
+// Local users can also report a hashtag, eg., one that's trending inappropriately.
+// These reports are never federated, and target the instance account.
 type Report struct {
 	ID                     string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
 	CreatedAt              time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
@@ -34,6 +37,8 @@ type Report struct {
 	Account                *Account  `validate:"-" bun:"-"`                                                           // account corresponding to AccountID
 	TargetAccountID        string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // which account is targeted by this report
 	TargetAccount          *Account  `validate:"-" bun:"-"`                                                           // account corresponding to TargetAccountID
+	TargetTagID            string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // which hashtag is targeted by this report, if any; TargetAccountID is then the instance account
+	TargetTag              *Tag      `validate:"-" bun:"-"`                                                           // tag corresponding to TargetTagID, if any
 	Comment                string    `validate:"-" bun:",nullzero"`                                                   // comment / explanation for this report, by the reporter
 	StatusIDs              []string  `validate:"dive,ulid" bun:"statuses,array"`                                      // database IDs of any statuses referenced by this report
 	Statuses               []*Status `validate:"-" bun:"-"`                                                           // statuses corresponding to StatusIDs
@@ -66,6 +66,8 @@ type Status struct {
 	Boostable                *bool              `validate:"-" bun:",notnull"`                                                                          // This status can be boosted/reblogged
 	Replyable                *bool              `validate:"-" bun:",notnull"`                                                                          // This status can be replied to
 	Likeable                 *bool              `validate:"-" bun:",notnull"`                                                                          // This status can be liked/faved
+
+	InteractionPolicy StatusInteractionPolicy `validate:"-" bun:"embed:interaction_policy_"` // Who may reply to, boost, or like this status
 }
 
 // GetID implements timeline.Timelineable{}.
@@ -250,6 +252,38 @@ func (s *Status) MentionsAccount(id string) bool {
 	return false
 }
 
+// InteractionPolicyValue describes which accounts
+// may perform a given interaction with a status.
+type InteractionPolicyValue string
+
+// InteractionPolicyValue values.
+const (
+	InteractionPolicyEveryone      InteractionPolicyValue = "everyone"       // anyone who can see the status
+	InteractionPolicyFollowersOnly InteractionPolicyValue = "followers_only" // only followers of the status author
+	InteractionPolicyMentionedOnly InteractionPolicyValue = "mentioned_only" // only accounts mentioned in the status
+	InteractionPolicySelfOnly      InteractionPolicyValue = "self_only"      // only the status author
+)
+
+// StatusInteractionPolicy describes who may interact with a status, and how.
+// The status author is always permitted. An empty value is equivalent to
+// InteractionPolicyEveryone, which is the default for all statuses.
+type StatusInteractionPolicy struct {
+	CanReplyTo InteractionPolicyValue `validate:"omitempty,oneof=everyone followers_only mentioned_only self_only" bun:",nullzero"` // Who may reply to the status
+	CanBoost   InteractionPolicyValue `validate:"omitempty,oneof=everyone followers_only mentioned_only self_only" bun:",nullzero"` // Who may boost the status
+	CanLike    InteractionPolicyValue `validate:"omitempty,oneof=everyone followers_only mentioned_only self_only" bun:",nullzero"` // Who may like/fave the status
+}
+
+// Normalize returns a copy of the policy with any
+// empty values set to InteractionPolicyEveryone.
+func (p StatusInteractionPolicy) Normalize() StatusInteractionPolicy {
+	for _, v := range []*InteractionPolicyValue{&p.CanReplyTo, &p.CanBoost, &p.CanLike} {
+		if *v == "" {
+			*v = InteractionPolicyEveryone
+		}
+	}
+	return p
+}
+
 // StatusToTag is an intermediate struct to facilitate the many2many relationship between a status and one or more tags.
 type StatusToTag struct {
 	StatusID string  `validate:"ulid,required" bun:"type:CHAR(26),unique:statustag,nullzero,notnull"`
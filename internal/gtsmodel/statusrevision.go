@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package gtsmodel
+
+import "time"
+
+// StatusRevision represents one earlier version of a status, stored
+// when the status is edited, so that its edit history can be shown.
+// The current version of a status lives in the status itself.
+type StatusRevision struct {
+	ID             string     `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                     // id of this item in the database
+	CreatedAt      time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`              // when was item created, ie., when was the status edited
+	StatusID       string     `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                               // ID of the status this is a revision of
+	RevisedAt      time.Time  `validate:"-" bun:"type:timestamptz,nullzero,notnull"`                                        // when this version of the status was made
+	Content        string     `validate:"-" bun:""`                                                                         // content of the status at this revision
+	ContentWarning string     `validate:"-" bun:",nullzero"`                                                                // cw string of the status at this revision
+	Sensitive      *bool      `validate:"-" bun:",nullzero,notnull,default:false"`                                          // was the status marked as sensitive at this revision?
+	Visibility     Visibility `validate:"oneof=public unlocked followers_only mutuals_only direct" bun:",nullzero,notnull"` // visibility of the status at this revision
+	AttachmentIDs  []string   `validate:"dive,ulid" bun:"attachments,array"`                                                // Database IDs of media attachments of the status at this revision
+	MentionIDs     []string   `validate:"dive,ulid" bun:"mentions,array"`                                                   // Database IDs of mentions in the status at this revision
+}
+
+// NewStatusRevision returns a revision recording
+// the current version of the given status.
+func NewStatusRevision(status *Status) *StatusRevision {
+	revisedAt := status.UpdatedAt
+	if revisedAt.IsZero() {
+		revisedAt = status.CreatedAt
+	}
+
+	return &StatusRevision{
+		StatusID:       status.ID,
+		RevisedAt:      revisedAt,
+		Content:        status.Content,
+		ContentWarning: status.ContentWarning,
+		Sensitive:      status.Sensitive,
+		Visibility:     status.Visibility,
+		AttachmentIDs:  status.AttachmentIDs,
+		MentionIDs:     status.MentionIDs,
+	}
+}
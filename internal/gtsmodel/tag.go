@@ -29,5 +29,25 @@ type Tag struct {
 	FirstSeenFromAccountID string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // Which account ID is the first one we saw using this tag?
 	Useable                *bool     `validate:"-" bun:",nullzero,notnull,default:true"`                              // can our instance users use this tag?
 	Listable               *bool     `validate:"-" bun:",nullzero,notnull,default:true"`                              // can our instance users look up this tag?
+	Trendable              *bool     `validate:"-" bun:",nullzero,notnull,default:true"`                              // can this tag appear in trends?
+	RequiresReview         *bool     `validate:"-" bun:",nullzero,notnull,default:false"`                             // does this tag need reviewing by an admin?
 	LastStatusAt           time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was this tag last used?
 }
+
+// TagHistory summarises how much a hashtag was used on
+// one day. It's not stored in the database, but rather
+// aggregated from statuses using the tag.
+type TagHistory struct {
+	Day      time.Time // Start of the day (UTC)
+	Uses     int       // Number of statuses using the tag that day
+	Accounts int       // Number of distinct accounts using the tag that day
+}
+
+// TagUsage summarises how an account has used a hashtag.
+// It's not stored in the database, but rather aggregated
+// from the account's statuses.
+type TagUsage struct {
+	Tag          *Tag      // The used tag
+	StatusCount  int       // Number of the account's statuses using the tag
+	LastStatusAt time.Time // When the account's most recent status using the tag was created
+}
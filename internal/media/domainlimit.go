@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// DomainLimitEnforcement describes the uncaching done (or, for a
+// dry run, that would be done) for one domain whose cached remote
+// media exceeded the per-domain cache limit.
+type DomainLimitEnforcement struct {
+	Domain        string // Domain that was over the limit
+	Bytes         int64  // Total size of the domain's cached media before uncaching
+	Uncached      int    // Number of media attachments uncached
+	UncachedBytes int64  // Total size of the uncached media
+}
+
+// EnforceDomainCacheLimit uncaches remote media for every domain whose
+// cached media (including avatars and headers) takes up more than limit
+// bytes, starting with the least recently updated media, until the
+// domain is back under the limit. If limit <= 0, nothing is done.
+//
+// If dry is true, then nothing will be changed, only what *would* be
+// uncached is returned to the caller.
+func (m *Manager) EnforceDomainCacheLimit(ctx context.Context, limit int64, dry bool) ([]*DomainLimitEnforcement, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	usages, err := m.state.DB.GetCachedMediaUsageByDomain(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("EnforceDomainCacheLimit: db error getting media usage by domain: %w", err)
+	}
+
+	var enforcements []*DomainLimitEnforcement
+
+	for _, usage := range usages {
+		if usage.Bytes <= limit {
+			// Usages are ordered by size,
+			// so we can stop at the first
+			// one that's within the limit.
+			break
+		}
+
+		enforcement, err := m.enforceDomainCacheLimit(ctx, usage, limit, dry)
+		if enforcement != nil {
+			enforcements = append(enforcements, enforcement)
+		}
+		if err != nil {
+			return enforcements, fmt.Errorf("EnforceDomainCacheLimit: error enforcing limit for domain %s: %w", usage.Domain, err)
+		}
+	}
+
+	return enforcements, nil
+}
+
+func (m *Manager) enforceDomainCacheLimit(ctx context.Context, usage *gtsmodel.DomainMediaUsage, limit int64, dry bool) (*DomainLimitEnforcement, error) {
+	enforcement := &DomainLimitEnforcement{
+		Domain: usage.Domain,
+		Bytes:  usage.Bytes,
+	}
+
+	over := usage.Bytes - limit
+
+	// A dry run doesn't uncache anything, so we'd just select
+	// the same page again; select all attachments at once instead.
+	selectLimit := selectPruneLimit
+	if dry {
+		selectLimit = 0
+	}
+
+	for enforcement.UncachedBytes < over {
+		if err := ctx.Err(); err != nil {
+			return enforcement, err
+		}
+
+		attachments, err := m.state.DB.GetCachedAttachmentsForDomain(ctx, usage.Domain, selectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return enforcement, err
+		}
+
+		if len(attachments) == 0 {
+			// Nothing left to uncache.
+			break
+		}
+
+		for _, attachment := range attachments {
+			if enforcement.UncachedBytes >= over {
+				break
+			}
+
+			if !dry {
+				if err := m.uncacheAttachment(ctx, attachment); err != nil {
+					return enforcement, err
+				}
+			}
+
+			enforcement.Uncached++
+			enforcement.UncachedBytes += int64(attachment.File.FileSize + attachment.Thumbnail.FileSize)
+		}
+
+		if dry {
+			// Already selected everything.
+			break
+		}
+	}
+
+	if dry {
+		log.Infof(ctx,
+			"DRY RUN: domain %s has %d bytes of cached media, over the limit of %d bytes; %d media (%d bytes) are eligible to be uncached",
+			enforcement.Domain, enforcement.Bytes, limit, enforcement.Uncached, enforcement.UncachedBytes,
+		)
+	} else {
+		log.Infof(ctx,
+			"domain %s had %d bytes of cached media, over the limit of %d bytes; uncached %d media (%d bytes)",
+			enforcement.Domain, enforcement.Bytes, limit, enforcement.Uncached, enforcement.UncachedBytes,
+		)
+	}
+
+	return enforcement, nil
+}
@@ -0,0 +1,135 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+)
+
+type DomainLimitTestSuite struct {
+	MediaStandardTestSuite
+}
+
+// largestDomain returns the cached media
+// usage of the domain using the most storage.
+func (suite *DomainLimitTestSuite) largestDomain() *gtsmodel.DomainMediaUsage {
+	usages, err := suite.db.GetCachedMediaUsageByDomain(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if len(usages) == 0 {
+		suite.FailNow("expected some cached remote media")
+	}
+	return usages[0]
+}
+
+func enforcementFor(enforcements []*media.DomainLimitEnforcement, domain string) *media.DomainLimitEnforcement {
+	for _, enforcement := range enforcements {
+		if enforcement.Domain == domain {
+			return enforcement
+		}
+	}
+	return nil
+}
+
+func (suite *DomainLimitTestSuite) TestEnforceDomainCacheLimitNoLimit() {
+	enforcements, err := suite.manager.EnforceDomainCacheLimit(context.Background(), 0, false)
+	suite.NoError(err)
+	suite.Empty(enforcements)
+}
+
+func (suite *DomainLimitTestSuite) TestEnforceDomainCacheLimitUnderLimit() {
+	usage := suite.largestDomain()
+
+	enforcements, err := suite.manager.EnforceDomainCacheLimit(context.Background(), usage.Bytes, false)
+	suite.NoError(err)
+	suite.Empty(enforcements)
+}
+
+func (suite *DomainLimitTestSuite) TestEnforceDomainCacheLimitDry() {
+	ctx := context.Background()
+	usage := suite.largestDomain()
+	limit := usage.Bytes - 1
+
+	enforcements, err := suite.manager.EnforceDomainCacheLimit(ctx, limit, true)
+	suite.NoError(err)
+
+	enforcement := enforcementFor(enforcements, usage.Domain)
+	suite.NotNil(enforcement)
+	suite.Equal(usage.Bytes, enforcement.Bytes)
+	suite.NotZero(enforcement.Uncached)
+	suite.GreaterOrEqual(enforcement.UncachedBytes, int64(1))
+
+	// Nothing should have changed.
+	suite.Equal(usage, suite.largestDomain())
+}
+
+func (suite *DomainLimitTestSuite) TestEnforceDomainCacheLimit() {
+	ctx := context.Background()
+	usage := suite.largestDomain()
+	limit := usage.Bytes - 1
+
+	// Take the least recently updated attachment,
+	// which should be the first to be uncached.
+	attachments, err := suite.db.GetCachedAttachmentsForDomain(ctx, usage.Domain, 1)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	lru := attachments[0]
+
+	enforcements, err := suite.manager.EnforceDomainCacheLimit(ctx, limit, false)
+	suite.NoError(err)
+
+	enforcement := enforcementFor(enforcements, usage.Domain)
+	suite.NotNil(enforcement)
+	suite.Equal(usage.Bytes, enforcement.Bytes)
+	suite.Equal(1, enforcement.Uncached)
+	suite.EqualValues(lru.File.FileSize+lru.Thumbnail.FileSize, enforcement.UncachedBytes)
+
+	// The domain should now be within the limit.
+	usages, err := suite.db.GetCachedMediaUsageByDomain(ctx)
+	suite.NoError(err)
+	for _, u := range usages {
+		if u.Domain == usage.Domain {
+			suite.LessOrEqual(u.Bytes, limit)
+		}
+	}
+
+	// The attachment should be uncached + its files removed.
+	uncached, err := suite.db.GetAttachmentByID(ctx, lru.ID)
+	suite.NoError(err)
+	suite.False(*uncached.Cached)
+
+	hasKey, err := suite.storage.Has(ctx, lru.File.Path)
+	suite.NoError(err)
+	suite.False(hasKey)
+
+	// Enforcing again should do nothing.
+	enforcements, err = suite.manager.EnforceDomainCacheLimit(ctx, limit, false)
+	suite.NoError(err)
+	suite.Nil(enforcementFor(enforcements, usage.Domain))
+}
+
+func TestDomainLimitTestSuite(t *testing.T) {
+	suite.Run(t, &DomainLimitTestSuite{})
+}
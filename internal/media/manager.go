@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"codeberg.org/gruf/go-iotools"
@@ -36,6 +37,10 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
+// SupportedMIMETypes are the content types of media attachments that
+// can be processed, and are advertised as such to clients. Keep this in
+// sync with the types handled by ProcessingMedia when storing and
+// decoding new media.
 var SupportedMIMETypes = []string{
 	mimeImageJpeg,
 	mimeImageGif,
@@ -44,6 +49,8 @@ var SupportedMIMETypes = []string{
 	mimeVideoMp4,
 }
 
+// SupportedEmojiMIMETypes are the content types
+// of emoji images that can be processed.
 var SupportedEmojiMIMETypes = []string{
 	mimeImageGif,
 	mimeImagePng,
@@ -51,6 +58,17 @@ var SupportedEmojiMIMETypes = []string{
 
 type Manager struct {
 	state *state.State
+
+	// inFlight holds the path IDs of media
+	// and emojis currently being processed,
+	// which may have files in storage before
+	// they have a row in the database.
+	inFlight sync.Map
+
+	// dereferenceMedia fetches remote
+	// media when warming the cache after
+	// a prune; see SetDereferenceMedia.
+	dereferenceMedia DereferenceMedia
 }
 
 // NewManager returns a media manager with the given db and underlying storage.
@@ -172,6 +190,13 @@ func (m *Manager) PreProcessMediaRecache(ctx context.Context, data DataFunc, att
 		return nil, err
 	}
 
+	// Mark the attachment as received again, so that
+	// it's clear processing hasn't finished until it has.
+	attachment.Processing = gtsmodel.ProcessingStatusReceived
+	if err := m.state.DB.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+		return nil, err
+	}
+
 	processingMedia := &ProcessingMedia{
 		media:   attachment,
 		dataFn:  data,
@@ -385,4 +410,17 @@ func scheduleCleanupJobs(m *Manager) {
 		}
 		log.Infof(nil, "finished pruning all in %s", time.Since(now))
 	}).EveryAt(midnight, day))
+
+	// Schedule enforcing the per-domain cache limit to execute every hour.
+	m.state.Workers.Scheduler.Schedule(sched.NewJob(func(now time.Time) {
+		limit := int64(config.GetMediaPerDomainCacheLimit())
+		if limit <= 0 {
+			// No limit set.
+			return
+		}
+
+		if _, err := m.EnforceDomainCacheLimit(doneCtx, limit, false); err != nil {
+			log.Errorf(nil, "error enforcing per-domain cache limit: %v", err)
+		}
+	}).Every(time.Hour))
 }
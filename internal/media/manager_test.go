@@ -20,6 +20,7 @@ package media_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -29,6 +30,7 @@ import (
 
 	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -304,6 +306,52 @@ func (suite *ManagerTestSuite) TestEmojiProcessBlockingNoFileSizeGiven() {
 	suite.Equal(processedStaticBytesExpected, processedStaticBytes)
 }
 
+func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingPathTemplate() {
+	ctx := context.Background()
+
+	// store new media by date, then by account
+	config.SetStorageMediaPathTemplate("{yyyy}/{mm}/{dd}/{accountID}/{mediaType}/{mediaSize}/{mediaID}.{extension}")
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	processingMedia, err := suite.manager.ProcessMedia(ctx, data, accountID, nil)
+	suite.NoError(err)
+
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	date := attachment.CreatedAt.UTC().Format("2006/01/02")
+	suite.Equal(date+"/"+accountID+"/image/original/"+attachment.ID+".jpg", attachment.File.Path)
+	suite.Equal(date+"/"+accountID+"/image/small/"+attachment.ID+".jpg", attachment.Thumbnail.Path)
+
+	// the files should be in storage at the templated paths
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedFullBytes)
+
+	processedThumbnailBytes, err := suite.storage.Get(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.NotEmpty(processedThumbnailBytes)
+
+	// and the path should be recorded in the database
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(attachment.File.Path, dbAttachment.File.Path)
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessBlocking() {
 	ctx := context.Background()
 
@@ -345,6 +393,7 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlocking() {
 	suite.Equal("image/jpeg", attachment.Thumbnail.ContentType)
 	suite.Equal(269739, attachment.File.FileSize)
 	suite.Equal("LiBzRk#6V[WF_NvzV@WY_3rqV@a$", attachment.Blurhash)
+	suite.True(*attachment.MetadataStripped)
 
 	// now make sure the attachment is in the database
 	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachmentID)
@@ -376,6 +425,47 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlocking() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingNoStripMetadata() {
+	ctx := context.Background()
+	config.SetMediaStripMetadata(false)
+
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processingMedia, err := suite.manager.ProcessMedia(ctx, data, accountID, nil)
+	suite.NoError(err)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// metadata stripping was disabled
+	suite.False(*attachment.MetadataStripped)
+
+	// the stored attachment should record this too
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.False(*dbAttachment.MetadataStripped)
+
+	// the bytes in storage should be exactly the original bytes
+	processedFullBytes, err := suite.storage.Get(ctx, attachment.File.Path)
+	suite.NoError(err)
+
+	originalBytes, err := os.ReadFile("./test/test-jpeg.jpg")
+	suite.NoError(err)
+	suite.Equal(originalBytes, processedFullBytes)
+}
+
 func (suite *ManagerTestSuite) TestSlothVineProcessBlocking() {
 	ctx := context.Background()
 
@@ -673,6 +763,9 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingNoContentLengthGiven
 	suite.Equal(269739, attachment.File.FileSize)
 	suite.Equal("LiBzRk#6V[WF_NvzV@WY_3rqV@a$", attachment.Blurhash)
 
+	// metadata can't be stripped without knowing the file size
+	suite.False(*attachment.MetadataStripped)
+
 	// now make sure the attachment is in the database
 	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachmentID)
 	suite.NoError(err)
@@ -1248,6 +1341,77 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingWithDiskStorage() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestRecacheProcessingStates() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	var (
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	// data function that waits to be
+	// released before returning data.
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		close(started)
+		<-release
+
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+	}
+
+	processingMedia, err := suite.manager.PreProcessMediaRecache(ctx, data, testAttachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Not started yet, so just received.
+	state, err := suite.db.GetAttachmentProcessingState(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.ProcessingStatusReceived, state)
+
+	loaded := make(chan error)
+	go func() {
+		_, err := processingMedia.LoadAttachment(ctx)
+		loaded <- err
+	}()
+
+	// Now in progress.
+	<-started
+	state, err = suite.db.GetAttachmentProcessingState(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.ProcessingStatusProcessing, state)
+
+	// Let it finish.
+	close(release)
+	suite.NoError(<-loaded)
+
+	state, err = suite.db.GetAttachmentProcessingState(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.ProcessingStatusProcessed, state)
+
+	// Now recache again, but fail this time.
+	failing := func(_ context.Context) (io.ReadCloser, int64, error) {
+		return nil, 0, errors.New("404 Not Found")
+	}
+
+	processingMedia, err = suite.manager.PreProcessMediaRecache(ctx, failing, testAttachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = processingMedia.LoadAttachment(ctx)
+	suite.Error(err)
+
+	state, err = suite.db.GetAttachmentProcessingState(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.ProcessingStatusError, state)
+}
+
 func TestManagerTestSuite(t *testing.T) {
 	suite.Run(t, &ManagerTestSuite{})
 }
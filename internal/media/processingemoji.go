@@ -108,6 +108,15 @@ func (p *ProcessingEmoji) load(ctx context.Context) (*gtsmodel.Emoji, bool, erro
 			p.err = err
 		}()
 
+		// Mark this emoji as in flight until we're done,
+		// so that it isn't mistaken for an orphaned file.
+		pathID := p.emoji.ID
+		if p.refresh {
+			pathID = p.newPathID
+		}
+		p.mgr.inFlight.Store(pathID, struct{}{})
+		defer p.mgr.inFlight.Delete(pathID)
+
 		// Attempt to store media and calculate
 		// full-size media attachment details.
 		if err = p.store(ctx); err != nil {
@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"image/jpeg"
 	"io"
+	"regexp"
+	"strings"
 	"time"
 
 	"codeberg.org/gruf/go-errors/v2"
@@ -30,6 +32,7 @@ import (
 	"github.com/disintegration/imaging"
 	"github.com/h2non/filetype"
 	terminator "github.com/superseriousbusiness/exif-terminator"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
@@ -106,8 +109,22 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			// Store final values.
 			p.done = true
 			p.err = err
+
+			if err != nil && p.media.RemoteURL != "" {
+				// Remote media failed permanently, record
+				// the error so that it can be retried later.
+				p.recordError(ctx, err)
+			}
 		}()
 
+		// Mark this media as in flight until we're done,
+		// so that it isn't mistaken for an orphaned file.
+		p.mgr.inFlight.Store(p.media.ID, struct{}{})
+		defer p.mgr.inFlight.Delete(p.media.ID)
+
+		// Let anyone querying know we're on it.
+		p.setProcessing(ctx, gtsmodel.ProcessingStatusProcessing)
+
 		// Attempt to store media and calculate
 		// full-size media attachment details.
 		if err = p.store(ctx); err != nil {
@@ -120,6 +137,11 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 			return err
 		}
 
+		// Clear any error from previous attempts.
+		p.media.ProcessingError = ""
+		p.media.FetchFailures = 0
+		p.media.FetchFailingSince = time.Time{}
+
 		if p.recache {
 			// Existing attachment we're recaching, so only update.
 			err = p.mgr.state.DB.UpdateAttachment(ctx, p.media)
@@ -138,6 +160,53 @@ func (p *ProcessingMedia) load(ctx context.Context) (*gtsmodel.MediaAttachment,
 	return p.media, done, nil
 }
 
+// setProcessing sets the processing state of the attachment. If the attachment
+// is already in the database (ie., we're recaching it), the new state is stored
+// straight away, so that it can be queried while processing is ongoing.
+func (p *ProcessingMedia) setProcessing(ctx context.Context, state gtsmodel.ProcessingStatus) {
+	p.media.Processing = state
+
+	if !p.recache {
+		// Not in the database yet, the state will
+		// be stored along with everything else
+		// once processing is finished (or errors).
+		return
+	}
+
+	if err := p.mgr.state.DB.UpdateAttachment(ctx, p.media, "processing"); err != nil {
+		log.Errorf(ctx, "error updating processing state of media %s: %v", p.media.ID, err)
+	}
+}
+
+// recordError marks the attachment as having failed processing with the given error,
+// storing it in the database so that it can later be enumerated and retried by an admin.
+func (p *ProcessingMedia) recordError(ctx context.Context, err error) {
+	p.media.Processing = gtsmodel.ProcessingStatusError
+	p.media.ProcessingError = err.Error()
+	p.media.FetchFailures++
+	if p.media.FetchFailingSince.IsZero() {
+		p.media.FetchFailingSince = time.Now()
+	}
+	p.media.Cached = func() *bool {
+		ok := false
+		return &ok
+	}()
+
+	var dbErr error
+
+	if p.recache {
+		// Existing attachment we're recaching, so only update error columns.
+		dbErr = p.mgr.state.DB.UpdateAttachment(ctx, p.media, "processing", "processing_error", "fetch_failures", "fetch_failing_since", "cached")
+	} else {
+		// First time seeing this attachment, insert it.
+		dbErr = p.mgr.state.DB.PutAttachment(ctx, p.media)
+	}
+
+	if dbErr != nil {
+		log.Errorf(ctx, "error recording processing error for media %s: %v", p.media.ID, dbErr)
+	}
+}
+
 // store calls the data function attached to p if it hasn't been called yet,
 // and updates the underlying attachment fields as necessary. It will then stream
 // bytes from p's reader directly into storage so that it can be retrieved later.
@@ -174,6 +243,9 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// Recombine header bytes with remaining stream
 	r := io.MultiReader(bytes.NewReader(hdrBuf), rc)
 
+	// Whether metadata was stripped from the stream.
+	var stripped bool
+
 	switch info.Extension {
 	case "mp4":
 		p.media.Type = gtsmodel.FileTypeVideo
@@ -183,12 +255,15 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 
 	case "jpg", "jpeg", "png", "webp":
 		p.media.Type = gtsmodel.FileTypeImage
-		if sz > 0 {
+		if sz > 0 && config.GetMediaStripMetadata() {
 			// A file size was provided so we can clean exif data from image.
+			// The orientation tag is kept, so that the image (and thumbnails
+			// generated from it) are still displayed the right way up.
 			r, err = terminator.Terminate(r, int(sz), info.Extension)
 			if err != nil {
 				return fmt.Errorf("error cleaning exif data: %w", err)
 			}
+			stripped = true
 		}
 
 	default:
@@ -196,14 +271,7 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	}
 
 	// Calculate attachment file path.
-	p.media.File.Path = fmt.Sprintf(
-		"%s/%s/%s/%s.%s",
-		p.media.AccountID,
-		TypeAttachment,
-		SizeOriginal,
-		p.media.ID,
-		info.Extension,
-	)
+	p.media.File.Path = attachmentPath(p.media, SizeOriginal, info.Extension)
 
 	// This shouldn't already exist, but we do a check as it's worth logging.
 	if have, _ := p.mgr.state.Storage.Has(ctx, p.media.File.Path); have {
@@ -233,6 +301,7 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		info.Extension,
 	)
 	p.media.File.ContentType = info.MIME.Value
+	p.media.MetadataStripped = &stripped
 	p.media.Cached = func() *bool {
 		ok := true
 		return &ok
@@ -296,13 +365,7 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 	p.media.FileMeta.Original.Aspect = fullImg.AspectRatio()
 
 	// Calculate attachment thumbnail file path
-	p.media.Thumbnail.Path = fmt.Sprintf(
-		"%s/%s/%s/%s.jpg",
-		p.media.AccountID,
-		TypeAttachment,
-		SizeSmall,
-		p.media.ID,
-	)
+	p.media.Thumbnail.Path = attachmentPath(p.media, SizeSmall, "jpg")
 
 	// Get smaller thumbnail image
 	thumbImg := fullImg.Thumbnail()
@@ -368,3 +431,40 @@ func (p *ProcessingMedia) finish(ctx context.Context) error {
 
 	return nil
 }
+
+// attachmentPath renders the configured storage media path
+// template for the given attachment, size and file extension.
+//
+// Only newly stored files use this; files already in storage
+// are always found using the path recorded in the database.
+func attachmentPath(attachment *gtsmodel.MediaAttachment, size Size, ext string) string {
+	createdAt := attachment.CreatedAt.UTC()
+	return strings.NewReplacer(
+		"{accountID}", attachment.AccountID,
+		"{mediaType}", strings.ToLower(string(attachment.Type)),
+		"{mediaSize}", string(size),
+		"{mediaID}", attachment.ID,
+		"{extension}", ext,
+		"{yyyy}", createdAt.Format("2006"),
+		"{mm}", createdAt.Format("01"),
+		"{dd}", createdAt.Format("02"),
+	).Replace(config.GetStorageMediaPathTemplate())
+}
+
+// mediaPathTemplateRegex converts the given storage media path
+// template into a regular expression matching the paths that
+// attachmentPath renders from it, capturing the media ID
+// in a subexpression named "mediaID".
+func mediaPathTemplateRegex(tmpl string) *regexp.Regexp {
+	const id = `[0123456789ABCDEFGHJKMNPQRSTVWXYZ]{26}`
+	return regexp.MustCompile("^/?" + strings.NewReplacer(
+		regexp.QuoteMeta("{accountID}"), id,
+		regexp.QuoteMeta("{mediaType}"), `[a-z]+`,
+		regexp.QuoteMeta("{mediaSize}"), `(?:`+string(SizeOriginal)+`|`+string(SizeSmall)+`)`,
+		regexp.QuoteMeta("{mediaID}"), `(?P<mediaID>`+id+`)`,
+		regexp.QuoteMeta("{extension}"), `[a-z0-9]+`,
+		regexp.QuoteMeta("{yyyy}"), `[0-9]{4}`,
+		regexp.QuoteMeta("{mm}"), `[0-9]{2}`,
+		regexp.QuoteMeta("{dd}"), `[0-9]{2}`,
+	).Replace(regexp.QuoteMeta(tmpl)) + "$")
+}
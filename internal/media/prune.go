@@ -21,13 +21,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"time"
 
+	"codeberg.org/gruf/go-kv"
 	"codeberg.org/gruf/go-store/v2/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
@@ -35,11 +40,14 @@ import (
 const (
 	selectPruneLimit          = 50 // Amount of media entries to select at a time from the db when pruning.
 	unusedLocalAttachmentDays = 3  // Number of days to keep local media in storage if not attached to a status.
+	deadRemoteFetchFailures   = 5  // Number of consecutive failed fetches after which uncached remote media may be considered dead.
+	deadRemoteFailingDays     = 7  // Number of days over which those fetches must have failed before uncached remote media is considered dead.
 )
 
 // PruneAll runs all of the below pruning/uncacheing functions, and then cleans up any resulting
 // empty directories from the storage driver. It can be called as a shortcut for calling the below
-// pruning functions one by one.
+// pruning functions one by one. If media-warm-after-prune is enabled, recently interacted with
+// remote media is then fetched again with WarmRemote, using the manager's DereferenceMedia function.
 //
 // If blocking is true, then any errors encountered during the prune will be combined + returned to
 // the caller. If blocking is false, the prune is run in the background and errors are just logged
@@ -71,6 +79,13 @@ func (m *Manager) PruneAll(ctx context.Context, mediaCacheRemoteDays int, blocki
 			log.Infof(ctx, "uncached %d remote media older than %d day(s)", pruned, mediaCacheRemoteDays)
 		}
 
+		pruned, err = m.PruneDeadRemote(innerCtx, dry)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("error pruning dead remote media: (%s)", err))
+		} else {
+			log.Infof(ctx, "pruned %d dead remote media", pruned)
+		}
+
 		pruned, err = m.PruneOrphaned(innerCtx, dry)
 		if err != nil {
 			errs = append(errs, fmt.Sprintf("error pruning orphaned media: (%s)", err))
@@ -84,6 +99,15 @@ func (m *Manager) PruneAll(ctx context.Context, mediaCacheRemoteDays int, blocki
 			log.Info(ctx, "cleaned storage")
 		}
 
+		if config.GetMediaWarmAfterPrune() && m.dereferenceMedia != nil {
+			warmed, bytes, err := m.WarmRemote(innerCtx, mediaCacheRemoteDays, int64(config.GetMediaWarmBudget()), m.dereferenceMedia)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("error warming remote media: (%s)", err))
+			} else {
+				log.Infof(ctx, "warmed %d remote media (%d bytes)", warmed, bytes)
+			}
+		}
+
 		return errs.Combine()
 	}
 
@@ -118,7 +142,7 @@ func (m *Manager) PruneUnusedRemote(ctx context.Context, dry bool) (int, error)
 	// check inside the loop a million times.
 	var f func(ctx context.Context, attachment *gtsmodel.MediaAttachment) error
 	if !dry {
-		f = m.deleteAttachment
+		f = m.DeleteAttachment
 	} else {
 		f = func(_ context.Context, _ *gtsmodel.MediaAttachment) error {
 			return nil // noop
@@ -168,28 +192,46 @@ func (m *Manager) PruneUnusedRemote(ctx context.Context, dry bool) (int, error)
 // If dry is true, then nothing will be changed, only the amount that *would* be removed
 // is returned to the caller.
 func (m *Manager) PruneOrphaned(ctx context.Context, dry bool) (int, error) {
+	pruned, _, err := m.GarbageCollectStorage(ctx, dry)
+	return pruned, err
+}
+
+// GarbageCollectStorage walks the storage driver and removes files
+// that do not have a corresponding entry in the database. Files
+// belonging to media or emojis that are currently being processed
+// are skipped, since they won't have a database entry until
+// processing has finished.
+//
+// The returned values are the amount of objects and the total
+// bytes that were (or, if dry is true, would be) reclaimed. Note
+// that the byte count only includes files for which the storage
+// driver is able to report a size.
+func (m *Manager) GarbageCollectStorage(ctx context.Context, dry bool) (int, int64, error) {
 	// Emojis are stored under the instance account, so we
 	// need the ID of the instance account for the next part.
 	instanceAccount, err := m.state.DB.GetInstanceAccount(ctx, "")
 	if err != nil {
-		return 0, fmt.Errorf("PruneOrphaned: error getting instance account: %w", err)
+		return 0, 0, fmt.Errorf("GarbageCollectStorage: error getting instance account: %w", err)
 	}
 
 	instanceAccountID := instanceAccount.ID
 
-	var orphanedKeys []string
+	var (
+		orphanedKeys  []string
+		orphanedSizes []int64
+	)
+
+	// Attachments stored using a custom storage media path template
+	// have keys in that layout, so also match keys against it.
+	templatePath := mediaPathTemplateRegex(config.GetStorageMediaPathTemplate())
 
 	// Keys in storage will look like the following format:
 	// `[ACCOUNT_ID]/[MEDIA_TYPE]/[MEDIA_SIZE]/[MEDIA_ID].[EXTENSION]`
-	// We can filter out keys we're not interested in by matching through a regex.
-	if err := m.state.Storage.WalkKeys(ctx, func(ctx context.Context, key string) error {
-		if !regexes.FilePath.MatchString(key) {
-			// This is not our expected key format.
-			return nil
-		}
-
+	// or the configured template. We can filter out keys we're not
+	// interested in by matching through a regex.
+	if err := m.state.Storage.WalkEntries(ctx, func(ctx context.Context, key string, size int64) error {
 		// Check whether this storage entry is orphaned.
-		orphaned, err := m.orphaned(ctx, key, instanceAccountID)
+		orphaned, err := m.orphaned(ctx, key, instanceAccountID, templatePath)
 		if err != nil {
 			return fmt.Errorf("error checking orphaned status: %w", err)
 		}
@@ -197,37 +239,76 @@ func (m *Manager) PruneOrphaned(ctx context.Context, dry bool) (int, error) {
 		if orphaned {
 			// Add this orphaned entry to list of keys.
 			orphanedKeys = append(orphanedKeys, key)
+			orphanedSizes = append(orphanedSizes, size)
 		}
 
 		return nil
 	}); err != nil {
-		return 0, fmt.Errorf("PruneOrphaned: error walking keys: %w", err)
+		return 0, 0, fmt.Errorf("GarbageCollectStorage: error walking keys: %w", err)
 	}
 
-	totalPruned := len(orphanedKeys)
-
 	if dry {
 		// Dry run: don't remove anything.
-		return totalPruned, nil
+		var totalBytes int64
+		for _, size := range orphanedSizes {
+			if size > 0 {
+				totalBytes += size
+			}
+		}
+		return len(orphanedKeys), totalBytes, nil
 	}
 
 	// This is not a drill! We have to delete stuff!
-	return m.removeFiles(ctx, orphanedKeys...)
+	var (
+		totalPruned int
+		totalBytes  int64
+		errs        = make(gtserror.MultiError, 0)
+	)
+
+	for i, key := range orphanedKeys {
+		if err := m.state.Storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			errs = append(errs, "storage error removing "+key+": "+err.Error())
+			continue
+		}
+
+		totalPruned++
+		if size := orphanedSizes[i]; size > 0 {
+			totalBytes += size
+		}
+	}
+
+	return totalPruned, totalBytes, errs.Combine()
 }
 
-func (m *Manager) orphaned(ctx context.Context, key string, instanceAccountID string) (bool, error) {
-	pathParts := regexes.FilePath.FindStringSubmatch(key)
-	if len(pathParts) != 6 {
+func (m *Manager) orphaned(ctx context.Context, key string, instanceAccountID string, templatePath *regexp.Regexp) (bool, error) {
+	var (
+		mediaType string
+		mediaID   string
+		orphaned  = false
+	)
+
+	if pathParts := regexes.FilePath.FindStringSubmatch(key); len(pathParts) == 6 {
+		// Default layout, used for emojis and
+		// for attachments stored before any
+		// custom template was configured.
+		mediaType = pathParts[2]
+		mediaID = pathParts[4]
+	} else if pathParts := templatePath.FindStringSubmatch(key); pathParts != nil {
+		// Attachment stored using the
+		// configured path template.
+		mediaType = string(TypeAttachment)
+		mediaID = pathParts[templatePath.SubexpIndex("mediaID")]
+	} else {
 		// This doesn't match our expectations so
 		// it wasn't created by gts; ignore it.
 		return false, nil
 	}
 
-	var (
-		mediaType = pathParts[2]
-		mediaID   = pathParts[4]
-		orphaned  = false
-	)
+	if _, ok := m.inFlight.Load(mediaID); ok {
+		// This media is still being processed,
+		// so it won't have a database entry yet.
+		return false, nil
+	}
 
 	// Look for keys in storage that we don't have an attachment for.
 	switch Type(mediaType) {
@@ -274,17 +355,155 @@ func (m *Manager) UncacheRemote(ctx context.Context, olderThanDays int, dry bool
 		return m.state.DB.CountRemoteOlderThan(ctx, olderThan)
 	}
 
+	var (
+		totalPruned     int
+		totalCandidates int
+		totalBytes      int64
+
+		// Keyset of the last selected attachment,
+		// used to select the next page of candidates.
+		sinceCreatedAt time.Time
+		sinceID        string
+	)
+
+	// Report the size of the candidate set versus what
+	// was actually uncached, to help operators tune pruning.
+	start := time.Now()
+	defer func() {
+		log.WithContext(ctx).
+			WithFields(kv.Fields{
+				{"olderThanDays", olderThanDays},
+				{"candidates", totalCandidates},
+				{"uncached", totalPruned},
+				{"bytesReclaimed", totalBytes},
+				{"duration", time.Since(start)},
+			}...).
+			Info("remote media uncache pass finished")
+	}()
+
+	// Select and uncache one page of candidates at a time, so that
+	// no single select has to run over the whole candidate set.
+	for {
+		attachments, err := m.state.DB.GetRemoteOlderThanAscending(ctx, olderThan, sinceCreatedAt, sinceID, selectPruneLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return totalPruned, err
+		}
+
+		if len(attachments) == 0 {
+			// Nothing left.
+			break
+		}
+
+		totalCandidates += len(attachments)
+
+		for _, attachment := range attachments {
+			if err := m.uncacheAttachment(ctx, attachment); err != nil {
+				return totalPruned, err
+			}
+			totalPruned++
+			totalBytes += int64(attachment.File.FileSize + attachment.Thumbnail.FileSize)
+		}
+
+		last := attachments[len(attachments)-1]
+		sinceCreatedAt, sinceID = last.CreatedAt, last.ID
+	}
+
+	return totalPruned, nil
+}
+
+// UncacheRemoteBytes uncaches remote media attachments older than olderThanDays, oldest
+// first, until at least targetBytes of file and thumbnail storage has been reclaimed, or
+// there's nothing left to uncache. If olderThanDays < 0 or targetBytes <= 0, nothing is done.
+//
+// Candidates are selected and uncached one page at a time, so no more than
+// a page of attachments is ever held in memory.
+//
+// If 'dry' is true, then only a dry run will be performed: nothing will actually be changed.
+//
+// The returned int and int64 are the amount of media, and the amount of bytes,
+// that were/would be uncached by this function.
+func (m *Manager) UncacheRemoteBytes(ctx context.Context, olderThanDays int, targetBytes int64, dry bool) (int, int64, error) {
+	if olderThanDays < 0 || targetBytes <= 0 {
+		return 0, 0, nil
+	}
+
+	var (
+		olderThan   = time.Now().Add(-time.Hour * 24 * time.Duration(olderThanDays))
+		totalPruned int
+		totalBytes  int64
+
+		// Keyset of the last selected attachment,
+		// used to select the next page of candidates.
+		sinceCreatedAt time.Time
+		sinceID        string
+	)
+
+	for totalBytes < targetBytes {
+		attachments, err := m.state.DB.GetRemoteOlderThanAscending(ctx, olderThan, sinceCreatedAt, sinceID, selectPruneLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return totalPruned, totalBytes, err
+		}
+
+		if len(attachments) == 0 {
+			// Nothing left.
+			break
+		}
+
+		for _, attachment := range attachments {
+			if totalBytes >= targetBytes {
+				break
+			}
+
+			if !dry {
+				if err := m.uncacheAttachment(ctx, attachment); err != nil {
+					return totalPruned, totalBytes, err
+				}
+			}
+
+			totalPruned++
+			totalBytes += int64(attachment.File.FileSize + attachment.Thumbnail.FileSize)
+		}
+
+		last := attachments[len(attachments)-1]
+		sinceCreatedAt, sinceID = last.CreatedAt, last.ID
+	}
+
+	return totalPruned, totalBytes, nil
+}
+
+// PruneDeadRemote fully deletes uncached remote media attachments which have failed
+// to be fetched again several times in a row, over the course of several days, so
+// that a brief outage isn't mistaken for a dead instance. Such media most likely
+// belongs to an instance which is permanently gone, so it can never be served again.
+//
+// This does nothing unless media-prune-dead-instances is enabled.
+//
+// If 'dry' is true, then only a dry run will be performed: nothing will actually be changed.
+//
+// The returned int is the amount of media that was/would be pruned by this function.
+func (m *Manager) PruneDeadRemote(ctx context.Context, dry bool) (int, error) {
+	if !config.GetMediaPruneDeadInstances() {
+		return 0, nil
+	}
+
+	failingSince := time.Now().Add(-time.Hour * 24 * time.Duration(deadRemoteFailingDays))
+
+	if dry {
+		// Dry run, just count eligible entries without removing them.
+		return m.state.DB.CountRemoteDead(ctx, deadRemoteFetchFailures, failingSince)
+	}
+
 	var (
 		totalPruned int
 		attachments []*gtsmodel.MediaAttachment
 		err         error
 	)
 
-	for attachments, err = m.state.DB.GetRemoteOlderThan(ctx, olderThan, selectPruneLimit); err == nil && len(attachments) != 0; attachments, err = m.state.DB.GetRemoteOlderThan(ctx, olderThan, selectPruneLimit) {
-		olderThan = attachments[len(attachments)-1].CreatedAt // use the created time of the last attachment in the slice as the next 'olderThan' value
-
+	// Pruned attachments drop out of the
+	// selection, so keep taking the first page.
+	for attachments, err = m.state.DB.GetRemoteDead(ctx, deadRemoteFetchFailures, failingSince, selectPruneLimit); err == nil && len(attachments) != 0; attachments, err = m.state.DB.GetRemoteDead(ctx, deadRemoteFetchFailures, failingSince, selectPruneLimit) {
 		for _, attachment := range attachments {
-			if err := m.uncacheAttachment(ctx, attachment); err != nil {
+			if err := m.DeleteAttachment(ctx, attachment); err != nil {
 				return totalPruned, err
 			}
 			totalPruned++
@@ -322,7 +541,7 @@ func (m *Manager) PruneUnusedLocal(ctx context.Context, dry bool) (int, error) {
 		olderThan = attachments[len(attachments)-1].CreatedAt // use the created time of the last attachment in the slice as the next 'olderThan' value
 
 		for _, attachment := range attachments {
-			if err := m.deleteAttachment(ctx, attachment); err != nil {
+			if err := m.DeleteAttachment(ctx, attachment); err != nil {
 				return totalPruned, err
 			}
 			totalPruned++
@@ -337,21 +556,120 @@ func (m *Manager) PruneUnusedLocal(ctx context.Context, dry bool) (int, error) {
 	return totalPruned, nil
 }
 
+// DeleteAttachment removes any stored files for the given attachment,
+// including generated variants, and then deletes the attachment from
+// the database.
+//
+// If the attachment belonged to a status, it is also removed from
+// that status, so the status doesn't point at missing media. If the
+// status is local, an update of it is enqueued so that other
+// instances learn of the change too.
+func (m *Manager) DeleteAttachment(ctx context.Context, attachment *gtsmodel.MediaAttachment) error {
+	keys, err := m.attachmentKeys(ctx, attachment)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.removeFiles(ctx, keys...); err != nil {
+		return err
+	}
+
+	// Delete attachment completely.
+	if err := m.state.DB.DeleteAttachment(ctx, attachment.ID); err != nil {
+		return err
+	}
+
+	if attachment.StatusID == "" {
+		// Not attached
+		// to a status.
+		return nil
+	}
+
+	status, err := m.state.DB.GetStatusByID(ctx, attachment.StatusID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Status already gone.
+			return nil
+		}
+		return gtserror.Newf("db error getting status %s: %w", attachment.StatusID, err)
+	}
+
+	attachmentIDs := make([]string, 0, len(status.AttachmentIDs))
+	for _, attachmentID := range status.AttachmentIDs {
+		if attachmentID != attachment.ID {
+			attachmentIDs = append(attachmentIDs, attachmentID)
+		}
+	}
+
+	if len(attachmentIDs) == len(status.AttachmentIDs) {
+		// Status didn't
+		// list the media.
+		return nil
+	}
+
+	attachments := make([]*gtsmodel.MediaAttachment, 0, len(status.Attachments))
+	for _, a := range status.Attachments {
+		if a.ID != attachment.ID {
+			attachments = append(attachments, a)
+		}
+	}
+
+	status.AttachmentIDs = attachmentIDs
+	status.Attachments = attachments
+	if err := m.state.DB.UpdateStatus(ctx, status, "attachment_ids"); err != nil {
+		return gtserror.Newf("db error updating status %s: %w", status.ID, err)
+	}
+
+	if !*status.Local {
+		// Not our status,
+		// nothing to send.
+		return nil
+	}
+
+	m.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       status,
+		OriginAccount:  status.Account,
+	})
+
+	return nil
+}
+
 /*
 	Handy little helpers
 */
 
-func (m *Manager) deleteAttachment(ctx context.Context, attachment *gtsmodel.MediaAttachment) error {
-	if _, err := m.removeFiles(ctx, attachment.File.Path, attachment.Thumbnail.Path); err != nil {
-		return err
+// attachmentKeys returns the storage keys of all files stored
+// for the given attachment, including any generated variants.
+func (m *Manager) attachmentKeys(ctx context.Context, attachment *gtsmodel.MediaAttachment) ([]string, error) {
+	variants, err := m.state.DB.GetAttachmentVariants(ctx, attachment.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting variants of attachment %s: %w", attachment.ID, err)
 	}
 
-	// Delete attachment completely.
-	return m.state.DB.DeleteAttachment(ctx, attachment.ID)
+	keys := make([]string, 0, 2+len(variants))
+	keys = append(keys, attachment.File.Path, attachment.Thumbnail.Path)
+	for _, variant := range variants {
+		keys = append(keys, variant.Path)
+	}
+
+	return keys, nil
 }
 
 func (m *Manager) uncacheAttachment(ctx context.Context, attachment *gtsmodel.MediaAttachment) error {
-	if _, err := m.removeFiles(ctx, attachment.File.Path, attachment.Thumbnail.Path); err != nil {
+	keys, err := m.attachmentKeys(ctx, attachment)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.removeFiles(ctx, keys...); err != nil {
+		return err
+	}
+
+	// Variants are generated from the cached original, so
+	// they'll be generated again if it's ever recached.
+	if err := m.state.DB.DeleteAttachmentVariants(ctx, attachment.ID); err != nil {
 		return err
 	}
 
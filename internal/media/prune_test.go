@@ -23,11 +23,16 @@ import (
 	"io"
 	"os"
 	"testing"
+	"time"
 
 	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type PruneTestSuite struct {
@@ -80,6 +85,154 @@ func (suite *PruneTestSuite) TestPruneOrphanedMoist() {
 	suite.False(hasKey)
 }
 
+func (suite *PruneTestSuite) TestGarbageCollectStorageBytes() {
+	ctx := context.Background()
+
+	// add a big orphan panda to store
+	b, err := os.ReadFile("./test/big-panda.gif")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pandaPath := "01GJQJ1YD9QCHCE12GG0EYHVNW/attachment/original/01GJQJ2AYM1VKSRW96YVAJ3NK3.gif"
+	if _, err := suite.storage.Put(ctx, pandaPath, b); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// dry run should report the panda + its size
+	objects, reclaimed, err := suite.manager.GarbageCollectStorage(ctx, true)
+	suite.NoError(err)
+	suite.Equal(1, objects)
+	suite.EqualValues(len(b), reclaimed)
+
+	// real run should report the same
+	objects, reclaimed, err = suite.manager.GarbageCollectStorage(ctx, false)
+	suite.NoError(err)
+	suite.Equal(1, objects)
+	suite.EqualValues(len(b), reclaimed)
+
+	// nothing left to collect
+	objects, reclaimed, err = suite.manager.GarbageCollectStorage(ctx, false)
+	suite.NoError(err)
+	suite.Zero(objects)
+	suite.Zero(reclaimed)
+}
+
+func (suite *PruneTestSuite) TestGarbageCollectStoragePathTemplate() {
+	ctx := context.Background()
+
+	// store new media by date, then by account
+	config.SetStorageMediaPathTemplate("{yyyy}/{mm}/{dd}/{accountID}/{mediaSize}/{mediaID}.{extension}")
+
+	b, err := os.ReadFile("./test/big-panda.gif")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// process some media using the template
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		return io.NopCloser(bytes.NewReader(b)), int64(len(b)), nil
+	}
+
+	processingMedia, err := suite.manager.ProcessMedia(ctx, data, "01F8MH17FWEB39HZJ76B6VXSKF", nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// add an orphan panda to store at a templated path
+	pandaPath := "2023/06/29/01GJQJ1YD9QCHCE12GG0EYHVNW/original/01GJQJ2AYM1VKSRW96YVAJ3NK3.gif"
+	if _, err := suite.storage.Put(ctx, pandaPath, b); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// only the orphan should be collected
+	objects, _, err := suite.manager.GarbageCollectStorage(ctx, false)
+	suite.NoError(err)
+	suite.Equal(1, objects)
+
+	hasKey, err := suite.storage.Has(ctx, pandaPath)
+	suite.NoError(err)
+	suite.False(hasKey)
+
+	// the processed media should still be in storage
+	hasKey, err = suite.storage.Has(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.True(hasKey)
+
+	hasKey, err = suite.storage.Has(ctx, attachment.Thumbnail.Path)
+	suite.NoError(err)
+	suite.True(hasKey)
+}
+
+// blockingCloser signals when it is
+// closed, and then waits to be released.
+type blockingCloser struct {
+	io.Reader
+	closed  chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingCloser) Close() error {
+	close(b.closed)
+	<-b.release
+	return nil
+}
+
+func (suite *PruneTestSuite) TestGarbageCollectStorageSkipsInFlight() {
+	ctx := context.Background()
+
+	b, err := os.ReadFile("./test/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The data reader is only closed once the original
+	// file has been written to storage, so blocking in
+	// Close leaves us with a file that has no db entry.
+	rc := &blockingCloser{
+		Reader:  bytes.NewReader(b),
+		closed:  make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	data := func(_ context.Context) (io.ReadCloser, int64, error) {
+		return rc, int64(len(b)), nil
+	}
+
+	processingMedia, err := suite.manager.ProcessMedia(ctx, data, "01FS1X72SK9ZPW0J1QQ68BD264", nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := processingMedia.LoadAttachment(ctx)
+		errs <- err
+	}()
+
+	<-rc.closed
+
+	// The in-flight media should not be collected.
+	objects, _, err := suite.manager.GarbageCollectStorage(ctx, false)
+	suite.NoError(err)
+	suite.Zero(objects)
+
+	close(rc.release)
+	suite.NoError(<-errs)
+
+	// Once processed, the media should still be retrievable.
+	attachment, err := suite.db.GetAttachmentByID(ctx, processingMedia.AttachmentID())
+	suite.NoError(err)
+
+	hasKey, err := suite.storage.Has(ctx, attachment.File.Path)
+	suite.NoError(err)
+	suite.True(hasKey)
+}
+
 func (suite *PruneTestSuite) TestPruneUnusedLocal() {
 	testAttachment := suite.testAttachments["local_account_1_unattached_1"]
 	suite.True(*testAttachment.Cached)
@@ -104,6 +257,53 @@ func (suite *PruneTestSuite) TestPruneUnusedLocalDry() {
 	suite.NoError(err)
 }
 
+func (suite *PruneTestSuite) TestPruneUnusedLocalSkipsInstanceAsset() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	// Old and unattached, but used for instance branding.
+	if err := suite.db.SetAttachmentInstanceAsset(ctx, testAttachment.ID, true); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	totalPruned, err := suite.manager.PruneUnusedLocal(ctx, false)
+	suite.NoError(err)
+	suite.Zero(totalPruned)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.True(*dbAttachment.InstanceAsset)
+	suite.True(*dbAttachment.Cached)
+}
+
+func (suite *PruneTestSuite) TestPruneUnusedLocalSkipsScheduled() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	// Old and not attached to a status yet,
+	// but waiting on a status scheduled for later.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	dbAttachment.ScheduledStatusID = "01H3P5Z9Q0TVJH9XJ4ZJ1ZJ6X2"
+	if err := suite.db.UpdateAttachment(ctx, dbAttachment, "scheduled_status_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	totalPruned, err := suite.manager.PruneUnusedLocal(ctx, false)
+	suite.NoError(err)
+	suite.Zero(totalPruned)
+
+	dbAttachment, err = suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.True(*dbAttachment.Cached)
+
+	hasKey, err := suite.storage.Has(ctx, dbAttachment.File.Path)
+	suite.NoError(err)
+	suite.True(hasKey)
+}
+
 func (suite *PruneTestSuite) TestPruneRemoteTwice() {
 	totalPruned, err := suite.manager.PruneUnusedLocal(context.Background(), false)
 	suite.NoError(err)
@@ -132,6 +332,99 @@ func (suite *PruneTestSuite) TestPruneOneNonExistent() {
 	suite.Equal(1, totalPruned)
 }
 
+func (suite *PruneTestSuite) TestDeleteAttachmentWithVariants() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	// Store two variants of the attachment
+	// alongside its original and thumbnail.
+	variants := []*gtsmodel.MediaAttachmentVariant{
+		{
+			ID:           "01H3MR2B6HVRZTS1K2SJ5D6K3Q",
+			AttachmentID: testAttachment.ID,
+			Name:         "webp",
+			Path:         testAttachment.AccountID + "/attachment/webp/" + testAttachment.ID + ".webp",
+			ContentType:  "image/webp",
+			FileSize:     3,
+		},
+		{
+			ID:           "01H3MR2B6HZ4N6RV0RXW7VGN8F",
+			AttachmentID: testAttachment.ID,
+			Name:         "720p",
+			Path:         testAttachment.AccountID + "/attachment/720p/" + testAttachment.ID + ".jpeg",
+			ContentType:  "image/jpeg",
+			FileSize:     3,
+		},
+	}
+	for _, variant := range variants {
+		if _, err := suite.storage.Put(ctx, variant.Path, []byte("hi!")); err != nil {
+			suite.FailNow(err.Error())
+		}
+		if err := suite.db.PutAttachmentVariant(ctx, variant); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	err := suite.manager.DeleteAttachment(ctx, testAttachment)
+	suite.NoError(err)
+
+	// Original, thumbnail, and
+	// both variants should be gone.
+	for _, key := range []string{
+		testAttachment.File.Path,
+		testAttachment.Thumbnail.Path,
+		variants[0].Path,
+		variants[1].Path,
+	} {
+		hasKey, err := suite.storage.Has(ctx, key)
+		suite.NoError(err)
+		suite.False(hasKey, key)
+	}
+
+	// As should their records.
+	_, err = suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	dbVariants, err := suite.db.GetAttachmentVariants(ctx, testAttachment.ID)
+	suite.NoError(err)
+	suite.Empty(dbVariants)
+}
+
+func (suite *PruneTestSuite) TestDeleteAttachmentUpdatesStatus() {
+	ctx := context.Background()
+	testStatus := testrig.NewTestStatuses()["local_account_1_status_4"]
+	suite.Len(testStatus.AttachmentIDs, 2)
+
+	var msgs []messages.FromClientAPI
+	suite.state.Workers.EnqueueClientAPI = func(_ context.Context, m ...messages.FromClientAPI) {
+		msgs = append(msgs, m...)
+	}
+
+	// Delete the first of the status' two attachments.
+	deleted := suite.testAttachments["local_account_1_status_4_attachment_1"]
+	suite.Equal(testStatus.AttachmentIDs[0], deleted.ID)
+	if err := suite.manager.DeleteAttachment(ctx, deleted); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The status should now only list the remaining attachment.
+	status, err := suite.db.GetStatusByID(ctx, testStatus.ID)
+	suite.NoError(err)
+	suite.Equal([]string{testStatus.AttachmentIDs[1]}, status.AttachmentIDs)
+	suite.Len(status.Attachments, 1)
+	suite.Equal(testStatus.AttachmentIDs[1], status.Attachments[0].ID)
+
+	// And an update of the status should have been sent.
+	suite.Len(msgs, 1)
+	suite.Equal(ap.ObjectNote, msgs[0].APObjectType)
+	suite.Equal(ap.ActivityUpdate, msgs[0].APActivityType)
+	suite.Equal(testStatus.AccountID, msgs[0].OriginAccount.ID)
+
+	updated := msgs[0].GTSModel.(*gtsmodel.Status)
+	suite.Equal(testStatus.ID, updated.ID)
+	suite.Equal([]string{testStatus.AttachmentIDs[1]}, updated.AttachmentIDs)
+}
+
 func (suite *PruneTestSuite) TestPruneUnusedRemote() {
 	ctx := context.Background()
 
@@ -248,6 +541,60 @@ func (suite *PruneTestSuite) TestUncacheRemote() {
 	suite.False(*uncachedAttachment.Cached)
 }
 
+func (suite *PruneTestSuite) TestUncacheRemoteRemovesVariants() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	variant := &gtsmodel.MediaAttachmentVariant{
+		ID:           "01H3MR2B6HVRZTS1K2SJ5D6K3Q",
+		AttachmentID: testStatusAttachment.ID,
+		Name:         "webp",
+		Path:         testStatusAttachment.AccountID + "/attachment/webp/" + testStatusAttachment.ID + ".webp",
+		ContentType:  "image/webp",
+		FileSize:     3,
+	}
+	if _, err := suite.storage.Put(ctx, variant.Path, []byte("hi!")); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := suite.db.PutAttachmentVariant(ctx, variant); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.manager.UncacheRemote(ctx, 1, false)
+	suite.NoError(err)
+
+	hasKey, err := suite.storage.Has(ctx, variant.Path)
+	suite.NoError(err)
+	suite.False(hasKey)
+
+	dbVariants, err := suite.db.GetAttachmentVariants(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.Empty(dbVariants)
+}
+
+func (suite *PruneTestSuite) TestUncacheRemoteSkipsInstanceAsset() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	testHeader := suite.testAttachments["remote_account_3_header"]
+
+	if err := suite.db.SetAttachmentInstanceAsset(ctx, testStatusAttachment.ID, true); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only the header should be uncached.
+	totalUncached, err := suite.manager.UncacheRemote(ctx, 1, false)
+	suite.NoError(err)
+	suite.Equal(1, totalUncached)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.True(*dbAttachment.Cached)
+
+	dbAttachment, err = suite.db.GetAttachmentByID(ctx, testHeader.ID)
+	suite.NoError(err)
+	suite.False(*dbAttachment.Cached)
+}
+
 func (suite *PruneTestSuite) TestUncacheRemoteDry() {
 	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
 	suite.True(*testStatusAttachment.Cached)
@@ -268,6 +615,46 @@ func (suite *PruneTestSuite) TestUncacheRemoteDry() {
 	suite.True(*uncachedAttachment.Cached)
 }
 
+func (suite *PruneTestSuite) TestUncacheRemoteBytes() {
+	ctx := context.Background()
+
+	// Oldest to newest.
+	candidates, err := suite.db.GetRemoteOlderThanAscending(ctx, time.Now(), time.Time{}, "", 20)
+	suite.NoError(err)
+	suite.Len(candidates, 2)
+	oldest, newest := candidates[0], candidates[1]
+
+	// Only the oldest attachment should be uncached to reach a 1 byte target.
+	totalUncached, totalBytes, err := suite.manager.UncacheRemoteBytes(ctx, 1, 1, false)
+	suite.NoError(err)
+	suite.Equal(1, totalUncached)
+	suite.Equal(int64(oldest.File.FileSize+oldest.Thumbnail.FileSize), totalBytes)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, oldest.ID)
+	suite.NoError(err)
+	suite.False(*dbAttachment.Cached)
+
+	dbAttachment, err = suite.db.GetAttachmentByID(ctx, newest.ID)
+	suite.NoError(err)
+	suite.True(*dbAttachment.Cached)
+}
+
+func (suite *PruneTestSuite) TestUncacheRemoteBytesTooNew() {
+	ctx := context.Background()
+
+	// Nothing is old enough to be uncached, however much we ask for.
+	totalUncached, totalBytes, err := suite.manager.UncacheRemoteBytes(ctx, 100000, 1<<40, false)
+	suite.NoError(err)
+	suite.Zero(totalUncached)
+	suite.Zero(totalBytes)
+
+	candidates, err := suite.db.GetRemoteOlderThanAscending(ctx, time.Now(), time.Time{}, "", 20)
+	suite.NoError(err)
+	for _, candidate := range candidates {
+		suite.True(*candidate.Cached)
+	}
+}
+
 func (suite *PruneTestSuite) TestUncacheRemoteTwice() {
 	totalUncached, err := suite.manager.UncacheRemote(context.Background(), 1, false)
 	suite.NoError(err)
@@ -352,6 +739,100 @@ func (suite *PruneTestSuite) TestUncacheOneNonExistent() {
 	suite.Equal(2, totalUncached)
 }
 
+// markFailing sets the given attachment as uncached after
+// failing to be fetched again the given amount of times,
+// since the given time.
+func (suite *PruneTestSuite) markFailing(attachmentID string, failures int, since time.Time) {
+	ctx := context.Background()
+
+	attachment, err := suite.db.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachment.Cached = testrig.FalseBool()
+	attachment.FetchFailures = failures
+	attachment.FetchFailingSince = since
+	if err := suite.db.UpdateAttachment(ctx, attachment, "cached", "fetch_failures", "fetch_failing_since"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+// markDead sets the given attachment as uncached after
+// repeatedly failing to be fetched again for weeks.
+func (suite *PruneTestSuite) markDead(attachmentID string) {
+	suite.markFailing(attachmentID, 5, time.Now().Add(-14*24*time.Hour))
+}
+
+func (suite *PruneTestSuite) TestPruneDeadRemote() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	suite.markDead(testStatusAttachment.ID)
+
+	config.SetMediaPruneDeadInstances(true)
+	defer config.SetMediaPruneDeadInstances(false)
+
+	totalPruned, err := suite.manager.PruneDeadRemote(ctx, true)
+	suite.NoError(err)
+	suite.Equal(1, totalPruned)
+
+	totalPruned, err = suite.manager.PruneDeadRemote(ctx, false)
+	suite.NoError(err)
+	suite.Equal(1, totalPruned)
+
+	_, err = suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *PruneTestSuite) TestPruneDeadRemoteDisabled() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	suite.markDead(testStatusAttachment.ID)
+
+	// Off by default, so dead media is just left uncached.
+	totalPruned, err := suite.manager.PruneDeadRemote(ctx, false)
+	suite.NoError(err)
+	suite.Zero(totalPruned)
+
+	_, err = suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+}
+
+func (suite *PruneTestSuite) TestPruneDeadRemoteSkipsFewFailures() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	// Only failed once, so the remote
+	// may just be temporarily down.
+	suite.markFailing(testStatusAttachment.ID, 1, time.Now().Add(-14*24*time.Hour))
+
+	config.SetMediaPruneDeadInstances(true)
+	defer config.SetMediaPruneDeadInstances(false)
+
+	totalPruned, err := suite.manager.PruneDeadRemote(ctx, false)
+	suite.NoError(err)
+	suite.Zero(totalPruned)
+}
+
+func (suite *PruneTestSuite) TestPruneDeadRemoteSkipsRecentFailures() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	// Failed plenty of times, but only in the last
+	// hour, so the remote may just be having an outage.
+	suite.markFailing(testStatusAttachment.ID, 10, time.Now().Add(-time.Hour))
+
+	config.SetMediaPruneDeadInstances(true)
+	defer config.SetMediaPruneDeadInstances(false)
+
+	totalPruned, err := suite.manager.PruneDeadRemote(ctx, false)
+	suite.NoError(err)
+	suite.Zero(totalPruned)
+
+	_, err = suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+}
+
 func TestPruneOrphanedTestSuite(t *testing.T) {
 	suite.Run(t, &PruneTestSuite{})
 }
@@ -24,7 +24,9 @@ import (
 	"io"
 	"net/url"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -38,6 +40,10 @@ type DereferenceMedia func(ctx context.Context, iri *url.URL) (io.ReadCloser, in
 // If not, the manager will refetch and reprocess full size and static images for the emoji.
 //
 // The provided DereferenceMedia function will be used when it's necessary to refetch something this way.
+//
+// Each emoji refetch is limited to the configured media-refetch-emoji-timeout. If the given context is
+// cancelled or its deadline passes, the refetch is aborted and the number of emojis refetched so far is
+// returned alongside the context error.
 func (m *Manager) RefetchEmojis(ctx context.Context, domain string, dereferenceMedia DereferenceMedia) (int, error) {
 	// normalize domain
 	if domain == "" {
@@ -91,6 +97,11 @@ func (m *Manager) RefetchEmojis(ctx context.Context, domain string, dereferenceM
 
 	var totalRefetched int
 	for _, emojiID := range refetchIDs {
+		if err := ctx.Err(); err != nil {
+			// We've run out of time (or been cancelled), bail.
+			return totalRefetched, fmt.Errorf("refetch aborted after %d of %d emoji(s): %w", totalRefetched, toRefetchCount, err)
+		}
+
 		emoji, err := m.state.DB.GetEmojiByID(ctx, emojiID)
 		if err != nil {
 			// this shouldn't happen--since we know we have the emoji--so return if it does
@@ -125,7 +136,11 @@ func (m *Manager) RefetchEmojis(ctx context.Context, domain string, dereferenceM
 			continue
 		}
 
-		if _, err := processingEmoji.LoadEmoji(ctx); err != nil {
+		if err := m.loadRefetchedEmoji(ctx, processingEmoji); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				// The whole refetch ran out of time, not just this emoji.
+				return totalRefetched, fmt.Errorf("refetch aborted after %d of %d emoji(s): %w", totalRefetched, toRefetchCount, ctxErr)
+			}
 			log.Errorf(ctx, "emoji %s could not be refreshed because of an error during loading: %s", shortcodeDomain, err)
 			continue
 		}
@@ -137,6 +152,21 @@ func (m *Manager) RefetchEmojis(ctx context.Context, domain string, dereferenceM
 	return totalRefetched, nil
 }
 
+// loadRefetchedEmoji loads the given processing emoji within the configured
+// per-emoji refetch timeout. Unlike LoadEmoji, an emoji which times out is
+// not requeued for asynchronous processing, as that would let a slow remote
+// keep a worker busy long after the refetch itself has given up.
+func (m *Manager) loadRefetchedEmoji(ctx context.Context, processingEmoji *ProcessingEmoji) error {
+	if timeout := config.GetMediaRefetchEmojiTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	_, _, err := processingEmoji.load(ctx)
+	return err
+}
+
 func (m *Manager) emojiRequiresRefetch(ctx context.Context, emoji *gtsmodel.Emoji) (bool, error) {
 	if has, err := m.state.Storage.Has(ctx, emoji.ImagePath); err != nil {
 		return false, err
@@ -152,3 +182,82 @@ func (m *Manager) emojiRequiresRefetch(ctx context.Context, emoji *gtsmodel.Emoj
 
 	return false, nil
 }
+
+// RefetchErrored iterates through up to limit remote media attachments which previously failed
+// to be dereferenced or processed, and attempts to recache each of them using the provided
+// DereferenceMedia function. Attachments which are successfully recached will be reattached
+// to their owning status, if they were dropped from it when originally failing.
+//
+// Returns the number of attachments which were successfully recached.
+func (m *Manager) RefetchErrored(ctx context.Context, limit int, dereferenceMedia DereferenceMedia) (int, error) {
+	attachments, err := m.state.DB.GetAttachmentsInErrorState(ctx, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, fmt.Errorf("error fetching errored attachments from database: %w", err)
+	}
+
+	// bail early if we've got nothing to do
+	if len(attachments) == 0 {
+		log.Debug(ctx, "no remote media attachments in error state")
+		return 0, nil
+	}
+	log.Debugf(ctx, "%d remote media attachment(s) in error state, refetching them now...", len(attachments))
+
+	var totalRefetched int
+	for _, attachment := range attachments {
+		remoteIRI, err := url.Parse(attachment.RemoteURL)
+		if err != nil {
+			log.Errorf(ctx, "media %s could not be refetched because its RemoteURL (%s) is not a valid uri: %s", attachment.ID, attachment.RemoteURL, err)
+			continue
+		}
+
+		dataFunc := func(ctx context.Context) (io.ReadCloser, int64, error) {
+			return dereferenceMedia(ctx, remoteIRI)
+		}
+
+		processingMedia, err := m.PreProcessMediaRecache(ctx, dataFunc, attachment.ID)
+		if err != nil {
+			log.Errorf(ctx, "media %s could not be refetched because of an error during processing: %s", attachment.ID, err)
+			continue
+		}
+
+		if _, err := processingMedia.LoadAttachment(ctx); err != nil {
+			log.Errorf(ctx, "media %s could not be refetched because of an error during loading: %s", attachment.ID, err)
+			continue
+		}
+
+		if attachment.StatusID != "" {
+			if err := m.reattachToStatus(ctx, attachment.ID, attachment.StatusID); err != nil {
+				log.Errorf(ctx, "media %s could not be reattached to status %s: %s", attachment.ID, attachment.StatusID, err)
+			}
+		}
+
+		log.Tracef(ctx, "refetched media %s successfully from remote", attachment.ID)
+		totalRefetched++
+	}
+
+	return totalRefetched, nil
+}
+
+// reattachToStatus ensures that the attachment with given ID is
+// included in the attachment IDs of the status with given ID.
+func (m *Manager) reattachToStatus(ctx context.Context, attachmentID string, statusID string) error {
+	status, err := m.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), statusID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// status was deleted,
+			// nothing to do here.
+			return nil
+		}
+		return err
+	}
+
+	for _, id := range status.AttachmentIDs {
+		if id == attachmentID {
+			// already attached.
+			return nil
+		}
+	}
+
+	status.AttachmentIDs = append(status.AttachmentIDs, attachmentID)
+	return m.state.DB.UpdateStatus(ctx, status, "attachments")
+}
@@ -19,9 +19,13 @@ package media_test
 
 import (
 	"context"
+	"io"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
 type RefetchTestSuite struct {
@@ -79,6 +83,40 @@ func (suite *RefetchTestSuite) TestRefetchEmojisLocal() {
 	suite.Equal(0, refetched) // shouldn't refetch anything because local
 }
 
+func (suite *RefetchTestSuite) TestRefetchEmojisEmojiTimeout() {
+	ctx := context.Background()
+	config.SetMediaRefetchEmojiTimeout(50 * time.Millisecond)
+
+	if err := suite.storage.Delete(ctx, suite.testEmojis["yell"].ImagePath); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// hangs until the refetch gives up on it
+	refetched, err := suite.manager.RefetchEmojis(ctx, "", hangingDereferenceMedia)
+	suite.NoError(err)
+	suite.Equal(0, refetched)
+}
+
+func (suite *RefetchTestSuite) TestRefetchEmojisTotalTimeout() {
+	config.SetMediaRefetchEmojiTimeout(0)
+
+	if err := suite.storage.Delete(context.Background(), suite.testEmojis["yell"].ImagePath); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	refetched, err := suite.manager.RefetchEmojis(ctx, "", hangingDereferenceMedia)
+	suite.ErrorIs(err, context.DeadlineExceeded)
+	suite.Equal(0, refetched)
+}
+
+func hangingDereferenceMedia(ctx context.Context, iri *url.URL) (io.ReadCloser, int64, error) {
+	<-ctx.Done()
+	return nil, 0, ctx.Err()
+}
+
 func TestRefetchTestSuite(t *testing.T) {
 	suite.Run(t, &RefetchTestSuite{})
 }
@@ -0,0 +1,154 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// warmSelectLimit is the maximum number of candidate
+// attachments to consider when warming the cache.
+const warmSelectLimit = 200
+
+// SetDereferenceMedia sets the function used to fetch uncached remote media
+// again when warming the cache after a prune. Until it's set, no warming is
+// done after pruning. It must be set before the manager is in use.
+func (m *Manager) SetDereferenceMedia(dereferenceMedia DereferenceMedia) {
+	m.dereferenceMedia = dereferenceMedia
+}
+
+// WarmRemote fetches again uncached remote media attached to the statuses which
+// were most recently faved or boosted within the last mediaCacheRemoteDays, most
+// recently interacted with first, so that it doesn't all have to be fetched at
+// once the next time timelines are loaded after a prune.
+//
+// Fetching stops once budget bytes of media have been fetched; media which is
+// bigger than what's left of the budget is skipped. As the recorded size of
+// uncached media can't be relied on, reads are limited to what's left of the
+// budget, and media which turns out to be bigger is discarded unprocessed.
+//
+// The returned int and int64 are the amount of media, and the amount of
+// bytes, which were fetched again by this function.
+func (m *Manager) WarmRemote(ctx context.Context, mediaCacheRemoteDays int, budget int64, dereferenceMedia DereferenceMedia) (int, int64, error) {
+	if budget <= 0 {
+		return 0, 0, nil
+	}
+
+	var since time.Time
+	if mediaCacheRemoteDays > 0 {
+		since = time.Now().Add(-time.Hour * 24 * time.Duration(mediaCacheRemoteDays))
+	}
+
+	attachments, err := m.state.DB.GetUncachedRecentlyInteracted(ctx, since, warmSelectLimit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, 0, fmt.Errorf("WarmRemote: db error getting recently interacted media: %w", err)
+	}
+
+	var (
+		totalWarmed int
+		totalBytes  int64
+	)
+
+	for _, attachment := range attachments {
+		if err := ctx.Err(); err != nil {
+			return totalWarmed, totalBytes, err
+		}
+
+		if totalBytes >= budget {
+			// Budget spent.
+			break
+		}
+
+		remaining := budget - totalBytes
+
+		if size := int64(attachment.File.FileSize + attachment.Thumbnail.FileSize); size > remaining {
+			// Known to be too big for what's left.
+			continue
+		}
+
+		remoteIRI, err := url.Parse(attachment.RemoteURL)
+		if err != nil {
+			log.Errorf(ctx, "media %s could not be warmed because its RemoteURL (%s) is not a valid uri: %s", attachment.ID, attachment.RemoteURL, err)
+			continue
+		}
+
+		b, err := fetchWithin(ctx, dereferenceMedia, remoteIRI, remaining)
+		if err != nil {
+			log.Debugf(ctx, "media %s could not be warmed: %v", attachment.ID, err)
+			continue
+		}
+
+		dataFunc := func(ctx context.Context) (io.ReadCloser, int64, error) {
+			return io.NopCloser(bytes.NewReader(b)), int64(len(b)), nil
+		}
+
+		processingMedia, err := m.PreProcessMediaRecache(ctx, dataFunc, attachment.ID)
+		if err != nil {
+			log.Errorf(ctx, "media %s could not be warmed because of an error during processing: %s", attachment.ID, err)
+			continue
+		}
+
+		warmed, err := processingMedia.LoadAttachment(ctx)
+		if err != nil {
+			log.Errorf(ctx, "media %s could not be warmed because of an error during loading: %s", attachment.ID, err)
+			continue
+		}
+
+		totalWarmed++
+		totalBytes += int64(warmed.File.FileSize + warmed.Thumbnail.FileSize)
+	}
+
+	return totalWarmed, totalBytes, nil
+}
+
+// fetchWithin fetches the remote media at the given IRI, reading no more than
+// limit bytes. Media bigger than limit is not returned, but neither does it
+// count as a fetch failure, as there's nothing wrong with it.
+func fetchWithin(ctx context.Context, dereferenceMedia DereferenceMedia, remoteIRI *url.URL, limit int64) ([]byte, error) {
+	rc, sz, err := dereferenceMedia(ctx, remoteIRI)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	if sz > limit {
+		return nil, fmt.Errorf("reported size %d is more than what's left of the budget (%d)", sz, limit)
+	}
+
+	// Don't trust the reported size, read
+	// at most one byte more than the limit.
+	b, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(b)) > limit {
+		return nil, fmt.Errorf("size is more than what's left of the budget (%d)", limit)
+	}
+
+	return b, nil
+}
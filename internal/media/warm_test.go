@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WarmTestSuite struct {
+	MediaStandardTestSuite
+}
+
+// dogDereferenceMedia serves a test image for any remote media.
+func dogDereferenceMedia(_ context.Context, _ *url.URL) (io.ReadCloser, int64, error) {
+	b, err := os.ReadFile("../../testrig/media/thoughtsofdog-original.jpg")
+	if err != nil {
+		return nil, 0, err
+	}
+	return io.NopCloser(bytes.NewBuffer(b)), int64(len(b)), nil
+}
+
+// uncacheAndFave uncaches all remote media, then
+// faves the status of the given attachment, so its
+// media is a candidate for warming the cache.
+func (suite *WarmTestSuite) uncacheAndFave(attachment *gtsmodel.MediaAttachment) {
+	ctx := context.Background()
+
+	if _, err := suite.manager.UncacheRemote(ctx, 1, false); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	faveID := id.NewULID()
+	status := testrig.NewTestStatuses()["remote_account_1_status_1"]
+	suite.Equal(status.ID, attachment.StatusID)
+
+	account := suite.testAccounts["local_account_1"]
+	if err := suite.db.PutStatusFave(ctx, &gtsmodel.StatusFave{
+		ID:              faveID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		URI:             account.URI + "/liked/" + faveID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *WarmTestSuite) TestWarmRemote() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	testHeader := suite.testAttachments["remote_account_3_header"]
+	suite.uncacheAndFave(testStatusAttachment)
+
+	warmed, warmedBytes, err := suite.manager.WarmRemote(ctx, 30, 1<<30, dogDereferenceMedia)
+	suite.NoError(err)
+	suite.Equal(1, warmed)
+	suite.Positive(warmedBytes)
+
+	// Media of the faved status should be cached again...
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.True(*dbAttachment.Cached)
+
+	// ...but nothing else.
+	dbAttachment, err = suite.db.GetAttachmentByID(ctx, testHeader.ID)
+	suite.NoError(err)
+	suite.False(*dbAttachment.Cached)
+}
+
+func (suite *WarmTestSuite) TestWarmRemoteOverBudget() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	suite.uncacheAndFave(testStatusAttachment)
+
+	// The attachment is known to be bigger than the budget.
+	warmed, warmedBytes, err := suite.manager.WarmRemote(ctx, 30, 1, dogDereferenceMedia)
+	suite.NoError(err)
+	suite.Zero(warmed)
+	suite.Zero(warmedBytes)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.False(*dbAttachment.Cached)
+}
+
+func (suite *WarmTestSuite) TestWarmRemoteOldMedia() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	// The media itself is older than the cutoff, but
+	// the status was faved recently, so it's warmed.
+	testStatusAttachment.CreatedAt = time.Now().Add(-31 * 24 * time.Hour)
+	if err := suite.db.UpdateAttachment(ctx, testStatusAttachment, "created_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.uncacheAndFave(testStatusAttachment)
+
+	warmed, _, err := suite.manager.WarmRemote(ctx, 30, 1<<30, dogDereferenceMedia)
+	suite.NoError(err)
+	suite.Equal(1, warmed)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.True(*dbAttachment.Cached)
+}
+
+func (suite *WarmTestSuite) TestWarmRemoteUnknownSizeOverBudget() {
+	ctx := context.Background()
+	testStatusAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+	suite.uncacheAndFave(testStatusAttachment)
+
+	// Forget the recorded sizes, and serve the
+	// media without saying how big it is.
+	testStatusAttachment.File.FileSize = 0
+	testStatusAttachment.Thumbnail.FileSize = 0
+	if err := suite.db.UpdateAttachment(ctx, testStatusAttachment, "file_file_size", "thumbnail_file_size"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dereferenceMedia := func(ctx context.Context, iri *url.URL) (io.ReadCloser, int64, error) {
+		rc, _, err := dogDereferenceMedia(ctx, iri)
+		return rc, -1, err
+	}
+
+	// The media turns out to be bigger than the budget
+	// while it's being read, so it's discarded, without
+	// counting as a failure to fetch it.
+	warmed, warmedBytes, err := suite.manager.WarmRemote(ctx, 30, 1024, dereferenceMedia)
+	suite.NoError(err)
+	suite.Zero(warmed)
+	suite.Zero(warmedBytes)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, testStatusAttachment.ID)
+	suite.NoError(err)
+	suite.False(*dbAttachment.Cached)
+	suite.Zero(dbAttachment.FetchFailures)
+}
+
+func TestWarmTestSuite(t *testing.T) {
+	suite.Run(t, &WarmTestSuite{})
+}
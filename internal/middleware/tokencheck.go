@@ -18,8 +18,12 @@
 package middleware
 
 import (
+	"context"
+	"net"
 	"net/http"
+	"time"
 
+	"codeberg.org/gruf/go-cache/v3/ttl"
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -49,7 +53,14 @@ import (
 // If an invalid token is presented, or a user/account/application can't be found, then this middleware
 // won't abort the request, since the server might want to still allow public requests that don't have a
 // Bearer token set (eg., for public instance information and so on).
+//
+// The time and IP address at which each user-level token was last used are also recorded, at
+// most once per tokenLastUsedFreq per token, so that admins can review them later.
 func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.TokenInfo, error)) func(*gin.Context) {
+	// Access tokens whose last use was recorded recently.
+	recentlyUsed := ttl.New[string, struct{}](0, 1000, tokenLastUsedFreq)
+	recentlyUsed.Start(time.Minute)
+
 	return func(c *gin.Context) {
 		// Acquire context from gin request.
 		ctx := c.Request.Context()
@@ -118,6 +129,10 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 			}
 
 			c.Set(oauth.SessionAuthorizedAccount, user.Account)
+
+			if access := ti.GetAccess(); access != "" && recentlyUsed.Add(access, struct{}{}) {
+				recordTokenUsed(ctx, dbConn, access, net.ParseIP(c.ClientIP()))
+			}
 		}
 
 		// check for application token
@@ -138,3 +153,23 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 	}
 }
+
+// tokenLastUsedFreq is the minimum time between
+// recording successive uses of the same token.
+const tokenLastUsedFreq = 5 * time.Minute
+
+// recordTokenUsed updates the last used time and IP of the token with the given access code.
+func recordTokenUsed(ctx context.Context, dbConn db.DB, access string, ip net.IP) {
+	token := &gtsmodel.Token{}
+	if err := dbConn.GetWhere(ctx, []db.Where{{Key: "access", Value: access}}, token); err != nil {
+		log.Errorf(ctx, "database error looking for token: %s", err)
+		return
+	}
+
+	token.LastUsedAt = time.Now()
+	token.LastUsedIP = ip
+
+	if err := dbConn.UpdateByID(ctx, token, token.ID, "last_used_at", "last_used_ip"); err != nil {
+		log.Errorf(ctx, "database error recording token use: %s", err)
+	}
+}
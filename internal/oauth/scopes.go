@@ -0,0 +1,154 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+// Scopes that may be requested by applications. This
+// covers every scope Mastodon defines, so apps written
+// against Mastodon can register here unchanged.
+// Parent scopes grant all of their child scopes.
+const (
+	ScopeRead              = "read"
+	ScopeReadAccounts      = "read:accounts"
+	ScopeReadBlocks        = "read:blocks"
+	ScopeReadBookmarks     = "read:bookmarks"
+	ScopeReadFavourites    = "read:favourites"
+	ScopeReadFilters       = "read:filters"
+	ScopeReadFollows       = "read:follows"
+	ScopeReadLists         = "read:lists"
+	ScopeReadMutes         = "read:mutes"
+	ScopeReadNotifications = "read:notifications"
+	ScopeReadSearch        = "read:search"
+	ScopeReadStatuses      = "read:statuses"
+
+	ScopeWrite              = "write"
+	ScopeWriteAccounts      = "write:accounts"
+	ScopeWriteBlocks        = "write:blocks"
+	ScopeWriteBookmarks     = "write:bookmarks"
+	ScopeWriteConversations = "write:conversations"
+	ScopeWriteFavourites    = "write:favourites"
+	ScopeWriteFilters       = "write:filters"
+	ScopeWriteFollows       = "write:follows"
+	ScopeWriteLists         = "write:lists"
+	ScopeWriteMedia         = "write:media"
+	ScopeWriteMutes         = "write:mutes"
+	ScopeWriteNotifications = "write:notifications"
+	ScopeWriteReports       = "write:reports"
+	ScopeWriteStatuses      = "write:statuses"
+
+	ScopeFollow  = "follow"
+	ScopePush    = "push"
+	ScopeProfile = "profile"
+
+	ScopeAdmin                          = "admin"
+	ScopeAdminRead                      = "admin:read"
+	ScopeAdminReadAccounts              = "admin:read:accounts"
+	ScopeAdminReadReports               = "admin:read:reports"
+	ScopeAdminReadDomainAllows          = "admin:read:domain_allows"
+	ScopeAdminReadDomainBlocks          = "admin:read:domain_blocks"
+	ScopeAdminReadIPBlocks              = "admin:read:ip_blocks"
+	ScopeAdminReadEmailDomainBlocks     = "admin:read:email_domain_blocks"
+	ScopeAdminReadCanonicalEmailBlocks  = "admin:read:canonical_email_blocks"
+	ScopeAdminWrite                     = "admin:write"
+	ScopeAdminWriteAccounts             = "admin:write:accounts"
+	ScopeAdminWriteReports              = "admin:write:reports"
+	ScopeAdminWriteDomainAllows         = "admin:write:domain_allows"
+	ScopeAdminWriteDomainBlocks         = "admin:write:domain_blocks"
+	ScopeAdminWriteIPBlocks             = "admin:write:ip_blocks"
+	ScopeAdminWriteEmailDomainBlocks    = "admin:write:email_domain_blocks"
+	ScopeAdminWriteCanonicalEmailBlocks = "admin:write:canonical_email_blocks"
+)
+
+// Scope describes one OAuth scope, and
+// the parent scope that includes it, if any.
+type Scope struct {
+	Name        string
+	Description string
+	Parent      string
+}
+
+// scopes is the full scope hierarchy, in display order.
+var scopes = []Scope{
+	{ScopeRead, "Read all data for the account.", ""},
+	{ScopeReadAccounts, "Read account information.", ScopeRead},
+	{ScopeReadBlocks, "Read blocked accounts and domains.", ScopeRead},
+	{ScopeReadBookmarks, "Read bookmarked statuses.", ScopeRead},
+	{ScopeReadFavourites, "Read favourited statuses.", ScopeRead},
+	{ScopeReadFilters, "Read filters.", ScopeRead},
+	{ScopeReadFollows, "Read follows and follow requests.", ScopeRead},
+	{ScopeReadLists, "Read lists.", ScopeRead},
+	{ScopeReadMutes, "Read muted accounts.", ScopeRead},
+	{ScopeReadNotifications, "Read notifications.", ScopeRead},
+	{ScopeReadSearch, "Perform searches.", ScopeRead},
+	{ScopeReadStatuses, "Read statuses and timelines.", ScopeRead},
+
+	{ScopeWrite, "Modify all data for the account.", ""},
+	{ScopeWriteAccounts, "Update account profile and settings.", ScopeWrite},
+	{ScopeWriteBlocks, "Block and unblock accounts and domains.", ScopeWrite},
+	{ScopeWriteBookmarks, "Bookmark and unbookmark statuses.", ScopeWrite},
+	{ScopeWriteConversations, "Mute and delete conversations.", ScopeWrite},
+	{ScopeWriteFavourites, "Favourite and unfavourite statuses.", ScopeWrite},
+	{ScopeWriteFilters, "Create, update and delete filters.", ScopeWrite},
+	{ScopeWriteFollows, "Follow and unfollow accounts, and handle follow requests.", ScopeWrite},
+	{ScopeWriteLists, "Create, update and delete lists.", ScopeWrite},
+	{ScopeWriteMedia, "Upload and update media attachments.", ScopeWrite},
+	{ScopeWriteMutes, "Mute and unmute accounts.", ScopeWrite},
+	{ScopeWriteNotifications, "Dismiss notifications.", ScopeWrite},
+	{ScopeWriteReports, "Submit reports.", ScopeWrite},
+	{ScopeWriteStatuses, "Post, edit and delete statuses.", ScopeWrite},
+
+	{ScopeFollow, "Manage relationships (deprecated, use read/write scopes instead).", ""},
+	{ScopePush, "Receive push notifications.", ""},
+	{ScopeProfile, "Read only the account's own profile, for signing in with this instance.", ""},
+
+	{ScopeAdmin, "Perform all moderation and administration actions.", ""},
+	{ScopeAdminRead, "Read moderation and administration data.", ScopeAdmin},
+	{ScopeAdminReadAccounts, "Read sensitive information of all accounts.", ScopeAdminRead},
+	{ScopeAdminReadReports, "Read sensitive information of all reports.", ScopeAdminRead},
+	{ScopeAdminReadDomainAllows, "Read allowed domains.", ScopeAdminRead},
+	{ScopeAdminReadDomainBlocks, "Read blocked domains.", ScopeAdminRead},
+	{ScopeAdminReadIPBlocks, "Read blocked IP addresses.", ScopeAdminRead},
+	{ScopeAdminReadEmailDomainBlocks, "Read blocked email domains.", ScopeAdminRead},
+	{ScopeAdminReadCanonicalEmailBlocks, "Read blocked canonical email addresses.", ScopeAdminRead},
+	{ScopeAdminWrite, "Perform moderation and administration actions.", ScopeAdmin},
+	{ScopeAdminWriteAccounts, "Perform moderation actions on accounts.", ScopeAdminWrite},
+	{ScopeAdminWriteReports, "Perform moderation actions on reports.", ScopeAdminWrite},
+	{ScopeAdminWriteDomainAllows, "Allow and disallow domains.", ScopeAdminWrite},
+	{ScopeAdminWriteDomainBlocks, "Block and unblock domains.", ScopeAdminWrite},
+	{ScopeAdminWriteIPBlocks, "Block and unblock IP addresses.", ScopeAdminWrite},
+	{ScopeAdminWriteEmailDomainBlocks, "Block and unblock email domains.", ScopeAdminWrite},
+	{ScopeAdminWriteCanonicalEmailBlocks, "Block and unblock canonical email addresses.", ScopeAdminWrite},
+}
+
+// Scopes returns a copy of all scopes
+// recognized by this instance.
+func Scopes() []Scope {
+	s := make([]Scope, len(scopes))
+	copy(s, scopes)
+	return s
+}
+
+// IsValidScope returns true if the
+// given scope is recognized by this instance.
+func IsValidScope(scope string) bool {
+	for _, s := range scopes {
+		if s.Name == scope {
+			return true
+		}
+	}
+	return false
+}
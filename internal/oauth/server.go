@@ -99,7 +99,14 @@ func New(ctx context.Context, database db.Basic) Server {
 			oauth2.AuthorizationCode,
 			oauth2.ClientCredentials,
 		},
-		AllowedCodeChallengeMethods: []oauth2.CodeChallengeMethod{oauth2.CodeChallengePlain},
+		// Allow:
+		// - S256 (for clients using PKCE)
+		// - Plain (only because the library treats requests without a
+		//   code challenge as plain; explicit plain is rejected by the API)
+		AllowedCodeChallengeMethods: []oauth2.CodeChallengeMethod{
+			oauth2.CodeChallengePlain,
+			oauth2.CodeChallengeS256,
+		},
 	}
 
 	srv := server.NewServer(sc, manager)
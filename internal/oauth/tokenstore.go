@@ -139,6 +139,10 @@ func (ts *tokenStore) GetByCode(ctx context.Context, code string) (oauth2.TokenI
 	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "code", Value: code}}, dbt); err != nil {
 		return nil, err
 	}
+	if !dbt.RevokedAt.IsZero() {
+		// Revoked tokens are as good as gone.
+		return nil, db.ErrNoEntries
+	}
 	return DBTokenToToken(dbt), nil
 }
 
@@ -153,6 +157,10 @@ func (ts *tokenStore) GetByAccess(ctx context.Context, access string) (oauth2.To
 	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "access", Value: access}}, dbt); err != nil {
 		return nil, err
 	}
+	if !dbt.RevokedAt.IsZero() {
+		// Revoked tokens are as good as gone.
+		return nil, db.ErrNoEntries
+	}
 	return DBTokenToToken(dbt), nil
 }
 
@@ -167,6 +175,10 @@ func (ts *tokenStore) GetByRefresh(ctx context.Context, refresh string) (oauth2.
 	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "refresh", Value: refresh}}, dbt); err != nil {
 		return nil, err
 	}
+	if !dbt.RevokedAt.IsZero() {
+		// Revoked tokens are as good as gone.
+		return nil, db.ErrNoEntries
+	}
 	return DBTokenToToken(dbt), nil
 }
 
@@ -18,6 +18,8 @@
 package account
 
 import (
+	"sync/atomic"
+
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
@@ -40,6 +42,13 @@ type Processor struct {
 	formatter    text.Formatter
 	federator    federation.Federator
 	parseMention gtsmodel.ParseMentionFunc
+
+	// latest follows import of each account
+	imports *followsImports
+
+	// set while a suspended accounts
+	// cleanup job is running
+	suspendedCleanup *atomic.Bool
 }
 
 // New returns a new account processor.
@@ -52,7 +61,7 @@ func New(
 	filter *visibility.Filter,
 	parseMention gtsmodel.ParseMentionFunc,
 ) Processor {
-	return Processor{
+	p := Processor{
 		state:        state,
 		tc:           tc,
 		mediaManager: mediaManager,
@@ -61,5 +70,10 @@ func New(
 		formatter:    text.NewFormatter(state.DB),
 		federator:    federator,
 		parseMention: parseMention,
+		imports:      newFollowsImports(),
+
+		suspendedCleanup: new(atomic.Bool),
 	}
+	scheduleDeleteSweep(&p)
+	return p
 }
@@ -22,12 +22,17 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"reflect"
 	"time"
 
 	"codeberg.org/gruf/go-kv"
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
 	"github.com/google/uuid"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -35,7 +40,13 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-const deleteSelectLimit = 50
+const (
+	deleteSelectLimit     = 50
+	deleteSweepFrequency  = 10 * time.Minute
+	deleteRetryAfter      = time.Hour
+	peripheralSelectLimit = 200
+	restubbifySelectLimit = 200
+)
 
 // Delete deletes an account, and all of that account's statuses, media, follows, notifications, etc etc etc.
 // The origin passed here should be either the ID of the account doing the delete (can be itself), or the ID of a domain block.
@@ -46,6 +57,10 @@ func (p *Processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 	}...)
 	l.Trace("beginning account delete process")
 
+	if errWithCode := checkNotSystem(account); errWithCode != nil {
+		return errWithCode
+	}
+
 	if account.IsLocal() {
 		if err := p.deleteUserAndTokensForAccount(ctx, account); err != nil {
 			return gtserror.NewErrorInternalError(err)
@@ -81,17 +96,79 @@ func (p *Processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 		return gtserror.NewErrorInternalError(err)
 	}
 
+	if account.IsLocal() {
+		// Deletion is complete, so this
+		// user no longer has one pending.
+		if err := p.clearDeleteRequested(ctx, account); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
 	l.Info("account deleted")
 	return nil
 }
 
+// clearDeleteRequested clears the pending deletion
+// marker, if any, from the given local account's user.
+func (p *Processor) clearDeleteRequested(ctx context.Context, account *gtsmodel.Account) error {
+	user, err := p.state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		return fmt.Errorf("clearDeleteRequested: db error getting user for account %s: %w", account.ID, err)
+	}
+
+	if user.DeleteRequestedAt.IsZero() {
+		// Nothing to do.
+		return nil
+	}
+
+	user.DeleteRequestedAt = time.Time{}
+	if err := p.state.DB.UpdateUser(ctx, user, "delete_requested_at"); err != nil {
+		return fmt.Errorf("clearDeleteRequested: db error updating user %s: %w", user.ID, err)
+	}
+
+	return nil
+}
+
 // DeleteSelf is like Delete, but specifically for local accounts deleting themselves.
 //
 // Calling DeleteSelf results in a delete message being enqueued in the processor,
 // which causes side effects to occur: delete will be federated out to other instances,
 // and the above Delete function will be called afterwards from the processor, to clear
 // out the account's bits and bobs, and stubbify it.
-func (p *Processor) DeleteSelf(ctx context.Context, account *gtsmodel.Account) gtserror.WithCode {
+//
+// DeleteSelf refuses to delete the last remaining active admin of the instance, as that
+// would leave nobody able to administrate it, unless force is set (eg., for teardown).
+func (p *Processor) DeleteSelf(ctx context.Context, account *gtsmodel.Account, force bool) gtserror.WithCode {
+	if errWithCode := checkNotSystem(account); errWithCode != nil {
+		return errWithCode
+	}
+
+	if !force {
+		if errWithCode := p.checkNotLastAdmin(ctx, account); errWithCode != nil {
+			return errWithCode
+		}
+	}
+
+	return p.requestSelfDelete(ctx, account)
+}
+
+// requestSelfDelete records on the given local account's user that deletion
+// was requested, and only then enqueues the delete message. That way, if the
+// message is lost (eg., we crash before the worker gets to it), the request
+// isn't: the delete sweep will pick up the user and enqueue it again.
+func (p *Processor) requestSelfDelete(ctx context.Context, account *gtsmodel.Account) gtserror.WithCode {
+	user, err := p.state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		err = fmt.Errorf("requestSelfDelete: db error getting user for account %s: %w", account.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	user.DeleteRequestedAt = time.Now()
+	if err := p.state.DB.UpdateUser(ctx, user, "delete_requested_at"); err != nil {
+		err = fmt.Errorf("requestSelfDelete: db error updating user %s: %w", user.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
 	fromClientAPIMessage := messages.FromClientAPI{
 		APObjectType:   ap.ActorPerson,
 		APActivityType: ap.ActivityDelete,
@@ -105,8 +182,352 @@ func (p *Processor) DeleteSelf(ctx context.Context, account *gtsmodel.Account) g
 	return nil
 }
 
-// deleteUserAndTokensForAccount deletes the gtsmodel.User and
-// any OAuth tokens and applications for the given account.
+// checkNotSystem returns a forbidden error if the given account is a system
+// account, such as the instance actor, which federation depends upon.
+func checkNotSystem(account *gtsmodel.Account) gtserror.WithCode {
+	if account.System != nil && *account.System {
+		err := fmt.Errorf("account %s is a system account, which can't be deleted", account.ID)
+		return gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	return nil
+}
+
+// checkNotLastAdmin returns a forbidden error if the given local
+// account belongs to the only remaining active admin of the instance.
+func (p *Processor) checkNotLastAdmin(ctx context.Context, account *gtsmodel.Account) gtserror.WithCode {
+	user, err := p.state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		err = fmt.Errorf("checkNotLastAdmin: db error getting user for account %s: %w", account.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !*user.Admin {
+		// Not an admin, nothing to check.
+		return nil
+	}
+
+	admins, err := p.state.DB.CountActiveAdmins(ctx)
+	if err != nil {
+		err = fmt.Errorf("checkNotLastAdmin: db error counting active admins: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if admins <= 1 {
+		err := errors.New("this account is the last active admin of the instance; promote another account to admin before deleting it")
+		return gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	return nil
+}
+
+// ScheduleSelfDelete schedules the given local account to be deleted at the given
+// time, via DeleteSelf. The schedule is stored on the account's user, so that it
+// survives restarts, and is cancelled if the user signs in again before then.
+func (p *Processor) ScheduleSelfDelete(ctx context.Context, account *gtsmodel.Account, at time.Time) gtserror.WithCode {
+	if !at.After(time.Now()) {
+		err := errors.New("scheduled deletion time must be in the future")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if errWithCode := p.checkNotLastAdmin(ctx, account); errWithCode != nil {
+		return errWithCode
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, account.ID)
+	if err != nil {
+		err = fmt.Errorf("ScheduleSelfDelete: db error getting user for account %s: %w", account.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	user.DeleteScheduledAt = at
+	if err := p.state.DB.UpdateUser(ctx, user, "delete_scheduled_at"); err != nil {
+		err = fmt.Errorf("ScheduleSelfDelete: db error updating user %s: %w", user.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// CancelScheduledSelfDelete cancels any pending scheduled
+// deletion of the given user's account, if one was set.
+func (p *Processor) CancelScheduledSelfDelete(ctx context.Context, user *gtsmodel.User) error {
+	if user.DeleteScheduledAt.IsZero() {
+		// Nothing to do.
+		return nil
+	}
+
+	user.DeleteScheduledAt = time.Time{}
+	if err := p.state.DB.UpdateUser(ctx, user, "delete_scheduled_at"); err != nil {
+		return fmt.Errorf("CancelScheduledSelfDelete: db error updating user %s: %w", user.ID, err)
+	}
+
+	return nil
+}
+
+// DeleteScheduled calls DeleteSelf for every local account
+// whose scheduled deletion time is at or before now.
+func (p *Processor) DeleteScheduled(ctx context.Context, now time.Time) error {
+	users, err := p.state.DB.GetUsersScheduledForDeletion(ctx, now)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("DeleteScheduled: db error getting users scheduled for deletion: %w", err)
+	}
+
+	for _, user := range users {
+		account, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			log.Errorf(ctx, "error getting account %s scheduled for deletion: %v", user.AccountID, err)
+			continue
+		}
+
+		// Clear the schedule first, so that we don't
+		// enqueue this deletion again on the next run.
+		user.DeleteScheduledAt = time.Time{}
+		if err := p.state.DB.UpdateUser(ctx, user, "delete_scheduled_at"); err != nil {
+			log.Errorf(ctx, "error clearing scheduled deletion for user %s: %v", user.ID, err)
+			continue
+		}
+
+		log.Infof(ctx, "deleting account %s as scheduled", account.ID)
+		if errWithCode := p.DeleteSelf(ctx, account, false); errWithCode != nil {
+			log.Errorf(ctx, "error deleting account %s as scheduled: %v", account.ID, errWithCode)
+		}
+	}
+
+	return nil
+}
+
+// RetryPendingDeletes enqueues the delete message again for every local
+// account whose deletion was requested at least deleteRetryAfter before
+// now, but hasn't completed, eg., because the message was lost in a crash.
+func (p *Processor) RetryPendingDeletes(ctx context.Context, now time.Time) error {
+	users, err := p.state.DB.GetUsersPendingDeletion(ctx, now.Add(-deleteRetryAfter))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return fmt.Errorf("RetryPendingDeletes: db error getting users pending deletion: %w", err)
+	}
+
+	for _, user := range users {
+		account, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			log.Errorf(ctx, "error getting account %s pending deletion: %v", user.AccountID, err)
+			continue
+		}
+
+		// The last admin check was already done when deletion
+		// was first requested, so go straight to requesting it
+		// again. This also bumps the request time, so that we
+		// don't retry again until deleteRetryAfter has passed.
+		log.Infof(ctx, "retrying pending deletion of account %s", account.ID)
+		if errWithCode := p.requestSelfDelete(ctx, account); errWithCode != nil {
+			log.Errorf(ctx, "error retrying deletion of account %s: %v", account.ID, errWithCode)
+		}
+	}
+
+	return nil
+}
+
+// scheduleDeleteSweep schedules a job to periodically delete accounts whose
+// scheduled deletion time has passed, and retry any stalled deletions.
+func scheduleDeleteSweep(p *Processor) {
+	// Get ctx associated with scheduler run state.
+	done := p.state.Workers.Scheduler.Done()
+	doneCtx := runners.CancelCtx(done)
+
+	p.state.Workers.Scheduler.Schedule(sched.NewJob(func(now time.Time) {
+		if err := p.DeleteScheduled(doneCtx, now); err != nil {
+			log.Errorf(nil, "error during scheduled account deletion: %v", err)
+		}
+
+		if err := p.RetryPendingDeletes(doneCtx, now); err != nil {
+			log.Errorf(nil, "error retrying pending account deletions: %v", err)
+		}
+	}).Every(deleteSweepFrequency))
+}
+
+// CleanupPeripheralForSuspendedAccounts re-runs peripheral cleanup (faves,
+// bookmarks, status mutes) for every suspended account which still has any
+// of these left over, eg., from before status mutes were cleaned up on
+// account deletion. It's safe to call repeatedly: accounts with nothing
+// left to clean up are skipped.
+//
+// The returned ints are the amount of accounts that were cleaned up,
+// and the total amount of faves, bookmarks and mutes that were removed.
+func (p *Processor) CleanupPeripheralForSuspendedAccounts(ctx context.Context) (int, int, error) {
+	var (
+		maxID    string
+		accounts int
+		items    int
+	)
+
+	for {
+		accountIDs, err := p.state.DB.GetSuspendedAccountIDs(ctx, maxID, peripheralSelectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return accounts, items, fmt.Errorf("CleanupPeripheralForSuspendedAccounts: db error getting suspended accounts: %w", err)
+		}
+
+		if len(accountIDs) == 0 {
+			// No more accounts.
+			return accounts, items, nil
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = accountIDs[len(accountIDs)-1]
+
+		for _, accountID := range accountIDs {
+			count, err := p.state.DB.CountAccountPeripheral(ctx, accountID)
+			if err != nil {
+				return accounts, items, fmt.Errorf("CleanupPeripheralForSuspendedAccounts: db error counting peripheral for account %s: %w", accountID, err)
+			}
+
+			if count == 0 {
+				// Nothing to do.
+				continue
+			}
+
+			account, err := p.state.DB.GetAccountByID(ctx, accountID)
+			if err != nil {
+				return accounts, items, fmt.Errorf("CleanupPeripheralForSuspendedAccounts: db error getting account %s: %w", accountID, err)
+			}
+
+			if err := p.deleteAccountPeripheral(ctx, account); err != nil {
+				return accounts, items, fmt.Errorf("CleanupPeripheralForSuspendedAccounts: error cleaning up peripheral for account %s: %w", accountID, err)
+			}
+
+			accounts++
+			items += count
+		}
+	}
+}
+
+// RestubbifyAccount re-applies the current stubbifyAccount logic to the
+// already-suspended account with the given ID, keeping its original
+// suspension time and origin. This clears any fields that stubbifyAccount
+// has started clearing since the account was suspended.
+//
+// The returned bool indicates whether the account needed updating.
+func (p *Processor) RestubbifyAccount(ctx context.Context, accountID string) (bool, error) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return false, fmt.Errorf("RestubbifyAccount: db error getting account %s: %w", accountID, err)
+	}
+
+	if account.SuspendedAt.IsZero() {
+		return false, fmt.Errorf("RestubbifyAccount: account %s is not suspended", accountID)
+	}
+
+	// Stubbify a copy, so we can
+	// tell if anything has changed.
+	stub := new(gtsmodel.Account)
+	*stub = *account
+	columns := stubbifyAccount(stub, account.SuspensionOrigin)
+	stub.SuspendedAt = account.SuspendedAt
+
+	// Nil and empty are the same as far
+	// as the db is concerned, so ignore
+	// the difference between them.
+	if len(account.EmojiIDs) == 0 {
+		stub.EmojiIDs = account.EmojiIDs
+	}
+	if len(account.Emojis) == 0 {
+		stub.Emojis = account.Emojis
+	}
+	if len(account.Fields) == 0 {
+		stub.Fields = account.Fields
+	}
+
+	if reflect.DeepEqual(stub, account) {
+		// Already up to date.
+		return false, nil
+	}
+
+	if err := p.state.DB.UpdateAccount(ctx, stub, columns...); err != nil {
+		return false, fmt.Errorf("RestubbifyAccount: db error updating account %s: %w", accountID, err)
+	}
+
+	return true, nil
+}
+
+// RestubbifyAllSuspended calls RestubbifyAccount for every suspended account,
+// so that accounts suspended by an older version are cleared of any fields
+// that stubbifyAccount has since started clearing. It's safe to call
+// repeatedly: accounts that are already up to date are left alone.
+//
+// The returned int is the amount of accounts that were updated.
+func (p *Processor) RestubbifyAllSuspended(ctx context.Context) (int, error) {
+	var (
+		maxID   string
+		updated int
+	)
+
+	for {
+		accountIDs, err := p.state.DB.GetSuspendedAccountIDs(ctx, maxID, restubbifySelectLimit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return updated, fmt.Errorf("RestubbifyAllSuspended: db error getting suspended accounts: %w", err)
+		}
+
+		if len(accountIDs) == 0 {
+			// No more accounts.
+			return updated, nil
+		}
+
+		// Use last ID as the next 'maxID' value.
+		maxID = accountIDs[len(accountIDs)-1]
+
+		for _, accountID := range accountIDs {
+			changed, err := p.RestubbifyAccount(ctx, accountID)
+			if err != nil {
+				return updated, err
+			}
+
+			if changed {
+				updated++
+			}
+		}
+	}
+}
+
+// SuspendedCleanup starts a background job to clean up anything left behind by
+// accounts suspended by an older version: fields that stubbifyAccount didn't
+// clear back then, and faves, bookmarks and status mutes.
+// This walks every suspended account, so rather than running on every startup,
+// it's left to admins to run it on demand, eg., once after upgrading.
+//
+// A conflict error is returned if a cleanup is already running.
+func (p *Processor) SuspendedCleanup() gtserror.WithCode {
+	if !p.suspendedCleanup.CompareAndSwap(false, true) {
+		err := errors.New("a suspended accounts cleanup is already running")
+		return gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	// Get ctx associated with scheduler run state.
+	done := p.state.Workers.Scheduler.Done()
+	doneCtx := runners.CancelCtx(done)
+
+	p.state.Workers.Scheduler.Schedule(sched.NewJob(func(now time.Time) {
+		defer p.suspendedCleanup.Store(false)
+
+		restubbified, err := p.RestubbifyAllSuspended(doneCtx)
+		if err != nil {
+			log.Errorf(nil, "error restubbifying suspended accounts: %v", err)
+			return
+		}
+
+		log.Infof(nil, "restubbified %d suspended accounts", restubbified)
+
+		accounts, items, err := p.CleanupPeripheralForSuspendedAccounts(doneCtx)
+		if err != nil {
+			log.Errorf(nil, "error cleaning up peripheral for suspended accounts: %v", err)
+			return
+		}
+
+		log.Infof(nil, "cleaned up %d faves/bookmarks/mutes left behind by %d suspended accounts", items, accounts)
+	}).At(time.Now()))
+
+	return nil
+}
+
+// deleteUserAndTokensForAccount deletes the gtsmodel.User and any
+// OAuth tokens, applications and sign-ins for the given account.
 //
 // Callers to this function should already have checked that
 // this is a local account, or else it won't have a user associated
@@ -139,6 +560,11 @@ func (p *Processor) deleteUserAndTokensForAccount(ctx context.Context, account *
 		}
 	}
 
+	// Delete any sign-ins recorded for activity stats.
+	if err := p.state.DB.DeleteSignInsForUser(ctx, user.ID); err != nil {
+		return fmt.Errorf("deleteUserAndTokensForAccount: db error deleting sign-ins: %w", err)
+	}
+
 	columns, err := stubbifyUser(user)
 	if err != nil {
 		return fmt.Errorf("deleteUserAndTokensForAccount: error stubbifying user: %w", err)
@@ -254,6 +680,15 @@ func (p *Processor) unfollowSideEffectsFunc(deletedAccount *gtsmodel.Account) fu
 	}
 
 	return func(ctx context.Context, account *gtsmodel.Account, follow *gtsmodel.Follow) *messages.FromClientAPI {
+		if follow.TargetAccountID == account.ID {
+			// Account follows itself; this should never
+			// happen, but may turn up via imports or db
+			// corruption. Nobody else needs to hear about
+			// it, so there's nothing to federate.
+			log.WithContext(ctx).WithField("follow", follow).Debug("skipping side effects for self-follow")
+			return nil
+		}
+
 		if follow.TargetAccount == nil {
 			// TargetAccount seems to have gone;
 			// race condition? db corruption?
@@ -277,6 +712,9 @@ func (p *Processor) unfollowSideEffectsFunc(deletedAccount *gtsmodel.Account) fu
 	}
 }
 
+// deleteAccountBlocks deletes all blocks created by or targeting
+// account, including any (malformed) block of account by itself.
+// Block removals aren't federated, so there are no side effects.
 func (p *Processor) deleteAccountBlocks(ctx context.Context, account *gtsmodel.Account) error {
 	if err := p.state.DB.DeleteAccountBlocks(ctx, account.ID); err != nil {
 		return fmt.Errorf("deleteAccountBlocks: db error deleting account blocks for %s: %w", account.ID, err)
@@ -287,6 +725,10 @@ func (p *Processor) deleteAccountBlocks(ctx context.Context, account *gtsmodel.A
 // deleteAccountStatuses iterates through all statuses owned by
 // the given account, passing each discovered status (and boosts
 // thereof) to the processor workers for further async processing.
+//
+// The account's own original statuses are deleted, along with any
+// boosts of them, while the account's boosts of other statuses
+// are undone, so that the correct activity type is federated out.
 func (p *Processor) deleteAccountStatuses(ctx context.Context, account *gtsmodel.Account) error {
 	// We'll select statuses 50 at a time so we don't wreck the db,
 	// and pass them through to the client api worker to handle.
@@ -304,8 +746,20 @@ func (p *Processor) deleteAccountStatuses(ctx context.Context, account *gtsmodel
 
 statusLoop:
 	for {
-		// Page through account's statuses.
-		statuses, err = p.state.DB.GetAccountStatuses(ctx, account.ID, deleteSelectLimit, false, false, maxID, "", false, false)
+		// Page through account's statuses, including
+		// both replies and boosts; these are separated
+		// out below depending on whether they're boosts.
+		statuses, err = p.state.DB.GetAccountStatuses(
+			ctx,
+			account.ID,
+			deleteSelectLimit,
+			false, // excludeReplies
+			false, // excludeReblogs
+			maxID,
+			"",    // minID
+			false, // mediaOnly
+			false, // publicOnly
+		)
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			// Make sure we don't have a real error.
 			return err
@@ -318,9 +772,45 @@ statusLoop:
 		// Update next maxID from last status.
 		maxID = statuses[len(statuses)-1].ID
 
+		// IDs of the account's own (non-boost)
+		// statuses on this page, in page order.
+		statusIDs := make([]string, 0, len(statuses))
+
 		for _, status := range statuses {
 			status.Account = account // ensure account is set
 
+			if status.BoostOfID != "" {
+				// This is the account's boost of
+				// a status, so it must be undone
+				// rather than deleted.
+				msg, err := p.undoAccountBoost(ctx, account, status)
+				if err != nil {
+					return err
+				}
+
+				if msg != nil {
+					msgs = append(msgs, *msg)
+				}
+
+				continue
+			}
+
+			statusIDs = append(statusIDs, status.ID)
+		}
+
+		// Look for any boosts of this page of statuses
+		// in one go, rather than querying per status.
+		boostsByID, err := p.state.DB.GetReblogsForStatusIDs(ctx, statusIDs)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return fmt.Errorf("deleteAccountStatuses: error fetching status reblogs: %w", err)
+		}
+
+		for _, status := range statuses {
+			if status.BoostOfID != "" {
+				// Already handled above.
+				continue
+			}
+
 			// Pass the status delete through the client api worker for processing.
 			msgs = append(msgs, messages.FromClientAPI{
 				APObjectType:   ap.ObjectNote,
@@ -330,13 +820,13 @@ statusLoop:
 				TargetAccount:  account,
 			})
 
-			// Look for any boosts of this status in DB.
-			boosts, err := p.state.DB.GetStatusReblogs(ctx, status)
-			if err != nil && !errors.Is(err, db.ErrNoEntries) {
-				return fmt.Errorf("deleteAccountStatuses: error fetching status reblogs for %s: %w", status.ID, err)
-			}
+			for _, boost := range boostsByID[status.ID] {
+				if boost.AccountID == account.ID {
+					// Self-boost, already undone
+					// as one of account's boosts.
+					continue
+				}
 
-			for _, boost := range boosts {
 				if boost.Account == nil {
 					// Fetch the relevant account for this status boost.
 					boostAcc, err := p.state.DB.GetAccountByID(ctx, boost.AccountID)
@@ -354,11 +844,15 @@ statusLoop:
 					boost.Account = boostAcc
 				}
 
+				// The boosted status is deleted alongside
+				// this Undo, so make sure it's already set.
+				boost.BoostOf = status
+
 				// Pass the boost delete through the client api worker for processing.
 				msgs = append(msgs, messages.FromClientAPI{
 					APObjectType:   ap.ActivityAnnounce,
 					APActivityType: ap.ActivityUndo,
-					GTSModel:       status,
+					GTSModel:       boost,
 					OriginAccount:  boost.Account,
 					TargetAccount:  account,
 				})
@@ -372,20 +866,114 @@ statusLoop:
 	return nil
 }
 
+// undoAccountBoost returns a message to undo the given boost
+// owned by account, or nil if the boosted status or account is gone.
+//
+// The boosted status is set on the boost up front, since the
+// worker deletes rows before federating the Undo, and the boosted
+// status may itself be getting deleted (eg., it's a self-boost).
+func (p *Processor) undoAccountBoost(ctx context.Context, account *gtsmodel.Account, boost *gtsmodel.Status) (*messages.FromClientAPI, error) {
+	if boost.BoostOf == nil {
+		// Fetch the boosted status.
+		boostOf, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), boost.BoostOfID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// We don't have the boosted status
+				// for some reason, so just skip it.
+				log.WithContext(ctx).WithField("boost", boost).Warnf("no status found with id %s for boost %s", boost.BoostOfID, boost.ID)
+				return nil, nil
+			}
+			return nil, fmt.Errorf("undoAccountBoost: error fetching boosted status %s: %w", boost.BoostOfID, err)
+		}
+
+		// Set status model
+		boost.BoostOf = boostOf
+	}
+
+	if boost.BoostOfAccount == nil {
+		// Fetch the account that owns the boosted status.
+		boostOfAcc, err := p.state.DB.GetAccountByID(ctx, boost.BoostOfAccountID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// We don't have the boosted account
+				// for some reason, so just skip it.
+				log.WithContext(ctx).WithField("boost", boost).Warnf("no account found with id %s for boost %s", boost.BoostOfAccountID, boost.ID)
+				return nil, nil
+			}
+			return nil, fmt.Errorf("undoAccountBoost: error fetching boosted account for %s: %w", boost.BoostOfAccountID, err)
+		}
+
+		// Set account model
+		boost.BoostOfAccount = boostOfAcc
+	}
+
+	return &messages.FromClientAPI{
+		APObjectType:   ap.ActivityAnnounce,
+		APActivityType: ap.ActivityUndo,
+		GTSModel:       boost,
+		OriginAccount:  account,
+		TargetAccount:  boost.BoostOfAccount,
+	}, nil
+}
+
 func (p *Processor) deleteAccountNotifications(ctx context.Context, account *gtsmodel.Account) error {
 	// Delete all notifications of all types targeting given account.
-	if err := p.state.DB.DeleteNotifications(ctx, nil, account.ID, ""); err != nil && !errors.Is(err, db.ErrNoEntries) {
+	if err := p.deleteNotifications(ctx, account.ID, ""); err != nil {
 		return err
 	}
 
 	// Delete all notifications of all types originating from given account.
-	if err := p.state.DB.DeleteNotifications(ctx, nil, "", account.ID); err != nil && !errors.Is(err, db.ErrNoEntries) {
+	if err := p.deleteNotifications(ctx, "", account.ID); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// deleteNotifications deletes all notifications of all types targeting and/or
+// originating from the given accounts. If batching is configured, they're deleted
+// in batches with a pause in between, so as not to lock the notifications table
+// for too long when there's loads of them. Small sets fit in one batch, so are
+// still deleted in one go.
+func (p *Processor) deleteNotifications(ctx context.Context, targetAccountID string, originAccountID string) error {
+	batchSize := config.GetAccountsDeleteNotificationsBatchSize()
+	if batchSize <= 0 {
+		// Batching disabled, delete all at once.
+		err := p.state.DB.DeleteNotifications(ctx, nil, targetAccountID, originAccountID, time.Time{})
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+		return nil
+	}
+
+	pause := config.GetAccountsDeleteNotificationsBatchPause()
+	for {
+		deleted, err := p.state.DB.DeleteNotificationsBatch(ctx, targetAccountID, originAccountID, time.Time{}, batchSize)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		if deleted < batchSize {
+			// Last batch, we're done.
+			return nil
+		}
+
+		// There may be more, take a
+		// breather before the next batch.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pause):
+		}
+	}
+}
+
+// deleteAccountPeripheral deletes faves and bookmarks owned by
+// or targeting the given account, and the account's saved
+// searches. These are removed from the db
+// only: no Undo is federated for them (not even for faves of the
+// account's own statuses), as remote instances drop them anyway
+// when they receive the Delete for the account or its statuses.
 func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmodel.Account) error {
 	// Delete all bookmarks owned by given account.
 	if err := p.state.DB.DeleteStatusBookmarks(ctx, account.ID, ""); // nocollapse
@@ -411,7 +999,23 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return err
 	}
 
-	// TODO: add status mutes here when they're implemented.
+	// Delete all status mutes owned by given account.
+	if err := p.state.DB.DeleteStatusMutes(ctx, "", account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Delete all status mutes targeting given account.
+	if err := p.state.DB.DeleteStatusMutes(ctx, account.ID, ""); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Delete all saved searches of given account.
+	if err := p.state.DB.DeleteAccountSavedSearches(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
 
 	return nil
 }
@@ -521,6 +1125,7 @@ func stubbifyUser(user *gtsmodel.User) ([]string, error) {
 	user.ConfirmationSentAt = never
 	user.ResetPasswordToken = ""
 	user.ResetPasswordSentAt = never
+	user.DeleteScheduledAt = never
 
 	return []string{
 		"encrypted_password",
@@ -537,5 +1142,6 @@ func stubbifyUser(user *gtsmodel.User) ([]string, error) {
 		"confirmation_sent_at",
 		"reset_password_token",
 		"reset_password_sent_at",
+		"delete_scheduled_at",
 	}, nil
 }
@@ -19,12 +19,19 @@ package account_test
 
 import (
 	"context"
+	"errors"
 	"net"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type AccountDeleteTestSuite struct {
@@ -97,6 +104,680 @@ func (suite *AccountDeleteTestSuite) TestAccountDeleteLocal() {
 	suite.Zero(updatedUser.ResetPasswordSentAt)
 }
 
+func (suite *AccountDeleteTestSuite) TestAccountDeleteKeepsReports() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["remote_account_1"]
+	testReports := testrig.NewTestReports()
+
+	// This account is the target of one report and
+	// the creator of another; deleting it should
+	// leave both reports in place for the admins.
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	targetedReport, err := suite.db.GetReportByID(ctx, testReports["local_account_2_report_remote_account_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testAccount.ID, targetedReport.TargetAccount.ID)
+	suite.False(targetedReport.TargetAccount.SuspendedAt.IsZero())
+
+	createdReport, err := suite.db.GetReportByID(ctx, testReports["remote_account_1_report_local_account_2"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testAccount.ID, createdReport.Account.ID)
+	suite.False(createdReport.Account.SuspendedAt.IsZero())
+	suite.NotEmpty(createdReport.ActionTaken)
+
+	// Reports targeting the account should still be
+	// listed, so repeat offenders can be looked into.
+	reports, err := suite.db.GetReports(ctx, nil, "", testAccount.ID, "", "", "", 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(reports, 1)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteNotificationsBatched() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	// Delete notifications one at a time.
+	config.SetAccountsDeleteNotificationsBatchSize(1)
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	notifs := []*gtsmodel.Notification{}
+	if err := suite.db.GetAll(ctx, &notifs); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+
+	for _, notif := range notifs {
+		suite.NotEqual(testAccount.ID, notif.TargetAccountID)
+		suite.NotEqual(testAccount.ID, notif.OriginAccountID)
+	}
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteClearsCustomCSS() {
+	ctx := context.Background()
+
+	// Give the account some custom CSS.
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.CustomCSS = "body { background: hotpink; }"
+	if err := suite.db.UpdateAccount(ctx, testAccount, "custom_css"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Custom CSS is only stored on the account row,
+	// so once that's stubbified there's nothing left
+	// to serve at the account's custom CSS endpoint.
+	customCSS, err := suite.db.GetAccountCustomCSSByUsername(ctx, testAccount.Username)
+	suite.NoError(err)
+	suite.Empty(customCSS)
+}
+
+func (suite *AccountDeleteTestSuite) TestScheduleSelfDelete() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Can't schedule a deletion in the past.
+	errWithCode := suite.accountProcessor.ScheduleSelfDelete(ctx, testAccount, time.Now().Add(-time.Hour))
+	suite.Error(errWithCode)
+
+	at := time.Now().Add(24 * time.Hour)
+	if errWithCode := suite.accountProcessor.ScheduleSelfDelete(ctx, testAccount, at); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	user, err := suite.db.GetUserByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(at, user.DeleteScheduledAt, time.Second)
+
+	// Not due yet, so nothing should be picked up.
+	users, err := suite.db.GetUsersScheduledForDeletion(ctx, time.Now())
+	suite.NoError(err)
+	suite.Empty(users)
+
+	// Once due, the user should be picked up.
+	users, err = suite.db.GetUsersScheduledForDeletion(ctx, at.Add(time.Minute))
+	suite.NoError(err)
+	suite.Len(users, 1)
+
+	// Signing back in cancels the deletion.
+	if err := suite.accountProcessor.CancelScheduledSelfDelete(ctx, user); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	users, err = suite.db.GetUsersScheduledForDeletion(ctx, at.Add(time.Minute))
+	suite.NoError(err)
+	suite.Empty(users)
+}
+
+func (suite *AccountDeleteTestSuite) TestDeleteSelfLastAdmin() {
+	ctx := context.Background()
+	adminAccount := suite.testAccounts["admin_account"]
+
+	// The admin is the only admin, so they can't delete themselves...
+	errWithCode := suite.accountProcessor.DeleteSelf(ctx, adminAccount, false)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// ...or schedule their own deletion...
+	errWithCode = suite.accountProcessor.ScheduleSelfDelete(ctx, adminAccount, time.Now().Add(24*time.Hour))
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// ...unless they really mean it.
+	errWithCode = suite.accountProcessor.DeleteSelf(ctx, adminAccount, true)
+	suite.Nil(errWithCode)
+}
+
+func (suite *AccountDeleteTestSuite) TestDeleteSelfNotLastAdmin() {
+	ctx := context.Background()
+	adminAccount := suite.testAccounts["admin_account"]
+
+	// Promote another user to admin.
+	user := new(gtsmodel.User)
+	*user = *suite.testUsers["local_account_1"]
+	user.Admin = testrig.TrueBool()
+	if err := suite.db.UpdateUser(ctx, user, "admin"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now the original admin can leave.
+	errWithCode := suite.accountProcessor.DeleteSelf(ctx, adminAccount, false)
+	suite.Nil(errWithCode)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteInstanceActor() {
+	ctx := context.Background()
+	instanceAccount := suite.testAccounts["instance_account"]
+
+	// The instance actor can't be deleted...
+	errWithCode := suite.accountProcessor.Delete(ctx, instanceAccount, instanceAccount.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// ...not even by force.
+	errWithCode = suite.accountProcessor.DeleteSelf(ctx, instanceAccount, true)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// It should be untouched.
+	dbAccount, err := suite.db.GetAccountByID(ctx, instanceAccount.ID)
+	suite.NoError(err)
+	suite.True(dbAccount.SuspendedAt.IsZero())
+}
+
+func (suite *AccountDeleteTestSuite) TestDeleteSelfEnqueueLost() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Drop enqueued messages on the floor, as
+	// though we crashed before the worker got them.
+	enqueue := suite.state.Workers.EnqueueClientAPI
+	suite.state.Workers.EnqueueClientAPI = func(context.Context, ...messages.FromClientAPI) {}
+
+	if errWithCode := suite.accountProcessor.DeleteSelf(ctx, testAccount, false); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// The request should have been recorded anyway...
+	user, err := suite.db.GetUserByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	requestedAt := user.DeleteRequestedAt
+	suite.WithinDuration(time.Now(), requestedAt, 1*time.Minute)
+
+	// ...and shown to the user.
+	apiAccount, err := suite.tc.AccountToAPIAccountSensitive(ctx, testAccount)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(apiAccount.Source.DeletionRequestedAt)
+
+	// Back from the "crash".
+	suite.state.Workers.EnqueueClientAPI = enqueue
+
+	// Too soon to retry, so nothing should be enqueued.
+	if err := suite.accountProcessor.RetryPendingDeletes(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(suite.fromClientAPIChan)
+
+	// Once it's been a while, the delete should be enqueued again.
+	if err := suite.accountProcessor.RetryPendingDeletes(ctx, time.Now().Add(2*time.Hour)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var retried bool
+	for len(suite.fromClientAPIChan) > 0 {
+		msg := <-suite.fromClientAPIChan
+		if msg.APObjectType == ap.ActorPerson &&
+			msg.APActivityType == ap.ActivityDelete &&
+			msg.TargetAccount.ID == testAccount.ID {
+			retried = true
+		}
+	}
+	suite.True(retried)
+
+	// The retry pushes back the next one.
+	user, err = suite.db.GetUserByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(user.DeleteRequestedAt.Before(requestedAt))
+
+	// Once the worker finishes the delete,
+	// it's no longer pending.
+	if errWithCode := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	users, err := suite.db.GetUsersPendingDeletion(ctx, time.Now().Add(2*time.Hour))
+	suite.NoError(err)
+	suite.Empty(users)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteUndoesBoostOfRemote() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	boostedStatus := suite.testStatuses["remote_account_1_status_1"]
+	boostedAccount := suite.testAccounts["remote_account_1"]
+
+	// Have the account boost a remote status.
+	boost := &gtsmodel.Status{
+		ID:                       "01H2KX6N6Y0Q3QY7AR5P6Z3S1T",
+		URI:                      "http://localhost:8080/users/the_mighty_zork/statuses/01H2KX6N6Y0Q3QY7AR5P6Z3S1T",
+		URL:                      "http://localhost:8080/@the_mighty_zork/statuses/01H2KX6N6Y0Q3QY7AR5P6Z3S1T",
+		CreatedAt:                time.Now(),
+		UpdatedAt:                time.Now(),
+		Local:                    testrig.TrueBool(),
+		AccountURI:               testAccount.URI,
+		AccountID:                testAccount.ID,
+		BoostOfID:                boostedStatus.ID,
+		BoostOfAccountID:         boostedAccount.ID,
+		Visibility:               gtsmodel.VisibilityPublic,
+		CreatedWithApplicationID: "01F8MGXQRHYF5QPMTMXP78QC2F",
+		Federated:                testrig.TrueBool(),
+		Boostable:                testrig.TrueBool(),
+		Replyable:                testrig.TrueBool(),
+		Likeable:                 testrig.TrueBool(),
+		ActivityStreamsType:      ap.ActivityAnnounce,
+	}
+	if err := suite.db.PutStatus(ctx, boost); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var undone bool
+	for len(suite.fromClientAPIChan) > 0 {
+		msg := <-suite.fromClientAPIChan
+
+		status, ok := msg.GTSModel.(*gtsmodel.Status)
+		if !ok || status.ID != boost.ID {
+			continue
+		}
+
+		// The boost should be undone, never deleted.
+		suite.Equal(ap.ActivityAnnounce, msg.APObjectType)
+		suite.Equal(ap.ActivityUndo, msg.APActivityType)
+		suite.Equal(testAccount.ID, msg.OriginAccount.ID)
+		suite.Equal(boostedAccount.ID, msg.TargetAccount.ID)
+		undone = true
+	}
+
+	suite.True(undone)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteUndoesSelfBoost() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	boostedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// Have the account boost its own status.
+	boost := &gtsmodel.Status{
+		ID:                       "01H3TQ5D3V2B8N4W6X1YJZ7K0R",
+		URI:                      "http://localhost:8080/users/the_mighty_zork/statuses/01H3TQ5D3V2B8N4W6X1YJZ7K0R",
+		URL:                      "http://localhost:8080/@the_mighty_zork/statuses/01H3TQ5D3V2B8N4W6X1YJZ7K0R",
+		CreatedAt:                time.Now(),
+		UpdatedAt:                time.Now(),
+		Local:                    testrig.TrueBool(),
+		AccountURI:               testAccount.URI,
+		AccountID:                testAccount.ID,
+		BoostOfID:                boostedStatus.ID,
+		BoostOfAccountID:         testAccount.ID,
+		Visibility:               gtsmodel.VisibilityPublic,
+		CreatedWithApplicationID: "01F8MGXQRHYF5QPMTMXP78QC2F",
+		Federated:                testrig.TrueBool(),
+		Boostable:                testrig.TrueBool(),
+		Replyable:                testrig.TrueBool(),
+		Likeable:                 testrig.TrueBool(),
+		ActivityStreamsType:      ap.ActivityAnnounce,
+	}
+	if err := suite.db.PutStatus(ctx, boost); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var undos, deletes int
+	for len(suite.fromClientAPIChan) > 0 {
+		msg := <-suite.fromClientAPIChan
+
+		status, ok := msg.GTSModel.(*gtsmodel.Status)
+		if !ok {
+			continue
+		}
+
+		switch status.ID {
+		case boost.ID:
+			// The boost should be undone exactly once, with the
+			// boosted status already set, since that status is
+			// getting deleted at the same time.
+			suite.Equal(ap.ActivityAnnounce, msg.APObjectType)
+			suite.Equal(ap.ActivityUndo, msg.APActivityType)
+			suite.Equal(testAccount.ID, msg.OriginAccount.ID)
+			suite.Equal(testAccount.ID, msg.TargetAccount.ID)
+			suite.NotNil(status.BoostOf)
+			suite.Equal(boostedStatus.URI, status.BoostOf.URI)
+			undos++
+		case boostedStatus.ID:
+			suite.Equal(ap.ActivityDelete, msg.APActivityType)
+			deletes++
+		}
+	}
+
+	suite.Equal(1, undos)
+	suite.Equal(1, deletes)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteSelfFaveNoUndo() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	testStatus := suite.testStatuses["local_account_1_status_1"]
+
+	// Have the account fave its own status.
+	fave := &gtsmodel.StatusFave{
+		ID:              "01H2S4C0C8PZ7S4W5YV0B7Q1DN",
+		AccountID:       testAccount.ID,
+		TargetAccountID: testAccount.ID,
+		StatusID:        testStatus.ID,
+		URI:             "http://localhost:8080/users/the_mighty_zork/liked/01H2S4C0C8PZ7S4W5YV0B7Q1DN",
+	}
+	if err := suite.db.PutStatusFave(ctx, fave); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The fave should be gone...
+	_, err := suite.db.GetStatusFaveByID(ctx, fave.ID)
+	suite.Error(err)
+
+	// ...without any Undo Like being federated for it.
+	for len(suite.fromClientAPIChan) > 0 {
+		msg := <-suite.fromClientAPIChan
+		suite.False(
+			msg.APObjectType == ap.ActivityLike && msg.APActivityType == ap.ActivityUndo,
+			"unexpected undo like: %+v", msg.GTSModel,
+		)
+	}
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteSelfFollowAndBlock() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Seed a follow and a block of the account by itself.
+	follow := &gtsmodel.Follow{
+		ID:              "01H3M0QF2ZC7Y3B6Q8N1W4T5VA",
+		URI:             "http://localhost:8080/users/the_mighty_zork/follow/01H3M0QF2ZC7Y3B6Q8N1W4T5VA",
+		AccountID:       testAccount.ID,
+		TargetAccountID: testAccount.ID,
+	}
+	if err := suite.db.PutFollow(ctx, follow); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	block := &gtsmodel.Block{
+		ID:              "01H3M0SWB8R4E6K2Y9G5D7P1HC",
+		URI:             "http://localhost:8080/users/the_mighty_zork/blocks/01H3M0SWB8R4E6K2Y9G5D7P1HC",
+		AccountID:       testAccount.ID,
+		TargetAccountID: testAccount.ID,
+	}
+	if err := suite.db.PutBlock(ctx, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Both should be gone...
+	_, err := suite.db.GetFollowByID(ctx, follow.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.db.GetBlockByID(ctx, block.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// ...without anything being federated for them.
+	for len(suite.fromClientAPIChan) > 0 {
+		msg := <-suite.fromClientAPIChan
+		suite.False(
+			msg.APActivityType == ap.ActivityUndo &&
+				(msg.APObjectType == ap.ActivityFollow || msg.APObjectType == ap.ActivityBlock),
+			"unexpected undo: %+v", msg.GTSModel,
+		)
+	}
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteDecrementsRemoteFaveCount() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	remoteStatus := suite.testStatuses["remote_account_1_status_1"]
+
+	// Have the account fave a remote status.
+	fave := &gtsmodel.StatusFave{
+		ID:              "01H3E8ZB5P2W7MKQ4T1V6XNR9C",
+		AccountID:       testAccount.ID,
+		TargetAccountID: remoteStatus.AccountID,
+		StatusID:        remoteStatus.ID,
+		URI:             "http://localhost:8080/users/the_mighty_zork/liked/01H3E8ZB5P2W7MKQ4T1V6XNR9C",
+	}
+	if err := suite.db.PutStatusFave(ctx, fave); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	before, err := suite.db.CountStatusFaves(ctx, remoteStatus)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Counts are derived from the faves
+	// table, so removing the fave should
+	// be reflected straight away.
+	after, err := suite.db.CountStatusFaves(ctx, remoteStatus)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(before-1, after)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteEnqueuedStatusDeletes() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Work out which of the account's
+	// statuses should be deleted.
+	expected := make(map[string]bool)
+	for _, status := range suite.testStatuses {
+		if status.AccountID == testAccount.ID && status.BoostOfID == "" {
+			expected[status.ID] = true
+		}
+	}
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Gather every status delete that was enqueued
+	// (enqueueing is captured by the test suite).
+	deleted := make(map[string]bool)
+	for len(suite.fromClientAPIChan) > 0 {
+		msg := <-suite.fromClientAPIChan
+		if msg.APObjectType != ap.ObjectNote || msg.APActivityType != ap.ActivityDelete {
+			continue
+		}
+
+		status, ok := msg.GTSModel.(*gtsmodel.Status)
+		if !ok {
+			suite.FailNow("", "unexpected model in status delete: %+v", msg.GTSModel)
+		}
+
+		suite.False(deleted[status.ID], "status %s delete enqueued twice", status.ID)
+		suite.Equal(testAccount.ID, msg.OriginAccount.ID)
+		suite.Equal(testAccount.ID, msg.TargetAccount.ID)
+		deleted[status.ID] = true
+	}
+
+	suite.Equal(expected, deleted)
+}
+
+// reblogQueryCountingDB wraps a db.DB to count
+// how many times boosts of statuses are looked up.
+type reblogQueryCountingDB struct {
+	db.DB
+	singleQueries int
+	batchQueries  int
+}
+
+func (r *reblogQueryCountingDB) GetStatusReblogs(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, db.Error) {
+	r.singleQueries++
+	return r.DB.GetStatusReblogs(ctx, status)
+}
+
+func (r *reblogQueryCountingDB) GetReblogsForStatusIDs(ctx context.Context, statusIDs []string) (map[string][]*gtsmodel.Status, db.Error) {
+	r.batchQueries++
+	return r.DB.GetReblogsForStatusIDs(ctx, statusIDs)
+}
+
+func (suite *AccountDeleteTestSuite) TestAccountDeleteBatchesReblogQueries() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	boostingAccount := suite.testAccounts["admin_account"]
+	boost := suite.testStatuses["admin_account_status_4"]
+
+	countingDB := &reblogQueryCountingDB{DB: suite.db}
+	suite.state.DB = countingDB
+
+	if err := suite.accountProcessor.Delete(ctx, testAccount, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// All of the account's statuses fit in one
+	// page, so there should be one batched lookup
+	// of boosts, and no per-status lookups at all.
+	suite.Equal(1, countingDB.batchQueries)
+	suite.Zero(countingDB.singleQueries)
+
+	// The admin's boost of the account's status
+	// should still have been picked up and undone.
+	var undone bool
+	for len(suite.fromClientAPIChan) > 0 {
+		msg := <-suite.fromClientAPIChan
+
+		status, ok := msg.GTSModel.(*gtsmodel.Status)
+		if !ok || status.ID != boost.ID {
+			continue
+		}
+
+		suite.Equal(ap.ActivityAnnounce, msg.APObjectType)
+		suite.Equal(ap.ActivityUndo, msg.APActivityType)
+		suite.Equal(boostingAccount.ID, msg.OriginAccount.ID)
+		undone = true
+	}
+
+	suite.True(undone)
+}
+
+func (suite *AccountDeleteTestSuite) TestCleanupPeripheralForSuspendedAccounts() {
+	ctx := context.Background()
+
+	// Suspend an account without cleaning up after
+	// it, as though it was stubbified by an older version.
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.SuspendedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, testAccount, "suspended_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Leave a status mute behind, too.
+	status := suite.testStatuses["admin_account_status_1"]
+	if err := suite.db.Put(ctx, &gtsmodel.StatusMute{
+		ID:              "01H9CGSN3YSGC8RPRP9X5YS8ZZ",
+		AccountID:       testAccount.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	leftover, err := suite.db.CountAccountPeripheral(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(leftover)
+
+	accounts, items, err := suite.accountProcessor.CleanupPeripheralForSuspendedAccounts(ctx)
+	suite.NoError(err)
+	suite.Equal(1, accounts)
+	suite.Equal(leftover, items)
+
+	muted, err := suite.db.IsStatusMutedBy(ctx, status, testAccount.ID)
+	suite.NoError(err)
+	suite.False(muted)
+
+	leftover, err = suite.db.CountAccountPeripheral(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Zero(leftover)
+
+	// Running again should be a no-op.
+	accounts, items, err = suite.accountProcessor.CleanupPeripheralForSuspendedAccounts(ctx)
+	suite.NoError(err)
+	suite.Zero(accounts)
+	suite.Zero(items)
+}
+
+func (suite *AccountDeleteTestSuite) TestRestubbifyAllSuspended() {
+	ctx := context.Background()
+
+	// Suspend an account without clearing
+	// fields that stubbifyAccount now clears, as
+	// though it was stubbified by an older version.
+	suspendedAt := time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.SuspendedAt = suspendedAt
+	testAccount.SuspensionOrigin = testAccount.ID
+	testAccount.CustomCSS = "body { background: hotpink; }"
+	if err := suite.db.UpdateAccount(ctx, testAccount, "suspended_at", "suspension_origin", "custom_css"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	updated, err := suite.accountProcessor.RestubbifyAllSuspended(ctx)
+	suite.NoError(err)
+	suite.Equal(1, updated)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(dbAccount.CustomCSS)
+	suite.Zero(dbAccount.DisplayName)
+	suite.Zero(dbAccount.Note)
+	suite.True(*dbAccount.HideCollections)
+
+	// The original suspension should be kept.
+	suite.True(suspendedAt.Equal(dbAccount.SuspendedAt))
+	suite.Equal(testAccount.ID, dbAccount.SuspensionOrigin)
+
+	// Running again should be a no-op.
+	updated, err = suite.accountProcessor.RestubbifyAllSuspended(ctx)
+	suite.NoError(err)
+	suite.Zero(updated)
+}
+
+func (suite *AccountDeleteTestSuite) TestRestubbifyAccountNotSuspended() {
+	_, err := suite.accountProcessor.RestubbifyAccount(context.Background(), suite.testAccounts["local_account_1"].ID)
+	suite.Error(err)
+}
+
 func TestAccountDeleteTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountDeleteTestSuite))
 }
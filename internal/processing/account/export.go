@@ -0,0 +1,208 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// exportSelectLimit is the amount of rows to
+// select from the db at once when exporting.
+const exportSelectLimit = 100
+
+// ExportAccountBlocks returns a CSV of the accounts blocked by the given
+// account, one namestring per line, eg., `someone@example.org`, in the
+// same format accepted by ImportFollows, so that a user can take their
+// blocklist with them to another instance before deleting their account.
+//
+// Blocks are paged from the db and written as the returned reader is read,
+// so big blocklists are never held in memory all at once. The caller must
+// close the reader, which stops the export if it isn't finished.
+//
+// Only account blocks are exported; this instance has no user-level domain
+// blocks, and instance-level domain blocks aren't the account's to export.
+func (p *Processor) ExportAccountBlocks(ctx context.Context, account *gtsmodel.Account) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+
+	go func() {
+		// Close the write end with the export error, if any,
+		// so that it's returned to the reader instead of EOF.
+		pw.CloseWithError(p.exportAccountBlocks(ctx, account, pw))
+	}()
+
+	return pr, nil
+}
+
+func (p *Processor) exportAccountBlocks(ctx context.Context, account *gtsmodel.Account, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	maxID := ""
+
+	for {
+		targets, nextMaxID, _, err := p.state.DB.GetAccountBlocks(ctx, account.ID, maxID, "", exportSelectLimit)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// No more blocks.
+				break
+			}
+			return fmt.Errorf("exportAccountBlocks: db error getting blocks of %s: %w", account.ID, err)
+		}
+
+		for _, target := range targets {
+			if target == nil {
+				// Target account has
+				// been deleted, skip.
+				continue
+			}
+
+			if err := cw.Write([]string{exportNamestring(target)}); err != nil {
+				log.WithContext(ctx).Debugf("block export of %s stopped: %v", account.Username, err)
+				return err
+			}
+		}
+
+		// Flush each page through to the reader.
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+
+		maxID = nextMaxID
+	}
+
+	return nil
+}
+
+// ExportFollowList returns a CSV of the accounts followed by the given account,
+// in the same format as Mastodon's following_accounts.csv export, so that it
+// can be imported by other fediverse software:
+//
+//	Account address,Show boosts,Notify on new posts,Languages
+//	someone@example.org,true,false,
+//
+// Follows here aren't filtered by language, so Languages is always empty.
+func (p *Processor) ExportFollowList(ctx context.Context, account *gtsmodel.Account) ([]byte, error) {
+	follows, err := p.state.DB.GetAccountFollows(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("ExportFollowList: db error getting follows of %s: %w", account.ID, err)
+	}
+
+	buf := new(bytes.Buffer)
+	cw := csv.NewWriter(buf)
+
+	if err := cw.Write([]string{
+		"Account address",
+		"Show boosts",
+		"Notify on new posts",
+		"Languages",
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, follow := range follows {
+		if follow.TargetAccount == nil {
+			// Target account has
+			// been deleted, skip.
+			continue
+		}
+
+		if err := cw.Write([]string{
+			exportNamestring(follow.TargetAccount),
+			strconv.FormatBool(*follow.ShowReblogs),
+			strconv.FormatBool(*follow.Notify),
+			"",
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ExportAccountBundle returns a zip of the account's exports, for a user
+// to download before deleting their account. It contains the follows CSV
+// from ExportFollowList as following_accounts.csv, and the blocks CSV from
+// ExportAccountBlocks as blocked_accounts.csv, named as in Mastodon exports.
+//
+// Like ExportAccountBlocks, the zip is written as the returned reader is
+// read, and the caller must close the reader.
+func (p *Processor) ExportAccountBundle(ctx context.Context, account *gtsmodel.Account) (io.ReadCloser, error) {
+	follows, err := p.ExportFollowList(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(p.exportAccountBundle(ctx, account, follows, pw))
+	}()
+
+	return pr, nil
+}
+
+func (p *Processor) exportAccountBundle(ctx context.Context, account *gtsmodel.Account, follows []byte, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	fw, err := zw.Create("following_accounts.csv")
+	if err != nil {
+		return err
+	}
+
+	if _, err := fw.Write(follows); err != nil {
+		return err
+	}
+
+	bw, err := zw.Create("blocked_accounts.csv")
+	if err != nil {
+		return err
+	}
+
+	if err := p.exportAccountBlocks(ctx, account, bw); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// exportNamestring returns the namestring of the given account,
+// including the domain even for local accounts, since an export
+// is meant to be imported elsewhere.
+func exportNamestring(account *gtsmodel.Account) string {
+	domain := account.Domain
+	if domain == "" {
+		domain = config.GetAccountDomain()
+	}
+	return account.Username + "@" + domain
+}
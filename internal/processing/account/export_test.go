@@ -0,0 +1,130 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ExportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ExportTestSuite) TestExportAccountBlocks() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_2"]
+
+	// Block a local account too, on top of
+	// the remote account from the test blocks.
+	if err := suite.db.PutBlock(ctx, &gtsmodel.Block{
+		ID:              "01H3WB6Y2R1XQ7Z8T3GJ5N0K9M",
+		URI:             "http://localhost:8080/users/1happyturtle/blocks/01H3WB6Y2R1XQ7Z8T3GJ5N0K9M",
+		AccountID:       account.ID,
+		TargetAccountID: suite.testAccounts["local_account_1"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	export, err := suite.accountProcessor.ExportAccountBlocks(ctx, account)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer export.Close()
+
+	b, err := io.ReadAll(export)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Newest block first, and local
+	// accounts include the domain.
+	suite.Equal("the_mighty_zork@localhost:8080\nfoss_satan@fossbros-anonymous.io\n", string(b))
+}
+
+func (suite *ExportTestSuite) TestExportAccountBlocksEmpty() {
+	export, err := suite.accountProcessor.ExportAccountBlocks(context.Background(), suite.testAccounts["local_account_1"])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer export.Close()
+
+	b, err := io.ReadAll(export)
+	suite.NoError(err)
+	suite.Empty(b)
+}
+
+func (suite *ExportTestSuite) TestExportFollowList() {
+	b, err := suite.accountProcessor.ExportFollowList(context.Background(), suite.testAccounts["local_account_2"])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("Account address,Show boosts,Notify on new posts,Languages\n"+
+		"the_mighty_zork@localhost:8080,true,false,\n", string(b))
+}
+
+func (suite *ExportTestSuite) TestExportAccountBundle() {
+	export, err := suite.accountProcessor.ExportAccountBundle(context.Background(), suite.testAccounts["local_account_2"])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer export.Close()
+
+	b, err := io.ReadAll(export)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string]string, len(zr.File))
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		files[f.Name] = string(content)
+	}
+
+	suite.Equal(map[string]string{
+		"following_accounts.csv": "Account address,Show boosts,Notify on new posts,Languages\n" +
+			"the_mighty_zork@localhost:8080,true,false,\n",
+		"blocked_accounts.csv": "foss_satan@fossbros-anonymous.io\n",
+	}, files)
+}
+
+func TestExportTestSuite(t *testing.T) {
+	suite.Run(t, new(ExportTestSuite))
+}
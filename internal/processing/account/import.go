@@ -0,0 +1,364 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"sync"
+	"time"
+
+	"codeberg.org/gruf/go-kv"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const importFollowsLogEvery = 50
+
+// importFollowsInterval is the minimum time to wait between
+// outbound lookups of remote accounts when importing follows,
+// so that a big import doesn't hammer remote instances.
+var importFollowsInterval = 2 * time.Second
+
+// followsImports keeps track of the latest follows
+// import of each account, keyed by account ID, so
+// that its progress can be checked while it runs.
+type followsImports struct {
+	jobs map[string]*followsImport
+	mu   sync.Mutex
+}
+
+// followsImport is the record of one follows import job.
+type followsImport struct {
+	total      int
+	succeeded  int
+	failed     int
+	createdAt  time.Time
+	finishedAt time.Time
+	mu         sync.Mutex
+}
+
+func newFollowsImports() *followsImports {
+	return &followsImports{jobs: make(map[string]*followsImport)}
+}
+
+// add records a new import of total targets for the given
+// account, returning false if one is still in progress.
+func (f *followsImports) add(accountID string, total int) (*followsImport, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if job, ok := f.jobs[accountID]; ok && !job.finished() {
+		return nil, false
+	}
+
+	job := &followsImport{
+		total:     total,
+		createdAt: time.Now(),
+	}
+	f.jobs[accountID] = job
+	return job, true
+}
+
+// get returns the latest import for the given account, if any.
+func (f *followsImports) get(accountID string) (*followsImport, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	job, ok := f.jobs[accountID]
+	return job, ok
+}
+
+func (j *followsImport) record(succeeded bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if succeeded {
+		j.succeeded++
+	} else {
+		j.failed++
+	}
+}
+
+func (j *followsImport) finish() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.finishedAt = time.Now()
+}
+
+func (j *followsImport) finished() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return !j.finishedAt.IsZero()
+}
+
+func (j *followsImport) toAPI() *apimodel.FollowsImport {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	apiImport := &apimodel.FollowsImport{
+		State:     "in_progress",
+		Total:     j.total,
+		Succeeded: j.succeeded,
+		Failed:    j.failed,
+		CreatedAt: util.FormatISO8601(j.createdAt),
+	}
+
+	if !j.finishedAt.IsZero() {
+		apiImport.State = "finished"
+		finishedAt := util.FormatISO8601(j.finishedAt)
+		apiImport.FinishedAt = &finishedAt
+	}
+
+	return apiImport
+}
+
+// FollowsImportStart parses the given follows CSV, in the same format as
+// ExportFollowList, and starts importing it with ImportFollows in the
+// background. The returned import can be checked with FollowsImportGet.
+//
+// Only one import per account may run at a time.
+func (p *Processor) FollowsImportStart(ctx context.Context, account *gtsmodel.Account, data *multipart.FileHeader) (*apimodel.FollowsImport, gtserror.WithCode) {
+	f, err := data.Open()
+	if err != nil {
+		err = fmt.Errorf("FollowsImportStart: error opening attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer f.Close()
+
+	targetURIs, err := readFollowsCSV(f)
+	if err != nil {
+		err = fmt.Errorf("FollowsImportStart: error reading attachment: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(targetURIs) == 0 {
+		err := errors.New("FollowsImportStart: no accounts to follow in attachment")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	job, ok := p.imports.add(account.ID, len(targetURIs))
+	if !ok {
+		err := errors.New("a follows import is already in progress")
+		return nil, gtserror.NewErrorConflict(err, err.Error())
+	}
+
+	_ = p.state.Workers.ClientAPI.MustEnqueueCtx(ctx, func(ctx context.Context) {
+		defer job.finish()
+
+		if _, _, err := p.importFollows(ctx, account, targetURIs, job); err != nil {
+			log.Errorf(ctx, "error importing follows for %s: %v", account.Username, err)
+		}
+	})
+
+	return job.toAPI(), nil
+}
+
+// FollowsImportGet returns the latest follows import of the given account.
+func (p *Processor) FollowsImportGet(ctx context.Context, account *gtsmodel.Account) (*apimodel.FollowsImport, gtserror.WithCode) {
+	job, ok := p.imports.get(account.ID)
+	if !ok {
+		err := fmt.Errorf("FollowsImportGet: no follows import for account %s", account.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return job.toAPI(), nil
+}
+
+// readFollowsCSV reads the account addresses from the first column of
+// the given follows CSV, skipping the header line if there is one.
+func readFollowsCSV(r io.Reader) ([]string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	targetURIs := make([]string, 0, len(records))
+	for i, record := range records {
+		if i == 0 && record[0] == "Account address" {
+			// Header line.
+			continue
+		}
+		targetURIs = append(targetURIs, record[0])
+	}
+
+	return targetURIs, nil
+}
+
+// ImportFollows makes the given local account follow each of the accounts in
+// targetURIs, which should be namestrings like those found in a follows CSV
+// export, eg., `someone@example.org` or `acct:someone@example.org`.
+//
+// Targets that the account already follows or has requested to follow are
+// skipped, but still count as succeeded. Failure to resolve or follow one
+// target (eg., because its instance is unreachable) doesn't stop the import.
+//
+// The returned ints are the amount of targets that succeeded, and that failed.
+func (p *Processor) ImportFollows(ctx context.Context, account *gtsmodel.Account, targetURIs []string) (int, int, error) {
+	return p.importFollows(ctx, account, targetURIs, &followsImport{total: len(targetURIs)})
+}
+
+// importFollows does the work of ImportFollows,
+// recording the outcome of each target on job.
+func (p *Processor) importFollows(ctx context.Context, account *gtsmodel.Account, targetURIs []string, job *followsImport) (int, int, error) {
+	l := log.WithContext(ctx).WithFields(kv.Fields{
+		{"username", account.Username},
+		{"targets", len(targetURIs)},
+	}...)
+	l.Info("beginning follows import")
+
+	following, err := p.existingFollowTargets(ctx, account)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var (
+		succeeded  int
+		failed     int
+		lastRemote time.Time
+	)
+
+	// Record the outcome of each
+	// target as soon as it's known.
+	succeed := func() { succeeded++; job.record(true) }
+	fail := func() { failed++; job.record(false) }
+
+	for i, targetURI := range targetURIs {
+		if i != 0 && i%importFollowsLogEvery == 0 {
+			l.Infof("imported %d/%d follows (%d failed)", i, len(targetURIs), failed)
+		}
+
+		targetURI = strings.TrimSpace(targetURI)
+		if targetURI == "" {
+			// Blank line,
+			// just skip it.
+			continue
+		}
+
+		username, domain, err := util.ExtractWebfingerParts(targetURI)
+		if err != nil {
+			l.Debugf("skipping invalid follow target %q: %v", targetURI, err)
+			fail()
+			continue
+		}
+
+		if domain == config.GetHost() || domain == config.GetAccountDomain() {
+			// We do local lookups using an empty domain,
+			// else it will fail the db search below.
+			domain = ""
+		}
+
+		if domain != "" {
+			// Wait between remote lookups, unless
+			// enough time has passed already.
+			if wait := importFollowsInterval - time.Since(lastRemote); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return succeeded, failed, ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+			lastRemote = time.Now()
+		}
+
+		targetAccount, err := p.importFollowTarget(ctx, account, username, domain)
+		if err != nil {
+			l.Debugf("error getting follow target %s: %v", targetURI, err)
+			fail()
+			continue
+		}
+
+		if _, ok := following[targetAccount.ID]; ok {
+			// Already following or
+			// requested, nothing to do.
+			succeed()
+			continue
+		}
+
+		if _, errWithCode := p.FollowCreate(ctx, account, &apimodel.AccountFollowRequest{
+			ID: targetAccount.ID,
+		}); errWithCode != nil {
+			l.Debugf("error following %s: %v", targetURI, errWithCode)
+			fail()
+			continue
+		}
+
+		// Don't try to follow the same
+		// target twice if it's listed twice.
+		following[targetAccount.ID] = struct{}{}
+		succeed()
+	}
+
+	l.Infof("follows import finished: %d succeeded, %d failed", succeeded, failed)
+	return succeeded, failed, nil
+}
+
+// existingFollowTargets returns the IDs of all accounts
+// that the given account follows or has requested to follow.
+func (p *Processor) existingFollowTargets(ctx context.Context, account *gtsmodel.Account) (map[string]struct{}, error) {
+	follows, err := p.state.DB.GetAccountFollows(gtscontext.SetBarebones(ctx), account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("existingFollowTargets: db error getting follows owned by account %s: %w", account.ID, err)
+	}
+
+	followRequests, err := p.state.DB.GetAccountFollowRequesting(gtscontext.SetBarebones(ctx), account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("existingFollowTargets: db error getting follow requests owned by account %s: %w", account.ID, err)
+	}
+
+	targets := make(map[string]struct{}, len(follows)+len(followRequests))
+	for _, follow := range follows {
+		targets[follow.TargetAccountID] = struct{}{}
+	}
+	for _, followRequest := range followRequests {
+		targets[followRequest.TargetAccountID] = struct{}{}
+	}
+
+	return targets, nil
+}
+
+// importFollowTarget gets the account with the given username and
+// domain, dereferencing it first if it's remote and not yet known.
+func (p *Processor) importFollowTarget(ctx context.Context, account *gtsmodel.Account, username string, domain string) (*gtsmodel.Account, error) {
+	if domain == "" {
+		return p.state.DB.GetAccountByUsernameDomain(ctx, username, "")
+	}
+
+	targetAccount, _, err := p.federator.GetAccountByUsernameDomain(
+		gtscontext.SetFastFail(ctx),
+		account.Username,
+		username, domain,
+	)
+	return targetAccount, err
+}
@@ -0,0 +1,176 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ImportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ImportTestSuite) TestImportFollows() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_2"]
+	adminAccount := suite.testAccounts["admin_account"]
+
+	succeeded, failed, err := suite.accountProcessor.ImportFollows(ctx, account, []string{
+		"the_mighty_zork@localhost:8080", // already followed
+		"acct:admin@localhost:8080",
+		"@admin@localhost:8080", // listed twice
+		"",                      // blank line
+		"not a namestring",
+		"nobody@localhost:8080", // doesn't exist
+	})
+	suite.NoError(err)
+	suite.Equal(3, succeeded)
+	suite.Equal(2, failed)
+
+	// Admin account isn't locked, so
+	// the follow should be in place.
+	follows, err := suite.db.IsFollowing(ctx, account.ID, adminAccount.ID)
+	suite.NoError(err)
+	suite.True(follows)
+}
+
+func (suite *ImportTestSuite) TestImportFollowsThrottled() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_2"]
+
+	// Local targets don't wait for anything.
+	start := time.Now()
+	_, _, err := suite.accountProcessor.ImportFollows(ctx, account, []string{
+		"admin@localhost:8080",
+		"the_mighty_zork@localhost:8080",
+	})
+	suite.NoError(err)
+	suite.Less(time.Since(start), 2*time.Second)
+
+	// The second remote target has to wait for
+	// the import interval after the first one.
+	start = time.Now()
+	_, _, err = suite.accountProcessor.ImportFollows(ctx, account, []string{
+		"foss_satan@fossbros-anonymous.io",
+		"Some_User@example.org",
+	})
+	suite.NoError(err)
+	suite.GreaterOrEqual(time.Since(start), 2*time.Second)
+}
+
+func (suite *ImportTestSuite) TestImportFollowsThrottledCancelled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Cancelling the import while it waits between
+	// remote targets stops it with the context error.
+	succeeded, failed, err := suite.accountProcessor.ImportFollows(ctx, suite.testAccounts["local_account_2"], []string{
+		"foss_satan@fossbros-anonymous.io",
+		"Some_User@example.org",
+	})
+	suite.ErrorIs(err, context.Canceled)
+	suite.Equal(1, succeeded+failed)
+}
+
+func (suite *ImportTestSuite) TestFollowsImportStart() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_2"]
+
+	data := suite.followsCSV("Account address,Show boosts,Notify on new posts,Languages\n" +
+		"admin@localhost:8080,true,false,\n" +
+		"foss_satan@fossbros-anonymous.io,true,false,\n" +
+		"Some_User@example.org,true,false,\n")
+
+	// No import yet.
+	_, errWithCode := suite.accountProcessor.FollowsImportGet(ctx, account)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	followsImport, errWithCode := suite.accountProcessor.FollowsImportStart(ctx, account, data)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("in_progress", followsImport.State)
+	suite.Equal(3, followsImport.Total)
+	suite.Nil(followsImport.FinishedAt)
+
+	// The second remote target keeps the import
+	// waiting, so another can't be started yet.
+	_, errWithCode = suite.accountProcessor.FollowsImportStart(ctx, account, data)
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	if !testrig.WaitFor(func() bool {
+		followsImport, errWithCode = suite.accountProcessor.FollowsImportGet(ctx, account)
+		return errWithCode == nil && followsImport.State == "finished"
+	}) {
+		suite.FailNow("timed out waiting for import to finish")
+	}
+	suite.Equal(3, followsImport.Succeeded+followsImport.Failed)
+	suite.NotNil(followsImport.FinishedAt)
+
+	follows, err := suite.db.IsFollowing(ctx, account.ID, suite.testAccounts["admin_account"].ID)
+	suite.NoError(err)
+	suite.True(follows)
+}
+
+func (suite *ImportTestSuite) TestFollowsImportStartEmpty() {
+	_, errWithCode := suite.accountProcessor.FollowsImportStart(
+		context.Background(),
+		suite.testAccounts["local_account_2"],
+		suite.followsCSV("Account address,Show boosts,Notify on new posts,Languages\n"),
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+// followsCSV returns the given CSV as if it had
+// been uploaded in the data field of a form.
+func (suite *ImportTestSuite) followsCSV(data string) *multipart.FileHeader {
+	b := new(bytes.Buffer)
+	w := multipart.NewWriter(b)
+
+	fw, err := w.CreateFormFile("data", "follows.csv")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := fw.Write([]byte(data)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(b, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return form.File["data"][0]
+}
+
+func TestImportTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportTestSuite))
+}
@@ -0,0 +1,238 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"codeberg.org/gruf/go-kv"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// MergeAccounts merges the local account with sourceID into the local
+// account with targetID, for cleaning up duplicate accounts.
+//
+// Statuses, media, follows, faves, boosts, and bookmarks of the source
+// account are reassigned to the target account in one transaction. Where
+// the target account already has an equivalent follow, fave, boost, or
+// bookmark, the source's copy is dropped, as are follows which would end
+// up with the target following itself.
+//
+// Follows of remote accounts by the source are undone, and the target
+// requests to follow those accounts instead, since remotes have to
+// accept the target as a follower themselves.
+//
+// Statuses keep their original URIs, so that remote instances can still
+// dereference them. Once everything has been moved across, the source
+// account is stubbified in the same way as in Delete, with the target
+// account ID as suspension origin.
+func (p *Processor) MergeAccounts(ctx context.Context, sourceID string, targetID string) gtserror.WithCode {
+	if sourceID == targetID {
+		err := errors.New("cannot merge an account into itself")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	source, errWithCode := p.getMergeAccount(ctx, sourceID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	target, errWithCode := p.getMergeAccount(ctx, targetID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	l := log.WithContext(ctx).WithFields(kv.Fields{
+		{"source", source.Username},
+		{"target", target.Username},
+	}...)
+	l.Trace("beginning account merge process")
+
+	// The merge drops follows of remote accounts by
+	// source, so get them first to federate the undo.
+	remoteFollows, err := p.getMergeRemoteFollows(ctx, source)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.MergeAccounts(ctx, source, target); err != nil {
+		err = fmt.Errorf("MergeAccounts: db error merging accounts: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	msgs, err := p.mergeRemoteFollows(ctx, source, target, remoteFollows)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Whatever is left attached to the source
+	// account can now be cleared out as in Delete.
+	if err := p.deleteUserAndTokensForAccount(ctx, source); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.deleteAccountBlocks(ctx, source); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.deleteAccountNotifications(ctx, source); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	columns := stubbifyAccount(source, target.ID)
+	if err := p.state.DB.UpdateAccount(ctx, source, columns...); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Process the undos + follow requests.
+	p.state.Workers.EnqueueClientAPI(ctx, msgs...)
+
+	l.Info("accounts merged")
+	return nil
+}
+
+// getMergeAccount fetches the account with the given ID,
+// checking that it's a local, non-suspended account.
+func (p *Processor) getMergeAccount(ctx context.Context, id string) (*gtsmodel.Account, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("account %s not found", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !account.IsLocal() {
+		err := fmt.Errorf("account %s is not a local account", id)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if !account.SuspendedAt.IsZero() {
+		err := fmt.Errorf("account %s is suspended", id)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	return account, nil
+}
+
+// getMergeRemoteFollows returns follows and follow requests of remote accounts
+// by the given account. Follow requests are dummied out as follows, like in
+// deleteAccountFollows; these never enter the db, they're just for convenience.
+func (p *Processor) getMergeRemoteFollows(ctx context.Context, account *gtsmodel.Account) ([]*gtsmodel.Follow, error) {
+	following, err := p.state.DB.GetAccountFollows(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("getMergeRemoteFollows: db error getting follows owned by account %s: %w", account.ID, err)
+	}
+
+	followRequesting, err := p.state.DB.GetAccountFollowRequesting(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("getMergeRemoteFollows: db error getting follow requests owned by account %s: %w", account.ID, err)
+	}
+
+	for _, followRequest := range followRequesting {
+		following = append(following, &gtsmodel.Follow{
+			URI:             followRequest.URI,
+			AccountID:       followRequest.AccountID,
+			Account:         followRequest.Account,
+			TargetAccountID: followRequest.TargetAccountID,
+			TargetAccount:   followRequest.TargetAccount,
+			ShowReblogs:     followRequest.ShowReblogs,
+			Notify:          followRequest.Notify,
+		})
+	}
+
+	remoteFollows := make([]*gtsmodel.Follow, 0, len(following))
+	for _, follow := range following {
+		if follow.TargetAccount == nil || follow.TargetAccount.IsLocal() {
+			continue
+		}
+		remoteFollows = append(remoteFollows, follow)
+	}
+
+	return remoteFollows, nil
+}
+
+// mergeRemoteFollows returns messages undoing each of source's given follows
+// of remote accounts, and requests to follow the same accounts from target,
+// where target doesn't already follow or request to follow them.
+func (p *Processor) mergeRemoteFollows(ctx context.Context, source *gtsmodel.Account, target *gtsmodel.Account, remoteFollows []*gtsmodel.Follow) ([]messages.FromClientAPI, error) {
+	var (
+		msgs                = make([]messages.FromClientAPI, 0, 2*len(remoteFollows))
+		unfollowSideEffects = p.unfollowSideEffectsFunc(source)
+	)
+
+	for _, follow := range remoteFollows {
+		if msg := unfollowSideEffects(ctx, source, follow); msg != nil {
+			msgs = append(msgs, *msg)
+		}
+
+		following, err := p.state.DB.IsFollowing(ctx, target.ID, follow.TargetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("mergeRemoteFollows: db error checking follow: %w", err)
+		}
+
+		requested, err := p.state.DB.IsFollowRequested(ctx, target.ID, follow.TargetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("mergeRemoteFollows: db error checking follow request: %w", err)
+		}
+
+		if following || requested {
+			continue
+		}
+
+		followID, err := id.NewRandomULID()
+		if err != nil {
+			return nil, fmt.Errorf("mergeRemoteFollows: error generating id: %w", err)
+		}
+
+		fr := &gtsmodel.FollowRequest{
+			ID:              followID,
+			URI:             uris.GenerateURIForFollow(target.Username, followID),
+			AccountID:       target.ID,
+			Account:         target,
+			TargetAccountID: follow.TargetAccountID,
+			TargetAccount:   follow.TargetAccount,
+			ShowReblogs:     follow.ShowReblogs,
+			Notify:          follow.Notify,
+		}
+
+		if err := p.state.DB.PutFollowRequest(ctx, fr); err != nil {
+			return nil, fmt.Errorf("mergeRemoteFollows: db error putting follow request: %w", err)
+		}
+
+		msgs = append(msgs, messages.FromClientAPI{
+			APObjectType:   ap.ActivityFollow,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       fr,
+			OriginAccount:  target,
+			TargetAccount:  follow.TargetAccount,
+		})
+	}
+
+	return msgs, nil
+}
@@ -0,0 +1,252 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type AccountMergeTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountMergeTestSuite) TestMergeAccounts() {
+	var (
+		ctx           = context.Background()
+		testFaves     = testrig.NewTestFaves()
+		source        = suite.testAccounts["local_account_2"]
+		target        = suite.testAccounts["local_account_1"]
+		admin         = suite.testAccounts["admin_account"]
+		remote        = suite.testAccounts["remote_account_1"]
+		sourceStatus  = suite.testStatuses["local_account_2_status_1"]
+		adminStatus1  = suite.testStatuses["admin_account_status_1"]
+		adminStatus2  = suite.testStatuses["admin_account_status_2"]
+		existingFave  = testFaves["local_account_1_admin_account_status_1"]
+		retargetedFav = testFaves["local_account_1_local_account_2_status_1"]
+	)
+
+	// Source follows admin (which target already
+	// follows), and a remote account (which it doesn't).
+	dupeFollow := &gtsmodel.Follow{
+		ID:              "01H2RZV3X3ZQ6A3M3S1YBDYB7N",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       source.ID,
+		TargetAccountID: admin.ID,
+		URI:             "http://localhost:8080/users/1happyturtle/follow/01H2RZV3X3ZQ6A3M3S1YBDYB7N",
+	}
+	remoteFollow := &gtsmodel.Follow{
+		ID:              "01H2RZWFJ0Y8ZK8Q2ZB1MWMQ4A",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       source.ID,
+		TargetAccountID: remote.ID,
+		URI:             "http://localhost:8080/users/1happyturtle/follow/01H2RZWFJ0Y8ZK8Q2ZB1MWMQ4A",
+	}
+	for _, follow := range []*gtsmodel.Follow{dupeFollow, remoteFollow} {
+		if err := suite.db.PutFollow(ctx, follow); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Source faves admin status 1 (which target already
+	// faved), and admin status 2 (which it hasn't).
+	dupeFave := &gtsmodel.StatusFave{
+		ID:              "01H2RZY0DBRRJ8Y3A6N7WQQ6CE",
+		AccountID:       source.ID,
+		TargetAccountID: admin.ID,
+		StatusID:        adminStatus1.ID,
+		URI:             "http://localhost:8080/users/1happyturtle/liked/01H2RZY0DBRRJ8Y3A6N7WQQ6CE",
+	}
+	movedFave := &gtsmodel.StatusFave{
+		ID:              "01H2RZYQ2VJ3Z4JHZ5J5D8X5QK",
+		AccountID:       source.ID,
+		TargetAccountID: admin.ID,
+		StatusID:        adminStatus2.ID,
+		URI:             "http://localhost:8080/users/1happyturtle/liked/01H2RZYQ2VJ3Z4JHZ5J5D8X5QK",
+	}
+	for _, fave := range []*gtsmodel.StatusFave{dupeFave, movedFave} {
+		if err := suite.db.PutStatusFave(ctx, fave); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Source and target both boost admin status 1.
+	targetBoost := &gtsmodel.Status{
+		ID:               "01H2S0A5RZ2G5Q4E1W3Q0WQ9AZ",
+		URI:              "http://localhost:8080/users/the_mighty_zork/statuses/01H2S0A5RZ2G5Q4E1W3Q0WQ9AZ",
+		Local:            testrig.TrueBool(),
+		AccountURI:       target.URI,
+		AccountID:        target.ID,
+		BoostOfID:        adminStatus1.ID,
+		BoostOfAccountID: admin.ID,
+		Visibility:       gtsmodel.VisibilityPublic,
+		Federated:        testrig.TrueBool(),
+		Boostable:        testrig.TrueBool(),
+		Replyable:        testrig.TrueBool(),
+		Likeable:         testrig.TrueBool(),
+	}
+	dupeBoost := &gtsmodel.Status{
+		ID:               "01H2S0AW1H3C3K9YJ1E9S2N6QG",
+		URI:              "http://localhost:8080/users/1happyturtle/statuses/01H2S0AW1H3C3K9YJ1E9S2N6QG",
+		Local:            testrig.TrueBool(),
+		AccountURI:       source.URI,
+		AccountID:        source.ID,
+		BoostOfID:        adminStatus1.ID,
+		BoostOfAccountID: admin.ID,
+		Visibility:       gtsmodel.VisibilityPublic,
+		Federated:        testrig.TrueBool(),
+		Boostable:        testrig.TrueBool(),
+		Replyable:        testrig.TrueBool(),
+		Likeable:         testrig.TrueBool(),
+	}
+	for _, boost := range []*gtsmodel.Status{targetBoost, dupeBoost} {
+		if err := suite.db.PutStatus(ctx, boost); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	if errWithCode := suite.accountProcessor.MergeAccounts(ctx, source.ID, target.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Source's statuses belong to target now, but keep their URI.
+	status, err := suite.db.GetStatusByID(ctx, sourceStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(target.ID, status.AccountID)
+	suite.Equal(target.URI, status.AccountURI)
+	suite.Equal(sourceStatus.URI, status.URI)
+
+	// Target's fave of source's status now targets target.
+	fave, err := suite.db.GetStatusFaveByID(ctx, retargetedFav.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(target.ID, fave.TargetAccountID)
+
+	// Source's fave was moved, its duplicate dropped.
+	fave, err = suite.db.GetStatusFaveByID(ctx, movedFave.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(target.ID, fave.AccountID)
+	suite.Equal(movedFave.URI, fave.URI)
+
+	_, err = suite.db.GetStatusFaveByID(ctx, dupeFave.ID)
+	suite.Error(err)
+
+	_, err = suite.db.GetStatusFaveByID(ctx, existingFave.ID)
+	suite.NoError(err)
+
+	// Source's boost duplicating target's was dropped.
+	_, err = suite.db.GetStatusByID(ctx, dupeBoost.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	boost, err := suite.db.GetStatusByID(ctx, targetBoost.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(target.ID, boost.AccountID)
+
+	// Source's follow of admin duplicated target's, so was dropped.
+	_, err = suite.db.GetFollowByID(ctx, dupeFollow.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	following, err := suite.db.IsFollowing(ctx, target.ID, admin.ID)
+	suite.NoError(err)
+	suite.True(following)
+
+	// Source's follow of a remote account was undone,
+	// and target requested to follow it instead.
+	_, err = suite.db.GetFollowByID(ctx, remoteFollow.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	followRequest, err := suite.db.GetFollowRequest(ctx, target.ID, remote.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var undo, create *messages.FromClientAPI
+	for len(suite.fromClientAPIChan) > 0 {
+		msg := <-suite.fromClientAPIChan
+		switch {
+		case msg.APObjectType == ap.ActivityFollow && msg.APActivityType == ap.ActivityUndo:
+			undo = &msg
+		case msg.APObjectType == ap.ActivityFollow && msg.APActivityType == ap.ActivityCreate:
+			create = &msg
+		}
+	}
+
+	if suite.NotNil(undo) {
+		suite.Equal(source.ID, undo.OriginAccount.ID)
+		suite.Equal(remoteFollow.URI, undo.GTSModel.(*gtsmodel.Follow).URI)
+	}
+
+	if suite.NotNil(create) {
+		suite.Equal(target.ID, create.OriginAccount.ID)
+		suite.Equal(followRequest.URI, create.GTSModel.(*gtsmodel.FollowRequest).URI)
+	}
+
+	// Follows between source and target would
+	// have become self-follows, so are dropped.
+	selfFollow, err := suite.db.IsFollowing(ctx, target.ID, target.ID)
+	suite.NoError(err)
+	suite.False(selfFollow)
+
+	_, err = suite.db.GetFollowByID(ctx, suite.testFollows["local_account_1_local_account_2"].ID)
+	suite.Error(err)
+
+	_, err = suite.db.GetFollowByID(ctx, suite.testFollows["local_account_2_local_account_1"].ID)
+	suite.Error(err)
+
+	// Source itself is stubbified, with target as origin.
+	updatedSource, err := suite.db.GetAccountByID(ctx, source.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(time.Now(), updatedSource.SuspendedAt, time.Minute)
+	suite.Equal(target.ID, updatedSource.SuspensionOrigin)
+
+	count, err := suite.db.CountAccountStatuses(ctx, source.ID)
+	suite.NoError(err)
+	suite.Zero(count)
+}
+
+func (suite *AccountMergeTestSuite) TestMergeAccountsRemote() {
+	errWithCode := suite.accountProcessor.MergeAccounts(
+		context.Background(),
+		suite.testAccounts["remote_account_1"].ID,
+		suite.testAccounts["local_account_1"].ID,
+	)
+	suite.Error(errWithCode)
+}
+
+func TestAccountMergeTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountMergeTestSuite))
+}
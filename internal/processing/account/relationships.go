@@ -92,6 +92,31 @@ func (p *Processor) RelationshipGet(ctx context.Context, requestingAccount *gtsm
 	return r, nil
 }
 
+// RelationshipsGet is like RelationshipGet, but for many target accounts at once, eg., all
+// the authors on a page of a list timeline. Relationships are fetched from the db in bulk,
+// rather than one target at a time, and returned in the same order as targetAccountIDs.
+func (p *Processor) RelationshipsGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountIDs []string) ([]*apimodel.Relationship, gtserror.WithCode) {
+	if requestingAccount == nil {
+		return nil, gtserror.NewErrorForbidden(errors.New("not authed"))
+	}
+
+	gtsRs, err := p.state.DB.GetRelationships(ctx, requestingAccount.ID, targetAccountIDs)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting relationships: %w", err))
+	}
+
+	rs := make([]*apimodel.Relationship, 0, len(gtsRs))
+	for _, gtsR := range gtsRs {
+		r, err := p.tc.RelationshipToAPIRelationship(ctx, gtsR)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting relationship: %w", err))
+		}
+		rs = append(rs, r)
+	}
+
+	return rs, nil
+}
+
 func (p *Processor) accountsFromFollows(ctx context.Context, follows []*gtsmodel.Follow, requestingAccountID string) ([]apimodel.Account, gtserror.WithCode) {
 	accounts := make([]apimodel.Account, 0, len(follows))
 	for _, follow := range follows {
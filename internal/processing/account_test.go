@@ -51,7 +51,7 @@ func (suite *AccountTestSuite) TestAccountDeleteLocal() {
 	err := suite.db.Put(ctx, follow)
 	suite.NoError(err)
 
-	errWithCode := suite.processor.Account().DeleteSelf(ctx, suite.testAccounts["local_account_1"])
+	errWithCode := suite.processor.Account().DeleteSelf(ctx, suite.testAccounts["local_account_1"], false)
 	suite.NoError(errWithCode)
 
 	// the delete should be federated outwards to the following account's inbox
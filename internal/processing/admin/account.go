@@ -19,19 +19,29 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	"codeberg.org/gruf/go-kv"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
 func (p *Processor) AccountAction(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminAccountActionRequest) gtserror.WithCode {
 	targetAccount, err := p.state.DB.GetAccountByID(ctx, form.TargetAccountID)
 	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("account %s not found", form.TargetAccountID)
+			return gtserror.NewErrorNotFound(err, err.Error())
+		}
 		return gtserror.NewErrorInternalError(err)
 	}
 
@@ -44,7 +54,40 @@ func (p *Processor) AccountAction(ctx context.Context, account *gtsmodel.Account
 
 	switch form.Type {
 	case string(gtsmodel.AdminActionSuspend):
+		// Validate the suspension before counting it
+		// towards the limit, so rejected requests
+		// don't use up the admin's allowance.
+		if targetAccount.ID == account.ID {
+			err := errors.New("admins can't suspend their own account")
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		if !targetAccount.SuspendedAt.IsZero() {
+			err := fmt.Errorf("account %s is already suspended", targetAccount.ID)
+			return gtserror.NewErrorConflict(err, err.Error())
+		}
+
+		// Deletes triggered by domain blocks don't come
+		// through here, so they're not counted or limited.
+		now := time.Now()
+		if !p.accountDeletes.allow(account.ID, now) {
+			log.WithContext(ctx).WithFields(kv.Fields{
+				{"admin", account.Username},
+				{"target", targetAccount.ID},
+			}...).Warn("rejecting account suspension: admin delete limit reached")
+
+			err := fmt.Errorf("admin %s has reached the limit of account deletions", account.ID)
+			return gtserror.NewErrorTooManyRequests(err, "you've suspended too many accounts recently, please try again later")
+		}
+
 		adminAction.Type = gtsmodel.AdminActionSuspend
+		if err := p.state.DB.Put(ctx, adminAction); err != nil {
+			// The delete won't happen,
+			// so don't count it either.
+			p.accountDeletes.release(account.ID, now)
+			return gtserror.NewErrorInternalError(err)
+		}
+
 		// pass the account delete through the client api channel for processing
 		p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
 			APObjectType:   ap.ActorPerson,
@@ -56,9 +99,5 @@ func (p *Processor) AccountAction(ctx context.Context, account *gtsmodel.Account
 		return gtserror.NewErrorBadRequest(fmt.Errorf("admin action type %s is not supported for this endpoint", form.Type))
 	}
 
-	if err := p.state.DB.Put(ctx, adminAction); err != nil {
-		return gtserror.NewErrorInternalError(err)
-	}
-
 	return nil
 }
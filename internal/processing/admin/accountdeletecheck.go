@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// VerifyAccountDeleted checks that the deleted (stubbified) account with
+// the given ID really has no statuses, media, follows, blocks, faves,
+// bookmarks, notifications, saved searches or tokens left, and reports any leftovers.
+// It only reads from the database; nothing is changed.
+func (p *Processor) VerifyAccountDeleted(ctx context.Context, accountID string) (*apimodel.AdminAccountDeleteCheck, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("account %s not found", accountID)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		err = gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account.SuspendedAt.IsZero() {
+		err := fmt.Errorf("account %s has not been deleted", accountID)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	leftovers, err := p.state.DB.CountAccountLeftovers(ctx, account.ID)
+	if err != nil {
+		err = gtserror.Newf("db error counting leftovers for account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AdminAccountDeleteCheck{
+		AccountID: account.ID,
+		Complete:  len(leftovers) == 0,
+		Leftovers: leftovers,
+	}, nil
+}
@@ -0,0 +1,65 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// AccountRefetch forces a re-dereference of the remote account with the
+// given ID, using a transport for the requesting admin account. This is
+// useful when an Update for the account was missed or failed to process.
+//
+// The full account update is run, including avatar, header and emojis,
+// and the cached account model is replaced with the refreshed one.
+func (p *Processor) AccountRefetch(ctx context.Context, account *gtsmodel.Account, targetAccountID string) gtserror.WithCode {
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("account %s not found", targetAccountID)
+			return gtserror.NewErrorNotFound(err, err.Error())
+		}
+		err = gtserror.Newf("db error getting account %s: %w", targetAccountID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if targetAccount.IsLocal() {
+		err := fmt.Errorf("account %s is local, so cannot be refetched", targetAccountID)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if _, _, err := p.federator.RefreshAccount(ctx,
+		account.Username,
+		targetAccount,
+		nil,
+		true, // force
+	); err != nil {
+		err = gtserror.Newf("error refetching account %s: %w", targetAccount.URI, err)
+		return gtserror.NewErrorInternalError(err, "error refetching account from remote")
+	}
+
+	log.WithContext(ctx).Infof("account %s refetched by %s", targetAccount.URI, account.Username)
+	return nil
+}
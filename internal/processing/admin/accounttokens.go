@@ -0,0 +1,146 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// AccountTokensGet returns the active OAuth access tokens
+// belonging to the user of the given local account. Tokens
+// which have been revoked, or which have expired, are left out.
+func (p *Processor) AccountTokensGet(ctx context.Context, account *gtsmodel.Account, targetAccountID string) ([]*apimodel.AdminToken, gtserror.WithCode) {
+	user, errWithCode := p.localUser(ctx, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tokens := []*gtsmodel.Token{}
+	if err := p.state.DB.GetWhere(ctx, []db.Where{{Key: "user_id", Value: user.ID}}, &tokens); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting tokens for user %s: %w", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+
+	apiTokens := make([]*apimodel.AdminToken, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Access == "" {
+			// Authorization code not
+			// (yet) exchanged for access.
+			continue
+		}
+
+		if !token.RevokedAt.IsZero() {
+			// Revoked by its client.
+			continue
+		}
+
+		if !token.AccessExpiresAt.IsZero() && token.AccessExpiresAt.Before(now) {
+			// No longer usable.
+			continue
+		}
+		apiTokens = append(apiTokens, p.apiAdminToken(ctx, token))
+	}
+
+	return apiTokens, nil
+}
+
+// AccountTokenDelete revokes one OAuth token belonging to the user of the given local account.
+func (p *Processor) AccountTokenDelete(ctx context.Context, account *gtsmodel.Account, targetAccountID string, tokenID string) (*apimodel.AdminToken, gtserror.WithCode) {
+	user, errWithCode := p.localUser(ctx, targetAccountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	token := &gtsmodel.Token{}
+	if err := p.state.DB.GetByID(ctx, tokenID, token); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting token %s: %w", tokenID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		err = fmt.Errorf("token %s not found", tokenID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if token.UserID != user.ID {
+		// Don't reveal that the token exists.
+		err := fmt.Errorf("token %s not found", tokenID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if err := p.state.DB.DeleteByID(ctx, token.ID, token); err != nil {
+		err = gtserror.Newf("db error deleting token %s: %w", tokenID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	log.Infof(ctx, "account %s revoked token %s of user %s", account.ID, token.ID, user.ID)
+
+	return p.apiAdminToken(ctx, token), nil
+}
+
+// localUser returns the user for the local account with the given ID.
+func (p *Processor) localUser(ctx context.Context, accountID string) (*gtsmodel.User, gtserror.WithCode) {
+	user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting user for account %s: %w", accountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		err = fmt.Errorf("no local user found for account %s", accountID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+	return user, nil
+}
+
+// apiAdminToken converts the given token to its admin API representation.
+func (p *Processor) apiAdminToken(ctx context.Context, token *gtsmodel.Token) *apimodel.AdminToken {
+	apiToken := &apimodel.AdminToken{
+		ID:        token.ID,
+		CreatedAt: util.FormatISO8601(token.CreatedAt),
+		Scope:     token.Scope,
+	}
+
+	app := &gtsmodel.Application{}
+	if err := p.state.DB.GetWhere(ctx, []db.Where{{Key: "client_id", Value: token.ClientID}}, app); err != nil {
+		log.Debugf(ctx, "couldn't get application for client %s: %v", token.ClientID, err)
+	} else {
+		apiToken.ClientName = app.Name
+	}
+
+	if !token.LastUsedAt.IsZero() {
+		lastUsedAt := util.FormatISO8601(token.LastUsedAt)
+		apiToken.LastUsedAt = &lastUsedAt
+	}
+
+	if token.LastUsedIP != nil {
+		ip := token.LastUsedIP.String()
+		apiToken.IPAddress = &ip
+	}
+
+	return apiToken
+}
@@ -18,7 +18,12 @@
 package admin
 
 import (
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
@@ -29,17 +34,32 @@ type Processor struct {
 	state               *state.State
 	tc                  typeutils.TypeConverter
 	mediaManager        *media.Manager
+	federator           federation.Federator
 	transportController transport.Controller
 	emailSender         email.Sender
+
+	// domainStats caches pages of domain
+	// stats, keyed by limit and offset.
+	domainStats *ttl.Cache[string, []*apimodel.AdminDomainStats]
+
+	// accountDeletes limits how many accounts
+	// each admin can delete in a given window.
+	accountDeletes *deleteLimiter
 }
 
 // New returns a new admin processor.
-func New(state *state.State, tc typeutils.TypeConverter, mediaManager *media.Manager, transportController transport.Controller, emailSender email.Sender) Processor {
+func New(state *state.State, tc typeutils.TypeConverter, mediaManager *media.Manager, federator federation.Federator, emailSender email.Sender) Processor {
+	domainStats := ttl.New[string, []*apimodel.AdminDomainStats](0, 100, domainStatsCacheTTL)
+	domainStats.Start(time.Minute)
+
 	return Processor{
 		state:               state,
 		tc:                  tc,
 		mediaManager:        mediaManager,
-		transportController: transportController,
+		federator:           federator,
+		transportController: federator.TransportController(),
 		emailSender:         emailSender,
+		domainStats:         domainStats,
+		accountDeletes:      newDeleteLimiter(),
 	}
 }
@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// deleteLimiter keeps track of how many accounts each
+// admin has deleted recently, so that a compromised admin
+// token can't be used to mass-delete accounts in one go.
+type deleteLimiter struct {
+	// times of recent deletes,
+	// keyed by admin account ID.
+	deletes map[string][]time.Time
+	mu      sync.Mutex
+}
+
+func newDeleteLimiter() *deleteLimiter {
+	return &deleteLimiter{
+		deletes: make(map[string][]time.Time),
+	}
+}
+
+// allow returns whether the admin with the given account ID may
+// delete another account at time now, according to the configured
+// limit + window. If so, the delete is counted towards the limit.
+func (l *deleteLimiter) allow(adminID string, now time.Time) bool {
+	limit := config.GetAccountsAdminDeleteLimit()
+	if limit <= 0 {
+		// No limit set.
+		return true
+	}
+
+	windowStart := now.Add(-config.GetAccountsAdminDeleteWindow())
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop deletes that have
+	// fallen out of the window.
+	recent := make([]time.Time, 0, limit)
+	for _, t := range l.deletes[adminID] {
+		if t.After(windowStart) {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) >= limit {
+		l.deletes[adminID] = recent
+		return false
+	}
+
+	l.deletes[adminID] = append(recent, now)
+	return true
+}
+
+// release uncounts a delete previously allowed at
+// time now, for when the delete didn't go ahead.
+func (l *deleteLimiter) release(adminID string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	deletes := l.deletes[adminID]
+	for i, t := range deletes {
+		if t.Equal(now) {
+			l.deletes[adminID] = append(deletes[:i], deletes[i+1:]...)
+			return
+		}
+	}
+}
@@ -128,14 +128,10 @@ func (p *Processor) initiateDomainBlockSideEffects(ctx context.Context, account
 		l.Debug("domainBlockProcessSideEffects: instance entry updated")
 	}
 
-	// if we have an instance account for this instance, delete it
-	if instanceAccount, err := p.state.DB.GetAccountByUsernameDomain(ctx, block.Domain, block.Domain); err == nil {
-		if err := p.state.DB.DeleteAccount(ctx, instanceAccount.ID); err != nil {
-			l.Errorf("domainBlockProcessSideEffects: db error deleting instance account: %s", err)
-		}
-	}
-
 	// delete accounts through the normal account deletion system (which should also delete media + posts + remove posts from timelines)
+	//
+	// this includes the instance account, which must not be removed from the db outright: reports received
+	// from the instance are usually created by its instance account, and would no longer load without it
 
 	limit := 20      // just select 20 accounts at a time so we don't nuke our DB/mem with one huge query
 	var maxID string // this is initially an empty string so we'll start at the top of accounts list (sorted by ID)
@@ -253,6 +249,58 @@ func (p *Processor) DomainBlockGet(ctx context.Context, account *gtsmodel.Accoun
 	return apiDomainBlock, nil
 }
 
+// DomainBlockUpdate updates the obfuscation and/or comments of the
+// domain block with the given ID. Parameters that are nil are left
+// unchanged. Since the blocked domain itself can't be changed, there
+// are no further side effects to process.
+func (p *Processor) DomainBlockUpdate(ctx context.Context, account *gtsmodel.Account, id string, obfuscate *bool, publicComment *string, privateComment *string) (*apimodel.DomainBlock, gtserror.WithCode) {
+	domainBlock := &gtsmodel.DomainBlock{}
+
+	if err := p.state.DB.GetByID(ctx, id, domainBlock); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// something has gone really wrong
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		// there are no entries for this ID
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	updatingColumns := []string{}
+
+	if obfuscate != nil {
+		updatingColumns = append(updatingColumns, "obfuscate")
+		domainBlock.Obfuscate = obfuscate
+	}
+
+	if publicComment != nil {
+		updatingColumns = append(updatingColumns, "public_comment")
+		domainBlock.PublicComment = text.SanitizePlaintext(*publicComment)
+	}
+
+	if privateComment != nil {
+		updatingColumns = append(updatingColumns, "private_comment")
+		domainBlock.PrivateComment = text.SanitizePlaintext(*privateComment)
+	}
+
+	if len(updatingColumns) != 0 {
+		updatingColumns = append(updatingColumns, "updated_at")
+		domainBlock.UpdatedAt = time.Now()
+
+		if err := p.state.DB.UpdateByID(ctx, domainBlock, domainBlock.ID, updatingColumns...); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error updating domain block %s: %s", domainBlock.Domain, err))
+		}
+
+		log.Infof(ctx, "account %s updated domain block %s for %s", account.ID, domainBlock.ID, domainBlock.Domain)
+	}
+
+	apiDomainBlock, err := p.tc.DomainBlockToAPIDomainBlock(ctx, domainBlock, false)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiDomainBlock, nil
+}
+
 // DomainBlockDelete removes one domain block with the given ID.
 func (p *Processor) DomainBlockDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainBlock, gtserror.WithCode) {
 	domainBlock := &gtsmodel.DomainBlock{}
@@ -293,19 +341,63 @@ func (p *Processor) DomainBlockDelete(ctx context.Context, account *gtsmodel.Acc
 	}
 
 	// unsuspend all accounts whose suspension origin was this domain block
-	// 1. remove the 'suspended_at' entry from their accounts
-	if err := p.state.DB.UpdateWhere(ctx, []db.Where{
-		{Key: "suspension_origin", Value: domainBlock.ID},
-	}, "suspended_at", nil, &[]*gtsmodel.Account{}); err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error removing suspended_at from accounts: %s", err))
+	restored, unrestorable, err := p.DomainBlockDeletesReverse(ctx, domainBlock.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	// 2. remove the 'suspension_origin' entry from their accounts
-	if err := p.state.DB.UpdateWhere(ctx, []db.Where{
-		{Key: "suspension_origin", Value: domainBlock.ID},
-	}, "suspension_origin", nil, &[]*gtsmodel.Account{}); err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("database error removing suspension_origin from accounts: %s", err))
-	}
+	log.Infof(ctx, "reversed domain block %s: restored %d accounts, %d accounts not restorable", domainBlock.ID, restored, unrestorable)
 
 	return apiDomainBlock, nil
 }
+
+// DomainBlockDeletesReverse unsuspends, as a group, all accounts that were deleted
+// (stubbified) as a side effect of the domain block with the given ID. It returns the
+// number of accounts restored, and the number which could not be restored.
+//
+// Stubbified remote accounts are restored by unsuspending them; since their fetched_at
+// was cleared on deletion, their profile will be dereferenced again on next use. Local
+// accounts can't be dereferenced again, so these are left suspended and counted as
+// not restorable.
+func (p *Processor) DomainBlockDeletesReverse(ctx context.Context, blockID string) (int, int, error) {
+	var restored, unrestorable int
+
+	limit := 20      // just select 20 accounts at a time so we don't nuke our DB/mem with one huge query
+	var maxID string // this is initially an empty string so we'll start at the top of accounts list (sorted by ID)
+
+	for {
+		accounts, err := p.state.DB.GetAccountsBySuspensionOrigin(ctx, blockID, maxID, limit)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				// No accounts left, we're done.
+				return restored, unrestorable, nil
+			}
+			return restored, unrestorable, fmt.Errorf("DomainBlockDeletesReverse: db error getting accounts suspended by %s: %w", blockID, err)
+		}
+
+		if len(accounts) == 0 {
+			// None of this page could be
+			// loaded, nothing to page from.
+			return restored, unrestorable, nil
+		}
+
+		for _, account := range accounts {
+			if account.IsLocal() {
+				log.Warnf(ctx, "local account %s suspended by domain block %s can't be restored", account.ID, blockID)
+				unrestorable++
+				continue
+			}
+
+			account.SuspendedAt = time.Time{}
+			account.SuspensionOrigin = ""
+			if err := p.state.DB.UpdateAccount(ctx, account, "suspended_at", "suspension_origin"); err != nil {
+				return restored, unrestorable, fmt.Errorf("DomainBlockDeletesReverse: db error unsuspending account %s: %w", account.ID, err)
+			}
+
+			restored++
+		}
+
+		// Set the maxID for the next page.
+		maxID = accounts[len(accounts)-1].ID
+	}
+}
@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainCachePurge makes sure no in-memory cache entries for accounts, statuses,
+// or media attachments from the given domain are used any more, so that they will
+// be reloaded from the database on next use. This is useful straight after
+// updating a domain block, for example.
+//
+// Finding every entry from the domain would mean going through everything the
+// database holds from it, so instead these caches are cleared entirely: entries
+// from other domains are dropped too, and are just reloaded as they're needed.
+func (p *Processor) DomainCachePurge(ctx context.Context, account *gtsmodel.Account, domain string) (*apimodel.AdminDomainCachePurge, gtserror.WithCode) {
+	if domain == "" {
+		err := errors.New("no domain given")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	punyDomain, err := util.Punify(strings.ToLower(domain))
+	if err != nil {
+		err = gtserror.Newf("invalid domain %s: %w", domain, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	domain = punyDomain
+
+	if domain == config.GetHost() || domain == config.GetAccountDomain() {
+		err := errors.New("cannot purge cache for this instance's own domain")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	p.state.Caches.GTS.Media().Clear()
+	p.state.Caches.GTS.Status().Clear()
+	p.state.Caches.GTS.Account().Clear()
+
+	log.Infof(ctx, "account %s purged account, status and media caches for domain %s", account.ID, domain)
+
+	return &apimodel.AdminDomainCachePurge{
+		Domain: domain,
+	}, nil
+}
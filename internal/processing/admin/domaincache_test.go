@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainCacheTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainCacheTestSuite) TestDomainCachePurge() {
+	ctx := context.Background()
+	remoteAccount := suite.testAccounts["remote_account_1"]
+	remoteStatus := testrig.NewTestStatuses()["remote_account_1_status_1"]
+	remoteAttachment := testrig.NewTestAttachments()["remote_account_1_status_1_attachment_1"]
+
+	// Load things from the domain into the caches.
+	if _, err := suite.db.GetAccountByID(ctx, remoteAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := suite.db.GetStatusByID(ctx, remoteStatus.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := suite.db.GetAttachmentByID(ctx, remoteAttachment.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	purge, errWithCode := suite.adminProcessor.DomainCachePurge(ctx, suite.testAccounts["admin_account"], remoteAccount.Domain)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(remoteAccount.Domain, purge.Domain)
+
+	suite.False(suite.state.Caches.GTS.Account().Has("ID", remoteAccount.ID))
+	suite.False(suite.state.Caches.GTS.Status().Has("ID", remoteStatus.ID))
+	suite.False(suite.state.Caches.GTS.Media().Has("ID", remoteAttachment.ID))
+}
+
+func (suite *DomainCacheTestSuite) TestDomainCachePurgeOwnDomain() {
+	purge, errWithCode := suite.adminProcessor.DomainCachePurge(context.Background(), suite.testAccounts["admin_account"], "localhost:8080")
+	suite.Nil(purge)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestDomainCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainCacheTestSuite))
+}
@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// domainStatsCacheTTL is how long a page of domain stats is
+// served from cache, since the aggregation is costly to run.
+const domainStatsCacheTTL = 30 * time.Minute
+
+// DomainStatsGet returns a page of per-domain stats for known remote
+// domains, ordered by number of known accounts from most to fewest.
+//
+// Results are cached for 30 minutes, so they may lag slightly behind.
+func (p *Processor) DomainStatsGet(ctx context.Context, limit int, offset int) ([]*apimodel.AdminDomainStats, gtserror.WithCode) {
+	if offset < 0 {
+		err := errors.New("offset must not be negative")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	key := strconv.Itoa(limit) + "/" + strconv.Itoa(offset)
+	if apiStats, ok := p.domainStats.Get(key); ok {
+		return apiStats, nil
+	}
+
+	stats, err := p.state.DB.GetDomainStats(ctx, limit, offset)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting domain stats: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiStats := make([]*apimodel.AdminDomainStats, 0, len(stats))
+	for _, domainStats := range stats {
+		apiDomainStats := &apimodel.AdminDomainStats{
+			Domain:          domainStats.Domain,
+			AccountCount:    domainStats.AccountCount,
+			StatusCount:     domainStats.StatusCount,
+			MediaCountBytes: domainStats.MediaBytes,
+		}
+
+		if !domainStats.LastActivity.IsZero() {
+			lastActivity := util.FormatISO8601(domainStats.LastActivity)
+			apiDomainStats.LastActivity = &lastActivity
+		}
+
+		apiStats = append(apiStats, apiDomainStats)
+	}
+
+	p.domainStats.Set(key, apiStats)
+	return apiStats, nil
+}
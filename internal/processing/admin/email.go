@@ -19,24 +19,30 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 )
 
-// EmailTest sends a generic test email to the given toAddress (which
+// SendTestEmail sends a generic test email to the given toAddress (which
 // should be a valid email address). To help callers differentiate between
 // proper errors and the smtp errors they're likely fishing for, will return
-// 422 + help text on an SMTP error, or error 500 otherwise.
-func (p *Processor) EmailTest(ctx context.Context, account *gtsmodel.Account, toAddress string) gtserror.WithCode {
+// 422 + help text on an SMTP error, or error 500 otherwise. The help text
+// indicates which part of the SMTP exchange failed, and which smtp config
+// fields are the likely culprit.
+func (p *Processor) SendTestEmail(ctx context.Context, account *gtsmodel.Account, toAddress string) gtserror.WithCode {
 	// Pull our instance entry from the database,
 	// so we can greet the email recipient nicely.
 	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
 	if err != nil {
-		err = fmt.Errorf("SendConfirmEmail: error getting instance: %s", err)
+		err = fmt.Errorf("SendTestEmail: error getting instance: %s", err)
 		return gtserror.NewErrorInternalError(err)
 	}
 
@@ -51,7 +57,8 @@ func (p *Processor) EmailTest(ctx context.Context, account *gtsmodel.Account, to
 			// An error occurred during the SMTP part.
 			// We should indicate this to the caller, as
 			// it will likely help them debug the issue.
-			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+			info := email.ClassifySMTPError(err)
+			return gtserror.NewErrorUnprocessableEntity(err, info.HelpText(err))
 		}
 		// An actual error has occurred.
 		return gtserror.NewErrorInternalError(err)
@@ -59,3 +66,108 @@ func (p *Processor) EmailTest(ctx context.Context, account *gtsmodel.Account, to
 
 	return nil
 }
+
+// EmailTemplatesGet returns all customizable email templates,
+// along with their currently effective subject and body templates.
+func (p *Processor) EmailTemplatesGet(ctx context.Context) ([]*apimodel.AdminEmailTemplate, gtserror.WithCode) {
+	names := email.TemplateNames()
+	templates := make([]*apimodel.AdminEmailTemplate, 0, len(names))
+
+	for _, name := range names {
+		template, errWithCode := p.EmailTemplateGet(ctx, name)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+// EmailTemplateGet returns the customizable email template with the
+// given name, along with its currently effective subject and body templates.
+func (p *Processor) EmailTemplateGet(ctx context.Context, name string) (*apimodel.AdminEmailTemplate, gtserror.WithCode) {
+	subject, body, customized, err := p.emailSender.GetTemplate(name)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return &apimodel.AdminEmailTemplate{
+		Name:            name,
+		SubjectTemplate: subject,
+		BodyTemplate:    body,
+		Customized:      customized,
+	}, nil
+}
+
+// EmailTemplateUpdate validates and stores new subject and body templates for the
+// customizable email template with the given name, and sets them to be used for
+// subsequently sent emails. If both subject and body are empty, the template is
+// reset to the compiled-in default.
+func (p *Processor) EmailTemplateUpdate(ctx context.Context, name string, form *apimodel.AdminEmailTemplateUpdateRequest) (*apimodel.AdminEmailTemplate, gtserror.WithCode) {
+	if _, _, _, err := p.emailSender.GetTemplate(name); err != nil {
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if form.SubjectTemplate == "" && form.BodyTemplate == "" {
+		// Reset to default by removing any stored override.
+		if err := p.state.DB.DeleteEmailTemplateByName(ctx, name); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("EmailTemplateUpdate: db error deleting email template %s: %w", name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if err := p.emailSender.SetTemplate(name, "", ""); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		return p.EmailTemplateGet(ctx, name)
+	}
+
+	if form.SubjectTemplate == "" || form.BodyTemplate == "" {
+		err := errors.New("both subject_template and body_template must be set, or neither")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Ensure templates parse and execute
+	// correctly before we store them.
+	if err := email.ValidateTemplate(name, form.SubjectTemplate, form.BodyTemplate); err != nil {
+		err = fmt.Errorf("invalid email template: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	template, err := p.state.DB.GetEmailTemplateByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = fmt.Errorf("EmailTemplateUpdate: db error getting email template %s: %w", name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if template == nil {
+		// No override stored yet, create one.
+		template = &gtsmodel.EmailTemplate{
+			ID:              id.NewULID(),
+			Name:            name,
+			SubjectTemplate: form.SubjectTemplate,
+			BodyTemplate:    form.BodyTemplate,
+		}
+
+		if err := p.state.DB.PutEmailTemplate(ctx, template); err != nil {
+			err = fmt.Errorf("EmailTemplateUpdate: db error putting email template %s: %w", name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	} else {
+		// Update existing override.
+		template.SubjectTemplate = form.SubjectTemplate
+		template.BodyTemplate = form.BodyTemplate
+
+		if err := p.state.DB.UpdateEmailTemplate(ctx, template, "subject_template", "body_template"); err != nil {
+			err = fmt.Errorf("EmailTemplateUpdate: db error updating email template %s: %w", name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.emailSender.SetTemplate(name, template.SubjectTemplate, template.BodyTemplate); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.EmailTemplateGet(ctx, name)
+}
@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// FederationActivityGet returns the most recent activities received
+// from the given domain, newest first, for debugging federation
+// issues. If domain is empty, activities from all domains are returned.
+//
+// Only the last 50 activities received from each domain are kept, in
+// memory, so this is emptied when the instance restarts.
+func (p *Processor) FederationActivityGet(ctx context.Context, domain string) ([]*apimodel.AdminFederationActivity, gtserror.WithCode) {
+	if domain != "" {
+		// Accounts are stored with
+		// lowercase punycode domains.
+		punyDomain, err := util.Punify(strings.ToLower(domain))
+		if err != nil {
+			err = fmt.Errorf("invalid domain %s: %w", domain, err)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		domain = punyDomain
+	}
+
+	entries := p.federator.ActivityLog().Entries(domain)
+
+	activities := make([]*apimodel.AdminFederationActivity, 0, len(entries))
+	for _, entry := range entries {
+		activities = append(activities, &apimodel.AdminFederationActivity{
+			ReceivedAt:   util.FormatISO8601(entry.ReceivedAt),
+			Domain:       entry.Domain,
+			ActivityType: entry.ActivityType,
+			ObjectType:   entry.ObjectType,
+			ActorURI:     entry.ActorURI,
+			Accepted:     entry.Accepted,
+			Reason:       entry.Reason,
+		})
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,125 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// FederationStateGet returns an overview of this instance's federation
+// relationship with the given domain, for debugging federation issues:
+// accepted follows in each direction, pending follow requests in each
+// direction, and any domain block in place.
+//
+// Rejected follow requests are deleted rather than stored, so they are
+// not included.
+func (p *Processor) FederationStateGet(ctx context.Context, domain string) (*apimodel.AdminFederationState, gtserror.WithCode) {
+	if domain == "" {
+		err := errors.New("no domain given")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Accounts and domain blocks are
+	// stored with lowercase punycode domains.
+	punyDomain, err := util.Punify(strings.ToLower(domain))
+	if err != nil {
+		err = fmt.Errorf("invalid domain %s: %w", domain, err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	domain = punyDomain
+
+	state := &apimodel.AdminFederationState{
+		Domain: domain,
+	}
+
+	block, err := p.state.DB.GetDomainBlock(ctx, domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting domain block for %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if block != nil {
+		state.DomainBlock, err = p.tc.DomainBlockToAPIDomainBlock(ctx, block, false)
+		if err != nil {
+			err = gtserror.Newf("error converting domain block: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	state.FollowsOutbound, err = p.state.DB.CountDomainFollows(ctx, domain, true)
+	if err != nil {
+		err = gtserror.Newf("db error counting outbound follows: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	state.FollowsInbound, err = p.state.DB.CountDomainFollows(ctx, domain, false)
+	if err != nil {
+		err = gtserror.Newf("db error counting inbound follows: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	outbound, err := p.state.DB.GetDomainFollowRequests(ctx, domain, true)
+	if err != nil {
+		err = gtserror.Newf("db error getting outbound follow requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	state.FollowRequestsOutbound = federationFollowRequests(outbound)
+
+	inbound, err := p.state.DB.GetDomainFollowRequests(ctx, domain, false)
+	if err != nil {
+		err = gtserror.Newf("db error getting inbound follow requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	state.FollowRequestsInbound = federationFollowRequests(inbound)
+
+	return state, nil
+}
+
+// federationFollowRequests converts the given follow
+// requests to their admin federation state representation.
+func federationFollowRequests(followReqs []*gtsmodel.FollowRequest) []apimodel.AdminFederationFollowRequest {
+	apiFollowReqs := make([]apimodel.AdminFederationFollowRequest, 0, len(followReqs))
+
+	for _, followReq := range followReqs {
+		apiFollowReqs = append(apiFollowReqs, apimodel.AdminFederationFollowRequest{
+			ID:            followReq.ID,
+			Account:       fullAcct(followReq.Account),
+			TargetAccount: fullAcct(followReq.TargetAccount),
+			CreatedAt:     util.FormatISO8601(followReq.CreatedAt),
+		})
+	}
+
+	return apiFollowReqs
+}
+
+// fullAcct returns the username@domain of the
+// given account, or just username if it's local.
+func fullAcct(account *gtsmodel.Account) string {
+	if account.Domain == "" {
+		return account.Username
+	}
+	return account.Username + "@" + account.Domain
+}
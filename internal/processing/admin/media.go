@@ -19,8 +19,12 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -35,11 +39,24 @@ func (p *Processor) MediaRefetch(ctx context.Context, requestingAccount *gtsmode
 	}
 
 	go func() {
+		// Limit the total runtime of the refetch, so
+		// that a slow / unresponsive remote can't keep
+		// this goroutine hanging around indefinitely.
+		refetchCtx := context.Background()
+		if timeout := config.GetMediaRefetchTimeout(); timeout > 0 {
+			var cancel context.CancelFunc
+			refetchCtx, cancel = context.WithTimeout(refetchCtx, timeout)
+			defer cancel()
+		}
+
 		log.Info(ctx, "starting emoji refetch")
-		refetched, err := p.mediaManager.RefetchEmojis(context.Background(), domain, transport.DereferenceMedia)
-		if err != nil {
+		refetched, err := p.mediaManager.RefetchEmojis(refetchCtx, domain, transport.DereferenceMedia)
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			log.Warnf(ctx, "emoji refetch timed out after %s, refetched %d emojis from remote: %s", config.GetMediaRefetchTimeout(), refetched, err)
+		case err != nil:
 			log.Errorf(ctx, "error refetching emojis: %s", err)
-		} else {
+		default:
 			log.Infof(ctx, "refetched %d emojis from remote", refetched)
 		}
 	}()
@@ -61,3 +78,107 @@ func (p *Processor) MediaPrune(ctx context.Context, mediaRemoteCacheDays int) gt
 
 	return nil
 }
+
+// MediaPruneBytes triggers a non-blocking uncache of remote media,
+// oldest first, until at least targetBytes of storage is reclaimed.
+// Media newer than media-remote-cache-days is never uncached.
+func (p *Processor) MediaPruneBytes(ctx context.Context, targetBytes int64) gtserror.WithCode {
+	if targetBytes <= 0 {
+		err := fmt.Errorf("MediaPruneBytes: invalid value for targetBytes: value was %d, must be greater than 0", targetBytes)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	go func() {
+		pruned, bytes, err := p.mediaManager.UncacheRemoteBytes(context.Background(), config.GetMediaRemoteCacheDays(), targetBytes, false)
+		if err != nil {
+			log.Errorf(ctx, "MediaPruneBytes: error uncacheing remote media: %v", err)
+			return
+		}
+		log.Infof(ctx, "uncached %d remote media (%d bytes) of %d byte(s) requested", pruned, bytes, targetBytes)
+	}()
+
+	return nil
+}
+
+// MediaUsageGet returns the amount of cached remote
+// media stored locally, per domain, largest first.
+func (p *Processor) MediaUsageGet(ctx context.Context) ([]*apimodel.AdminDomainMediaUsage, gtserror.WithCode) {
+	usages, err := p.state.DB.GetCachedMediaUsageByDomain(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = fmt.Errorf("MediaUsageGet: db error getting media usage by domain: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	limit := int64(config.GetMediaPerDomainCacheLimit())
+
+	apiUsages := make([]*apimodel.AdminDomainMediaUsage, 0, len(usages))
+	for _, usage := range usages {
+		apiUsages = append(apiUsages, &apimodel.AdminDomainMediaUsage{
+			Domain:    usage.Domain,
+			Count:     usage.Count,
+			Bytes:     usage.Bytes,
+			OverLimit: limit > 0 && usage.Bytes > limit,
+		})
+	}
+
+	return apiUsages, nil
+}
+
+// StorageGarbageCollect removes files from storage which have no corresponding
+// entry in the database, and reports the amount of objects and bytes reclaimed.
+// Unless confirm is true, only a dry run is performed and nothing is removed.
+func (p *Processor) StorageGarbageCollect(ctx context.Context, confirm bool) (*apimodel.AdminStorageGC, gtserror.WithCode) {
+	dry := !confirm
+
+	objects, bytes, err := p.mediaManager.GarbageCollectStorage(ctx, dry)
+	if err != nil {
+		err = fmt.Errorf("StorageGarbageCollect: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if dry {
+		log.Infof(ctx, "DRY RUN: %d orphaned items (%d bytes) are eligible to be removed from storage", objects, bytes)
+	} else {
+		log.Infof(ctx, "removed %d orphaned items (%d bytes) from storage", objects, bytes)
+	}
+
+	return &apimodel.AdminStorageGC{
+		Objects: objects,
+		Bytes:   bytes,
+		DryRun:  dry,
+	}, nil
+}
+
+// MediaErrorsGet returns a summary of remote media
+// attachments which failed to be dereferenced.
+func (p *Processor) MediaErrorsGet(ctx context.Context) (*apimodel.AdminMediaErrors, gtserror.WithCode) {
+	count, err := p.state.DB.CountAttachmentsInErrorState(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = fmt.Errorf("MediaErrorsGet: error counting errored media: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AdminMediaErrors{Count: count}, nil
+}
+
+// MediaErrorsRefetch triggers a non-blocking retry of
+// dereferencing up to limit errored remote media attachments.
+func (p *Processor) MediaErrorsRefetch(ctx context.Context, requestingAccount *gtsmodel.Account, limit int) gtserror.WithCode {
+	transport, err := p.transportController.NewTransportForUsername(ctx, requestingAccount.Username)
+	if err != nil {
+		err = fmt.Errorf("error getting transport for user %s during media errors refetch request: %w", requestingAccount.Username, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	go func() {
+		log.Info(ctx, "starting errored media refetch")
+		refetched, err := p.mediaManager.RefetchErrored(context.Background(), limit, transport.DereferenceMedia)
+		if err != nil {
+			log.Errorf(ctx, "error refetching errored media: %s", err)
+		} else {
+			log.Infof(ctx, "refetched %d errored media from remote", refetched)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+const (
+	// pruneNotificationsBatchSize is the max amount of
+	// notifications deleted in one query when pruning.
+	pruneNotificationsBatchSize = 5000
+
+	// pruneNotificationsBatchPause is how long to wait between
+	// batches, to let other database writers through.
+	pruneNotificationsBatchPause = 100 * time.Millisecond
+)
+
+// PruneOldNotifications deletes all notifications on the instance
+// which were created longer than olderThan ago, regardless of which
+// accounts they target or originate from. This lets operators apply
+// a retention policy to notifications.
+//
+// Notifications are deleted in batches with a pause in between,
+// so as not to lock the notifications table for too long.
+func (p *Processor) PruneOldNotifications(ctx context.Context, olderThan time.Duration) gtserror.WithCode {
+	if olderThan <= 0 {
+		err := fmt.Errorf("PruneOldNotifications: invalid value for olderThan: value was %s, must be greater than 0", olderThan)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	createdBefore := time.Now().Add(-olderThan)
+	for {
+		deleted, err := p.state.DB.DeleteNotificationsBatch(ctx, "", "", createdBefore, pruneNotificationsBatchSize)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("PruneOldNotifications: db error deleting notifications created before %s: %w", createdBefore, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if deleted < pruneNotificationsBatchSize {
+			// Last batch, we're done.
+			return nil
+		}
+
+		// There may be more, take a
+		// breather before the next batch.
+		select {
+		case <-ctx.Done():
+			err := fmt.Errorf("PruneOldNotifications: %w", ctx.Err())
+			return gtserror.NewErrorInternalError(err)
+		case <-time.After(pruneNotificationsBatchPause):
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// nodeInfoSnapshotTTL is how long a stored nodeinfo
+// snapshot of a remote instance is served before
+// the instance is asked for a fresh one.
+const nodeInfoSnapshotTTL = 24 * time.Hour
+
+// GetFederatedInstanceInfo returns software information about the known
+// remote instance with the given domain, taken from its nodeinfo document.
+//
+// The nodeinfo is dereferenced using a transport for the requesting admin
+// account, and a snapshot of it is stored on the instance entry, which is
+// served for 24 hours before being refreshed. If a refresh fails but an
+// older snapshot exists, the older snapshot is returned instead.
+func (p *Processor) GetFederatedInstanceInfo(ctx context.Context, adminAccount *gtsmodel.Account, domain string) (*apimodel.RemoteInstance, gtserror.WithCode) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		err := errors.New("no domain specified")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if domain == config.GetHost() || domain == config.GetAccountDomain() {
+		err := fmt.Errorf("domain %s is this instance's domain", domain)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	instance, err := p.state.DB.GetInstance(ctx, domain)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("instance %s not known", domain)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		err = gtserror.Newf("db error getting instance %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !instance.NodeInfoFetchedAt.IsZero() && time.Since(instance.NodeInfoFetchedAt) < nodeInfoSnapshotTTL {
+		// Snapshot is still fresh.
+		return remoteInstanceToAPI(instance), nil
+	}
+
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, domain)
+	if err != nil {
+		err = gtserror.Newf("db error checking block for domain %s: %w", domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		// Don't reach out to blocked
+		// domains, just show what we have.
+		return remoteInstanceToAPI(instance), nil
+	}
+
+	if err := p.refreshNodeInfo(ctx, adminAccount, instance); err != nil {
+		if instance.NodeInfoFetchedAt.IsZero() {
+			// Nothing to fall back on.
+			return nil, gtserror.NewErrorInternalError(err, "could not fetch nodeinfo from "+domain)
+		}
+		log.Warnf(ctx, "serving stale nodeinfo snapshot for %s: %v", domain, err)
+	}
+
+	return remoteInstanceToAPI(instance), nil
+}
+
+// refreshNodeInfo dereferences the nodeinfo of the
+// given instance, and stores a snapshot of it.
+func (p *Processor) refreshNodeInfo(ctx context.Context, adminAccount *gtsmodel.Account, instance *gtsmodel.Instance) error {
+	iri, err := url.Parse(instance.URI)
+	if err != nil {
+		return gtserror.Newf("error parsing uri %s of instance %s: %w", instance.URI, instance.Domain, err)
+	}
+
+	transport, err := p.transportController.NewTransportForUsername(ctx, adminAccount.Username)
+	if err != nil {
+		return gtserror.Newf("error getting transport for user %s: %w", adminAccount.Username, err)
+	}
+
+	ni, err := transport.DereferenceNodeInfo(ctx, iri)
+	if err != nil {
+		return gtserror.Newf("error dereferencing nodeinfo of instance %s: %w", instance.Domain, err)
+	}
+
+	instance.SoftwareName = ni.Software.Name
+	instance.SoftwareVersion = ni.Software.Version
+	instance.UserCount = ni.Usage.Users.Total
+	instance.NodeInfoFetchedAt = time.Now()
+
+	if err := p.state.DB.UpdateInstance(ctx, instance,
+		"software_name",
+		"software_version",
+		"user_count",
+		"node_info_fetched_at",
+	); err != nil {
+		return gtserror.Newf("db error updating instance %s: %w", instance.Domain, err)
+	}
+
+	return nil
+}
+
+func remoteInstanceToAPI(instance *gtsmodel.Instance) *apimodel.RemoteInstance {
+	apiInstance := &apimodel.RemoteInstance{
+		Domain:          instance.Domain,
+		SoftwareName:    instance.SoftwareName,
+		SoftwareVersion: instance.SoftwareVersion,
+		UserCount:       instance.UserCount,
+	}
+
+	if !instance.NodeInfoFetchedAt.IsZero() {
+		fetchedAt := util.FormatISO8601(instance.NodeInfoFetchedAt)
+		apiInstance.FetchedAt = &fetchedAt
+	}
+
+	return apiInstance
+}
@@ -0,0 +1,218 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/email"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// ApproveSignup approves the pending sign-up of the account with the
+// given ID, and lets them know by email. The user will still need
+// to confirm their email address before they can log in.
+func (p *Processor) ApproveSignup(ctx context.Context, adminAccount *gtsmodel.Account, accountID string) gtserror.WithCode {
+	user, errWithCode := p.getPendingSignup(ctx, accountID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	user.Approved = func() *bool { a := true; return &a }()
+	if err := p.state.DB.UpdateUser(ctx, user, "approved"); err != nil {
+		err = gtserror.Newf("db error updating user %s: %w", user.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	log.Infof(ctx, "account %s approved sign-up of account %s", adminAccount.ID, accountID)
+
+	// The sign-up is approved either way, so an
+	// email failure shouldn't be an error here.
+	if err := p.emailSignupApproved(ctx, user); err != nil {
+		log.Errorf(ctx, "error emailing user %s: %v", user.ID, err)
+	}
+
+	return nil
+}
+
+// RejectSignup rejects the pending sign-up of the account with the given ID.
+// If form.Notify is true, the applicant is told by email, including the reason
+// if one is given.
+//
+// The account is then deleted in the same way as any other deleted account,
+// which also cleans up the user, and any tokens, clients and applications
+// handed out during the sign-up flow.
+func (p *Processor) RejectSignup(ctx context.Context, adminAccount *gtsmodel.Account, accountID string, form *apimodel.AdminSignupRejectRequest) gtserror.WithCode {
+	user, errWithCode := p.getPendingSignup(ctx, accountID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if form.Notify {
+		// Email before the deletion is enqueued,
+		// since that clears the user's email address.
+		if err := p.emailSignupRejected(ctx, user, form.Reason); err != nil {
+			log.Errorf(ctx, "error emailing user %s: %v", user.ID, err)
+		}
+	}
+
+	// Pass the delete through the client API
+	// channel, just like an admin suspension.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityDelete,
+		OriginAccount:  adminAccount,
+		TargetAccount:  user.Account,
+	})
+
+	log.Infof(ctx, "account %s rejected sign-up of account %s", adminAccount.ID, accountID)
+
+	return nil
+}
+
+// GetPendingSignups returns a page of accounts whose sign-up is still
+// awaiting approval, newest first, including the reason they gave for
+// wanting to join.
+func (p *Processor) GetPendingSignups(ctx context.Context, adminAccount *gtsmodel.Account, maxID string, minID string, limit int) (*apimodel.PageableResponse, gtserror.WithCode) {
+	users, err := p.state.DB.GetPendingSignups(ctx, maxID, minID, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting pending sign-ups: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(users)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	items := make([]interface{}, 0, count)
+	for _, user := range users {
+		account := user.Account
+		if account == nil {
+			account, err = p.state.DB.GetAccountByID(ctx, user.AccountID)
+			if err != nil {
+				log.Errorf(ctx, "error getting account for user %s: %v", user.ID, err)
+				continue
+			}
+		}
+
+		adminAccountInfo, err := p.tc.AccountToAdminAPIAccount(ctx, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting account %s to admin account: %v", account.ID, err)
+			continue
+		}
+
+		items = append(items, adminAccountInfo)
+	}
+
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:            items,
+		Path:             "/api/v1/admin/accounts",
+		NextMaxIDValue:   users[count-1].AccountID,
+		PrevMinIDValue:   users[0].AccountID,
+		Limit:            limit,
+		ExtraQueryParams: []string{"pending=true"},
+		Filtered:         true,
+	})
+}
+
+// CountPendingSignups returns the number of
+// sign-ups which are still awaiting approval.
+func (p *Processor) CountPendingSignups(ctx context.Context) (*apimodel.AdminSignupCount, gtserror.WithCode) {
+	count, err := p.state.DB.CountPendingSignups(ctx)
+	if err != nil {
+		err = gtserror.Newf("db error counting pending sign-ups: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.AdminSignupCount{Count: count}, nil
+}
+
+// getPendingSignup fetches the user for the account with the
+// given ID, returning an error if they've already been approved.
+func (p *Processor) getPendingSignup(ctx context.Context, accountID string) (*gtsmodel.User, gtserror.WithCode) {
+	user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("user for account %s not found", accountID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err = gtserror.Newf("db error getting user for account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if *user.Approved {
+		err := gtserror.Newf("user %s has already been approved", user.ID)
+		return nil, gtserror.NewErrorBadRequest(err, "sign-up has already been approved")
+	}
+
+	if user.Account == nil {
+		user.Account, err = p.state.DB.GetAccountByID(ctx, user.AccountID)
+		if err != nil {
+			err = gtserror.Newf("db error getting account for user %s: %w", user.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return user, nil
+}
+
+func (p *Processor) emailSignupApproved(ctx context.Context, user *gtsmodel.User) error {
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("error getting instance: %w", err)
+	}
+
+	return p.emailSender.SendSignupApprovedEmail(signupEmailAddress(user), email.SignupApprovedData{
+		Username:     user.Account.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+	})
+}
+
+func (p *Processor) emailSignupRejected(ctx context.Context, user *gtsmodel.User, reason string) error {
+	instance, err := p.state.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("error getting instance: %w", err)
+	}
+
+	return p.emailSender.SendSignupRejectedEmail(signupEmailAddress(user), email.SignupRejectedData{
+		Username:     user.Account.Username,
+		InstanceURL:  instance.URI,
+		InstanceName: instance.Title,
+		Reason:       reason,
+	})
+}
+
+// signupEmailAddress returns the address to contact a
+// pending sign-up on, which may not be confirmed yet.
+func signupEmailAddress(user *gtsmodel.User) string {
+	if user.Email != "" {
+		return user.Email
+	}
+	return user.UnconfirmedEmail
+}
@@ -0,0 +1,107 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+type SignupTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *SignupTestSuite) TestApproveSignup() {
+	ctx := context.Background()
+	pending := suite.testUsers["unconfirmed_account"]
+
+	errWithCode := suite.adminProcessor.ApproveSignup(ctx, suite.testAccounts["admin_account"], pending.AccountID)
+	suite.NoError(errWithCode)
+
+	dbUser, err := suite.db.GetUserByID(ctx, pending.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbUser.Approved)
+	suite.Len(suite.sentEmails, 1)
+
+	// Can't approve twice.
+	errWithCode = suite.adminProcessor.ApproveSignup(ctx, suite.testAccounts["admin_account"], pending.AccountID)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *SignupTestSuite) TestRejectSignup() {
+	ctx := context.Background()
+	pending := suite.testUsers["unconfirmed_account"]
+
+	errWithCode := suite.adminProcessor.RejectSignup(ctx, suite.testAccounts["admin_account"], pending.AccountID, &apimodel.AdminSignupRejectRequest{
+		Reason: "no spammers please",
+	})
+	suite.NoError(errWithCode)
+
+	// Nobody should have been emailed,
+	// since notify wasn't set.
+	suite.Empty(suite.sentEmails)
+
+	// The account should be deleted via the
+	// normal account delete side effects.
+	var msg messages.FromClientAPI
+	select {
+	case msg = <-suite.fromClientAPIChan:
+	case <-time.After(5 * time.Second):
+		suite.FailNow("timed out waiting for delete message")
+	}
+	suite.Equal(ap.ActorPerson, msg.APObjectType)
+	suite.Equal(ap.ActivityDelete, msg.APActivityType)
+	suite.Equal(pending.AccountID, msg.TargetAccount.ID)
+	suite.Equal(suite.testAccounts["admin_account"].ID, msg.OriginAccount.ID)
+}
+
+func (suite *SignupTestSuite) TestRejectSignupNotify() {
+	ctx := context.Background()
+	pending := suite.testUsers["unconfirmed_account"]
+
+	errWithCode := suite.adminProcessor.RejectSignup(ctx, suite.testAccounts["admin_account"], pending.AccountID, &apimodel.AdminSignupRejectRequest{
+		Reason: "no spammers please",
+		Notify: true,
+	})
+	suite.NoError(errWithCode)
+
+	suite.Len(suite.sentEmails, 1)
+	suite.Contains(suite.sentEmails[pending.UnconfirmedEmail], "no spammers please")
+}
+
+func (suite *SignupTestSuite) TestRejectApprovedSignup() {
+	ctx := context.Background()
+
+	errWithCode := suite.adminProcessor.RejectSignup(ctx, suite.testAccounts["admin_account"], suite.testAccounts["local_account_1"].ID, &apimodel.AdminSignupRejectRequest{})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Nothing should have been deleted.
+	suite.Empty(suite.fromClientAPIChan)
+}
+
+func TestSignupTestSuite(t *testing.T) {
+	suite.Run(t, &SignupTestSuite{})
+}
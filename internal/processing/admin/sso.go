@@ -0,0 +1,41 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// SSOConfigGet returns the OIDC single sign-on
+// configuration currently in use by this instance.
+func (p *Processor) SSOConfigGet(ctx context.Context) (*apimodel.AdminSSOConfig, gtserror.WithCode) {
+	return &apimodel.AdminSSOConfig{
+		Enabled:       config.GetOIDCEnabled(),
+		IdpName:       config.GetOIDCIdpName(),
+		Issuer:        config.GetOIDCIssuer(),
+		ClientID:      config.GetOIDCClientID(),
+		Scopes:        config.GetOIDCScopes(),
+		LinkExisting:  config.GetOIDCLinkExisting(),
+		AutoProvision: config.GetOIDCAutoProvision(),
+		AdminGroups:   config.GetOIDCAdminGroups(),
+	}, nil
+}
@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// DeleteRemoteStatus asynchronously purges this instance's copy of the remote
+// status with the given ID, along with its cached media, mentions, notifications,
+// faves, bookmarks, and boosts. Nothing is federated: the status still exists on
+// its origin server, we just no longer keep or show it here.
+//
+// Local statuses can't be removed this way, since deleting
+// them must be federated; they are deleted by their author.
+func (p *Processor) DeleteRemoteStatus(ctx context.Context, account *gtsmodel.Account, statusID string) (*apimodel.Status, gtserror.WithCode) {
+	status, err := p.state.DB.GetStatusByID(ctx, statusID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("status %s not found", statusID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err = gtserror.Newf("db error getting status %s: %w", statusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if *status.Local {
+		err := gtserror.Newf("status %s is a local status", statusID)
+		return nil, gtserror.NewErrorBadRequest(err, "only remote statuses can be deleted via this endpoint")
+	}
+
+	// Convert before deleting, so the
+	// caller can see what was removed.
+	apiStatus, err := p.tc.StatusToAPIStatus(ctx, status, account)
+	if err != nil {
+		err = gtserror.Newf("error converting status %s: %w", statusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process the purge just as if the status had been deleted
+	// by its origin server, so that it's wiped from the db,
+	// storage, timelines and open streams in the usual way,
+	// without anything being federated out.
+	p.state.Workers.EnqueueFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ObjectNote,
+		APActivityType:   ap.ActivityDelete,
+		GTSModel:         status,
+		ReceivingAccount: account,
+	})
+
+	log.Infof(ctx, "account %s queued purge of remote status %s", account.ID, status.URI)
+
+	return apiStatus, nil
+}
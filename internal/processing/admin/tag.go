@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// TagGet returns the admin view of the hashtag with the given name.
+func (p *Processor) TagGet(ctx context.Context, name string) (*apimodel.AdminTag, gtserror.WithCode) {
+	tag, errWithCode := p.getTag(ctx, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return apiAdminTag(tag), nil
+}
+
+// TagUpdate updates the moderation settings of the hashtag with the
+// given name. Making a hashtag unusable prevents local accounts from
+// creating statuses which use it.
+func (p *Processor) TagUpdate(ctx context.Context, account *gtsmodel.Account, name string, form *apimodel.AdminTagUpdateRequest) (*apimodel.AdminTag, gtserror.WithCode) {
+	tag, errWithCode := p.getTag(ctx, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	columns := make([]string, 0, 4)
+
+	if form.Usable != nil {
+		tag.Useable = form.Usable
+		columns = append(columns, "useable")
+	}
+
+	if form.Listable != nil {
+		tag.Listable = form.Listable
+		columns = append(columns, "listable")
+	}
+
+	if form.Trendable != nil {
+		tag.Trendable = form.Trendable
+		columns = append(columns, "trendable")
+	}
+
+	if form.RequiresReview != nil {
+		tag.RequiresReview = form.RequiresReview
+		columns = append(columns, "requires_review")
+	}
+
+	if len(columns) == 0 {
+		err := errors.New("no tag settings given to update")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if err := p.state.DB.UpdateTag(ctx, tag, columns...); err != nil {
+		err = gtserror.Newf("db error updating tag %s: %w", tag.Name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	log.WithContext(ctx).Infof("tag %s updated by %s: %v", tag.Name, account.Username, columns)
+	return apiAdminTag(tag), nil
+}
+
+func (p *Processor) getTag(ctx context.Context, name string) (*gtsmodel.Tag, gtserror.WithCode) {
+	if name == "" {
+		err := errors.New("no tag name given")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	tag, err := p.state.DB.GetTagByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("tag %s not found", name)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		err = gtserror.Newf("db error getting tag %s: %w", name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return tag, nil
+}
+
+func apiAdminTag(tag *gtsmodel.Tag) *apimodel.AdminTag {
+	return &apimodel.AdminTag{
+		Name:           tag.Name,
+		URL:            tag.URL,
+		Usable:         *tag.Useable,
+		Listable:       *tag.Listable,
+		Trendable:      *tag.Trendable,
+		RequiresReview: *tag.RequiresReview,
+		LastStatusAt:   util.FormatISO8601(tag.LastStatusAt),
+	}
+}
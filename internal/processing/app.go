@@ -19,9 +19,13 @@ package processing
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -32,11 +36,18 @@ func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 	// set default 'read' for scopes if it's not set
 	var scopes string
 	if form.Scopes == "" {
-		scopes = "read"
+		scopes = oauth.ScopeRead
 	} else {
 		scopes = form.Scopes
 	}
 
+	for _, scope := range strings.Fields(scopes) {
+		if !oauth.IsValidScope(scope) {
+			err := fmt.Errorf("scope %q is not recognized", scope)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
 	// generate new IDs for this application and its associated client
 	clientID, err := id.NewRandomULID()
 	if err != nil {
@@ -86,3 +97,51 @@ func (p *Processor) AppCreate(ctx context.Context, authed *oauth.Auth, form *api
 
 	return apiApp, nil
 }
+
+// VerifyAppCredentials checks that the OAuth client with the given ID,
+// and its associated application, are still registered on this instance,
+// and returns the application. If either has been removed, a 401 is returned.
+func (p *Processor) VerifyAppCredentials(ctx context.Context, clientID string) (*apimodel.Application, gtserror.WithCode) {
+	client := &gtsmodel.Client{}
+	if err := p.state.DB.GetByID(ctx, clientID, client); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("VerifyAppCredentials: db error getting client %s: %w", clientID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		err = fmt.Errorf("client %s not found", clientID)
+		return nil, gtserror.NewErrorUnauthorized(err, "invalid app credentials")
+	}
+
+	app := &gtsmodel.Application{}
+	if err := p.state.DB.GetWhere(ctx, []db.Where{{Key: "client_id", Value: client.ID}}, app); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("VerifyAppCredentials: db error getting application for client %s: %w", clientID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		err = fmt.Errorf("application for client %s not found", clientID)
+		return nil, gtserror.NewErrorUnauthorized(err, "invalid app credentials")
+	}
+
+	apiApp, err := p.tc.AppToAPIAppPublic(ctx, app)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	apiApp.Scopes = strings.Fields(app.Scopes)
+
+	return apiApp, nil
+}
+
+// GetOAuthScopes returns all OAuth scopes recognized by this
+// instance, along with their descriptions and parent scopes.
+func (p *Processor) GetOAuthScopes() []*apimodel.OAuthScope {
+	scopes := oauth.Scopes()
+	apiScopes := make([]*apimodel.OAuthScope, 0, len(scopes))
+	for _, s := range scopes {
+		apiScopes = append(apiScopes, &apimodel.OAuthScope{
+			Scope:       s.Name,
+			Description: s.Description,
+			ParentScope: s.Parent,
+		})
+	}
+	return apiScopes
+}
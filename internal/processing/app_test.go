@@ -0,0 +1,135 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AppTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *AppTestSuite) TestVerifyAppCredentials() {
+	app := suite.testApplications["admin_account"]
+
+	apiApp, errWithCode := suite.processor.VerifyAppCredentials(context.Background(), app.ClientID)
+	suite.NoError(errWithCode)
+	suite.Equal(app.Name, apiApp.Name)
+	suite.Equal(app.Website, apiApp.Website)
+	suite.Equal([]string{"read", "write", "follow", "push"}, apiApp.Scopes)
+
+	// Secrets should not be echoed back.
+	suite.Empty(apiApp.ClientID)
+	suite.Empty(apiApp.ClientSecret)
+}
+
+func (suite *AppTestSuite) TestVerifyAppCredentialsNoClient() {
+	app := suite.testApplications["admin_account"]
+
+	// Revoke the client.
+	if err := suite.db.DeleteByID(context.Background(), app.ClientID, &gtsmodel.Client{}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiApp, errWithCode := suite.processor.VerifyAppCredentials(context.Background(), app.ClientID)
+	suite.Nil(apiApp)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+}
+
+func (suite *AppTestSuite) TestVerifyAppCredentialsNoApplication() {
+	app := suite.testApplications["admin_account"]
+
+	// Remove the application but leave its client.
+	if err := suite.db.DeleteByID(context.Background(), app.ID, &gtsmodel.Application{}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiApp, errWithCode := suite.processor.VerifyAppCredentials(context.Background(), app.ClientID)
+	suite.Nil(apiApp)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+}
+
+func (suite *AppTestSuite) TestAppCreateScopes() {
+	apiApp, errWithCode := suite.processor.AppCreate(context.Background(), &oauth.Auth{}, &apimodel.ApplicationCreateRequest{
+		ClientName:   "some app",
+		RedirectURIs: oauth.OOBURI,
+		Scopes:       "read:statuses write:media push",
+	})
+	suite.NoError(errWithCode)
+	suite.NotEmpty(apiApp.ClientID)
+}
+
+func (suite *AppTestSuite) TestAppCreateMastodonScopes() {
+	// Scopes some Mastodon apps ask for.
+	apiApp, errWithCode := suite.processor.AppCreate(context.Background(), &oauth.Auth{}, &apimodel.ApplicationCreateRequest{
+		ClientName:   "some app",
+		RedirectURIs: oauth.OOBURI,
+		Scopes:       "profile write:conversations admin:read:accounts admin:write:domain_blocks",
+	})
+	suite.NoError(errWithCode)
+	suite.NotEmpty(apiApp.ClientID)
+}
+
+func (suite *AppTestSuite) TestAppCreateInvalidScope() {
+	apiApp, errWithCode := suite.processor.AppCreate(context.Background(), &oauth.Auth{}, &apimodel.ApplicationCreateRequest{
+		ClientName:   "some app",
+		RedirectURIs: oauth.OOBURI,
+		Scopes:       "read write:everything",
+	})
+	suite.Nil(apiApp)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal(`Bad Request: scope "write:everything" is not recognized`, errWithCode.Safe())
+}
+
+func (suite *AppTestSuite) TestGetOAuthScopes() {
+	scopes := suite.processor.GetOAuthScopes()
+	suite.NotEmpty(scopes)
+
+	byName := make(map[string]string, len(scopes))
+	for _, s := range scopes {
+		suite.NotEmpty(s.Description)
+		byName[s.Scope] = s.ParentScope
+	}
+
+	// Every parent must itself be listed.
+	for scope, parent := range byName {
+		if parent == "" {
+			continue
+		}
+		_, ok := byName[parent]
+		suite.True(ok, "parent %s of %s not listed", parent, scope)
+	}
+
+	suite.Equal("read", byName["read:statuses"])
+	suite.Equal("write", byName["write:media"])
+	suite.Equal("admin:read", byName["admin:read:accounts"])
+	suite.Equal("admin", byName["admin:read"])
+	suite.Empty(byName["read"])
+}
+
+func TestAppTestSuite(t *testing.T) {
+	suite.Run(t, &AppTestSuite{})
+}
@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainBlockTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *DomainBlockTestSuite) TestDomainBlockKeepsReports() {
+	ctx := context.Background()
+	testReports := testrig.NewTestReports()
+	remoteAccount := suite.testAccounts["remote_account_1"]
+
+	// Give the instance an instance account,
+	// and have it file a report, as most
+	// remote instances do.
+	instanceAccount := new(gtsmodel.Account)
+	*instanceAccount = *remoteAccount
+	instanceAccount.ID = "01H3X1XG8B9T1D2KQ4S9NVJ7ZM"
+	instanceAccount.Username = remoteAccount.Domain
+	instanceAccount.ActorType = ap.ActorApplication
+	instanceAccount.URI = "http://fossbros-anonymous.io/users/fossbros-anonymous.io"
+	instanceAccount.URL = "http://fossbros-anonymous.io/@fossbros-anonymous.io"
+	instanceAccount.InboxURI = "http://fossbros-anonymous.io/users/fossbros-anonymous.io/inbox"
+	instanceAccount.OutboxURI = "http://fossbros-anonymous.io/users/fossbros-anonymous.io/outbox"
+	instanceAccount.FollowersURI = "http://fossbros-anonymous.io/users/fossbros-anonymous.io/followers"
+	instanceAccount.FollowingURI = "http://fossbros-anonymous.io/users/fossbros-anonymous.io/following"
+	instanceAccount.FeaturedCollectionURI = "http://fossbros-anonymous.io/users/fossbros-anonymous.io/collections/featured"
+	instanceAccount.PublicKeyURI = "http://fossbros-anonymous.io/users/fossbros-anonymous.io/main-key"
+	if err := suite.db.PutAccount(ctx, instanceAccount); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instanceReport := &gtsmodel.Report{
+		ID:              "01H3X1YB6F0V5R8W2PZ7QK3DNE",
+		URI:             "http://fossbros-anonymous.io/reports/01H3X1YB6F0V5R8W2PZ7QK3DNE",
+		AccountID:       instanceAccount.ID,
+		TargetAccountID: suite.testAccounts["local_account_2"].ID,
+		Comment:         "turtles again",
+		StatusIDs:       []string{},
+		Forwarded:       testrig.TrueBool(),
+	}
+	if err := suite.db.PutReport(ctx, instanceReport); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, errWithCode := suite.processor.Admin().DomainBlockCreate(ctx, suite.testAccounts["admin_account"], remoteAccount.Domain, false, "", "", ""); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Wait for the side effects of the block to
+	// delete both accounts from the blocked domain.
+	for _, account := range []*gtsmodel.Account{remoteAccount, instanceAccount} {
+		accountID := account.ID
+		if !testrig.WaitFor(func() bool {
+			dbAccount, err := suite.db.GetAccountByID(ctx, accountID)
+			return err == nil && !dbAccount.SuspendedAt.IsZero()
+		}) {
+			suite.FailNow("timed out waiting for account " + accountID + " to be deleted")
+		}
+	}
+
+	// All the reports from and about the blocked
+	// domain should still load, with their accounts.
+	for _, reportID := range []string{
+		instanceReport.ID,
+		testReports["local_account_2_report_remote_account_1"].ID,
+		testReports["remote_account_1_report_local_account_2"].ID,
+	} {
+		report, err := suite.db.GetReportByID(ctx, reportID)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.NotNil(report.Account)
+		suite.NotNil(report.TargetAccount)
+	}
+
+	reports, err := suite.db.GetReports(ctx, nil, "", "", "", "", "", 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(reports, 3)
+}
+
+func TestDomainBlockTestSuite(t *testing.T) {
+	suite.Run(t, &DomainBlockTestSuite{})
+}
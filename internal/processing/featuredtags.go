@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// featuredTagSuggestionsWindow is how far back to look
+	// through an account's statuses for featured tag suggestions.
+	featuredTagSuggestionsWindow = 90 * 24 * time.Hour
+
+	// featuredTagSuggestionsLimit is the max number
+	// of featured tag suggestions to return.
+	featuredTagSuggestionsLimit = 10
+)
+
+// FeaturedTagSuggestionsGet returns the hashtags most used by the requesting
+// account in recent statuses, as suggestions for tags to feature on their profile.
+// Tags are sorted by number of uses, most used first.
+func (p *Processor) FeaturedTagSuggestionsGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	since := time.Now().Add(-featuredTagSuggestionsWindow)
+
+	usages, err := p.state.DB.GetAccountTagUsage(ctx, authed.Account.ID, since, featuredTagSuggestionsLimit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting tag usage for account %s: %w", authed.Account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	suggestions := make([]*apimodel.FeaturedTag, 0, len(usages))
+	for _, usage := range usages {
+		suggestions = append(suggestions, &apimodel.FeaturedTag{
+			ID:            usage.Tag.ID,
+			Name:          usage.Tag.Name,
+			URL:           usage.Tag.URL,
+			StatusesCount: usage.StatusCount,
+			LastStatusAt:  util.FormatISO8601(usage.LastStatusAt),
+		})
+	}
+
+	return suggestions, nil
+}
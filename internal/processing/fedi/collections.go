@@ -22,11 +22,11 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"net/url"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 // InboxPost handles POST requests to a user's inbox for new activitypub messages.
@@ -65,10 +65,16 @@ func (p *Processor) OutboxGet(ctx context.Context, requestedUsername string, pag
 				"id": "https://example.org/users/whatever/outbox",
 				"type": "OrderedCollection",
 				"first": "https://example.org/users/whatever/outbox?page=true",
-				"last": "https://example.org/users/whatever/outbox?min_id=0&page=true"
+				"totalItems": 42
 			}
 		*/
-		collection, err := p.tc.OutboxToASCollection(ctx, requestedAccount.OutboxURI)
+		// Use the same statuses count as shown on the account's public profile.
+		totalItems, err := p.state.DB.CountAccountStatuses(ctx, requestedAccount.ID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		collection, err := p.tc.OutboxToASCollection(ctx, requestedAccount.OutboxURI, totalItems)
 		if err != nil {
 			return nil, gtserror.NewErrorInternalError(err)
 		}
@@ -102,49 +108,77 @@ func (p *Processor) OutboxGet(ctx context.Context, requestedUsername string, pag
 
 // FollowersGet handles the getting of a fedi/activitypub representation of a user/account's followers, performing appropriate
 // authentication before returning a JSON serializable interface to the caller.
+//
+// If the account hides its collections, the returned collection will be empty.
 func (p *Processor) FollowersGet(ctx context.Context, requestedUsername string) (interface{}, gtserror.WithCode) {
 	requestedAccount, _, errWithCode := p.authenticate(ctx, requestedUsername)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
-	requestedAccountURI, err := url.Parse(requestedAccount.URI)
-	if err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error parsing url %s: %s", requestedAccount.URI, err))
-	}
+	var accounts []*gtsmodel.Account
 
-	requestedFollowers, err := p.federator.FederatingDB().Followers(ctx, requestedAccountURI)
-	if err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching followers for uri %s: %s", requestedAccountURI.String(), err))
-	}
+	if !hidesCollections(requestedAccount) {
+		follows, err := p.state.DB.GetAccountFollowers(ctx, requestedAccount.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching followers for account %s: %w", requestedAccount.ID, err))
+		}
 
-	data, err := ap.Serialize(requestedFollowers)
-	if err != nil {
-		return nil, gtserror.NewErrorInternalError(err)
+		accounts = make([]*gtsmodel.Account, 0, len(follows))
+		for _, follow := range follows {
+			if follow.Account == nil {
+				// Follow account no longer exists,
+				// for some reason. Skip this one.
+				continue
+			}
+			accounts = append(accounts, follow.Account)
+		}
 	}
 
-	return data, nil
+	return p.accountsCollection(ctx, requestedAccount.FollowersURI, accounts)
 }
 
 // FollowingGet handles the getting of a fedi/activitypub representation of a user/account's following, performing appropriate
 // authentication before returning a JSON serializable interface to the caller.
+//
+// If the account hides its collections, the returned collection will be empty.
 func (p *Processor) FollowingGet(ctx context.Context, requestedUsername string) (interface{}, gtserror.WithCode) {
 	requestedAccount, _, errWithCode := p.authenticate(ctx, requestedUsername)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
-	requestedAccountURI, err := url.Parse(requestedAccount.URI)
-	if err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error parsing url %s: %s", requestedAccount.URI, err))
+	var accounts []*gtsmodel.Account
+
+	if !hidesCollections(requestedAccount) {
+		follows, err := p.state.DB.GetAccountFollows(ctx, requestedAccount.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching following for account %s: %w", requestedAccount.ID, err))
+		}
+
+		accounts = make([]*gtsmodel.Account, 0, len(follows))
+		for _, follow := range follows {
+			if follow.TargetAccount == nil {
+				// Follow target account no longer
+				// exists, for some reason. Skip it.
+				continue
+			}
+			accounts = append(accounts, follow.TargetAccount)
+		}
 	}
 
-	requestedFollowing, err := p.federator.FederatingDB().Following(ctx, requestedAccountURI)
+	return p.accountsCollection(ctx, requestedAccount.FollowingURI, accounts)
+}
+
+// accountsCollection serializes the given accounts
+// as an ordered collection with the given ID.
+func (p *Processor) accountsCollection(ctx context.Context, collectionID string, accounts []*gtsmodel.Account) (interface{}, gtserror.WithCode) {
+	collection, err := p.tc.AccountsToASCollection(ctx, collectionID, accounts)
 	if err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching following for uri %s: %s", requestedAccountURI.String(), err))
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	data, err := ap.Serialize(requestedFollowing)
+	data, err := ap.Serialize(collection)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -152,6 +186,12 @@ func (p *Processor) FollowingGet(ctx context.Context, requestedUsername string)
 	return data, nil
 }
 
+// hidesCollections returns whether the given
+// account has chosen to hide its collections.
+func hidesCollections(account *gtsmodel.Account) bool {
+	return account.HideCollections != nil && *account.HideCollections
+}
+
 // FeaturedCollectionGet returns an ordered collection of the requested username's Pinned posts.
 // The returned collection have an `items` property which contains an ordered list of status URIs.
 func (p *Processor) FeaturedCollectionGet(ctx context.Context, requestedUsername string) (interface{}, gtserror.WithCode) {
@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (p *Processor) FollowRequestsGet(ctx context.Context, auth *oauth.Auth) ([]apimodel.Account, gtserror.WithCode) {
@@ -56,6 +57,55 @@ func (p *Processor) FollowRequestsGet(ctx context.Context, auth *oauth.Auth) ([]
 	return accts, nil
 }
 
+// FollowRequestsOutboundGet returns a pageable response of accounts that the
+// requesting account has requested to follow, but which have not yet accepted.
+// Paging for this response is done based on follow request ID, newest first.
+func (p *Processor) FollowRequestsOutboundGet(ctx context.Context, auth *oauth.Auth, maxID string, minID string, limit int) (*apimodel.PageableResponse, gtserror.WithCode) {
+	followRequests, err := p.state.DB.GetAccountFollowRequestingPage(ctx, auth.Account.ID, maxID, minID, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(followRequests)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	var (
+		items = make([]interface{}, 0, count)
+
+		// Set next + prev values before filtering, so
+		// that paging still works even if every request
+		// on this page has to be skipped.
+		nextMaxIDValue = followRequests[count-1].ID
+		prevMinIDValue = followRequests[0].ID
+	)
+
+	for _, followRequest := range followRequests {
+		if followRequest.TargetAccount == nil {
+			// The target of the follow request doesn't
+			// exist anymore, just skip this one.
+			log.WithContext(ctx).WithField("followRequest", followRequest).Warn("follow request had no associated target account")
+			continue
+		}
+
+		apiAcct, err := p.tc.AccountToAPIAccountPublic(ctx, followRequest.TargetAccount)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		items = append(items, apiAcct)
+	}
+
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:          items,
+		Path:           "/api/v1/follow_requests/outbound",
+		NextMaxIDValue: nextMaxIDValue,
+		PrevMinIDValue: prevMinIDValue,
+		Limit:          limit,
+		Filtered:       true,
+	})
+}
+
 func (p *Processor) FollowRequestAccept(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode) {
 	follow, err := p.state.DB.AcceptFollowRequest(ctx, accountID, auth.Account.ID)
 	if err != nil {
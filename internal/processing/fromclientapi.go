@@ -78,6 +78,9 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 	case ap.ActivityUpdate:
 		// UPDATE
 		switch clientMsg.APObjectType {
+		case ap.ObjectNote:
+			// UPDATE NOTE/STATUS
+			return p.processUpdateStatusFromClientAPI(ctx, clientMsg)
 		case ap.ObjectProfile, ap.ActorPerson:
 			// UPDATE ACCOUNT/PROFILE
 			return p.processUpdateAccountFromClientAPI(ctx, clientMsg)
@@ -239,6 +242,15 @@ func (p *Processor) processUpdateAccountFromClientAPI(ctx context.Context, clien
 	return p.federateAccountUpdate(ctx, account, clientMsg.OriginAccount)
 }
 
+func (p *Processor) processUpdateStatusFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	status, ok := clientMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return errors.New("status was not parseable as *gtsmodel.Status")
+	}
+
+	return p.federateStatusUpdate(ctx, status)
+}
+
 func (p *Processor) processUpdateReportFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
 	report, ok := clientMsg.GTSModel.(*gtsmodel.Report)
 	if !ok {
@@ -469,6 +481,44 @@ func (p *Processor) federateStatus(ctx context.Context, status *gtsmodel.Status)
 	return err
 }
 
+func (p *Processor) federateStatusUpdate(ctx context.Context, status *gtsmodel.Status) error {
+	// do nothing if the status shouldn't be federated
+	if !*status.Federated {
+		return nil
+	}
+
+	if status.Account == nil {
+		statusAccount, err := p.state.DB.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return fmt.Errorf("federateStatusUpdate: error fetching status author account: %s", err)
+		}
+		status.Account = statusAccount
+	}
+
+	// Do nothing if this isn't our activity.
+	if !status.Account.IsLocal() {
+		return nil
+	}
+
+	asStatus, err := p.tc.StatusToAS(ctx, status)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error converting status to as format: %s", err)
+	}
+
+	update, err := p.tc.WrapNoteInUpdate(asStatus, status.Account)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error wrapping status in update: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(status.Account.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateStatusUpdate: error parsing outboxURI %s: %s", status.Account.OutboxURI, err)
+	}
+
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, update)
+	return err
+}
+
 func (p *Processor) federateStatusDelete(ctx context.Context, status *gtsmodel.Status) error {
 	if status.Account == nil {
 		statusAccount, err := p.state.DB.GetAccountByID(ctx, status.AccountID)
@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 )
 
 // ProcessFromFederator reads the APActivityType and APObjectType of an incoming message from the federator,
@@ -167,9 +168,50 @@ func (p *Processor) processCreateStatusFromFederator(ctx context.Context, federa
 		}
 	}
 
+	if status.InReplyToID != "" {
+		// Statuses created directly in our inbox are checked by the
+		// federating db, but dereferenced ones (eg., forwards) aren't
+		// yet, so make sure a reply to one of our statuses is permitted.
+		permitted, err := p.replyPermitted(ctx, status)
+		if err != nil {
+			return err
+		}
+
+		if !permitted {
+			log.Debugf(ctx, "reply to %s not permitted by its interaction policy, dropping it", status.InReplyToURI)
+			return p.wipeStatus(ctx, status, true)
+		}
+	}
+
 	return p.timelineAndNotifyStatus(ctx, status)
 }
 
+// replyPermitted checks whether the given remote status, which is a reply,
+// is permitted by the interaction policy of the status it replies to.
+// Replies to remote statuses are always permitted, as it's up to the
+// remote instance to enforce the interaction policies of its statuses.
+func (p *Processor) replyPermitted(ctx context.Context, status *gtsmodel.Status) (bool, error) {
+	inReplyTo := status.InReplyTo
+	if inReplyTo == nil {
+		var err error
+		inReplyTo, err = p.state.DB.GetStatusByID(ctx, status.InReplyToID)
+		if err != nil {
+			return false, fmt.Errorf("replyPermitted: error fetching replied-to status %s: %w", status.InReplyToID, err)
+		}
+	}
+
+	if !*inReplyTo.Local {
+		return true, nil
+	}
+
+	permitted, err := p.filter.StatusInteractionPermitted(ctx, status.Account, inReplyTo, visibility.InteractionReply)
+	if err != nil {
+		return false, fmt.Errorf("replyPermitted: error checking interaction policy of status %s: %w", inReplyTo.ID, err)
+	}
+
+	return permitted, nil
+}
+
 // processCreateFaveFromFederator handles Activity Create and Object Like
 func (p *Processor) processCreateFaveFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
 	incomingFave, ok := federatorMsg.GTSModel.(*gtsmodel.StatusFave)
@@ -305,6 +347,19 @@ func (p *Processor) processCreateAnnounceFromFederator(ctx context.Context, fede
 		return fmt.Errorf("error dereferencing announce from federator: %s", err)
 	}
 
+	if *incomingAnnounce.BoostOf.Local {
+		// Boosts of our statuses must be permitted by their interaction policy.
+		permitted, err := p.filter.StatusInteractionPermitted(ctx, incomingAnnounce.Account, incomingAnnounce.BoostOf, visibility.InteractionBoost)
+		if err != nil {
+			return fmt.Errorf("error checking interaction policy of status %s: %w", incomingAnnounce.BoostOfID, err)
+		}
+
+		if !permitted {
+			log.Debugf(ctx, "boost of %s not permitted by its interaction policy, dropping it", incomingAnnounce.BoostOf.URI)
+			return nil
+		}
+	}
+
 	incomingAnnounceID, err := id.NewULIDFromTime(incomingAnnounce.CreatedAt)
 	if err != nil {
 		return err
@@ -19,8 +19,10 @@ package processing
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -41,12 +43,143 @@ func (p *Processor) getThisInstance(ctx context.Context) (*gtsmodel.Instance, er
 	return i, nil
 }
 
+// contactAccountCacheTTL is how long a resolved
+// instance contact account is cached for.
+const contactAccountCacheTTL = 5 * time.Minute
+
+// GetInstanceContactAccount returns the contact account for this instance,
+// or nil if there isn't one. The account is, in order of preference:
+//
+//   - the account with the configured instance-contact-account-username
+//   - the contact account set via the instance settings
+//   - the first (oldest) admin account that isn't suspended or disabled
+func (p *Processor) GetInstanceContactAccount(ctx context.Context) (*apimodel.Account, error) {
+	contactAccount, err := p.instanceContactAccount(ctx)
+	if err != nil || contactAccount == nil {
+		return nil, err
+	}
+
+	return p.tc.AccountToAPIAccountPublic(ctx, contactAccount)
+}
+
+func (p *Processor) instanceContactAccount(ctx context.Context) (*gtsmodel.Account, error) {
+	// Only one value is ever cached.
+	const key = ""
+
+	accountID, ok := p.contactAccountID.Get(key)
+	if !ok {
+		var err error
+		accountID, err = p.resolveInstanceContactAccountID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.contactAccountID.Set(key, accountID)
+	}
+
+	if accountID == "" {
+		// No contact account.
+		return nil, nil
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Account was removed since we cached
+			// it, resolve again on next call.
+			p.contactAccountID.Invalidate(key)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (p *Processor) resolveInstanceContactAccountID(ctx context.Context) (string, error) {
+	if username := config.GetInstanceContactAccountUsername(); username != "" {
+		account, err := p.state.DB.GetAccountByUsernameDomain(ctx, username, "")
+		if err == nil {
+			return account.ID, nil
+		}
+
+		if !errors.Is(err, db.ErrNoEntries) {
+			return "", fmt.Errorf("db error getting configured contact account %s: %w", username, err)
+		}
+
+		log.Warnf(ctx, "configured instance contact account %s does not exist", username)
+	}
+
+	i, err := p.getThisInstance(ctx)
+	if err != nil {
+		return "", fmt.Errorf("db error fetching instance: %w", err)
+	}
+
+	if i.ContactAccountID != "" {
+		return i.ContactAccountID, nil
+	}
+
+	admins := []*gtsmodel.User{}
+	if err := p.state.DB.GetWhere(ctx, []db.Where{{Key: "admin", Value: true}}, &admins); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return "", fmt.Errorf("db error getting admin users: %w", err)
+	}
+
+	sort.Slice(admins, func(i, j int) bool {
+		return admins[i].ID < admins[j].ID
+	})
+
+	for _, admin := range admins {
+		if *admin.Disabled {
+			continue
+		}
+
+		account, err := p.state.DB.GetAccountByID(ctx, admin.AccountID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				continue
+			}
+			return "", fmt.Errorf("db error getting account for admin user %s: %w", admin.ID, err)
+		}
+
+		if !account.SuspendedAt.IsZero() {
+			continue
+		}
+
+		return account.ID, nil
+	}
+
+	return "", nil
+}
+
+// setInstanceContactAccount sets the resolved contact
+// account on i, so that it's included when converting
+// i to its API representation.
+func (p *Processor) setInstanceContactAccount(ctx context.Context, i *gtsmodel.Instance) error {
+	contactAccount, err := p.instanceContactAccount(ctx)
+	if err != nil {
+		return err
+	}
+
+	if contactAccount == nil {
+		i.ContactAccountID = ""
+		i.ContactAccount = nil
+		return nil
+	}
+
+	i.ContactAccountID = contactAccount.ID
+	i.ContactAccount = contactAccount
+	return nil
+}
+
 func (p *Processor) InstanceGetV1(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
 	i, err := p.getThisInstance(ctx)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error fetching instance: %s", err))
 	}
 
+	if err := p.setInstanceContactAccount(ctx, i); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting instance contact account: %s", err))
+	}
+
 	ai, err := p.tc.InstanceToAPIV1Instance(ctx, i)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance to api representation: %s", err))
@@ -61,6 +194,10 @@ func (p *Processor) InstanceGetV2(ctx context.Context) (*apimodel.InstanceV2, gt
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error fetching instance: %s", err))
 	}
 
+	if err := p.setInstanceContactAccount(ctx, i); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting instance contact account: %s", err))
+	}
+
 	ai, err := p.tc.InstanceToAPIV2Instance(ctx, i)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance to api representation: %s", err))
@@ -69,6 +206,18 @@ func (p *Processor) InstanceGetV2(ctx context.Context) (*apimodel.InstanceV2, gt
 	return ai, nil
 }
 
+// GetInstanceConfiguration returns the configured limits of this instance
+// for statuses, media attachments, and polls, so that clients can discover
+// them without fetching and parsing the whole instance object.
+func (p *Processor) GetInstanceConfiguration(ctx context.Context) (*apimodel.InstanceConfiguration, gtserror.WithCode) {
+	configuration, err := p.tc.InstanceConfigurationToAPIInstanceConfiguration(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance configuration to api representation: %s", err))
+	}
+
+	return configuration, nil
+}
+
 func (p *Processor) InstancePeersGet(ctx context.Context, includeSuspended bool, includeOpen bool, flat bool) (interface{}, gtserror.WithCode) {
 	domains := []*apimodel.Domain{}
 
@@ -234,7 +383,14 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		i.Terms = text.SanitizeHTML(*form.Terms) // html is OK in site terms, but we should sanitize it
 	}
 
-	var updateInstanceAccount bool
+	var (
+		updateInstanceAccount bool
+
+		// Media that's no longer (or now)
+		// used for instance branding.
+		oldAssetIDs []string
+		newAssetIDs []string
+	)
 
 	if form.Avatar != nil && form.Avatar.Size != 0 {
 		// process instance avatar image + description
@@ -242,8 +398,12 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		if err != nil {
 			return nil, gtserror.NewErrorBadRequest(err, "error processing avatar")
 		}
+		if ia.AvatarMediaAttachmentID != "" {
+			oldAssetIDs = append(oldAssetIDs, ia.AvatarMediaAttachmentID)
+		}
 		ia.AvatarMediaAttachmentID = avatarInfo.ID
 		ia.AvatarMediaAttachment = avatarInfo
+		newAssetIDs = append(newAssetIDs, avatarInfo.ID)
 		updateInstanceAccount = true
 	} else if form.AvatarDescription != nil && ia.AvatarMediaAttachment != nil {
 		// process just the description for the existing avatar
@@ -259,8 +419,12 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		if err != nil {
 			return nil, gtserror.NewErrorBadRequest(err, "error processing header")
 		}
+		if ia.HeaderMediaAttachmentID != "" {
+			oldAssetIDs = append(oldAssetIDs, ia.HeaderMediaAttachmentID)
+		}
 		ia.HeaderMediaAttachmentID = headerInfo.ID
 		ia.HeaderMediaAttachment = headerInfo
+		newAssetIDs = append(newAssetIDs, headerInfo.ID)
 		updateInstanceAccount = true
 	}
 
@@ -272,12 +436,36 @@ func (p *Processor) InstancePatch(ctx context.Context, form *apimodel.InstanceSe
 		}
 	}
 
+	// Instance branding should never be pruned, but
+	// replaced branding can go the way of other media.
+	for _, id := range oldAssetIDs {
+		if err := p.state.DB.SetAttachmentInstanceAsset(ctx, id, false); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error unmarking instance asset %s: %w", id, err))
+		}
+	}
+
+	for _, id := range newAssetIDs {
+		if err := p.state.DB.SetAttachmentInstanceAsset(ctx, id, true); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error marking instance asset %s: %w", id, err))
+		}
+	}
+
 	if len(updatingColumns) != 0 {
 		if err := p.state.DB.UpdateByID(ctx, i, i.ID, updatingColumns...); err != nil {
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error updating instance %s: %s", host, err))
 		}
 	}
 
+	if form.ContactUsername != nil {
+		// Contact account may have changed,
+		// so it needs to be resolved again.
+		p.contactAccountID.Clear()
+	}
+
+	if err := p.setInstanceContactAccount(ctx, i); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting instance contact account: %s", err))
+	}
+
 	ai, err := p.tc.InstanceToAPIV1Instance(ctx, i)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting instance to api representation: %s", err))
@@ -0,0 +1,213 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type InstanceTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *InstanceTestSuite) clearInstanceContactAccount() {
+	instance := &gtsmodel.Instance{}
+	if err := suite.db.GetWhere(context.Background(), []db.Where{{Key: "domain", Value: config.GetHost()}}, instance); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instance.ContactAccountUsername = ""
+	instance.ContactAccountID = ""
+	if err := suite.db.UpdateByID(context.Background(), instance, instance.ID, "contact_account_username", "contact_account_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *InstanceTestSuite) TestGetInstanceContactAccountFromInstance() {
+	account, err := suite.processor.GetInstanceContactAccount(context.Background())
+	suite.NoError(err)
+	suite.NotNil(account)
+	suite.Equal(suite.testAccounts["admin_account"].ID, account.ID)
+}
+
+func (suite *InstanceTestSuite) TestGetInstanceContactAccountConfigured() {
+	config.SetInstanceContactAccountUsername(suite.testAccounts["local_account_1"].Username)
+
+	account, err := suite.processor.GetInstanceContactAccount(context.Background())
+	suite.NoError(err)
+	suite.NotNil(account)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, account.ID)
+
+	// Both instance API versions should use it.
+	instanceV1, errWithCode := suite.processor.InstanceGetV1(context.Background())
+	suite.NoError(errWithCode)
+	suite.Equal(account.ID, instanceV1.ContactAccount.ID)
+
+	instanceV2, errWithCode := suite.processor.InstanceGetV2(context.Background())
+	suite.NoError(errWithCode)
+	suite.Equal(account.ID, instanceV2.Contact.Account.ID)
+}
+
+func (suite *InstanceTestSuite) TestGetInstanceContactAccountConfiguredNotFound() {
+	// A configured account that doesn't
+	// exist should just be skipped over.
+	config.SetInstanceContactAccountUsername("nobody_by_this_name")
+
+	account, err := suite.processor.GetInstanceContactAccount(context.Background())
+	suite.NoError(err)
+	suite.NotNil(account)
+	suite.Equal(suite.testAccounts["admin_account"].ID, account.ID)
+}
+
+func (suite *InstanceTestSuite) TestGetInstanceContactAccountFirstAdmin() {
+	suite.clearInstanceContactAccount()
+
+	account, err := suite.processor.GetInstanceContactAccount(context.Background())
+	suite.NoError(err)
+	suite.NotNil(account)
+	suite.Equal(suite.testAccounts["admin_account"].ID, account.ID)
+
+	instanceV1, errWithCode := suite.processor.InstanceGetV1(context.Background())
+	suite.NoError(errWithCode)
+	suite.Equal(account.ID, instanceV1.ContactAccount.ID)
+}
+
+func (suite *InstanceTestSuite) TestGetInstanceContactAccountNoAdmin() {
+	suite.clearInstanceContactAccount()
+
+	// Disable the only admin.
+	admin := &gtsmodel.User{}
+	*admin = *suite.testUsers["admin_account"]
+	disabled := true
+	admin.Disabled = &disabled
+	if err := suite.db.UpdateByID(context.Background(), admin, admin.ID, "disabled"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	account, err := suite.processor.GetInstanceContactAccount(context.Background())
+	suite.NoError(err)
+	suite.Nil(account)
+
+	instanceV2, errWithCode := suite.processor.InstanceGetV2(context.Background())
+	suite.NoError(errWithCode)
+	suite.Nil(instanceV2.Contact.Account)
+}
+
+func (suite *InstanceTestSuite) TestGetInstanceContactAccountCached() {
+	account, err := suite.processor.GetInstanceContactAccount(context.Background())
+	suite.NoError(err)
+	suite.Equal(suite.testAccounts["admin_account"].ID, account.ID)
+
+	// Config changes are only picked up
+	// once the cached value has expired.
+	config.SetInstanceContactAccountUsername(suite.testAccounts["local_account_1"].Username)
+
+	account, err = suite.processor.GetInstanceContactAccount(context.Background())
+	suite.NoError(err)
+	suite.Equal(suite.testAccounts["admin_account"].ID, account.ID)
+}
+
+func (suite *InstanceTestSuite) TestUpdateInstancePolicy() {
+	ctx := context.Background()
+
+	// No privacy policy set yet.
+	_, errWithCode := suite.processor.GetInstancePolicy(ctx, gtsmodel.InstancePolicyTypePrivacyPolicy)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	instance, errWithCode := suite.processor.InstanceGetV2(ctx)
+	suite.NoError(errWithCode)
+	suite.Nil(instance.PrivacyPolicyUpdatedAt)
+
+	// Set it.
+	policy, errWithCode := suite.processor.UpdateInstancePolicy(ctx, gtsmodel.InstancePolicyTypePrivacyPolicy, "We collect **nothing**.")
+	suite.NoError(errWithCode)
+	suite.Equal("<p>We collect <strong>nothing</strong>.</p>", policy.Content)
+
+	policy, errWithCode = suite.processor.GetInstancePolicy(ctx, gtsmodel.InstancePolicyTypePrivacyPolicy)
+	suite.NoError(errWithCode)
+	suite.Equal("<p>We collect <strong>nothing</strong>.</p>", policy.Content)
+
+	// Clients can now see when it was updated.
+	instance, errWithCode = suite.processor.InstanceGetV2(ctx)
+	suite.NoError(errWithCode)
+	if suite.NotNil(instance.PrivacyPolicyUpdatedAt) {
+		suite.Equal(policy.UpdatedAt, *instance.PrivacyPolicyUpdatedAt)
+	}
+
+	// Terms of service aren't a policy type.
+	_, errWithCode = suite.processor.UpdateInstancePolicy(ctx, "terms_of_service", "Be nice.")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Remove it again.
+	policy, errWithCode = suite.processor.UpdateInstancePolicy(ctx, gtsmodel.InstancePolicyTypePrivacyPolicy, "")
+	suite.NoError(errWithCode)
+	suite.Nil(policy)
+
+	_, errWithCode = suite.processor.GetInstancePolicy(ctx, gtsmodel.InstancePolicyTypePrivacyPolicy)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *InstanceTestSuite) TestUpdateInstancePolicyUnknownType() {
+	policy, errWithCode := suite.processor.UpdateInstancePolicy(context.Background(), "cookie_policy", "om nom")
+	suite.Nil(policy)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *InstanceTestSuite) TestGetInstanceTerms() {
+	ctx := context.Background()
+
+	// No terms set yet.
+	_, errWithCode := suite.processor.GetInstanceTerms(ctx)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Set them the usual way, via the instance.
+	terms := "<p>Be nice.</p>"
+	_, errWithCode = suite.processor.InstancePatch(ctx, &apimodel.InstanceSettingsUpdateRequest{Terms: &terms})
+	suite.NoError(errWithCode)
+
+	policy, errWithCode := suite.processor.GetInstanceTerms(ctx)
+	suite.NoError(errWithCode)
+	suite.Equal(terms, policy.Content)
+}
+
+func (suite *InstanceTestSuite) TestInstanceSignInRecordWithoutGeoIP() {
+	ctx := context.Background()
+	config.SetLogGeoIP(false)
+
+	suite.processor.InstanceSignInRecord(ctx, suite.testUsers["local_account_1"].ID, net.ParseIP("192.0.2.1"))
+
+	// The sign-in should still be counted, just without a country.
+	activity, errWithCode := suite.processor.InstanceActivityGet(ctx, false)
+	suite.NoError(errWithCode)
+	suite.Len(activity, 12)
+	suite.Equal("1", activity[0].Logins)
+	suite.Empty(activity[0].Countries)
+}
+
+func TestInstanceTestSuite(t *testing.T) {
+	suite.Run(t, &InstanceTestSuite{})
+}
@@ -0,0 +1,196 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package processing
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"codeberg.org/gruf/go-runners"
+	"codeberg.org/gruf/go-sched"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// instanceActivityWeeks is the number of weeks
+// of activity returned by InstanceActivityGet.
+const instanceActivityWeeks = 12
+
+// signInPruneFrequency is how often sign-ins that are
+// too old to be shown in instance activity are deleted.
+const signInPruneFrequency = 24 * time.Hour
+
+// activityWeekCacheTTL is how long counts for a week that has
+// ended are cached for; after this, the week is no longer shown.
+const activityWeekCacheTTL = 7 * 24 * time.Hour * instanceActivityWeeks
+
+// InstanceActivityGet returns weekly activity counts for this instance, most
+// recent week first, in the same format as the Mastodon API. If includeCountries
+// is true, sign-ins for each week will also be bucketed by country code.
+//
+// Counts for weeks that have already ended are cached, since they won't change;
+// only the current week is counted afresh each time.
+func (p *Processor) InstanceActivityGet(ctx context.Context, includeCountries bool) ([]*apimodel.InstanceActivity, gtserror.WithCode) {
+	// Weeks start on Monday at 00:00 UTC.
+	now := time.Now().UTC()
+	weekStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	weekStart = weekStart.AddDate(0, 0, -((int(weekStart.Weekday()) + 6) % 7))
+
+	activity := make([]*apimodel.InstanceActivity, 0, instanceActivityWeeks)
+
+	for i := 0; i < instanceActivityWeeks; i++ {
+		from := weekStart.AddDate(0, 0, -7*i)
+		key := strconv.FormatInt(from.Unix(), 10) + strconv.FormatBool(includeCountries)
+
+		if week, ok := p.activityWeeks.Get(key); ok {
+			activity = append(activity, week)
+			continue
+		}
+
+		week, err := p.instanceActivityWeek(ctx, from, includeCountries)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if i > 0 {
+			// This week has ended,
+			// its counts are final.
+			p.activityWeeks.Set(key, week)
+		}
+
+		activity = append(activity, week)
+	}
+
+	return activity, nil
+}
+
+// instanceActivityWeek counts activity for the week starting at from.
+func (p *Processor) instanceActivityWeek(ctx context.Context, from time.Time, includeCountries bool) (*apimodel.InstanceActivity, error) {
+	to := from.AddDate(0, 0, 7)
+
+	statuses, err := p.state.DB.CountLocalStatusesBetween(ctx, from, to)
+	if err != nil {
+		return nil, gtserror.Newf("error counting statuses: %w", err)
+	}
+
+	logins, err := p.state.DB.CountSignInUsers(ctx, from, to)
+	if err != nil {
+		return nil, gtserror.Newf("error counting sign-ins: %w", err)
+	}
+
+	registrations, err := p.state.DB.CountLocalUsersCreatedBetween(ctx, from, to)
+	if err != nil {
+		return nil, gtserror.Newf("error counting registrations: %w", err)
+	}
+
+	week := &apimodel.InstanceActivity{
+		Week:          strconv.FormatInt(from.Unix(), 10),
+		Statuses:      strconv.Itoa(statuses),
+		Logins:        strconv.Itoa(logins),
+		Registrations: strconv.Itoa(registrations),
+	}
+
+	if includeCountries {
+		countries, err := p.state.DB.CountSignInCountries(ctx, from, to)
+		if err != nil {
+			return nil, gtserror.Newf("error counting sign-in countries: %w", err)
+		}
+
+		week.Countries = make([]apimodel.CountryActivity, 0, len(countries))
+		for country, logins := range countries {
+			week.Countries = append(week.Countries, apimodel.CountryActivity{
+				Country: country,
+				Logins:  logins,
+			})
+		}
+
+		// Busiest countries first, then alphabetical.
+		sort.Slice(week.Countries, func(i, j int) bool {
+			if week.Countries[i].Logins != week.Countries[j].Logins {
+				return week.Countries[i].Logins > week.Countries[j].Logins
+			}
+			return week.Countries[i].Country < week.Countries[j].Country
+		})
+	}
+
+	return week, nil
+}
+
+// InstanceSignInRecord records a successful sign-in by the given user from the given
+// IP address, for instance activity statistics. The IP address itself is never stored,
+// only the country it resolves to, and only if log-geoip is enabled.
+//
+// Errors are logged rather than returned, since failing to record
+// a sign-in should never prevent the sign-in from going ahead.
+func (p *Processor) InstanceSignInRecord(ctx context.Context, userID string, ip net.IP) {
+	signIn := &gtsmodel.SignIn{
+		ID:     id.NewULID(),
+		UserID: userID,
+	}
+
+	if ip != nil && p.geoip != nil {
+		country, err := p.geoip.Country(ip)
+		if err != nil {
+			log.Warnf(ctx, "error resolving sign-in country: %v", err)
+		}
+		signIn.CountryCode = country
+	}
+
+	if err := p.state.DB.PutSignIn(ctx, signIn); err != nil {
+		log.Errorf(ctx, "error recording sign-in for user %s: %v", userID, err)
+	}
+}
+
+// scheduleSignInPrune schedules a job to periodically delete sign-ins
+// that are too old to be included in instance activity statistics.
+func scheduleSignInPrune(p *Processor) {
+	// Get ctx associated with scheduler run state.
+	done := p.state.Workers.Scheduler.Done()
+	doneCtx := runners.CancelCtx(done)
+
+	p.state.Workers.Scheduler.Schedule(sched.NewJob(func(now time.Time) {
+		// Keep the current, partial week, plus
+		// each of the full weeks that are shown.
+		olderThan := now.AddDate(0, 0, -7*(instanceActivityWeeks+1))
+
+		pruned, err := p.state.DB.DeleteSignInsOlderThan(doneCtx, olderThan)
+		if err != nil {
+			log.Errorf(nil, "error pruning old sign-ins: %v", err)
+			return
+		}
+
+		if pruned != 0 {
+			log.Infof(nil, "pruned %d old sign-ins", pruned)
+		}
+	}).Every(signInPruneFrequency))
+}
+
+// Close releases any resources held by the processor,
+// such as the geoip database. It should be called on
+// shutdown, once nothing is using the processor.
+func (p *Processor) Close() error {
+	if p.geoip == nil {
+		return nil
+	}
+	return p.geoip.Close()
+}
@@ -0,0 +1,140 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// GetInstancePolicy returns this instance's policy document of the
+// given type, eg., its privacy policy, rendered from markdown to HTML.
+func (p *Processor) GetInstancePolicy(ctx context.Context, policyType gtsmodel.InstancePolicyType) (*apimodel.InstancePolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetInstancePolicy(ctx, policyType)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("instance has no %s set", policyType)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+		err = fmt.Errorf("GetInstancePolicy: db error getting %s: %w", policyType, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInstancePolicy(ctx, policy), nil
+}
+
+// GetInstanceTerms returns this instance's terms of service, as
+// set by an admin via the instance update endpoint. They're served
+// in the same shape as other instance policy documents.
+func (p *Processor) GetInstanceTerms(ctx context.Context) (*apimodel.InstancePolicy, gtserror.WithCode) {
+	i, err := p.getThisInstance(ctx)
+	if err != nil {
+		err = fmt.Errorf("GetInstanceTerms: db error fetching instance: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if i.Terms == "" {
+		err := errors.New("instance has no terms_of_service set")
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return &apimodel.InstancePolicy{
+		UpdatedAt: util.FormatISO8601(i.UpdatedAt),
+		Content:   i.Terms, // already sanitized HTML
+	}, nil
+}
+
+// UpdateInstancePolicy sets the markdown content of this instance's policy
+// document of the given type, eg., its privacy policy. If content is
+// empty, the policy is removed, and nil is returned.
+//
+// Terms of service aren't an instance policy type; they're
+// stored on the instance itself, see GetInstanceTerms.
+func (p *Processor) UpdateInstancePolicy(ctx context.Context, policyType gtsmodel.InstancePolicyType, content string) (*apimodel.InstancePolicy, gtserror.WithCode) {
+	switch policyType {
+	case gtsmodel.InstancePolicyTypePrivacyPolicy:
+		// Valid type.
+	default:
+		err := fmt.Errorf("policy type %s not recognized", policyType)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if content == "" {
+		// Remove the policy.
+		if err := p.state.DB.DeleteInstancePolicy(ctx, policyType); err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("UpdateInstancePolicy: db error deleting %s: %w", policyType, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return nil, nil
+	}
+
+	if err := validate.SiteTerms(content); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	policy, err := p.state.DB.GetInstancePolicy(ctx, policyType)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = fmt.Errorf("UpdateInstancePolicy: db error getting %s: %w", policyType, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if policy == nil {
+		// No policy stored yet, create one.
+		now := time.Now()
+		policy = &gtsmodel.InstancePolicy{
+			ID:        id.NewULID(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			Type:      policyType,
+			Content:   content,
+		}
+
+		if err := p.state.DB.PutInstancePolicy(ctx, policy); err != nil {
+			err = fmt.Errorf("UpdateInstancePolicy: db error putting %s: %w", policyType, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	} else {
+		// Update existing policy.
+		policy.Content = content
+
+		if err := p.state.DB.UpdateInstancePolicy(ctx, policy, "content"); err != nil {
+			err = fmt.Errorf("UpdateInstancePolicy: db error updating %s: %w", policyType, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return apiInstancePolicy(ctx, policy), nil
+}
+
+func apiInstancePolicy(ctx context.Context, policy *gtsmodel.InstancePolicy) *apimodel.InstancePolicy {
+	return &apimodel.InstancePolicy{
+		UpdatedAt: util.FormatISO8601(policy.UpdatedAt),
+		Content:   text.MarkdownToHTML(ctx, policy.Content),
+	}
+}
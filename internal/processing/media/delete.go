@@ -21,9 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 
-	"codeberg.org/gruf/go-store/v2/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
@@ -32,7 +30,7 @@ import (
 func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserror.WithCode {
 	attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaAttachmentID)
 	if err != nil {
-		if err == db.ErrNoEntries {
+		if errors.Is(err, db.ErrNoEntries) {
 			// attachment already gone
 			return nil
 		}
@@ -40,29 +38,10 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 		return gtserror.NewErrorInternalError(err)
 	}
 
-	errs := []string{}
-
-	// delete the thumbnail from storage
-	if attachment.Thumbnail.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.Thumbnail.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
-			errs = append(errs, fmt.Sprintf("remove thumbnail at path %s: %s", attachment.Thumbnail.Path, err))
-		}
-	}
-
-	// delete the file from storage
-	if attachment.File.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !errors.Is(err, storage.ErrNotFound) {
-			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", attachment.File.Path, err))
-		}
-	}
-
-	// delete the attachment
-	if err := p.state.DB.DeleteAttachment(ctx, mediaAttachmentID); err != nil && !errors.Is(err, db.ErrNoEntries) {
-		errs = append(errs, fmt.Sprintf("remove attachment: %s", err))
-	}
-
-	if len(errs) != 0 {
-		return gtserror.NewErrorInternalError(fmt.Errorf("Delete: one or more errors removing attachment with id %s: %s", mediaAttachmentID, strings.Join(errs, "; ")))
+	// Delete via the media manager, so that any
+	// generated variants are cleaned up as well.
+	if err := p.mediaManager.DeleteAttachment(ctx, attachment); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("Delete: error removing attachment with id %s: %w", mediaAttachmentID, err))
 	}
 
 	return nil
@@ -0,0 +1,71 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package media_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type DeleteTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *DeleteTestSuite) TestDeleteMediaWithVariant() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	variant := &gtsmodel.MediaAttachmentVariant{
+		ID:           "01H3MR2B6HVRZTS1K2SJ5D6K3Q",
+		AttachmentID: testAttachment.ID,
+		Name:         "webp",
+		Path:         testAttachment.AccountID + "/attachment/webp/" + testAttachment.ID + ".webp",
+		ContentType:  "image/webp",
+		FileSize:     3,
+	}
+	if _, err := suite.storage.Put(ctx, variant.Path, []byte("hi!")); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := suite.db.PutAttachmentVariant(ctx, variant); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	errWithCode := suite.mediaProcessor.Delete(ctx, testAttachment.ID)
+	suite.NoError(errWithCode)
+
+	_, err := suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// The variant shouldn't have been left behind.
+	for _, key := range []string{
+		testAttachment.File.Path,
+		testAttachment.Thumbnail.Path,
+		variant.Path,
+	} {
+		hasKey, err := suite.storage.Has(ctx, key)
+		suite.NoError(err)
+		suite.False(hasKey, key)
+	}
+}
+
+func TestDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &DeleteTestSuite{})
+}
@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+// descriptionsUpdateLimit is the maximum number of
+// attachments that can be described in one request.
+const descriptionsUpdateLimit = 100
+
+// MissingDescriptionsGet returns a page of the given account's media attachments
+// which don't have a description (alt-text) yet, along with how many there are in total.
+func (p *Processor) MissingDescriptionsGet(ctx context.Context, account *gtsmodel.Account, maxID string, limit int) (*apimodel.AttachmentsMissingDescription, gtserror.WithCode) {
+	count, err := p.state.DB.CountAttachmentsMissingDescription(ctx, account.ID)
+	if err != nil {
+		err = fmt.Errorf("MissingDescriptionsGet: db error counting attachments: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	attachments, err := p.state.DB.GetAttachmentsMissingDescription(ctx, account.ID, maxID, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = fmt.Errorf("MissingDescriptionsGet: db error getting attachments: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	resp := &apimodel.AttachmentsMissingDescription{
+		Count:       count,
+		Attachments: make([]*apimodel.Attachment, 0, len(attachments)),
+	}
+
+	for _, attachment := range attachments {
+		apiAttachment, err := p.tc.AttachmentToAPIAttachment(ctx, attachment)
+		if err != nil {
+			err = fmt.Errorf("MissingDescriptionsGet: error converting attachment %s: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		resp.Attachments = append(resp.Attachments, &apiAttachment)
+	}
+
+	return resp, nil
+}
+
+// DescriptionsUpdate sets the descriptions of multiple media attachments at once, keyed by
+// attachment ID. All attachments must be owned by the given account, or nothing is updated.
+func (p *Processor) DescriptionsUpdate(ctx context.Context, account *gtsmodel.Account, descriptions map[string]string) ([]*apimodel.Attachment, gtserror.WithCode) {
+	if len(descriptions) == 0 {
+		err := errors.New("no descriptions provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(descriptions) > descriptionsUpdateLimit {
+		err := fmt.Errorf("too many descriptions provided, the maximum is %d", descriptionsUpdateLimit)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Sort IDs so the returned
+	// attachments are in a
+	// predictable order.
+	ids := make([]string, 0, len(descriptions))
+	for id := range descriptions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	// Check everything up front, so that we
+	// don't end up with a half-applied batch.
+	attachments := make([]*gtsmodel.MediaAttachment, 0, len(ids))
+	for _, id := range ids {
+		description := text.SanitizePlaintext(descriptions[id])
+		if description == "" {
+			err := fmt.Errorf("description for attachment %s was empty", id)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		attachment, err := p.state.DB.GetAttachmentByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				err = fmt.Errorf("attachment %s doesn't exist in the db", id)
+				return nil, gtserror.NewErrorNotFound(err, err.Error())
+			}
+			err = fmt.Errorf("DescriptionsUpdate: db error getting attachment %s: %w", id, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if attachment.AccountID != account.ID {
+			err = fmt.Errorf("attachment %s not owned by requesting account", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		attachment.Description = description
+		attachments = append(attachments, attachment)
+	}
+
+	apiAttachments := make([]*apimodel.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		if err := p.state.DB.UpdateAttachment(ctx, attachment, "description"); err != nil {
+			err = fmt.Errorf("DescriptionsUpdate: db error updating attachment %s: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiAttachment, err := p.tc.AttachmentToAPIAttachment(ctx, attachment)
+		if err != nil {
+			err = fmt.Errorf("DescriptionsUpdate: error converting attachment %s: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiAttachments = append(apiAttachments, &apiAttachment)
+	}
+
+	return apiAttachments, nil
+}
@@ -0,0 +1,120 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type DescriptionsTestSuite struct {
+	MediaStandardTestSuite
+}
+
+// clearDescriptions removes the description from
+// the test attachments with the given keys.
+func (suite *DescriptionsTestSuite) clearDescriptions(keys ...string) {
+	for _, key := range keys {
+		attachment := &gtsmodel.MediaAttachment{}
+		*attachment = *suite.testAttachments[key]
+		attachment.Description = ""
+		if err := suite.db.UpdateAttachment(context.Background(), attachment, "description"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+}
+
+func (suite *DescriptionsTestSuite) TestMissingDescriptionsGet() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	resp, errWithCode := suite.mediaProcessor.MissingDescriptionsGet(ctx, testAccount, "", 20)
+	suite.NoError(errWithCode)
+	suite.Zero(resp.Count)
+	suite.Empty(resp.Attachments)
+
+	// Avatars don't count.
+	suite.clearDescriptions(
+		"local_account_1_status_4_attachment_1",
+		"local_account_1_unattached_1",
+		"local_account_1_avatar",
+	)
+
+	resp, errWithCode = suite.mediaProcessor.MissingDescriptionsGet(ctx, testAccount, "", 1)
+	suite.NoError(errWithCode)
+	suite.Equal(2, resp.Count)
+	suite.Len(resp.Attachments, 1)
+	suite.Equal(suite.testAttachments["local_account_1_unattached_1"].ID, resp.Attachments[0].ID)
+
+	// Page down to the next one.
+	resp, errWithCode = suite.mediaProcessor.MissingDescriptionsGet(ctx, testAccount, resp.Attachments[0].ID, 1)
+	suite.NoError(errWithCode)
+	suite.Equal(2, resp.Count)
+	suite.Len(resp.Attachments, 1)
+	suite.Equal(suite.testAttachments["local_account_1_status_4_attachment_1"].ID, resp.Attachments[0].ID)
+}
+
+func (suite *DescriptionsTestSuite) TestDescriptionsUpdate() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	suite.clearDescriptions(
+		"local_account_1_status_4_attachment_1",
+		"local_account_1_unattached_1",
+	)
+
+	attachments, errWithCode := suite.mediaProcessor.DescriptionsUpdate(ctx, testAccount, map[string]string{
+		suite.testAttachments["local_account_1_unattached_1"].ID:          "the oh you meme, again",
+		suite.testAttachments["local_account_1_status_4_attachment_1"].ID: "<b>trent</b> reznor",
+	})
+	suite.NoError(errWithCode)
+	suite.Len(attachments, 2)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, suite.testAttachments["local_account_1_status_4_attachment_1"].ID)
+	suite.NoError(err)
+	suite.Equal("trent reznor", dbAttachment.Description)
+
+	resp, errWithCode := suite.mediaProcessor.MissingDescriptionsGet(ctx, testAccount, "", 20)
+	suite.NoError(errWithCode)
+	suite.Zero(resp.Count)
+}
+
+func (suite *DescriptionsTestSuite) TestDescriptionsUpdateNotOwned() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	suite.clearDescriptions("local_account_1_unattached_1")
+
+	_, errWithCode := suite.mediaProcessor.DescriptionsUpdate(ctx, testAccount, map[string]string{
+		suite.testAttachments["local_account_1_unattached_1"].ID:        "the oh you meme, again",
+		suite.testAttachments["admin_account_status_1_attachment_1"].ID: "not mine",
+	})
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Nothing should have been updated.
+	resp, errWithCode := suite.mediaProcessor.MissingDescriptionsGet(ctx, testAccount, "", 20)
+	suite.NoError(errWithCode)
+	suite.Equal(1, resp.Count)
+}
+
+func TestDescriptionsTestSuite(t *testing.T) {
+	suite.Run(t, &DescriptionsTestSuite{})
+}
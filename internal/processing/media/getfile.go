@@ -19,13 +19,15 @@ package media
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"io"
-	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
-	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
@@ -115,32 +117,18 @@ func parseSize(s string) (media.Size, error) {
 }
 
 func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount *gtsmodel.Account, wantedMediaID string, owningAccountID string, mediaSize media.Size) (*apimodel.Content, gtserror.WithCode) {
-	// retrieve attachment from the database and do basic checks on it
-	a, err := p.state.DB.GetAttachmentByID(ctx, wantedMediaID)
+	// retrieve attachment from the database along with its owners, and do basic checks on it
+	mediaCtx, err := p.state.DB.GetAttachmentWithContext(ctx, wantedMediaID)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("attachment %s could not be taken from the db: %s", wantedMediaID, err))
 	}
+	a := mediaCtx.Attachment
 
-	if a.AccountID != owningAccountID {
+	if mediaCtx.Account.ID != owningAccountID {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("attachment %s is not owned by %s", wantedMediaID, owningAccountID))
 	}
 
 	if !*a.Cached {
-		// if we don't have it cached, then we can assume two things:
-		// 1. this is remote media, since local media should never be uncached
-		// 2. we need to fetch it again using a transport and the media manager
-		remoteMediaIRI, err := url.Parse(a.RemoteURL)
-		if err != nil {
-			return nil, gtserror.NewErrorNotFound(fmt.Errorf("error parsing remote media iri %s: %s", a.RemoteURL, err))
-		}
-
-		// use an empty string as requestingUsername to use the instance account, unless the request for this
-		// media has been http signed, then use the requesting account to make the request to remote server
-		var requestingUsername string
-		if requestingAccount != nil {
-			requestingUsername = requestingAccount.Username
-		}
-
 		// Pour one out for tobi's original streamed recache
 		// (streaming data both to the client and storage).
 		// Gone and forever missed <3
@@ -150,25 +138,13 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 		//   client connection could hold open a storage
 		//   recache operation -> holding open a media worker.
 		// ]
-
-		dataFn := func(innerCtx context.Context) (io.ReadCloser, int64, error) {
-			t, err := p.transportController.NewTransportForUsername(innerCtx, requestingUsername)
-			if err != nil {
-				return nil, 0, err
-			}
-			return t.DereferenceMedia(gtscontext.SetFastFail(innerCtx), remoteMediaIRI)
-		}
-
-		// Start recaching this media with the prepared data function.
-		processingMedia, err := p.mediaManager.PreProcessMediaRecache(ctx, dataFn, wantedMediaID)
-		if err != nil {
-			return nil, gtserror.NewErrorNotFound(fmt.Errorf("error recaching media: %s", err))
-		}
-
-		// Load attachment and block until complete
-		a, err = processingMedia.LoadAttachment(ctx)
-		if err != nil {
-			return nil, gtserror.NewErrorNotFound(fmt.Errorf("error loading recached attachment: %s", err))
+		a, err = p.recacheAttachment(ctx, requestingAccount, a)
+		if errors.Is(err, errRecacheTimeout) {
+			// Still being fetched; serve a placeholder
+			// rather than holding the client up further.
+			return placeholderContent(), nil
+		} else if err != nil {
+			return nil, gtserror.NewErrorNotFound(err)
 		}
 	}
 
@@ -198,7 +174,6 @@ func (p *Processor) getAttachmentContent(ctx context.Context, requestingAccount
 }
 
 func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owningAccountID string, emojiSize media.Size) (*apimodel.Content, gtserror.WithCode) {
-	emojiContent := &apimodel.Content{}
 	var storagePath string
 
 	// reconstruct the static emoji image url -- reason
@@ -216,6 +191,10 @@ func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owning
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("emoji %s has been disabled", fileName))
 	}
 
+	emojiContent := &apimodel.Content{
+		ContentUpdated: e.ImageUpdatedAt,
+	}
+
 	switch emojiSize {
 	case media.SizeOriginal:
 		emojiContent.ContentType = e.ImageContentType
@@ -233,6 +212,10 @@ func (p *Processor) getEmojiContent(ctx context.Context, fileName string, owning
 }
 
 func (p *Processor) retrieveFromStorage(ctx context.Context, storagePath string, content *apimodel.Content) (*apimodel.Content, gtserror.WithCode) {
+	// Derive a stable tag for this content, so
+	// clients can make conditional requests for it.
+	content.ETag = contentETag(storagePath, content.ContentLength, content.ContentUpdated)
+
 	// If running on S3 storage with proxying disabled then
 	// just fetch a pre-signed URL instead of serving the content.
 	if url := p.state.Storage.URL(ctx, storagePath); url != nil {
@@ -248,3 +231,16 @@ func (p *Processor) retrieveFromStorage(ctx context.Context, storagePath string,
 	content.Content = reader
 	return content, nil
 }
+
+// contentETag derives a strong ETag for content at the given storage path. Stored media
+// is never modified in place without the owning model's updated time changing, so the
+// path, size and updated time together uniquely identify the stored bytes.
+func contentETag(storagePath string, size int64, updated time.Time) string {
+	h := sha256.New()
+	h.Write([]byte(storagePath))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(size, 10)))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(updated.UnixNano(), 10)))
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
@@ -29,6 +29,10 @@ type Processor struct {
 	tc                  typeutils.TypeConverter
 	mediaManager        *media.Manager
 	transportController transport.Controller
+
+	// recaches coalesces concurrent requests
+	// for the same uncached remote media.
+	recaches *recaches
 }
 
 // New returns a new media processor.
@@ -38,5 +42,6 @@ func New(state *state.State, tc typeutils.TypeConverter, mediaManager *media.Man
 		tc:                  tc,
 		mediaManager:        mediaManager,
 		transportController: transportController,
+		recaches:            newRecaches(),
 	}
 }
@@ -0,0 +1,181 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// errRecacheTimeout is returned by recacheAttachment when
+// fetching media takes longer than media-recache-timeout.
+var errRecacheTimeout = errors.New("timed out waiting for media recache")
+
+// placeholderPNG is a 1x1 transparent png, served in place of
+// uncached remote media which is taking too long to fetch again.
+var placeholderPNG = func() []byte {
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, image.NewNRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}()
+
+// recaches keeps track of uncached remote media currently
+// being fetched again, so that concurrent requests for the
+// same attachment share one fetch rather than each starting
+// their own.
+type recaches struct {
+	inFlight map[string]*recache
+	mu       sync.Mutex
+}
+
+// recache is one in-progress recache of an attachment.
+// The attachment and err fields are set before done is closed.
+type recache struct {
+	done       chan struct{}
+	attachment *gtsmodel.MediaAttachment
+	err        error
+}
+
+func newRecaches() *recaches {
+	return &recaches{inFlight: make(map[string]*recache)}
+}
+
+// start returns the in-progress recache of the attachment
+// with the given ID, or starts a new one using recacheFn
+// if the attachment isn't being recached already.
+func (r *recaches) start(attachmentID string, recacheFn func() (*gtsmodel.MediaAttachment, error)) *recache {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if rc, ok := r.inFlight[attachmentID]; ok {
+		return rc
+	}
+
+	rc := &recache{done: make(chan struct{})}
+	r.inFlight[attachmentID] = rc
+
+	go func() {
+		rc.attachment, rc.err = recacheFn()
+		close(rc.done)
+
+		r.mu.Lock()
+		delete(r.inFlight, attachmentID)
+		r.mu.Unlock()
+	}()
+
+	return rc
+}
+
+// recacheAttachment fetches the given uncached remote attachment again,
+// waiting up to media-recache-timeout for the fetch to finish. If it
+// doesn't finish in time, errRecacheTimeout is returned, but the fetch
+// carries on in the background so that it's ready for the next request.
+func (p *Processor) recacheAttachment(ctx context.Context, requestingAccount *gtsmodel.Account, attachment *gtsmodel.MediaAttachment) (*gtsmodel.MediaAttachment, error) {
+	// If we don't have it cached, then we can assume two things:
+	// 1. this is remote media, since local media should never be uncached
+	// 2. we need to fetch it again using a transport and the media manager
+	remoteMediaIRI, err := url.Parse(attachment.RemoteURL)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing remote media iri %s: %w", attachment.RemoteURL, err)
+	}
+
+	// Use an empty string as requestingUsername to use the instance account, unless the request for this
+	// media has been http signed, then use the requesting account to make the request to remote server.
+	var requestingUsername string
+	if requestingAccount != nil {
+		requestingUsername = requestingAccount.Username
+	}
+
+	rc := p.recaches.start(attachment.ID, func() (*gtsmodel.MediaAttachment, error) {
+		// The fetch outlives the request that
+		// started it, so don't use its context.
+		ctx := context.Background()
+
+		dataFn := func(innerCtx context.Context) (io.ReadCloser, int64, error) {
+			t, err := p.transportController.NewTransportForUsername(innerCtx, requestingUsername)
+			if err != nil {
+				return nil, 0, err
+			}
+			return t.DereferenceMedia(gtscontext.SetFastFail(innerCtx), remoteMediaIRI)
+		}
+
+		// Start recaching this media with the prepared data function.
+		processingMedia, err := p.mediaManager.PreProcessMediaRecache(ctx, dataFn, attachment.ID)
+		if err != nil {
+			return nil, gtserror.Newf("error recaching media: %w", err)
+		}
+
+		// Load attachment and block until complete.
+		attachment, err := processingMedia.LoadAttachment(ctx)
+		if err != nil {
+			return nil, gtserror.Newf("error loading recached attachment: %w", err)
+		}
+
+		return attachment, nil
+	})
+
+	return rc.wait(ctx, config.GetMediaRecacheTimeout())
+}
+
+// wait waits up to timeout for the recache to finish, and returns
+// its result. If timeout passes first, errRecacheTimeout is returned;
+// if ctx is done first, its error is returned. Either way the recache
+// itself carries on. A timeout <= 0 means wait indefinitely.
+func (rc *recache) wait(ctx context.Context, timeout time.Duration) (*gtsmodel.MediaAttachment, error) {
+	var timeoutC <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutC = timer.C
+	}
+
+	select {
+	case <-rc.done:
+		return rc.attachment, rc.err
+	case <-timeoutC:
+		return nil, errRecacheTimeout
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// placeholderContent returns a placeholder to serve
+// in place of media which is still being fetched.
+func placeholderContent() *apimodel.Content {
+	return &apimodel.Content{
+		ContentType:   "image/png",
+		ContentLength: int64(len(placeholderPNG)),
+		Content:       io.NopCloser(bytes.NewReader(placeholderPNG)),
+		Placeholder:   true,
+	}
+}
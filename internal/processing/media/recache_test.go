@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func TestRecachesShareFetch(t *testing.T) {
+	var (
+		r       = newRecaches()
+		release = make(chan struct{})
+		calls   int
+	)
+
+	recacheFn := func() (*gtsmodel.MediaAttachment, error) {
+		calls++
+		<-release
+		return &gtsmodel.MediaAttachment{ID: "01H3S5A0Q3V0ZJ4N6T7P8R9X2K"}, nil
+	}
+
+	// Both requests should get the same recache,
+	// and only the first should start a fetch.
+	rc1 := r.start("01H3S5A0Q3V0ZJ4N6T7P8R9X2K", recacheFn)
+	rc2 := r.start("01H3S5A0Q3V0ZJ4N6T7P8R9X2K", recacheFn)
+	if rc1 != rc2 {
+		t.Fatal("expected concurrent recaches to be shared")
+	}
+
+	close(release)
+
+	attachment, err := rc1.wait(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if attachment.ID != "01H3S5A0Q3V0ZJ4N6T7P8R9X2K" {
+		t.Fatalf("unexpected attachment %s", attachment.ID)
+	}
+
+	if calls != 1 {
+		t.Fatalf("expected 1 fetch, got %d", calls)
+	}
+}
+
+func TestRecacheWaitCancelled(t *testing.T) {
+	// A recache that hasn't finished yet.
+	rc := &recache{done: make(chan struct{})}
+
+	// The requester has already gone away, so there's
+	// nothing to wait for: wait should return straight
+	// away, without affecting the recache itself.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := rc.wait(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	select {
+	case <-rc.done:
+		t.Fatal("recache should still be in progress")
+	default:
+	}
+}
+
+func TestRecacheWaitTimeout(t *testing.T) {
+	// A recache that never finishes.
+	rc := &recache{done: make(chan struct{})}
+
+	if _, err := rc.wait(context.Background(), time.Millisecond); !errors.Is(err, errRecacheTimeout) {
+		t.Fatalf("expected errRecacheTimeout, got %v", err)
+	}
+}
+
+func TestPlaceholderContent(t *testing.T) {
+	content := placeholderContent()
+	defer content.Content.Close()
+
+	if !content.Placeholder {
+		t.Fatal("expected placeholder to be marked as such")
+	}
+
+	img, err := png.Decode(content.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b := img.Bounds(); b.Dx() != 1 || b.Dy() != 1 {
+		t.Fatalf("expected 1x1 placeholder, got %dx%d", b.Dx(), b.Dy())
+	}
+}
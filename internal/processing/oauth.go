@@ -18,9 +18,17 @@
 package processing
 
 import (
+	"context"
+	"crypto/subtle"
+	"errors"
 	"net/http"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
@@ -38,3 +46,61 @@ func (p *Processor) OAuthValidateBearerToken(r *http.Request) (oauth2.TokenInfo,
 	// todo: some kind of metrics stuff here
 	return p.oauthServer.ValidationBearerToken(r)
 }
+
+// RevokeOAuthToken revokes the access or refresh token with the given
+// value, on behalf of the client with the given credentials, as per
+// RFC 7009. Revoked tokens can no longer be used for authentication.
+//
+// If the token doesn't exist, or belongs to a different client, nothing
+// is done and no error is returned, so that callers can't probe for tokens.
+func (p *Processor) RevokeOAuthToken(ctx context.Context, clientID string, clientSecret string, token string) gtserror.WithCode {
+	client := &gtsmodel.Client{}
+	if err := p.state.DB.GetByID(ctx, clientID, client); err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting client %s: %w", clientID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+		return gtserror.NewErrorUnauthorized(oauth.InvalidClient(), "client authentication failed")
+	}
+
+	// Compare in constant time so the
+	// secret can't be guessed by timing.
+	if subtle.ConstantTimeCompare([]byte(client.Secret), []byte(clientSecret)) != 1 {
+		return gtserror.NewErrorUnauthorized(oauth.InvalidClient(), "client authentication failed")
+	}
+
+	// The token may be either an access or a refresh token;
+	// we don't need the type hint to figure out which.
+	dbToken := &gtsmodel.Token{}
+	err := p.state.DB.GetWhere(ctx, []db.Where{{Key: "access", Value: token}}, dbToken)
+	if errors.Is(err, db.ErrNoEntries) {
+		err = p.state.DB.GetWhere(ctx, []db.Where{{Key: "refresh", Value: token}}, dbToken)
+	}
+
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting token: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+		// Nothing to revoke.
+		return nil
+	}
+
+	if dbToken.ClientID != client.ID {
+		log.Warnf(ctx, "client %s tried to revoke token %s of client %s", client.ID, dbToken.ID, dbToken.ClientID)
+		return nil
+	}
+
+	if !dbToken.RevokedAt.IsZero() {
+		// Already revoked.
+		return nil
+	}
+
+	dbToken.RevokedAt = time.Now()
+	if err := p.state.DB.UpdateByID(ctx, dbToken, dbToken.ID, "revoked_at"); err != nil {
+		err = gtserror.Newf("db error revoking token %s: %w", dbToken.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
@@ -19,9 +19,14 @@ package processing
 
 import (
 	"context"
+	"time"
 
+	"codeberg.org/gruf/go-cache/v3/ttl"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/geoip"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	mm "github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
@@ -49,6 +54,15 @@ type Processor struct {
 	state        *state.State
 	emailSender  email.Sender
 	filter       *visibility.Filter
+	geoip        *geoip.Resolver // only set if log-geoip is enabled
+
+	// contactAccountID caches the ID of the resolved
+	// instance contact account (or "" if there is none).
+	contactAccountID *ttl.Cache[string, string]
+
+	// activityWeeks caches instance activity
+	// counts for weeks which have already ended.
+	activityWeeks *ttl.Cache[string, *apimodel.InstanceActivity]
 
 	/*
 		SUB-PROCESSORS
@@ -129,9 +143,28 @@ func NewProcessor(
 		emailSender:  emailSender,
 	}
 
+	processor.contactAccountID = ttl.New[string, string](0, 1, contactAccountCacheTTL)
+	processor.contactAccountID.Start(time.Minute)
+
+	// Enough for every shown week, with and without countries.
+	processor.activityWeeks = ttl.New[string, *apimodel.InstanceActivity](0, 2*instanceActivityWeeks, activityWeekCacheTTL)
+	processor.activityWeeks.Start(time.Hour)
+
+	if config.GetLogGeoIP() {
+		resolver, err := geoip.NewResolver(config.GetGeoIPDBPath())
+		if err != nil {
+			// Sign-ins will still be recorded, just without a country.
+			log.Errorf(nil, "error opening geoip database, sign-in countries will not be recorded: %v", err)
+		} else {
+			processor.geoip = resolver
+		}
+	}
+
+	scheduleSignInPrune(processor)
+
 	// Instantiate sub processors.
 	processor.account = account.New(state, tc, mediaManager, oauthServer, federator, filter, parseMentionFunc)
-	processor.admin = admin.New(state, tc, mediaManager, federator.TransportController(), emailSender)
+	processor.admin = admin.New(state, tc, mediaManager, federator, emailSender)
 	processor.fedi = fedi.New(state, tc, federator, filter)
 	processor.list = list.New(state, tc)
 	processor.media = media.New(state, tc, mediaManager, federator.TransportController())
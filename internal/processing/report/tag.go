@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// FlagHashtag creates a report about the hashtag with the given name,
+// eg., because it's trending and shouldn't be, for admins to review.
+//
+// Since hashtags aren't owned by anyone, the report targets the
+// instance account, and it's never forwarded to other instances.
+// Admins can act on it by making the tag untrendable with TagUpdate.
+func (p *Processor) FlagHashtag(ctx context.Context, account *gtsmodel.Account, tagName string, comment string) gtserror.WithCode {
+	tagName = strings.TrimPrefix(strings.TrimSpace(tagName), "#")
+	if tagName == "" {
+		err := errors.New("no tag name given")
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	tag, err := p.state.DB.GetTagByName(ctx, tagName)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("tag %s does not exist", tagName)
+			return gtserror.NewErrorNotFound(err, err.Error())
+		}
+		err = fmt.Errorf("db error fetching report target tag: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	instanceAccount, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err = fmt.Errorf("db error fetching instance account: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	forwarded := false
+	reportID := id.NewULID()
+	report := &gtsmodel.Report{
+		ID:              reportID,
+		URI:             uris.GenerateURIForReport(reportID),
+		AccountID:       account.ID,
+		Account:         account,
+		TargetAccountID: instanceAccount.ID,
+		TargetAccount:   instanceAccount,
+		TargetTagID:     tag.ID,
+		TargetTag:       tag,
+		Comment:         comment,
+		Forwarded:       &forwarded,
+	}
+
+	if err := p.state.DB.PutReport(ctx, report); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Not forwarded, so this
+	// just notifies moderators.
+	p.state.Workers.EnqueueClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityFlag,
+		GTSModel:       report,
+		OriginAccount:  account,
+		TargetAccount:  instanceAccount,
+	})
+
+	return nil
+}
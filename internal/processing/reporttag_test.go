@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ReportTagTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *ReportTagTestSuite) TestFlagHashtagReviewAndAct() {
+	ctx := context.Background()
+	reportingAccount := suite.testAccounts["local_account_1"]
+	adminAccount := suite.testAccounts["admin_account"]
+	tag := suite.testTags["welcome"]
+
+	// A user flags the tag.
+	if errWithCode := suite.processor.Report().FlagHashtag(ctx, reportingAccount, "#welcome", "this shouldn't be trending"); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// An admin sees it in the list of open reports.
+	resolved := false
+	resp, errWithCode := suite.processor.Admin().ReportsGet(ctx, adminAccount, &resolved, reportingAccount.ID, "", "", "", "", 10)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	var tagReport *apimodel.AdminReport
+	for _, item := range resp.Items {
+		report := item.(*apimodel.AdminReport)
+		if report.TargetType == "hashtag" {
+			tagReport = report
+		}
+	}
+	if !suite.NotNil(tagReport) {
+		suite.FailNow("tag report not found")
+	}
+	suite.Equal(tag.Name, tagReport.TargetTag.Name)
+	suite.Equal("this shouldn't be trending", tagReport.Comment)
+	suite.False(tagReport.Forwarded)
+	suite.Equal(suite.testAccounts["instance_account"].ID, tagReport.TargetAccount.ID)
+
+	// The admin makes the tag untrendable...
+	adminTag, errWithCode := suite.processor.Admin().TagUpdate(ctx, adminAccount, tag.Name, &apimodel.AdminTagUpdateRequest{
+		Trendable: testrig.FalseBool(),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(adminTag.Trendable)
+
+	// ...and resolves the report.
+	comment := "tag removed from trends"
+	resolvedReport, errWithCode := suite.processor.Admin().ReportResolve(ctx, adminAccount, tagReport.ID, &comment)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(resolvedReport.ActionTaken)
+	suite.Equal("hashtag", resolvedReport.TargetType)
+
+	dbTag, err := suite.db.GetTagByName(ctx, tag.Name)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*dbTag.Trendable)
+}
+
+func (suite *ReportTagTestSuite) TestFlagHashtagNotFound() {
+	errWithCode := suite.processor.Report().FlagHashtag(context.Background(), suite.testAccounts["local_account_1"], "doesnotexist", "")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestReportTagTestSuite(t *testing.T) {
+	suite.Run(t, &ReportTagTestSuite{})
+}
@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// savedSearchesLimit is the max number of saved searches kept
+	// per account. When exceeded, the oldest searches are dropped.
+	savedSearchesLimit = 20
+
+	// savedSearchQueryMaxChars is the max length of a saved search query.
+	savedSearchQueryMaxChars = 1000
+)
+
+// GetSavedSearches returns the saved searches of the given account, newest first.
+func (p *Processor) GetSavedSearches(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.SavedSearch, gtserror.WithCode) {
+	savedSearches, err := p.state.DB.GetAccountSavedSearches(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting saved searches for account %s: %w", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSavedSearches := make([]*apimodel.SavedSearch, 0, len(savedSearches))
+	for _, savedSearch := range savedSearches {
+		apiSavedSearches = append(apiSavedSearches, apiSavedSearch(savedSearch))
+	}
+
+	return apiSavedSearches, nil
+}
+
+// CreateSavedSearch saves the given query to the search history of the given
+// account. Saving a query that's already saved moves it to the front. Only the
+// newest savedSearchesLimit searches are kept; older ones are deleted.
+func (p *Processor) CreateSavedSearch(ctx context.Context, account *gtsmodel.Account, query string) (*apimodel.SavedSearch, gtserror.WithCode) {
+	if query == "" {
+		err := errors.New("query must be provided")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if length := len([]rune(query)); length > savedSearchQueryMaxChars {
+		err := fmt.Errorf("query must be %d characters or less, provided query was %d characters", savedSearchQueryMaxChars, length)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	existing, err := p.state.DB.GetAccountSavedSearches(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting saved searches for account %s: %w", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	savedSearch := &gtsmodel.SavedSearch{
+		ID:        id.NewULID(),
+		CreatedAt: time.Now(),
+		AccountID: account.ID,
+		Query:     query,
+	}
+
+	if err := p.state.DB.PutSavedSearch(ctx, savedSearch); err != nil {
+		err = gtserror.Newf("db error putting saved search: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Existing searches are newest first, and the new
+	// search now takes one of the available slots.
+	kept := 1
+	for _, old := range existing {
+		if old.Query != query && kept < savedSearchesLimit {
+			kept++
+			continue
+		}
+
+		// Duplicate of the new search, or beyond the limit.
+		if err := p.state.DB.DeleteSavedSearchByID(ctx, old.ID); err != nil {
+			err = gtserror.Newf("db error deleting saved search %s: %w", old.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return apiSavedSearch(savedSearch), nil
+}
+
+// DeleteSavedSearch deletes one saved search of the given account.
+func (p *Processor) DeleteSavedSearch(ctx context.Context, account *gtsmodel.Account, savedSearchID string) gtserror.WithCode {
+	savedSearch, err := p.state.DB.GetSavedSearchByID(ctx, savedSearchID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting saved search %s: %w", savedSearchID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+		err = fmt.Errorf("saved search %s not found", savedSearchID)
+		return gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if savedSearch.AccountID != account.ID {
+		// Don't reveal that the saved search exists.
+		err = fmt.Errorf("saved search %s not found", savedSearchID)
+		return gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	if err := p.state.DB.DeleteSavedSearchByID(ctx, savedSearch.ID); err != nil {
+		err = gtserror.Newf("db error deleting saved search %s: %w", savedSearchID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+func apiSavedSearch(savedSearch *gtsmodel.SavedSearch) *apimodel.SavedSearch {
+	return &apimodel.SavedSearch{
+		ID:        savedSearch.ID,
+		Query:     savedSearch.Query,
+		CreatedAt: util.FormatISO8601(savedSearch.CreatedAt),
+	}
+}
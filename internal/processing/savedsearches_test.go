@@ -0,0 +1,110 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processing_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SavedSearchesTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *SavedSearchesTestSuite) TestCreateAndGetSavedSearches() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	for _, query := range []string{"first", "second", "first"} {
+		if _, errWithCode := suite.processor.CreateSavedSearch(ctx, account, query); errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	savedSearches, errWithCode := suite.processor.GetSavedSearches(ctx, account)
+	suite.NoError(errWithCode)
+
+	// Saving "first" again moved it to the front.
+	if suite.Len(savedSearches, 2) {
+		suite.Equal("first", savedSearches[0].Query)
+		suite.Equal("second", savedSearches[1].Query)
+	}
+
+	// Other accounts don't see them.
+	savedSearches, errWithCode = suite.processor.GetSavedSearches(ctx, suite.testAccounts["local_account_2"])
+	suite.NoError(errWithCode)
+	suite.Empty(savedSearches)
+}
+
+func (suite *SavedSearchesTestSuite) TestCreateSavedSearchLimit() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	for i := 0; i < 21; i++ {
+		if _, errWithCode := suite.processor.CreateSavedSearch(ctx, account, fmt.Sprintf("query %d", i)); errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	savedSearches, errWithCode := suite.processor.GetSavedSearches(ctx, account)
+	suite.NoError(errWithCode)
+
+	// The oldest search was dropped.
+	if suite.Len(savedSearches, 20) {
+		suite.Equal("query 20", savedSearches[0].Query)
+		suite.Equal("query 1", savedSearches[19].Query)
+	}
+}
+
+func (suite *SavedSearchesTestSuite) TestCreateSavedSearchEmpty() {
+	savedSearch, errWithCode := suite.processor.CreateSavedSearch(context.Background(), suite.testAccounts["local_account_1"], "")
+	suite.Nil(savedSearch)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *SavedSearchesTestSuite) TestDeleteSavedSearch() {
+	ctx := context.Background()
+	account := suite.testAccounts["local_account_1"]
+
+	savedSearch, errWithCode := suite.processor.CreateSavedSearch(ctx, account, "some query")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Another account can't delete it.
+	errWithCode = suite.processor.DeleteSavedSearch(ctx, suite.testAccounts["local_account_2"], savedSearch.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	errWithCode = suite.processor.DeleteSavedSearch(ctx, account, savedSearch.ID)
+	suite.NoError(errWithCode)
+
+	savedSearches, errWithCode := suite.processor.GetSavedSearches(ctx, account)
+	suite.NoError(errWithCode)
+	suite.Empty(savedSearches)
+}
+
+func TestSavedSearchesTestSuite(t *testing.T) {
+	suite.Run(t, &SavedSearchesTestSuite{})
+}
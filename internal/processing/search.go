@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"unicode/utf8"
 
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -66,14 +67,21 @@ func (p *Processor) SearchGet(ctx context.Context, authed *oauth.Auth, search *a
 		Hashtags: []apimodel.Tag{},
 	}
 
-	// currently the search will only ever return one result,
-	// so return nothing if the offset is greater than 0
+	// currently the search will only ever return at most one
+	// result per type, so return nothing if the offset is > 0
 	if search.Offset > 0 {
 		return searchResult, nil
 	}
 
+	var (
+		wantAccounts = searchWants(search.Types, searchTypeAccounts)
+		wantStatuses = searchWants(search.Types, searchTypeStatuses)
+		wantHashtags = searchWants(search.Types, searchTypeHashtags)
+	)
+
 	foundAccounts := []*gtsmodel.Account{}
 	foundStatuses := []*gtsmodel.Status{}
+	foundTags := []*gtsmodel.Tag{}
 
 	var foundOne bool
 
@@ -86,7 +94,7 @@ func (p *Processor) SearchGet(ctx context.Context, authed *oauth.Auth, search *a
 		maybeNamestring = "@" + maybeNamestring
 	}
 
-	if username, domain, err := util.ExtractNamestringParts(maybeNamestring); err == nil {
+	if username, domain, err := util.ExtractNamestringParts(maybeNamestring); err == nil && wantAccounts {
 		l.Trace("search term is a mention, looking it up...")
 		blocked, err := p.state.DB.IsDomainBlocked(ctx, domain)
 		if err != nil {
@@ -104,12 +112,13 @@ func (p *Processor) SearchGet(ctx context.Context, authed *oauth.Auth, search *a
 				// return a proper error only if it wasn't just not retrievable
 				return nil, gtserror.NewErrorInternalError(fmt.Errorf("error looking up account: %w", err))
 			}
-			return searchResult, nil
+			// not retrievable, but the query might
+			// still be a hashtag, so carry on below
+		} else {
+			foundAccounts = append(foundAccounts, foundAccount)
+			foundOne = true
+			l.Trace("got an account by searching by mention")
 		}
-
-		foundAccounts = append(foundAccounts, foundAccount)
-		foundOne = true
-		l.Trace("got an account by searching by mention")
 	}
 
 	/*
@@ -130,24 +139,26 @@ func (p *Processor) SearchGet(ctx context.Context, authed *oauth.Auth, search *a
 				}
 
 				// check if it's a status...
-				foundStatus, err := p.searchStatusByURI(ctx, authed, uri)
-				if err != nil {
-					// Check for semi-expected error types.
-					var (
-						errNotRetrievable *dereferencing.ErrNotRetrievable
-						errWrongType      *ap.ErrWrongType
-					)
-					if !errors.As(err, &errNotRetrievable) && !errors.As(err, &errWrongType) {
-						return nil, gtserror.NewErrorInternalError(fmt.Errorf("error looking up status: %w", err))
+				if wantStatuses {
+					foundStatus, err := p.searchStatusByURI(ctx, authed, uri)
+					if err != nil {
+						// Check for semi-expected error types.
+						var (
+							errNotRetrievable *dereferencing.ErrNotRetrievable
+							errWrongType      *ap.ErrWrongType
+						)
+						if !errors.As(err, &errNotRetrievable) && !errors.As(err, &errWrongType) {
+							return nil, gtserror.NewErrorInternalError(fmt.Errorf("error looking up status: %w", err))
+						}
+					} else {
+						foundStatuses = append(foundStatuses, foundStatus)
+						foundOne = true
+						l.Trace("got a status by searching by URI")
 					}
-				} else {
-					foundStatuses = append(foundStatuses, foundStatus)
-					foundOne = true
-					l.Trace("got a status by searching by URI")
 				}
 
 				// ... or an account
-				if !foundOne {
+				if !foundOne && wantAccounts {
 					foundAccount, err := p.searchAccountByURI(ctx, authed, uri, search.Resolve)
 					if err != nil {
 						// Check for semi-expected error types.
@@ -168,6 +179,24 @@ func (p *Processor) SearchGet(ctx context.Context, authed *oauth.Auth, search *a
 		}
 	}
 
+	/*
+		SEARCH BY HASHTAG
+		check if the query is something like #whatever or just whatever -- this means it might be (the start of) hashtags we already know about
+	*/
+	if tagName, ok := searchHashtagName(query); ok && wantHashtags {
+		l.Trace("search term could be a hashtag, looking it up...")
+		tags, err := p.state.DB.GetListableTagsByNamePrefix(ctx, tagName, search.Limit)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error looking up tags: %w", err))
+		}
+
+		if len(tags) != 0 {
+			foundTags = append(foundTags, tags...)
+			foundOne = true
+			l.Trace("got tags by searching by name")
+		}
+	}
+
 	if !foundOne {
 		// we got nothing, we can return early
 		l.Trace("found nothing, returning")
@@ -191,6 +220,24 @@ func (p *Processor) SearchGet(ctx context.Context, authed *oauth.Auth, search *a
 			continue
 		}
 
+		if search.Following {
+			// only include accounts the requester follows
+			following, err := p.state.DB.IsFollowing(ctx, authed.Account.ID, foundAccount.ID)
+			if err != nil {
+				err = fmt.Errorf("SearchGet: error checking follow from %s to %s: %s", authed.Account.ID, foundAccount.ID, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			if !following {
+				l.Tracef("account %s is not followed by %s, skipping this result", foundAccount.ID, authed.Account.ID)
+				continue
+			}
+		}
+
+		if search.Limit > 0 && len(searchResult.Accounts) >= search.Limit {
+			break
+		}
+
 		apiAcct, err := p.tc.AccountToAPIAccountPublic(ctx, foundAccount)
 		if err != nil {
 			err = fmt.Errorf("SearchGet: error converting account %s to api account: %s", foundAccount.ID, err)
@@ -201,6 +248,11 @@ func (p *Processor) SearchGet(ctx context.Context, authed *oauth.Auth, search *a
 	}
 
 	for _, foundStatus := range foundStatuses {
+		if search.MaxID != "" && foundStatus.ID >= search.MaxID {
+			l.Tracef("status %s is not older than max id %s, skipping this result", foundStatus.ID, search.MaxID)
+			continue
+		}
+
 		// make sure each found status is visible to the requester
 		visible, err := p.filter.StatusVisible(ctx, authed.Account, foundStatus)
 		if err != nil {
@@ -213,6 +265,24 @@ func (p *Processor) SearchGet(ctx context.Context, authed *oauth.Auth, search *a
 			continue
 		}
 
+		if search.Following && foundStatus.AccountID != authed.Account.ID {
+			// only include statuses by accounts the requester follows
+			following, err := p.state.DB.IsFollowing(ctx, authed.Account.ID, foundStatus.AccountID)
+			if err != nil {
+				err = fmt.Errorf("SearchGet: error checking follow from %s to %s: %s", authed.Account.ID, foundStatus.AccountID, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			if !following {
+				l.Tracef("status %s is not by an account followed by %s, skipping this result", foundStatus.ID, authed.Account.ID)
+				continue
+			}
+		}
+
+		if search.Limit > 0 && len(searchResult.Statuses) >= search.Limit {
+			break
+		}
+
 		apiStatus, err := p.tc.StatusToAPIStatus(ctx, foundStatus, authed.Account)
 		if err != nil {
 			err = fmt.Errorf("SearchGet: error converting status %s to api status: %s", foundStatus.ID, err)
@@ -222,9 +292,238 @@ func (p *Processor) SearchGet(ctx context.Context, authed *oauth.Auth, search *a
 		searchResult.Statuses = append(searchResult.Statuses, *apiStatus)
 	}
 
+	for _, foundTag := range foundTags {
+		// make sure each found tag may be looked up by our users
+		if foundTag.Listable != nil && !*foundTag.Listable {
+			l.Tracef("tag %s is not listable, skipping this result", foundTag.Name)
+			continue
+		}
+
+		if search.ExcludeUnreviewed && foundTag.RequiresReview != nil && *foundTag.RequiresReview {
+			l.Tracef("tag %s has not been reviewed, skipping this result", foundTag.Name)
+			continue
+		}
+
+		if search.Limit > 0 && len(searchResult.Hashtags) >= search.Limit {
+			break
+		}
+
+		apiTag, err := p.tc.TagToAPITagWithHistory(ctx, foundTag)
+		if err != nil {
+			err = fmt.Errorf("SearchGet: error converting tag %s to api tag: %s", foundTag.Name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		searchResult.Hashtags = append(searchResult.Hashtags, apiTag)
+	}
+
 	return searchResult, nil
 }
 
+// SearchHashtags returns up to limit listable hashtags whose name starts
+// with the given query (minus any leading '#'), case-insensitively, for
+// hashtag autocomplete when composing a status. Each returned tag includes
+// its daily usage history over the last week.
+func (p *Processor) SearchHashtags(ctx context.Context, query string, limit int) ([]*apimodel.Tag, gtserror.WithCode) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		err := errors.New("search query was empty string after trimming space")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	tagName, ok := searchHashtagName(query)
+	if !ok {
+		// Can't be the start of
+		// a valid hashtag name.
+		return []*apimodel.Tag{}, nil
+	}
+
+	tags, err := p.state.DB.GetListableTagsByNamePrefix(ctx, tagName, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error searching tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTags := make([]*apimodel.Tag, 0, len(tags))
+	for _, tag := range tags {
+		apiTag, err := p.tc.TagToAPITagWithHistory(ctx, tag)
+		if err != nil {
+			err = gtserror.Newf("error converting tag %s to api tag: %w", tag.Name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiTags = append(apiTags, &apiTag)
+	}
+
+	return apiTags, nil
+}
+
+const (
+	searchTypeAccounts = "accounts"
+	searchTypeStatuses = "statuses"
+	searchTypeHashtags = "hashtags"
+
+	// accountSearchMinQueryLength is the minimum number
+	// of characters a non-namestring account search query
+	// must have before we bother hitting the database.
+	accountSearchMinQueryLength = 2
+)
+
+// searchWants returns true if results of searchType
+// should be included, given the requested types. An
+// empty types slice means all types should be included.
+func searchWants(types []string, searchType string) bool {
+	if len(types) == 0 {
+		return true
+	}
+
+	for _, t := range types {
+		if t == searchType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// searchHashtagName returns the tag name for the given
+// query, minus any leading '#', if the query could
+// plausibly be a hashtag we have stored.
+func searchHashtagName(query string) (string, bool) {
+	name := strings.TrimPrefix(query, "#")
+	if name == "" {
+		return "", false
+	}
+
+	for _, r := range name {
+		if !util.IsPermittedInHashtag(r) {
+			return "", false
+		}
+	}
+
+	return name, true
+}
+
+// SearchAccounts searches for accounts matching the given query, for
+// the account search API. Results are ranked by exact username match,
+// then username prefix match, then display name prefix match. Queries
+// shorter than accountSearchMinQueryLength return no results.
+//
+// If the query looks like a namestring with a domain, eg., @someone@example.org,
+// then only that exact account is looked up, and if resolve is true it will be
+// dereferenced from its instance if we don't know it yet.
+//
+// If following is true, only accounts followed by the requester are returned.
+func (p *Processor) SearchAccounts(
+	ctx context.Context,
+	authed *oauth.Auth,
+	query string,
+	limit int,
+	offset int,
+	resolve bool,
+	following bool,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	query = strings.TrimPrefix(strings.TrimSpace(query), "@")
+	if query == "" {
+		err := errors.New("search query was empty string after trimming space and @")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	var foundAccounts []*gtsmodel.Account
+
+	if username, domain, err := util.ExtractNamestringParts("@" + query); err == nil && domain != "" {
+		// Namestring with a domain: this can only
+		// ever match one account, so there's nothing
+		// to return beyond the first page of results.
+		if offset > 0 {
+			return []*apimodel.Account{}, nil
+		}
+
+		blocked, err := p.state.DB.IsDomainBlocked(ctx, domain)
+		if err != nil {
+			err = gtserror.Newf("error checking domain block: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			return []*apimodel.Account{}, nil
+		}
+
+		foundAccount, err := p.searchAccountByUsernameDomain(ctx, authed, username, domain, resolve)
+		if err != nil {
+			var errNotRetrievable *dereferencing.ErrNotRetrievable
+			if !errors.As(err, &errNotRetrievable) {
+				err = gtserror.Newf("error looking up account: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+			return []*apimodel.Account{}, nil
+		}
+
+		if following {
+			isFollowing, err := p.state.DB.IsFollowing(ctx, authed.Account.ID, foundAccount.ID)
+			if err != nil {
+				err = gtserror.Newf("error checking follow: %w", err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			if !isFollowing {
+				return []*apimodel.Account{}, nil
+			}
+		}
+
+		foundAccounts = append(foundAccounts, foundAccount)
+	} else if utf8.RuneCountInString(query) < accountSearchMinQueryLength {
+		// Too short to be a useful search,
+		// and too broad to be a cheap one.
+		return []*apimodel.Account{}, nil
+	} else {
+		var followedBy string
+		if following {
+			followedBy = authed.Account.ID
+		}
+
+		accountIDs, err := p.state.DB.SearchAccountIDs(ctx, query, followedBy, limit, offset)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error searching accounts: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		for _, accountID := range accountIDs {
+			foundAccount, err := p.state.DB.GetAccountByID(ctx, accountID)
+			if err != nil {
+				log.Errorf(ctx, "error getting account %s: %v", accountID, err)
+				continue
+			}
+
+			foundAccounts = append(foundAccounts, foundAccount)
+		}
+	}
+
+	apiAccounts := make([]*apimodel.Account, 0, len(foundAccounts))
+	for _, foundAccount := range foundAccounts {
+		// make sure there's no block in either direction between the account and the requester
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, authed.Account.ID, foundAccount.ID)
+		if err != nil {
+			err = gtserror.Newf("error checking block between %s and %s: %w", authed.Account.ID, foundAccount.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			continue
+		}
+
+		apiAccount, err := p.tc.AccountToAPIAccountPublic(ctx, foundAccount)
+		if err != nil {
+			err = gtserror.Newf("error converting account %s to api account: %w", foundAccount.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return apiAccounts, nil
+}
+
 func (p *Processor) searchStatusByURI(ctx context.Context, authed *oauth.Auth, uri *url.URL) (*gtsmodel.Status, error) {
 	status, _, err := p.federator.GetStatusByURI(gtscontext.SetFastFail(ctx), authed.Account.Username, uri)
 	return status, err
@@ -28,6 +28,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 )
 
 // BoostCreate processes the boost/reblog of a given status, returning the newly-created boost if all is well.
@@ -62,6 +64,10 @@ func (p *Processor) BoostCreate(ctx context.Context, requestingAccount *gtsmodel
 		return nil, gtserror.NewErrorNotFound(errors.New("status is not boostable"))
 	}
 
+	if errWithCode := checkInteractionPolicy(ctx, p.filter, requestingAccount, targetStatus, visibility.InteractionBoost); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	// it's visible! it's boostable! so let's boost the FUCK out of it
 	boostWrapperStatus, err := p.tc.StatusToBoost(ctx, targetStatus, requestingAccount)
 	if err != nil {
@@ -155,8 +161,9 @@ func (p *Processor) BoostRemove(ctx context.Context, requestingAccount *gtsmodel
 	return p.apiStatus(ctx, targetStatus, requestingAccount)
 }
 
-// StatusBoostedBy returns a slice of accounts that have boosted the given status, filtered according to privacy settings.
-func (p *Processor) StatusBoostedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.Account, gtserror.WithCode) {
+// StatusBoostedBy returns a pageable response of accounts that have boosted the given status, filtered according to privacy settings.
+// Paging for this response is done based on the ID of the boost, rather than the ID of the account.
+func (p *Processor) StatusBoostedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, maxID string, minID string, limit int) (*apimodel.PageableResponse, gtserror.WithCode) {
 	targetStatus, err := p.state.DB.GetStatusByID(ctx, targetStatusID)
 	if err != nil {
 		wrapped := fmt.Errorf("BoostedBy: error fetching status %s: %s", targetStatusID, err)
@@ -189,46 +196,66 @@ func (p *Processor) StatusBoostedBy(ctx context.Context, requestingAccount *gtsm
 		return nil, gtserror.NewErrorNotFound(err)
 	}
 
-	statusReblogs, err := p.state.DB.GetStatusReblogs(ctx, targetStatus)
-	if err != nil {
-		err = fmt.Errorf("BoostedBy: error seeing who boosted status: %s", err)
-		return nil, gtserror.NewErrorNotFound(err)
+	statusReblogs, err := p.state.DB.GetStatusReblogsPage(ctx, targetStatus.ID, maxID, minID, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = fmt.Errorf("BoostedBy: error seeing who boosted status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	// filter account IDs so the user doesn't see accounts they blocked or which blocked them
-	accountIDs := make([]string, 0, len(statusReblogs))
-	for _, s := range statusReblogs {
-		blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, s.AccountID)
+	count := len(statusReblogs)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	var (
+		items = make([]interface{}, 0, count)
+
+		// Page based on boost ID, not account ID,
+		// and set next + prev values before filtering,
+		// so that paging still works even if every boost
+		// on this page has to be skipped.
+		nextMaxIDValue = statusReblogs[count-1].ID
+		prevMinIDValue = statusReblogs[0].ID
+	)
+
+	for _, reblog := range statusReblogs {
+		// Don't show the requester accounts they
+		// block, or which block them.
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, reblog.AccountID)
 		if err != nil {
-			err = fmt.Errorf("BoostedBy: error checking blocks: %s", err)
-			return nil, gtserror.NewErrorNotFound(err)
-		}
-		if !blocked {
-			accountIDs = append(accountIDs, s.AccountID)
+			err = fmt.Errorf("BoostedBy: error checking blocks: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
 		}
-	}
 
-	// TODO: filter other things here? suspended? muted? silenced?
+		if blocked {
+			continue
+		}
 
-	// fetch accounts + create their API representations
-	apiAccounts := make([]*apimodel.Account, 0, len(accountIDs))
-	for _, accountID := range accountIDs {
-		account, err := p.state.DB.GetAccountByID(ctx, accountID)
+		account, err := p.state.DB.GetAccountByID(ctx, reblog.AccountID)
 		if err != nil {
-			wrapped := fmt.Errorf("BoostedBy: error fetching account %s: %s", accountID, err)
-			if !errors.Is(err, db.ErrNoEntries) {
-				return nil, gtserror.NewErrorInternalError(wrapped)
+			if errors.Is(err, db.ErrNoEntries) {
+				// We just don't have the
+				// account for some reason.
+				continue
 			}
-			return nil, gtserror.NewErrorNotFound(wrapped)
+			err = fmt.Errorf("BoostedBy: error fetching account %s: %w", reblog.AccountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
 		}
 
 		apiAccount, err := p.tc.AccountToAPIAccountPublic(ctx, account)
 		if err != nil {
-			err = fmt.Errorf("BoostedBy: error converting account to api model: %s", err)
+			err = fmt.Errorf("BoostedBy: error converting account to api model: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
 		}
-		apiAccounts = append(apiAccounts, apiAccount)
+		items = append(items, apiAccount)
 	}
 
-	return apiAccounts, nil
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:          items,
+		Path:           "/api/v1/statuses/" + targetStatus.ID + "/reblogged_by",
+		NextMaxIDValue: nextMaxIDValue,
+		PrevMinIDValue: prevMinIDValue,
+		Limit:          limit,
+		Filtered:       true,
+	})
 }
@@ -0,0 +1,279 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package status
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// previewCardRefreshAfter is how long a stored preview
+// card is served for before its page is fetched again.
+const previewCardRefreshAfter = 7 * 24 * time.Hour
+
+// GetStatusCard returns a preview card for the first link in the content of
+// the given status, or nil if the status doesn't link to anything. Cards are
+// stored by URL, so a page is only fetched the first time it's linked to,
+// and then again once the stored card is older than previewCardRefreshAfter.
+//
+// If the linked page can't be fetched, or doesn't contain enough metadata to
+// build a card from, nil (or the stale card, when refreshing) is returned
+// rather than an error. Visibility of the status to the requester is not
+// checked here, so callers should do so first where necessary.
+func (p *Processor) GetStatusCard(ctx context.Context, statusID string) (*apimodel.Card, error) {
+	status, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), statusID)
+	if err != nil {
+		return nil, fmt.Errorf("GetStatusCard: db error getting status %s: %w", statusID, err)
+	}
+
+	return p.statusCard(ctx, status)
+}
+
+// statusCard does the work of GetStatusCard for the given status.
+func (p *Processor) statusCard(ctx context.Context, status *gtsmodel.Status) (*apimodel.Card, error) {
+	link := firstCardLink(status.Content)
+	if link == nil {
+		// Nothing to
+		// preview here.
+		return nil, nil
+	}
+	linkStr := link.String()
+
+	card, err := p.state.DB.GetPreviewCardByURL(ctx, linkStr)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("GetStatusCard: db error getting preview card for %s: %w", linkStr, err)
+	}
+
+	if card != nil && time.Since(card.UpdatedAt) > previewCardRefreshAfter {
+		// Stored card is getting old,
+		// fetch the page again for it.
+		card = p.refreshPreviewCard(ctx, card, link)
+	}
+
+	if card == nil {
+		// Not generated a card for this
+		// link yet, fetch the page now.
+		card, err = p.fetchPreviewCard(ctx, link)
+		if err != nil {
+			log.Debugf(ctx, "couldn't generate preview card for %s: %v", linkStr, err)
+			return nil, nil
+		}
+
+		if err := p.state.DB.PutPreviewCard(ctx, card); err != nil {
+			if !errors.Is(err, db.ErrAlreadyExists) {
+				return nil, fmt.Errorf("GetStatusCard: db error putting preview card for %s: %w", linkStr, err)
+			}
+
+			// Someone else generated a card for this
+			// link in the meantime, serve theirs instead.
+			card, err = p.state.DB.GetPreviewCardByURL(ctx, linkStr)
+			if err != nil {
+				return nil, fmt.Errorf("GetStatusCard: db error getting preview card for %s: %w", linkStr, err)
+			}
+		}
+	}
+
+	return p.tc.PreviewCardToAPICard(ctx, card)
+}
+
+// refreshPreviewCard fetches the page at link again to update the given stored
+// card, returning the updated card, or the stale card if that doesn't work out.
+func (p *Processor) refreshPreviewCard(ctx context.Context, card *gtsmodel.PreviewCard, link *url.URL) *gtsmodel.PreviewCard {
+	fresh, err := p.fetchPreviewCard(ctx, link)
+	if err != nil {
+		log.Debugf(ctx, "couldn't refresh preview card for %s, serving stale card: %v", card.URL, err)
+		return card
+	}
+
+	// Keep the ID of the stored card.
+	fresh.ID = card.ID
+	fresh.CreatedAt = card.CreatedAt
+
+	if err := p.state.DB.UpdatePreviewCard(ctx, fresh); err != nil {
+		log.Errorf(ctx, "db error updating preview card for %s: %v", card.URL, err)
+		return card
+	}
+
+	return fresh
+}
+
+// fetchPreviewCard fetches the page at link using the instance
+// account's transport, and generates a card from its metadata.
+func (p *Processor) fetchPreviewCard(ctx context.Context, link *url.URL) (*gtsmodel.PreviewCard, error) {
+	tsport, err := p.federator.TransportController().NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("error getting instance transport: %w", err)
+	}
+
+	page, err := tsport.DereferencePage(gtscontext.SetFastFail(ctx), link)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching page: %w", err)
+	}
+
+	card := parsePreviewCard(page, link)
+	if card.Title == "" {
+		return nil, errors.New("page has no title")
+	}
+
+	card.ID = id.NewULID()
+	return card, nil
+}
+
+// firstCardLink returns the target of the first link in the given
+// status content that isn't a mention or a hashtag, or nil if there
+// isn't one. Only http and https links are considered.
+func firstCardLink(content string) *url.URL {
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			// End of
+			// content.
+			return nil
+
+		case html.StartTagToken:
+			t := z.Token()
+			if t.DataAtom != atom.A {
+				continue
+			}
+
+			href, class := attrVal(t, "href"), attrVal(t, "class")
+			if href == "" || strings.Contains(class, "mention") {
+				continue
+			}
+
+			link, err := url.Parse(href)
+			if err != nil || link.Host == "" ||
+				(link.Scheme != "http" && link.Scheme != "https") {
+				continue
+			}
+
+			return link
+		}
+	}
+}
+
+// parsePreviewCard generates a preview card for the page at link from
+// the OpenGraph tags in its head, falling back to the page's <title>.
+func parsePreviewCard(page []byte, link *url.URL) *gtsmodel.PreviewCard {
+	card := &gtsmodel.PreviewCard{
+		URL:         link.String(),
+		Type:        "link",
+		ProviderURL: link.Scheme + "://" + link.Host,
+	}
+
+	var (
+		z       = html.NewTokenizer(bytes.NewReader(page))
+		title   string
+		inTitle bool
+	)
+
+loop:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			break loop
+
+		case html.EndTagToken:
+			t := z.Token()
+			if t.DataAtom == atom.Head {
+				// All the metadata
+				// we need is in head.
+				break loop
+			}
+			inTitle = false
+
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = strings.TrimSpace(string(z.Text()))
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			switch t.DataAtom {
+			case atom.Body:
+				break loop
+			case atom.Title:
+				inTitle = true
+			case atom.Meta:
+				setPreviewCardProperty(card, link,
+					attrVal(t, "property"),
+					strings.TrimSpace(attrVal(t, "content")),
+				)
+			}
+		}
+	}
+
+	if card.Title == "" {
+		card.Title = title
+	}
+
+	return card
+}
+
+// setPreviewCardProperty sets the field of card
+// corresponding to the given OpenGraph property.
+func setPreviewCardProperty(card *gtsmodel.PreviewCard, link *url.URL, property string, content string) {
+	switch property {
+	case "og:title":
+		card.Title = content
+	case "og:description":
+		card.Description = content
+	case "og:site_name":
+		card.ProviderName = content
+	case "og:type":
+		if strings.HasPrefix(content, "video") {
+			card.Type = "video"
+		}
+	case "og:image", "og:image:url":
+		// Image may be given
+		// relative to the page.
+		if image, err := link.Parse(content); err == nil {
+			card.Image = image.String()
+		}
+	case "og:image:width":
+		card.Width, _ = strconv.Atoi(content)
+	case "og:image:height":
+		card.Height, _ = strconv.Atoi(content)
+	}
+}
+
+// attrVal returns the value of the
+// given attribute of t, if set.
+func attrVal(t html.Token, key string) string {
+	for _, attr := range t.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
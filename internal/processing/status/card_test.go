@@ -0,0 +1,247 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package status_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+const testCardPage = `<!DOCTYPE html>
+<html>
+<head>
+<title>Fallback title</title>
+<meta property="og:title" content="Is Water Wet?">
+<meta property="og:description" content="We asked an expert.">
+<meta property="og:site_name" content="Example News">
+<meta property="og:image" content="/images/water.jpg">
+<meta property="og:image:width" content="640">
+<meta property="og:image:height" content="480">
+</head>
+<body><p>Hello!</p></body>
+</html>`
+
+type StatusCardTestSuite struct {
+	StatusStandardTestSuite
+
+	// amount of page fetches done
+	fetches atomic.Int32
+
+	// page served at example.org/articles/water
+	page string
+}
+
+func (suite *StatusCardTestSuite) SetupTest() {
+	suite.StatusStandardTestSuite.SetupTest()
+	suite.fetches.Store(0)
+	suite.page = testCardPage
+
+	// Swap in a federator that serves our test
+	// page at example.org and 404s everything else.
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		suite.fetches.Add(1)
+
+		code, body, contentType := http.StatusNotFound, []byte("not found"), "text/plain"
+		switch req.URL.String() {
+		case "https://example.org/articles/water":
+			code, body, contentType = http.StatusOK, []byte(suite.page), "text/html; charset=utf-8"
+		case "https://example.org/water.jpg":
+			code, body, contentType = http.StatusOK, []byte(testCardPage), "image/jpeg"
+		}
+
+		return &http.Response{
+			StatusCode:    code,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Header:        http.Header{"Content-Type": {contentType}},
+		}, nil
+	}, "../../../testrig/media")
+
+	suite.tc = testrig.NewTestTransportController(&suite.state, httpClient)
+	suite.federator = testrig.NewTestFederator(&suite.state, suite.tc, suite.mediaManager)
+	filter := visibility.NewFilter(&suite.state)
+	suite.status = status.New(&suite.state, suite.federator, suite.typeConverter, filter, processing.GetParseMentionFunc(suite.db, suite.federator))
+}
+
+func (suite *StatusCardTestSuite) setContent(statusKey string, content string) string {
+	testStatus := suite.testStatuses[statusKey]
+	testStatus.Content = content
+	if err := suite.db.UpdateStatus(context.Background(), testStatus, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return testStatus.ID
+}
+
+func (suite *StatusCardTestSuite) TestGetStatusCard() {
+	ctx := context.Background()
+	statusID := suite.setContent("local_account_1_status_1", `<p>`+
+		`<span class="h-card"><a href="http://localhost:8080/@admin" class="u-url mention">@<span>admin</span></a></span> `+
+		`<a href="http://localhost:8080/tags/water" class="mention hashtag" rel="tag">#<span>water</span></a> `+
+		`read this: <a href="https://example.org/articles/water" rel="nofollow noreferrer noopener" target="_blank">https://example.org/articles/water</a>`+
+		`</p>`)
+
+	card, err := suite.status.GetStatusCard(ctx, statusID)
+	suite.NoError(err)
+	suite.NotNil(card)
+	suite.Equal("https://example.org/articles/water", card.URL)
+	suite.Equal("Is Water Wet?", card.Title)
+	suite.Equal("We asked an expert.", card.Description)
+	suite.Equal("link", card.Type)
+	suite.Equal("Example News", card.ProviderName)
+	suite.Equal("https://example.org", card.ProviderURL)
+	suite.Equal("https://example.org/images/water.jpg", card.Image)
+	suite.Equal(640, card.Width)
+	suite.Equal(480, card.Height)
+	suite.EqualValues(1, suite.fetches.Load())
+
+	// A second status linking to the same
+	// page should be served the stored card.
+	otherStatusID := suite.setContent("local_account_2_status_1", `<p><a href="https://example.org/articles/water">look</a></p>`)
+
+	cachedCard, err := suite.status.GetStatusCard(ctx, otherStatusID)
+	suite.NoError(err)
+	suite.Equal(card, cachedCard)
+	suite.EqualValues(1, suite.fetches.Load())
+}
+
+func (suite *StatusCardTestSuite) TestGetStatusCardUnreachable() {
+	ctx := context.Background()
+	statusID := suite.setContent("local_account_1_status_1", `<p><a href="https://example.org/nowhere">gone</a></p>`)
+
+	card, err := suite.status.GetStatusCard(ctx, statusID)
+	suite.NoError(err)
+	suite.Nil(card)
+
+	// Nothing should have been stored.
+	_, err = suite.db.GetPreviewCardByURL(ctx, "https://example.org/nowhere")
+	suite.Error(err)
+}
+
+func (suite *StatusCardTestSuite) TestGetStatusCardNoLinks() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	card, err := suite.status.GetStatusCard(ctx, targetStatus.ID)
+	suite.NoError(err)
+	suite.Nil(card)
+	suite.EqualValues(0, suite.fetches.Load())
+}
+
+func (suite *StatusCardTestSuite) TestGetStatusCardNotHTML() {
+	ctx := context.Background()
+
+	// Page has enough metadata for a
+	// card, but isn't served as HTML.
+	statusID := suite.setContent("local_account_1_status_1", `<p><a href="https://example.org/water.jpg">pic</a></p>`)
+
+	card, err := suite.status.GetStatusCard(ctx, statusID)
+	suite.NoError(err)
+	suite.Nil(card)
+	suite.EqualValues(1, suite.fetches.Load())
+}
+
+func (suite *StatusCardTestSuite) TestGetStatusCardRefresh() {
+	ctx := context.Background()
+	statusID := suite.setContent("local_account_1_status_1", `<p><a href="https://example.org/articles/water">look</a></p>`)
+
+	card, err := suite.status.GetStatusCard(ctx, statusID)
+	suite.NoError(err)
+	suite.Equal("Is Water Wet?", card.Title)
+
+	// Age the stored card past the refresh time,
+	// and change the title of the page meanwhile.
+	stored, err := suite.db.GetPreviewCardByURL(ctx, card.URL)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	stored.UpdatedAt = time.Now().Add(-8 * 24 * time.Hour)
+	if err := suite.db.UpdateByID(ctx, stored, stored.ID, "updated_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.page = strings.Replace(testCardPage, "Is Water Wet?", "Is Water Still Wet?", 1)
+
+	card, err = suite.status.GetStatusCard(ctx, statusID)
+	suite.NoError(err)
+	suite.Equal("Is Water Still Wet?", card.Title)
+	suite.EqualValues(2, suite.fetches.Load())
+
+	refreshed, err := suite.db.GetPreviewCardByURL(ctx, card.URL)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(stored.ID, refreshed.ID)
+	suite.Equal("Is Water Still Wet?", refreshed.Title)
+
+	// Fresh again, so no more fetching.
+	_, err = suite.status.GetStatusCard(ctx, statusID)
+	suite.NoError(err)
+	suite.EqualValues(2, suite.fetches.Load())
+}
+
+func (suite *StatusCardTestSuite) TestGetStatusCardRefreshUnreachable() {
+	ctx := context.Background()
+	statusID := suite.setContent("local_account_1_status_1", `<p><a href="https://example.org/articles/water">look</a></p>`)
+
+	card, err := suite.status.GetStatusCard(ctx, statusID)
+	suite.NoError(err)
+
+	stored, err := suite.db.GetPreviewCardByURL(ctx, card.URL)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	stored.UpdatedAt = time.Now().Add(-8 * 24 * time.Hour)
+	if err := suite.db.UpdateByID(ctx, stored, stored.ID, "updated_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The page has lost its metadata, so the
+	// stale card should be served instead.
+	suite.page = "<html><head></head><body></body></html>"
+
+	staleCard, err := suite.status.GetStatusCard(ctx, statusID)
+	suite.NoError(err)
+	suite.Equal(card, staleCard)
+	suite.EqualValues(2, suite.fetches.Load())
+}
+
+func (suite *StatusCardTestSuite) TestGetStatusWithCard() {
+	ctx := context.Background()
+	statusID := suite.setContent("local_account_1_status_1", `<p><a href="https://example.org/articles/water">look</a></p>`)
+
+	apiStatus, errWithCode := suite.status.Get(ctx, suite.testAccounts["local_account_1"], statusID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.NotNil(apiStatus.Card)
+	suite.Equal("Is Water Wet?", apiStatus.Card.Title)
+}
+
+func TestStatusCardTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusCardTestSuite))
+}
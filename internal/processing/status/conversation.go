@@ -0,0 +1,166 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// conversationSelectLimit is the amount of direct statuses to
+// select from the db at once when looking for a conversation.
+const conversationSelectLimit = 100
+
+// GetConversationByParticipants returns the conversation between the requesting
+// account and exactly the given participants, ie., the most recent direct status
+// visible to the requesting account whose author and mentioned accounts are those
+// participants plus the requesting account.
+//
+// The returned conversation ID is derived from the sorted participant IDs, so the
+// same thread always gets the same ID, no matter which participant looks it up or
+// the order participants are given in. Clients can use this to check for an
+// existing thread before starting a new one. 404 is returned if there's no thread.
+func (p *Processor) GetConversationByParticipants(ctx context.Context, requestingAccount *gtsmodel.Account, participantIDs []string) (*apimodel.Conversation, gtserror.WithCode) {
+	participantIDs = sortedParticipantIDs(append([]string{requestingAccount.ID}, participantIDs...))
+	if len(participantIDs) < 2 {
+		err := errors.New("at least one participant other than the requesting account must be given")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	key := conversationKey(participantIDs)
+
+	var (
+		lastStatus *gtsmodel.Status
+		maxID      string
+	)
+
+	for lastStatus == nil {
+		statuses, err := p.state.DB.GetDirectStatusesForAccount(ctx, requestingAccount.ID, maxID, conversationSelectLimit)
+		if err != nil {
+			err = gtserror.Newf("db error getting direct statuses: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if len(statuses) == 0 {
+			// No more direct
+			// statuses to check.
+			break
+		}
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			if conversationKey(sortedParticipantIDs(statusParticipantIDs(status))) != key {
+				continue
+			}
+
+			visible, err := p.filter.StatusVisible(ctx, requestingAccount, status)
+			if err != nil {
+				err = gtserror.Newf("error checking visibility of status %s: %w", status.ID, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			if visible {
+				lastStatus = status
+				break
+			}
+		}
+	}
+
+	if lastStatus == nil {
+		err := fmt.Errorf("no conversation found with participants %v", participantIDs)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	conversation := &apimodel.Conversation{
+		ID:       key,
+		Accounts: make([]apimodel.Account, 0, len(participantIDs)-1),
+	}
+
+	accounts, err := p.state.DB.GetAccountsByIDs(ctx, participantIDs)
+	if err != nil {
+		err = gtserror.Newf("db error getting participant accounts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, account := range accounts {
+		if account.ID == requestingAccount.ID {
+			// Don't list the requester
+			// in their own conversation.
+			continue
+		}
+
+		apiAccount, err := p.tc.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			err = gtserror.Newf("error converting account %s: %w", account.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		conversation.Accounts = append(conversation.Accounts, *apiAccount)
+	}
+
+	var errWithCode gtserror.WithCode
+	conversation.LastStatus, errWithCode = p.apiStatus(ctx, lastStatus, requestingAccount)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return conversation, nil
+}
+
+// statusParticipantIDs returns the IDs of the
+// author and mentioned accounts of the given status.
+func statusParticipantIDs(status *gtsmodel.Status) []string {
+	ids := make([]string, 0, len(status.Mentions)+1)
+	ids = append(ids, status.AccountID)
+	for _, mention := range status.Mentions {
+		if mention != nil {
+			ids = append(ids, mention.TargetAccountID)
+		}
+	}
+	return ids
+}
+
+// sortedParticipantIDs sorts the given
+// account IDs, dropping any duplicates.
+func sortedParticipantIDs(ids []string) []string {
+	sorted := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok || id == "" {
+			continue
+		}
+		seen[id] = struct{}{}
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// conversationKey derives a stable conversation
+// ID from the given sorted participant IDs.
+func conversationKey(sortedIDs []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(sortedIDs, ",")))
+	return hex.EncodeToString(sum[:16])
+}
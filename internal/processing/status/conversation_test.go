@@ -0,0 +1,81 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusConversationTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusConversationTestSuite) TestGetConversationByParticipants() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_2"]
+	otherAccount := suite.testAccounts["local_account_1"]
+
+	conversation, errWithCode := suite.status.GetConversationByParticipants(ctx, requestingAccount, []string{otherAccount.ID})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Len(conversation.Accounts, 1)
+	suite.Equal(otherAccount.ID, conversation.Accounts[0].ID)
+	suite.Equal(suite.testStatuses["local_account_2_status_6"].ID, conversation.LastStatus.ID)
+
+	// Giving participants in another order, with duplicates,
+	// or including the requester, should get the same ID.
+	again, errWithCode := suite.status.GetConversationByParticipants(ctx, requestingAccount, []string{
+		requestingAccount.ID,
+		otherAccount.ID,
+		otherAccount.ID,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(conversation.ID, again.ID)
+}
+
+func (suite *StatusConversationTestSuite) TestGetConversationByParticipantsNotFound() {
+	_, errWithCode := suite.status.GetConversationByParticipants(
+		context.Background(),
+		suite.testAccounts["local_account_2"],
+		[]string{suite.testAccounts["admin_account"].ID},
+	)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *StatusConversationTestSuite) TestGetConversationByParticipantsOnlySelf() {
+	requestingAccount := suite.testAccounts["local_account_2"]
+
+	_, errWithCode := suite.status.GetConversationByParticipants(
+		context.Background(),
+		requestingAccount,
+		[]string{requestingAccount.ID},
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestStatusConversationTestSuite(t *testing.T) {
+	suite.Run(t, &StatusConversationTestSuite{})
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 )
 
 // Create processes the given form to create a new status, returning the api model representation of that status if it's OK.
@@ -60,7 +61,7 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		Text:                     form.Status,
 	}
 
-	if errWithCode := processReplyToID(ctx, p.state.DB, form, account.ID, newStatus); errWithCode != nil {
+	if errWithCode := processReplyToID(ctx, p.state.DB, p.filter, form, account, newStatus); errWithCode != nil {
 		return nil, errWithCode
 	}
 
@@ -76,8 +77,8 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if err := processContent(ctx, p.state.DB, p.formatter, p.parseMention, form, account.ID, newStatus); err != nil {
-		return nil, gtserror.NewErrorInternalError(err)
+	if errWithCode := processContent(ctx, p.state.DB, p.formatter, p.parseMention, form, account.ID, newStatus); errWithCode != nil {
+		return nil, errWithCode
 	}
 
 	// put the new status in the database
@@ -96,7 +97,7 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 	return p.apiStatus(ctx, newStatus, account)
 }
 
-func processReplyToID(ctx context.Context, dbService db.DB, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) gtserror.WithCode {
+func processReplyToID(ctx context.Context, dbService db.DB, filter *visibility.Filter, form *apimodel.AdvancedStatusCreateForm, account *gtsmodel.Account, status *gtsmodel.Status) gtserror.WithCode {
 	if form.InReplyToID == "" {
 		return nil
 	}
@@ -106,6 +107,7 @@ func processReplyToID(ctx context.Context, dbService db.DB, form *apimodel.Advan
 	// 1. Does the replied status exist in the database?
 	// 2. Is the replied status marked as replyable?
 	// 3. Does a block exist between either the current account or the account that posted the status it's replying to?
+	// 4. Does the replied status' interaction policy permit the current account to reply to it?
 	//
 	// If this is all OK, then we fetch the repliedStatus and the repliedAccount for later processing.
 	repliedStatus := &gtsmodel.Status{}
@@ -133,7 +135,7 @@ func processReplyToID(ctx context.Context, dbService db.DB, form *apimodel.Advan
 		return gtserror.NewErrorInternalError(err)
 	}
 
-	if blocked, err := dbService.IsEitherBlocked(ctx, thisAccountID, repliedAccount.ID); err != nil {
+	if blocked, err := dbService.IsEitherBlocked(ctx, account.ID, repliedAccount.ID); err != nil {
 		err := fmt.Errorf("db error checking block: %s", err)
 		return gtserror.NewErrorInternalError(err)
 	} else if blocked {
@@ -141,6 +143,10 @@ func processReplyToID(ctx context.Context, dbService db.DB, form *apimodel.Advan
 		return gtserror.NewErrorNotFound(err)
 	}
 
+	if errWithCode := checkInteractionPolicy(ctx, filter, account, repliedStatus, visibility.InteractionReply); errWithCode != nil {
+		return errWithCode
+	}
+
 	status.InReplyToID = repliedStatus.ID
 	status.InReplyToURI = repliedStatus.URI
 	status.InReplyToAccountID = repliedAccount.ID
@@ -276,7 +282,7 @@ func processLanguage(ctx context.Context, form *apimodel.AdvancedStatusCreateFor
 	return nil
 }
 
-func processContent(ctx context.Context, dbService db.DB, formatter text.Formatter, parseMention gtsmodel.ParseMentionFunc, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error {
+func processContent(ctx context.Context, dbService db.DB, formatter text.Formatter, parseMention gtsmodel.ParseMentionFunc, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) gtserror.WithCode {
 	// if there's nothing in the status at all we can just return early
 	if form.Status == "" {
 		status.Content = ""
@@ -287,7 +293,8 @@ func processContent(ctx context.Context, dbService db.DB, formatter text.Formatt
 	if form.ContentType == "" {
 		acct, err := dbService.GetAccountByID(ctx, accountID)
 		if err != nil {
-			return fmt.Errorf("error processing new content: couldn't retrieve account from db to check post format: %s", err)
+			err := fmt.Errorf("error processing new content: couldn't retrieve account from db to check post format: %s", err)
+			return gtserror.NewErrorInternalError(err)
 		}
 
 		switch acct.StatusContentType {
@@ -308,10 +315,17 @@ func processContent(ctx context.Context, dbService db.DB, formatter text.Formatt
 	case apimodel.StatusContentTypeMarkdown:
 		f = formatter.FromMarkdown
 	default:
-		return fmt.Errorf("format %s not recognised as a valid status format", form.ContentType)
+		err := fmt.Errorf("format %s not recognised as a valid status format", form.ContentType)
+		return gtserror.NewErrorInternalError(err)
 	}
 	formatted := f(ctx, parseMention, accountID, status.ID, form.Status)
 
+	if len(formatted.UnusableTags) != 0 {
+		// An admin has banned use of this tag.
+		err := fmt.Errorf("hashtag #%s is not allowed on this instance", formatted.UnusableTags[0].Name)
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
 	// add full populated gts {mentions, tags, emojis} to the status for passing them around conveniently
 	// add just their ids to the status for putting in the db
 	status.Mentions = formatted.Mentions
@@ -31,6 +31,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 )
 
 // FaveCreate adds a fave for the requestingAccount, targeting the given status (no-op if fave already exists).
@@ -45,6 +47,10 @@ func (p *Processor) FaveCreate(ctx context.Context, requestingAccount *gtsmodel.
 		return p.apiStatus(ctx, targetStatus, requestingAccount)
 	}
 
+	if errWithCode := checkInteractionPolicy(ctx, p.filter, requestingAccount, targetStatus, visibility.InteractionLike); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	// Create and store a new fave
 	faveID := id.NewULID()
 	gtsFave := &gtsmodel.StatusFave{
@@ -105,23 +111,42 @@ func (p *Processor) FaveRemove(ctx context.Context, requestingAccount *gtsmodel.
 	return p.apiStatus(ctx, targetStatus, requestingAccount)
 }
 
-// FavedBy returns a slice of accounts that have liked the given status, filtered according to privacy settings.
-func (p *Processor) FavedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.Account, gtserror.WithCode) {
+// FavedBy returns a pageable response of accounts that have liked the given status, filtered according to privacy settings.
+// Paging for this response is done based on the ID of the fave, rather than the ID of the account.
+//
+// Only faves that this instance knows about are returned; for remote statuses, faves aren't fetched from the origin server.
+func (p *Processor) FavedBy(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, maxID string, minID string, limit int) (*apimodel.PageableResponse, gtserror.WithCode) {
 	targetStatus, errWithCode := p.getVisibleStatus(ctx, requestingAccount, targetStatusID)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
-	statusFaves, err := p.state.DB.GetStatusFavesForStatus(ctx, targetStatus.ID)
-	if err != nil {
-		return nil, gtserror.NewErrorNotFound(fmt.Errorf("FavedBy: error seeing who faved status: %s", err))
+	statusFaves, err := p.state.DB.GetStatusFavesPage(ctx, targetStatus.ID, maxID, minID, limit)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = fmt.Errorf("FavedBy: error seeing who faved status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	// For each fave, ensure that we're only showing
-	// the requester accounts that they don't block,
-	// and which don't block them.
-	apiAccounts := make([]*apimodel.Account, 0, len(statusFaves))
+	count := len(statusFaves)
+	if count == 0 {
+		return util.EmptyPageableResponse(), nil
+	}
+
+	var (
+		items = make([]interface{}, 0, count)
+
+		// Page based on fave ID, not account ID,
+		// and set next + prev values before filtering,
+		// so that paging still works even if every fave
+		// on this page has to be skipped.
+		nextMaxIDValue = statusFaves[count-1].ID
+		prevMinIDValue = statusFaves[0].ID
+	)
+
 	for _, fave := range statusFaves {
+		// Ensure that we're only showing
+		// the requester accounts that they don't
+		// block, and which don't block them.
 		if blocked, err := p.state.DB.IsEitherBlocked(ctx, requestingAccount.ID, fave.AccountID); err != nil {
 			err = fmt.Errorf("FavedBy: error checking blocks: %w", err)
 			return nil, gtserror.NewErrorInternalError(err)
@@ -140,10 +165,17 @@ func (p *Processor) FavedBy(ctx context.Context, requestingAccount *gtsmodel.Acc
 			err = fmt.Errorf("FavedBy: error converting account %s to frontend representation: %w", fave.AccountID, err)
 			return nil, gtserror.NewErrorInternalError(err)
 		}
-		apiAccounts = append(apiAccounts, apiAccount)
+		items = append(items, apiAccount)
 	}
 
-	return apiAccounts, nil
+	return util.PackagePageableResponse(util.PageableResponseParams{
+		Items:          items,
+		Path:           "/api/v1/statuses/" + targetStatus.ID + "/favourited_by",
+		NextMaxIDValue: nextMaxIDValue,
+		PrevMinIDValue: prevMinIDValue,
+		Limit:          limit,
+		Filtered:       true,
+	})
 }
 
 func (p *Processor) getFaveTarget(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*gtsmodel.Status, *gtsmodel.StatusFave, gtserror.WithCode) {
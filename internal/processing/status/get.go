@@ -19,11 +19,14 @@ package status
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
 // Get gets the given status, taking account of privacy settings and blocks etc.
@@ -33,7 +36,20 @@ func (p *Processor) Get(ctx context.Context, requestingAccount *gtsmodel.Account
 		return nil, errWithCode
 	}
 
-	return p.apiStatus(ctx, targetStatus, requestingAccount)
+	apiStatus, errWithCode := p.apiStatus(ctx, targetStatus, requestingAccount)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Cards are only generated when viewing a single
+	// status, so that timelines don't fetch every link.
+	card, err := p.statusCard(ctx, targetStatus)
+	if err != nil {
+		log.Errorf(ctx, "error getting card for status %s: %v", targetStatus.ID, err)
+	}
+	apiStatus.Card = card
+
+	return apiStatus, nil
 }
 
 // ContextGet returns the context (previous and following posts) from the given status ID.
@@ -82,3 +98,87 @@ func (p *Processor) ContextGet(ctx context.Context, requestingAccount *gtsmodel.
 
 	return context, nil
 }
+
+// GetStatusMentions returns the mentions of the given status, with the mentioned
+// accounts resolved from the cache or database in one go, the same way as status
+// serialization does. Visibility of the status to the requester is not checked
+// here, so callers should do so first where necessary.
+func (p *Processor) GetStatusMentions(ctx context.Context, statusID string) ([]*apimodel.Mention, error) {
+	status, err := p.state.DB.GetStatusByID(gtscontext.SetBarebones(ctx), statusID)
+	if err != nil {
+		return nil, fmt.Errorf("GetStatusMentions: db error getting status %s: %w", statusID, err)
+	}
+
+	mentions, err := p.state.DB.GetMentions(ctx, status.MentionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("GetStatusMentions: db error getting mentions of status %s: %w", statusID, err)
+	}
+
+	apiMentions, err := p.tc.MentionsToAPIMentions(ctx, mentions)
+	if err != nil {
+		// Partial failures shouldn't stop us
+		// returning what we could convert.
+		log.Errorf(ctx, "error converting mentions of status %s: %v", statusID, err)
+	}
+
+	mentionPtrs := make([]*apimodel.Mention, 0, len(apiMentions))
+	for i := range apiMentions {
+		mentionPtrs = append(mentionPtrs, &apiMentions[i])
+	}
+
+	return mentionPtrs, nil
+}
+
+// GetStatusTags returns the tags attached to the given status, each with the last
+// week of its daily usage history. Visibility of the status to the requester is
+// not checked here, so callers should do so first where necessary.
+func (p *Processor) GetStatusTags(ctx context.Context, statusID string) ([]*apimodel.Tag, error) {
+	tags, err := p.state.DB.GetTagsByStatusID(ctx, statusID)
+	if err != nil {
+		return nil, fmt.Errorf("GetStatusTags: db error getting tags of status %s: %w", statusID, err)
+	}
+
+	apiTags := make([]*apimodel.Tag, 0, len(tags))
+	for _, tag := range tags {
+		apiTag, err := p.tc.TagToAPITagWithHistory(ctx, tag)
+		if err != nil {
+			return nil, fmt.Errorf("GetStatusTags: error converting tag %s to api tag: %w", tag.ID, err)
+		}
+
+		apiTags = append(apiTags, &apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// GetStatusHistory returns the edit history of the given status, oldest version
+// first and ending with the current version. A status that has never been edited
+// has a history of just its current version.
+func (p *Processor) GetStatusHistory(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode) {
+	targetStatus, errWithCode := p.getVisibleStatus(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	revisions, err := p.state.DB.GetStatusRevisions(ctx, targetStatus.ID)
+	if err != nil {
+		err = gtserror.Newf("db error getting revisions of status %s: %w", targetStatus.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// The current version of the status
+	// comes last, after all the earlier ones.
+	revisions = append(revisions, gtsmodel.NewStatusRevision(targetStatus))
+
+	edits := make([]*apimodel.StatusEdit, 0, len(revisions))
+	for _, revision := range revisions {
+		edit, err := p.tc.StatusRevisionToAPIStatusEdit(ctx, revision, targetStatus.Account)
+		if err != nil {
+			err = gtserror.Newf("error converting revision of status %s: %w", targetStatus.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		edits = append(edits, edit)
+	}
+
+	return edits, nil
+}
@@ -0,0 +1,124 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusGetTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusGetTestSuite) TestGetStatusMentions() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["admin_account_status_3"]
+	mentionedAccount := suite.testAccounts["local_account_1"]
+
+	mentions, err := suite.status.GetStatusMentions(ctx, targetStatus.ID)
+	suite.NoError(err)
+	suite.Len(mentions, 1)
+	suite.Equal(mentionedAccount.ID, mentions[0].ID)
+	suite.Equal(mentionedAccount.Username, mentions[0].Username)
+	suite.Equal(mentionedAccount.Username, mentions[0].Acct)
+	suite.Equal(mentionedAccount.URL, mentions[0].URL)
+}
+
+func (suite *StatusGetTestSuite) TestGetStatusMentionsNoMentions() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	mentions, err := suite.status.GetStatusMentions(ctx, targetStatus.ID)
+	suite.NoError(err)
+	suite.Empty(mentions)
+}
+
+func (suite *StatusGetTestSuite) TestGetStatusTags() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+	testTag := suite.testTags["welcome"]
+
+	tags, err := suite.status.GetStatusTags(ctx, targetStatus.ID)
+	suite.NoError(err)
+	suite.Len(tags, 1)
+	suite.Equal(testTag.Name, tags[0].Name)
+	suite.Equal(testTag.URL, tags[0].URL)
+	suite.Len(tags[0].History, 7)
+}
+
+func (suite *StatusGetTestSuite) TestGetStatusTagsNoTags() {
+	ctx := context.Background()
+	targetStatus := suite.testStatuses["admin_account_status_2"]
+
+	tags, err := suite.status.GetStatusTags(ctx, targetStatus.ID)
+	suite.NoError(err)
+	suite.Empty(tags)
+}
+
+func (suite *StatusGetTestSuite) TestGetStatusHistoryNeverEdited() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	history, errWithCode := suite.status.GetStatusHistory(ctx, requestingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Len(history, 1)
+	suite.Equal(targetStatus.Content, history[0].Content)
+	suite.Equal(targetStatus.AccountID, history[0].Account.ID)
+}
+
+func (suite *StatusGetTestSuite) TestGetStatusHistory() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	// Store an earlier version of the status,
+	// with a content warning and no media.
+	revision := gtsmodel.NewStatusRevision(targetStatus)
+	revision.ID = "01H3R2FJ7RHP0Z9VZ7YV7B7M1T"
+	revision.RevisedAt = targetStatus.CreatedAt.Add(-time.Minute)
+	revision.Content = "hello world! first post on the instance!"
+	revision.ContentWarning = "first post"
+	revision.AttachmentIDs = nil
+	if err := suite.db.PutStatusRevision(ctx, revision); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	history, errWithCode := suite.status.GetStatusHistory(ctx, requestingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Len(history, 2)
+
+	// Oldest version first...
+	suite.Equal(revision.Content, history[0].Content)
+	suite.Equal(revision.ContentWarning, history[0].SpoilerText)
+	suite.Empty(history[0].MediaAttachments)
+
+	// ...then the current one.
+	suite.Equal(targetStatus.Content, history[1].Content)
+	suite.Equal(targetStatus.ContentWarning, history[1].SpoilerText)
+	suite.Len(history[1].MediaAttachments, len(targetStatus.AttachmentIDs))
+}
+
+func TestStatusGetTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusGetTestSuite))
+}
@@ -0,0 +1,158 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
+)
+
+// InteractionPolicyGet returns the interaction policy of the given status.
+func (p *Processor) InteractionPolicyGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.StatusInteractionPolicy, gtserror.WithCode) {
+	targetStatus, errWithCode := p.getVisibleStatus(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return apiInteractionPolicy(targetStatus.InteractionPolicy), nil
+}
+
+// InteractionPolicySet updates the interaction policy of the given status, which must
+// belong to the requesting account. Only the given (non-nil) values are changed.
+func (p *Processor) InteractionPolicySet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, canReplyTo *string, canBoost *string, canLike *string) (*apimodel.StatusInteractionPolicy, gtserror.WithCode) {
+	if canReplyTo == nil && canBoost == nil && canLike == nil {
+		err := errors.New("none of can_reply_to, can_boost, or can_like were set")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	targetStatus, errWithCode := p.getVisibleStatus(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if targetStatus.AccountID != requestingAccount.ID {
+		err := fmt.Errorf("InteractionPolicySet: status %s does not belong to account %s", targetStatusID, requestingAccount.ID)
+		return nil, gtserror.NewErrorForbidden(err, "you can only change the interaction policy of your own statuses")
+	}
+
+	if targetStatus.BoostOfID != "" {
+		err := errors.New("boosts don't have an interaction policy of their own")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	var (
+		policy  = targetStatus.InteractionPolicy
+		columns = make([]string, 0, 3)
+	)
+
+	for _, field := range []struct {
+		value  *string
+		target *gtsmodel.InteractionPolicyValue
+		column string
+	}{
+		{canReplyTo, &policy.CanReplyTo, "interaction_policy_can_reply_to"},
+		{canBoost, &policy.CanBoost, "interaction_policy_can_boost"},
+		{canLike, &policy.CanLike, "interaction_policy_can_like"},
+	} {
+		if field.value == nil {
+			continue
+		}
+
+		value, err := parseInteractionPolicyValue(*field.value)
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		*field.target = value
+		columns = append(columns, field.column)
+	}
+
+	targetStatus.InteractionPolicy = policy
+	if err := p.state.DB.UpdateStatus(ctx, targetStatus, columns...); err != nil {
+		err = fmt.Errorf("InteractionPolicySet: db error updating status %s: %w", targetStatus.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInteractionPolicy(targetStatus.InteractionPolicy), nil
+}
+
+// checkInteractionPolicy returns a forbidden error if the
+// given account isn't permitted by the status' interaction
+// policy to perform the given interaction with the status.
+func checkInteractionPolicy(ctx context.Context, filter *visibility.Filter, account *gtsmodel.Account, status *gtsmodel.Status, interaction visibility.Interaction) gtserror.WithCode {
+	permitted, err := filter.StatusInteractionPermitted(ctx, account, status, interaction)
+	if err != nil {
+		err = fmt.Errorf("error checking interaction policy of status %s: %w", status.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if permitted {
+		return nil
+	}
+
+	var verb string
+	switch interaction {
+	case visibility.InteractionReply:
+		verb = "reply to"
+	case visibility.InteractionBoost:
+		verb = "boost"
+	case visibility.InteractionLike:
+		verb = "like"
+	}
+
+	var who string
+	switch interaction.PolicyValue(status) {
+	case gtsmodel.InteractionPolicyFollowersOnly:
+		who = "followers of its author"
+	case gtsmodel.InteractionPolicyMentionedOnly:
+		who = "accounts mentioned in it"
+	default:
+		who = "its author"
+	}
+
+	text := fmt.Sprintf("you can't %s this status: only %s can %s it", verb, who, verb)
+	err = fmt.Errorf("checkInteractionPolicy: account %s can't %s status %s: %s", account.ID, verb, status.ID, text)
+	return gtserror.NewErrorForbidden(err, text)
+}
+
+func parseInteractionPolicyValue(s string) (gtsmodel.InteractionPolicyValue, error) {
+	switch value := gtsmodel.InteractionPolicyValue(s); value {
+	case gtsmodel.InteractionPolicyEveryone,
+		gtsmodel.InteractionPolicyFollowersOnly,
+		gtsmodel.InteractionPolicyMentionedOnly,
+		gtsmodel.InteractionPolicySelfOnly:
+		return value, nil
+	default:
+		return "", fmt.Errorf("interaction policy value %q not recognized, must be one of everyone, followers_only, mentioned_only, self_only", s)
+	}
+}
+
+func apiInteractionPolicy(policy gtsmodel.StatusInteractionPolicy) *apimodel.StatusInteractionPolicy {
+	policy = policy.Normalize()
+	return &apimodel.StatusInteractionPolicy{
+		CanReplyTo: string(policy.CanReplyTo),
+		CanBoost:   string(policy.CanBoost),
+		CanLike:    string(policy.CanLike),
+	}
+}
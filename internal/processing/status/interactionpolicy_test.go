@@ -0,0 +1,108 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusInteractionPolicyTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusInteractionPolicyTestSuite) TestInteractionPolicyDefault() {
+	ctx := context.Background()
+
+	policy, errWithCode := suite.status.InteractionPolicyGet(ctx, suite.testAccounts["local_account_1"], suite.testStatuses["admin_account_status_1"].ID)
+	suite.NoError(errWithCode)
+	suite.Equal("everyone", policy.CanReplyTo)
+	suite.Equal("everyone", policy.CanBoost)
+	suite.Equal("everyone", policy.CanLike)
+}
+
+func (suite *StatusInteractionPolicyTestSuite) TestInteractionPolicyFollowersOnlyLike() {
+	ctx := context.Background()
+	adminAccount := suite.testAccounts["admin_account"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	followersOnly := "followers_only"
+	policy, errWithCode := suite.status.InteractionPolicySet(ctx, adminAccount, targetStatus.ID, nil, nil, &followersOnly)
+	suite.NoError(errWithCode)
+	suite.Equal("everyone", policy.CanReplyTo)
+	suite.Equal("everyone", policy.CanBoost)
+	suite.Equal("followers_only", policy.CanLike)
+
+	// local_account_2 doesn't follow admin, so can't fave...
+	_, errWithCode = suite.status.FaveCreate(ctx, suite.testAccounts["local_account_2"], targetStatus.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// ...but local_account_1 does, so can.
+	_, errWithCode = suite.status.FaveCreate(ctx, suite.testAccounts["local_account_1"], targetStatus.ID)
+	suite.NoError(errWithCode)
+}
+
+func (suite *StatusInteractionPolicyTestSuite) TestInteractionPolicySelfOnlyBoost() {
+	ctx := context.Background()
+	adminAccount := suite.testAccounts["admin_account"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	selfOnly := "self_only"
+	if _, errWithCode := suite.status.InteractionPolicySet(ctx, adminAccount, targetStatus.ID, nil, &selfOnly, nil); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode := suite.status.BoostCreate(ctx, suite.testAccounts["local_account_1"], suite.testApplications["application_1"], targetStatus.ID)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.Equal("Forbidden: you can't boost this status: only its author can boost it", errWithCode.Safe())
+
+	// Liking is still fine.
+	_, errWithCode = suite.status.FaveCreate(ctx, suite.testAccounts["local_account_1"], targetStatus.ID)
+	suite.NoError(errWithCode)
+}
+
+func (suite *StatusInteractionPolicyTestSuite) TestInteractionPolicySetNotOwnStatus() {
+	ctx := context.Background()
+
+	selfOnly := "self_only"
+	_, errWithCode := suite.status.InteractionPolicySet(ctx, suite.testAccounts["local_account_1"], suite.testStatuses["admin_account_status_1"].ID, &selfOnly, nil, nil)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *StatusInteractionPolicyTestSuite) TestInteractionPolicySetInvalid() {
+	ctx := context.Background()
+
+	nobody := "nobody"
+	_, errWithCode := suite.status.InteractionPolicySet(ctx, suite.testAccounts["admin_account"], suite.testStatuses["admin_account_status_1"].ID, &nobody, nil, nil)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	_, errWithCode = suite.status.InteractionPolicySet(ctx, suite.testAccounts["admin_account"], suite.testStatuses["admin_account_status_1"].ID, nil, nil, nil)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestStatusInteractionPolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusInteractionPolicyTestSuite))
+}
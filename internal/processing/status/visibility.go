@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Audiences of a status, as explained by VisibilityGet.
+const (
+	audiencePublic    = "public"
+	audienceFollowers = "followers"
+	audienceMutuals   = "mutuals"
+	audienceMentioned = "mentioned"
+	audienceSelf      = "self"
+)
+
+// VisibilityGet explains who can see the given status and how it can be
+// shared, as well as why it is or is not visible to the requesting account:
+// which visibility rule applies, and any blocks or domain blocks in play.
+func (p *Processor) VisibilityGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.StatusVisibility, gtserror.WithCode) {
+	targetStatus, err := p.state.DB.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("VisibilityGet: status %s not found", targetStatusID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err = fmt.Errorf("VisibilityGet: db error fetching status %s: %w", targetStatusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	visibility := &apimodel.StatusVisibility{
+		Visibility:           p.tc.VisToAPIVis(ctx, targetStatus.Visibility),
+		LimitedToFollowersOf: []string{},
+		Mentioned:            targetStatus.MentionsAccount(requestingAccount.ID),
+	}
+
+	switch targetStatus.Visibility {
+	case gtsmodel.VisibilityPublic, gtsmodel.VisibilityUnlocked:
+		visibility.Audience = []string{audiencePublic, audienceFollowers, audienceMentioned, audienceSelf}
+	case gtsmodel.VisibilityFollowersOnly:
+		visibility.Audience = []string{audienceFollowers, audienceMentioned, audienceSelf}
+		visibility.LimitedToFollowersOf = append(visibility.LimitedToFollowersOf, targetStatus.AccountID)
+	case gtsmodel.VisibilityMutualsOnly:
+		// Only followers whom the author follows back.
+		visibility.Audience = []string{audienceMutuals, audienceMentioned, audienceSelf}
+		visibility.LimitedToFollowersOf = append(visibility.LimitedToFollowersOf, targetStatus.AccountID)
+	default:
+		visibility.Audience = []string{audienceMentioned, audienceSelf}
+	}
+
+	// Follow the same rules as the boostable filter and interaction
+	// policy do for anyone other than the author, who can always boost.
+	visibility.CanBoost = (targetStatus.Visibility == gtsmodel.VisibilityPublic || targetStatus.Visibility == gtsmodel.VisibilityUnlocked) &&
+		(targetStatus.Boostable == nil || *targetStatus.Boostable) &&
+		targetStatus.InteractionPolicy.Normalize().CanBoost != gtsmodel.InteractionPolicySelfOnly
+
+	// The filter has the final say on visibility;
+	// the rest explains its decision.
+	visibility.Visible, err = p.filter.StatusVisible(ctx, requestingAccount, targetStatus)
+	if err != nil {
+		err = fmt.Errorf("VisibilityGet: error checking status %s visibility: %w", targetStatus.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	visibility.BlockedByAuthor, err = p.state.DB.IsBlocked(ctx, targetStatus.AccountID, requestingAccount.ID)
+	if err != nil {
+		err = fmt.Errorf("VisibilityGet: error checking block %s->%s: %w", targetStatus.AccountID, requestingAccount.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	visibility.BlockingAuthor, err = p.state.DB.IsBlocked(ctx, requestingAccount.ID, targetStatus.AccountID)
+	if err != nil {
+		err = fmt.Errorf("VisibilityGet: error checking block %s->%s: %w", requestingAccount.ID, targetStatus.AccountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if targetStatus.Account != nil && targetStatus.Account.Domain != "" {
+		visibility.AuthorDomainBlocked, err = p.state.DB.IsDomainBlocked(ctx, targetStatus.Account.Domain)
+		if err != nil {
+			err = fmt.Errorf("VisibilityGet: error checking domain block for %s: %w", targetStatus.Account.Domain, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	visibility.Rule, err = p.visibilityRule(ctx, requestingAccount, targetStatus, visibility)
+	if err != nil {
+		err = fmt.Errorf("VisibilityGet: error determining visibility rule for status %s: %w", targetStatus.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return visibility, nil
+}
+
+// visibilityRule determines which rule decided the visibility of status
+// to requester, following the same order of checks as the visibility filter.
+func (p *Processor) visibilityRule(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, visibility *apimodel.StatusVisibility) (string, error) {
+	if visibility.BlockedByAuthor || visibility.BlockingAuthor || visibility.AuthorDomainBlocked {
+		return "account_not_visible", nil
+	}
+
+	if !visibility.Visible {
+		// Not visible for a reason other than the
+		// status visibility itself, eg., a suspended
+		// author, or a block involving the boosted author.
+		switch status.Visibility {
+		case gtsmodel.VisibilityPublic, gtsmodel.VisibilityUnlocked:
+			return "account_not_visible", nil
+		}
+	}
+
+	switch {
+	case status.Visibility == gtsmodel.VisibilityPublic:
+		return "public", nil
+	case status.Visibility == gtsmodel.VisibilityUnlocked:
+		return "unlisted", nil
+	case requester.ID == status.AccountID:
+		return "author", nil
+	case visibility.Mentioned:
+		return "mentioned", nil
+	}
+
+	if status.BoostOf != nil {
+		if !status.BoostOf.MentionsPopulated() {
+			// Boosted status needs its mentions populating, fetch these from database.
+			mentions, err := p.state.DB.GetMentions(ctx, status.BoostOf.MentionIDs)
+			if err != nil {
+				return "", err
+			}
+			status.BoostOf.Mentions = mentions
+		}
+
+		if status.BoostOf.MentionsAccount(requester.ID) {
+			return "boost_mentioned", nil
+		}
+	}
+
+	switch status.Visibility {
+	case gtsmodel.VisibilityFollowersOnly:
+		follows, err := p.state.DB.IsFollowing(ctx, requester.ID, status.AccountID)
+		if err != nil {
+			return "", err
+		}
+		if follows {
+			return "follower", nil
+		}
+		return "not_follower", nil
+
+	case gtsmodel.VisibilityMutualsOnly:
+		mutuals, err := p.state.DB.IsMutualFollowing(ctx, requester.ID, status.AccountID)
+		if err != nil {
+			return "", err
+		}
+		if mutuals {
+			return "mutual", nil
+		}
+		return "not_mutual", nil
+	}
+
+	return "direct", nil
+}
@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusVisibilityTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusVisibilityTestSuite) TestVisibilityPublic() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	visibility, errWithCode := suite.status.VisibilityGet(ctx, requestingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal([]string{"public", "followers", "mentioned", "self"}, visibility.Audience)
+	suite.True(visibility.CanBoost)
+	suite.False(visibility.CanQuote)
+	suite.Nil(visibility.ExpiresAt)
+	suite.Empty(visibility.LimitedToFollowersOf)
+	suite.True(visibility.Visible)
+	suite.Equal("public", visibility.Rule)
+}
+
+func (suite *StatusVisibilityTestSuite) TestVisibilityFollowersOnly() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_5"]
+
+	visibility, errWithCode := suite.status.VisibilityGet(ctx, requestingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal([]string{"followers", "mentioned", "self"}, visibility.Audience)
+	suite.False(visibility.CanBoost)
+	suite.Equal([]string{requestingAccount.ID}, visibility.LimitedToFollowersOf)
+}
+
+func (suite *StatusVisibilityTestSuite) TestVisibilityMutualsOnly() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_3"]
+
+	visibility, errWithCode := suite.status.VisibilityGet(ctx, requestingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal([]string{"mutuals", "mentioned", "self"}, visibility.Audience)
+	suite.False(visibility.CanBoost)
+	suite.Equal([]string{requestingAccount.ID}, visibility.LimitedToFollowersOf)
+}
+
+func (suite *StatusVisibilityTestSuite) TestVisibilityFollower() {
+	ctx := context.Background()
+
+	// admin follows local_account_1, so can see their followers-only status.
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetStatus := suite.testStatuses["local_account_1_status_5"]
+
+	visibility, errWithCode := suite.status.VisibilityGet(ctx, requestingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal([]string{"followers", "mentioned", "self"}, visibility.Audience)
+	suite.True(visibility.Visible)
+	suite.Equal("follower", visibility.Rule)
+	suite.False(visibility.BlockedByAuthor)
+	suite.False(visibility.BlockingAuthor)
+	suite.False(visibility.AuthorDomainBlocked)
+}
+
+func (suite *StatusVisibilityTestSuite) TestVisibilityNotFollower() {
+	ctx := context.Background()
+
+	// admin doesn't follow local_account_2, so can't see their followers-only status.
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetStatus := suite.testStatuses["local_account_2_status_7"]
+
+	visibility, errWithCode := suite.status.VisibilityGet(ctx, requestingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.False(visibility.Visible)
+	suite.Equal("not_follower", visibility.Rule)
+	suite.False(visibility.Mentioned)
+}
+
+func (suite *StatusVisibilityTestSuite) TestVisibilityNotFound() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	visibility, errWithCode := suite.status.VisibilityGet(ctx, requestingAccount, "01H2M1ZQ0A7XJ1N3W6Y9Q2R4TB")
+	suite.Nil(visibility)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestStatusVisibilityTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusVisibilityTestSuite))
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -34,6 +35,10 @@ import (
 
 var oneWeek = 168 * time.Hour
 
+// confirmResendInterval is the minimum time between
+// confirmation emails being sent to one address.
+var confirmResendInterval = 5 * time.Minute
+
 // EmailSendConfirmation sends an email address confirmation request email to the given user.
 func (p *Processor) EmailSendConfirmation(ctx context.Context, user *gtsmodel.User, username string) error {
 	if user.UnconfirmedEmail == "" || user.UnconfirmedEmail == user.Email {
@@ -84,6 +89,60 @@ func (p *Processor) EmailSendConfirmation(ctx context.Context, user *gtsmodel.Us
 	return nil
 }
 
+// EmailResendConfirmation resends an email address confirmation request email to the
+// user with the given unconfirmed email address, with a newly generated token.
+//
+// To avoid leaking which email addresses are known to this instance, no error is returned
+// if there's no unconfirmed user with the given address, and resends are rate limited per
+// address regardless of whether it's known. A resend for a known address will also quietly
+// do nothing if a confirmation email was sent to it within the last few minutes.
+func (p *Processor) EmailResendConfirmation(ctx context.Context, emailAddress string) gtserror.WithCode {
+	resendKey := strings.ToLower(emailAddress)
+	if p.confirmResends.Has(resendKey) {
+		err := fmt.Errorf("EmailResendConfirmation: confirmation resend already requested for %s within the last %s", emailAddress, confirmResendInterval)
+		return gtserror.NewErrorTooManyRequests(err, "please wait a few minutes before requesting another confirmation email")
+	}
+	p.confirmResends.Set(resendKey, struct{}{})
+
+	user := &gtsmodel.User{}
+	if err := p.state.DB.GetWhere(ctx, []db.Where{{Key: "unconfirmed_email", Value: emailAddress}}, user); err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// No-one is waiting to confirm this address.
+			return nil
+		}
+		err = fmt.Errorf("EmailResendConfirmation: db error getting user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if user.UnconfirmedEmail == user.Email {
+		// Already confirmed.
+		return nil
+	}
+
+	if time.Since(user.ConfirmationSentAt) < confirmResendInterval {
+		// We only just sent them one.
+		return nil
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, user.AccountID)
+	if err != nil {
+		err = fmt.Errorf("EmailResendConfirmation: db error getting account for user %s: %w", user.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if !account.SuspendedAt.IsZero() {
+		// Don't send anything to suspended accounts.
+		return nil
+	}
+
+	if err := p.EmailSendConfirmation(ctx, user, account.Username); err != nil {
+		err = fmt.Errorf("EmailResendConfirmation: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
 // EmailConfirm processes an email confirmation request, usually initiated as a result of clicking on a link
 // in a 'confirm your email address' type email.
 func (p *Processor) EmailConfirm(ctx context.Context, token string) (*gtsmodel.User, gtserror.WithCode) {
@@ -20,10 +20,13 @@ package user_test
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type EmailConfirmTestSuite struct {
@@ -110,6 +113,71 @@ func (suite *EmailConfirmTestSuite) TestConfirmEmailOldToken() {
 	suite.EqualError(errWithCode, "ConfirmEmail: confirmation token expired")
 }
 
+func (suite *EmailConfirmTestSuite) setUnconfirmed(ctx context.Context, user *gtsmodel.User, sentAt time.Time) {
+	// set a bunch of stuff on the user as though zork hasn't been confirmed yet
+	updatingColumns := []string{"unconfirmed_email", "email", "confirmed_at", "confirmation_sent_at", "confirmation_token"}
+	user.UnconfirmedEmail = "some.email@example.org"
+	user.Email = ""
+	user.ConfirmedAt = time.Time{}
+	user.ConfirmationSentAt = sentAt
+	user.ConfirmationToken = "1d1aa44b-afa4-49c8-ac4b-eceb61715cc6"
+
+	if err := suite.db.UpdateByID(ctx, user, user.ID, updatingColumns...); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *EmailConfirmTestSuite) TestResendConfirmation() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+	suite.setUnconfirmed(ctx, user, time.Now().Add(-10*time.Minute))
+
+	errWithCode := suite.user.EmailResendConfirmation(ctx, "some.email@example.org")
+	suite.NoError(errWithCode)
+
+	// a new email should have been sent, with a new token
+	suite.Len(suite.sentEmails, 1)
+	updatedUser := &gtsmodel.User{}
+	if err := suite.db.GetWhere(ctx, []db.Where{{Key: "id", Value: user.ID}}, updatedUser); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEqual("1d1aa44b-afa4-49c8-ac4b-eceb61715cc6", updatedUser.ConfirmationToken)
+	suite.Contains(suite.sentEmails["some.email@example.org"], updatedUser.ConfirmationToken)
+	suite.WithinDuration(time.Now(), updatedUser.ConfirmationSentAt, 1*time.Minute)
+
+	// asking again straight away should be rate limited
+	errWithCode = suite.user.EmailResendConfirmation(ctx, "Some.Email@example.org")
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+	suite.Len(suite.sentEmails, 1)
+}
+
+func (suite *EmailConfirmTestSuite) TestResendConfirmationRecentlySent() {
+	ctx := context.Background()
+
+	user := suite.testUsers["local_account_1"]
+	suite.setUnconfirmed(ctx, user, time.Now().Add(-1*time.Minute))
+
+	// too soon after the last email, so nothing should be sent,
+	// but the caller shouldn't be told that the address exists
+	errWithCode := suite.user.EmailResendConfirmation(ctx, "some.email@example.org")
+	suite.NoError(errWithCode)
+	suite.Empty(suite.sentEmails)
+}
+
+func (suite *EmailConfirmTestSuite) TestResendConfirmationUnknownEmail() {
+	ctx := context.Background()
+
+	// no-one has this address, but the caller shouldn't be able to tell
+	errWithCode := suite.user.EmailResendConfirmation(ctx, "nobody@example.org")
+	suite.NoError(errWithCode)
+	suite.Empty(suite.sentEmails)
+
+	// and it's rate limited just the same as a known address
+	errWithCode = suite.user.EmailResendConfirmation(ctx, "nobody@example.org")
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+}
+
 func TestEmailConfirmTestSuite(t *testing.T) {
 	suite.Run(t, &EmailConfirmTestSuite{})
 }
@@ -18,6 +18,9 @@
 package user
 
 import (
+	"time"
+
+	"codeberg.org/gruf/go-cache/v3/ttl"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
@@ -25,12 +28,21 @@ import (
 type Processor struct {
 	state       *state.State
 	emailSender email.Sender
+
+	// confirmResends holds the email addresses
+	// which a confirmation email resend has been
+	// requested for recently, known or not.
+	confirmResends *ttl.Cache[string, struct{}]
 }
 
 // New returns a new user processor
 func New(state *state.State, emailSender email.Sender) Processor {
+	confirmResends := ttl.New[string, struct{}](0, 1000, confirmResendInterval)
+	confirmResends.Start(time.Minute)
+
 	return Processor{
-		state:       state,
-		emailSender: emailSender,
+		state:          state,
+		emailSender:    emailSender,
+		confirmResends: confirmResends,
 	}
 }
@@ -100,6 +100,16 @@ func (d *Driver) WalkKeys(ctx context.Context, walk func(context.Context, string
 	})
 }
 
+// WalkEntries is like WalkKeys, but also passes the size in bytes
+// of each entry to walk. A size < 0 means the size is unknown.
+func (d *Driver) WalkEntries(ctx context.Context, walk func(ctx context.Context, key string, size int64) error) error {
+	return d.Storage.WalkKeys(ctx, storage.WalkKeysOptions{
+		WalkFn: func(ctx context.Context, entry storage.Entry) error {
+			return walk(ctx, entry.Key, entry.Size)
+		},
+	})
+}
+
 // Close will close the storage, releasing any file locks.
 func (d *Driver) Close() error {
 	return d.Storage.Close()
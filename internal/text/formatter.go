@@ -56,4 +56,9 @@ type FormatResult struct {
 	Mentions []*gtsmodel.Mention
 	Tags     []*gtsmodel.Tag
 	Emojis   []*gtsmodel.Emoji
+
+	// UnusableTags are hashtags found in the text
+	// which an admin has made unusable. These are
+	// left unlinked, and not included in Tags.
+	UnusableTags []*gtsmodel.Tag
 }
@@ -67,3 +67,35 @@ func (f *formatter) FromMarkdown(ctx context.Context, pmf gtsmodel.ParseMentionF
 
 	return result
 }
+
+// MarkdownToHTML parses an HTML text from a markdown-formatted text,
+// without any of the mention, hashtag or emoji handling of statuses,
+// for eg., instance documents written by an admin.
+func MarkdownToHTML(ctx context.Context, markdownText string) string {
+	md := goldmark.New(
+		goldmark.WithRendererOptions(
+			html.WithXHTML(),
+			html.WithUnsafe(), // allows raw HTML
+		),
+		goldmark.WithExtensions(
+			extension.Linkify, // turns URLs into links
+			extension.Strikethrough,
+		),
+	)
+
+	var htmlContentBytes bytes.Buffer
+	if err := md.Convert([]byte(markdownText), &htmlContentBytes); err != nil {
+		log.Errorf(ctx, "error formatting markdown to HTML: %s", err)
+	}
+
+	// clean anything dangerous out of the HTML
+	htmlContent := SanitizeHTML(htmlContentBytes.String())
+
+	// shrink ray
+	htmlContent, err := m.String("text/html", htmlContent)
+	if err != nil {
+		log.Errorf(ctx, "error minifying HTML: %s", err)
+	}
+
+	return htmlContent
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"golang.org/x/text/unicode/norm"
@@ -107,7 +108,14 @@ func (r *customRenderer) replaceHashtag(text string) string {
 	}
 
 	tag, err := r.f.db.TagStringToTag(r.ctx, normalized, r.accountID)
-	if err != nil {
+	if errors.Is(err, db.ErrTagNotUseable) {
+		// Leave the tag unlinked, and let the
+		// caller decide what to do about it.
+		r.result.UnusableTags = append(r.result.UnusableTags, &gtsmodel.Tag{
+			Name: util.NormalizeHashtag(normalized),
+		})
+		return text
+	} else if err != nil {
 		log.Errorf(r.ctx, "error generating hashtags from status: %s", err)
 		return text
 	}
@@ -121,7 +129,7 @@ func (r *customRenderer) replaceHashtag(text string) string {
 		}
 	}
 	if !listed {
-		err = r.f.db.Put(r.ctx, tag)
+		err = r.f.db.PutTag(r.ctx, tag)
 		if err != nil {
 			if !errors.Is(err, db.ErrAlreadyExists) {
 				log.Errorf(r.ctx, "error putting tags in db: %s", err)
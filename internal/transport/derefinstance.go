@@ -228,6 +228,20 @@ func dereferenceByNodeInfo(c context.Context, t *transport, iri *url.URL) (*gtsm
 	return i, nil
 }
 
+func (t *transport) DereferenceNodeInfo(ctx context.Context, iri *url.URL) (*apimodel.Nodeinfo, error) {
+	niIRI, err := callNodeInfoWellKnown(ctx, t, iri)
+	if err != nil {
+		return nil, fmt.Errorf("DereferenceNodeInfo: error during initial call to well-known nodeinfo: %w", err)
+	}
+
+	ni, err := callNodeInfo(ctx, t, niIRI)
+	if err != nil {
+		return nil, fmt.Errorf("DereferenceNodeInfo: error doing second call to nodeinfo uri %s: %w", niIRI.String(), err)
+	}
+
+	return ni, nil
+}
+
 func callNodeInfoWellKnown(ctx context.Context, t *transport, iri *url.URL) (*url.URL, error) {
 	cleanIRI := &url.URL{
 		Scheme: iri.Scheme,
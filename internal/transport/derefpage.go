@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package transport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// maxPageSize is the maximum amount of bytes
+// of a web page that DereferencePage will read.
+const maxPageSize = 1 << 20 // 1MiB
+
+func (t *transport) DereferencePage(ctx context.Context, iri *url.URL) ([]byte, error) {
+	// Build IRI just once
+	iriStr := iri.String()
+
+	// Prepare HTTP request to this page's IRI
+	req, err := http.NewRequestWithContext(ctx, "GET", iriStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("Host", iri.Host)
+
+	// Perform the HTTP request
+	rsp, err := t.GET(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	// Check for an expected status code
+	if rsp.StatusCode != http.StatusOK {
+		return nil, gtserror.NewFromResponse(rsp)
+	}
+
+	// Only web pages have metadata to read.
+	contentType, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+	if contentType != "text/html" && contentType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("DereferencePage: unexpected content type %q for %s", contentType, iriStr)
+	}
+
+	// We only need the head of the page
+	// for metadata, so don't read huge
+	// bodies through to the end.
+	return io.ReadAll(io.LimitReader(rsp.Body, maxPageSize))
+}
@@ -24,12 +24,65 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
+	"codeberg.org/gruf/go-cache/v3/ttl"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 )
 
+// fingerTimeout is the maximum time to wait for a remote
+// webfinger response that isn't freshly cached.
+const fingerTimeout = 10 * time.Second
+
+// fetchCached returns the response cached in c under key, if it's still fresh,
+// in which case fresh is true. Otherwise, fetch is called with a timeout of fingerTimeout, and its result is
+// cached on success. If fetch fails with a transport error, a 5xx or a timeout,
+// any stale response still cached under key is returned instead, so a slow or
+// broken remote doesn't hold up dereferencing. If the remote answers 404 or 410
+// the resource is gone, so any cached response is evicted rather than served.
+func fetchCached(ctx context.Context, c *ttl.Cache[string, *cache.Response], key string, fetch func(context.Context) ([]byte, error)) (b []byte, fresh bool, err error) {
+	// Use Cache.Get instead of Get, as the
+	// latter updates the item expiry which
+	// we only want to do on a new fetch.
+	c.Lock()
+	item, cached := c.Cache.Get(key)
+	c.Unlock()
+
+	if cached && item.Value.Fresh(config.GetCacheGTSWebfingerResponseTTL()) {
+		return item.Value.Body, true, nil
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, fingerTimeout)
+	defer cancel()
+
+	b, err = fetch(fetchCtx)
+	if err != nil {
+		switch code := gtserror.StatusCode(err); {
+		case code == http.StatusNotFound || code == http.StatusGone:
+			// Remote told us this resource doesn't
+			// exist (anymore), drop what we have.
+			c.Invalidate(key)
+		case cached && (code == 0 || code >= 500):
+			// Fall back to stale.
+			log.Debugf(ctx, "serving stale %s after fetch error: %v", key, err)
+			return item.Value.Body, false, nil
+		}
+		return nil, false, err
+	}
+
+	c.Set(key, &cache.Response{
+		Body:      b,
+		FetchedAt: time.Now(),
+	})
+
+	return b, false, nil
+}
+
 // webfingerURLFor returns the URL to try a webfinger request against, as
 // well as if the URL was retrieved from cache. When the URL is retrieved
 // from cache we don't have to try and do host-meta discovery
@@ -73,6 +126,20 @@ func prepWebfingerReq(ctx context.Context, loc, domain, username string) (*http.
 }
 
 func (t *transport) Finger(ctx context.Context, targetUsername string, targetDomain string) ([]byte, error) {
+	key := targetDomain + " acct:" + targetUsername + "@" + targetDomain
+	b, fresh, err := fetchCached(ctx, t.controller.state.Caches.GTS.WebfingerResponse(), key, func(ctx context.Context) ([]byte, error) {
+		return t.finger(ctx, targetUsername, targetDomain)
+	})
+	if fresh {
+		// A fresh cached response still counts as a successful
+		// finger, so renew the TTL of any cached webfinger URL
+		// for this domain the same way finger() would have.
+		t.controller.state.Caches.GTS.Webfinger().Get(targetDomain)
+	}
+	return b, err
+}
+
+func (t *transport) finger(ctx context.Context, targetUsername string, targetDomain string) ([]byte, error) {
 	// Generate new GET request
 	url, cached := t.webfingerURLFor(targetDomain)
 	req, err := prepWebfingerReq(ctx, url, targetDomain, targetUsername)
@@ -98,7 +165,8 @@ func (t *transport) Finger(ctx context.Context, targetUsername string, targetDom
 			t.controller.state.Caches.GTS.Webfinger().Set(targetDomain, url)
 		}
 		if rsp.StatusCode == http.StatusGone {
-			return nil, fmt.Errorf("account has been deleted/is gone")
+			err := fmt.Errorf("account has been deleted/is gone")
+			return nil, gtserror.WithStatusCode(err, http.StatusGone)
 		}
 		return io.ReadAll(rsp.Body)
 	}
@@ -120,16 +188,21 @@ func (t *transport) Finger(ctx context.Context, targetUsername string, targetDom
 	// So far we've failed to get a successful response from the expected
 	// webfinger endpoint. Lets try and discover the webfinger endpoint
 	// through /.well-known/host-meta
+	//
+	// If that doesn't get us anywhere, the original response status is
+	// kept on the returned error so callers can tell e.g. a 404 apart.
 	host, err := t.webfingerFromHostMeta(ctx, targetDomain)
 	if err != nil {
-		return nil, fmt.Errorf("failed to discover webfinger URL fallback for: %s through host-meta: %w", targetDomain, err)
+		err := fmt.Errorf("failed to discover webfinger URL fallback for: %s through host-meta: %w", targetDomain, err)
+		return nil, gtserror.WithStatusCode(err, rsp.StatusCode)
 	}
 
 	// Check if the original and host-meta URL are the same. If they
 	// are there's no sense in us trying the request again as it just
 	// failed
 	if host == url {
-		return nil, fmt.Errorf("webfinger discovery on %s returned endpoint we already tried: %s", targetDomain, host)
+		err := fmt.Errorf("webfinger discovery on %s returned endpoint we already tried: %s", targetDomain, host)
+		return nil, gtserror.WithStatusCode(err, rsp.StatusCode)
 	}
 
 	// Now that we have a different URL for the webfinger
@@ -152,7 +225,8 @@ func (t *transport) Finger(ctx context.Context, targetUsername string, targetDom
 		// cache it for future queries to the same domain
 		if rsp.StatusCode == http.StatusGone {
 			t.controller.state.Caches.GTS.Webfinger().Set(targetDomain, host)
-			return nil, fmt.Errorf("account has been deleted/is gone")
+			err := fmt.Errorf("account has been deleted/is gone")
+			return nil, gtserror.WithStatusCode(err, http.StatusGone)
 		}
 		// We've reached the end of the line here, both the original request
 		// and our attempt to resolve it through the fallback have failed
@@ -19,9 +19,16 @@ package transport_test
 
 import (
 	"context"
+	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type FingerTestSuite struct {
@@ -115,6 +122,86 @@ func (suite *FingerTestSuite) TestFingerWithHostMetaCacheStrategy() {
 	suite.Equal(repeatTime, lastTime)
 }
 
+func (suite *FingerTestSuite) TestFingerCachesResponse() {
+	rc := suite.state.Caches.GTS.WebfingerResponse()
+	suite.Equal(0, rc.Len(), "expect webfinger response cache to be empty")
+
+	first, err := suite.transport.Finger(context.TODO(), "brand_new_person", "unknown-instance.com")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(1, rc.Len(), "expect webfinger response cache to hold one entry")
+	suite.True(rc.Has("unknown-instance.com acct:brand_new_person@unknown-instance.com"))
+
+	// The second finger should be served from cache.
+	second, err := suite.transport.Finger(context.TODO(), "brand_new_person", "unknown-instance.com")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(first, second)
+	suite.Equal(1, rc.Len(), "expect webfinger response cache to hold one entry")
+}
+
+func (suite *FingerTestSuite) TestFingerStaleOnError() {
+	const key = "unknown-instance.com acct:brand_new_person@unknown-instance.com"
+	rc := suite.state.Caches.GTS.WebfingerResponse()
+
+	// Use a transport that can't reach the remote at all.
+	tc := testrig.NewTestTransportController(&suite.state, testrig.NewMockHTTPClient(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}, "../../testrig/media"))
+	tp, err := tc.NewTransportForUsername(context.TODO(), "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// With nothing cached the finger should just fail.
+	_, err = tp.Finger(context.TODO(), "brand_new_person", "unknown-instance.com")
+	suite.Error(err)
+	suite.False(rc.Has(key))
+
+	// Cache a response that's no longer fresh.
+	stale := &cache.Response{
+		Body:      []byte(`{"subject":"acct:brand_new_person@unknown-instance.com"}`),
+		FetchedAt: time.Now().Add(-config.GetCacheGTSWebfingerResponseTTL() - time.Minute),
+	}
+	rc.Set(key, stale)
+
+	// The fetch still fails, so the
+	// stale response is served instead.
+	b, err := tp.Finger(context.TODO(), "brand_new_person", "unknown-instance.com")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(stale.Body, b)
+
+	// And it's left as it was.
+	cached, ok := rc.Get(key)
+	if suite.True(ok) {
+		suite.Equal(stale.FetchedAt, cached.FetchedAt)
+	}
+}
+
+func (suite *FingerTestSuite) TestFingerEvictsOnNotFound() {
+	const key = "unknown-instance.com acct:nobody@unknown-instance.com"
+	rc := suite.state.Caches.GTS.WebfingerResponse()
+
+	// Cache a response that's no longer fresh.
+	rc.Set(key, &cache.Response{
+		Body:      []byte(`{"subject":"acct:nobody@unknown-instance.com"}`),
+		FetchedAt: time.Now().Add(-config.GetCacheGTSWebfingerResponseTTL() - time.Minute),
+	})
+
+	// The remote answers 404 for this account, so the
+	// finger fails and the stale response is evicted.
+	_, err := suite.transport.Finger(context.TODO(), "nobody", "unknown-instance.com")
+	suite.Error(err)
+	suite.Equal(http.StatusNotFound, gtserror.StatusCode(err))
+	suite.False(rc.Has(key))
+}
+
 func TestFingerTestSuite(t *testing.T) {
 	suite.Run(t, &FingerTestSuite{})
 }
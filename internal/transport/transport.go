@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/go-fed/httpsig"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
@@ -62,10 +63,18 @@ type Transport interface {
 	// DereferenceMedia fetches the given media attachment IRI, returning the reader and filesize.
 	DereferenceMedia(ctx context.Context, iri *url.URL) (io.ReadCloser, int64, error)
 
+	// DereferencePage fetches the web page located at this IRI with a GET request, returning at most the first 1MiB of it.
+	// Responses that aren't HTML are rejected.
+	DereferencePage(ctx context.Context, iri *url.URL) ([]byte, error)
+
 	// DereferenceInstance dereferences remote instance information, first by checking /api/v1/instance, and then by checking /.well-known/nodeinfo.
 	DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error)
 
+	// DereferenceNodeInfo fetches the nodeinfo document of the instance at the given IRI, by following the link at /.well-known/nodeinfo.
+	DereferenceNodeInfo(ctx context.Context, iri *url.URL) (*apimodel.Nodeinfo, error)
+
 	// Finger performs a webfinger request with the given username and domain, and returns the bytes from the response body.
+	// Responses are cached for a short while, and a stale cached response is returned if the remote fails or is too slow to answer.
 	Finger(ctx context.Context, targetUsername string, targetDomain string) ([]byte, error)
 }
 
@@ -365,6 +365,11 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	sensitive := ap.ExtractSensitive(statusable)
 	status.Sensitive = &sensitive
 
+	// interaction policy, if the status has one
+	if withUnknown, ok := statusable.(ap.WithUnknownProperties); ok {
+		status.InteractionPolicy = ap.ExtractInteractionPolicy(withUnknown)
+	}
+
 	// language
 	// we might be able to extract this from the contentMap field
 
@@ -52,6 +52,9 @@ type TypeConverter interface {
 	// something goes wrong. The returned account will be a bare minimum representation of the account. This function should be used
 	// when someone wants to view an account they've blocked.
 	AccountToAPIAccountBlocked(ctx context.Context, account *gtsmodel.Account) (*apimodel.Account, error)
+	// AccountToAdminAPIAccount converts a gts model account into an admin view account, including
+	// sensitive user information such as email address and sign-up reason, for serving to admins only.
+	AccountToAdminAPIAccount(ctx context.Context, account *gtsmodel.Account) (*apimodel.AdminAccountInfo, error)
 	// AppToAPIAppSensitive takes a db model application as a param, and returns a populated apitype application, or an error
 	// if something goes wrong. The returned application should be ready to serialize on an API level, and may have sensitive fields
 	// (such as client id and client secret), so serve it only to an authorized user who should have permission to see it.
@@ -64,6 +67,9 @@ type TypeConverter interface {
 	AttachmentToAPIAttachment(ctx context.Context, attachment *gtsmodel.MediaAttachment) (apimodel.Attachment, error)
 	// MentionToAPIMention converts a gts model mention into its api (frontend) representation for serialization on the API.
 	MentionToAPIMention(ctx context.Context, m *gtsmodel.Mention) (apimodel.Mention, error)
+	// MentionsToAPIMentions converts several gts model mentions into their api (frontend) representations,
+	// loading any target accounts that aren't populated yet in one go, rather than one by one.
+	MentionsToAPIMentions(ctx context.Context, mentions []*gtsmodel.Mention) ([]apimodel.Mention, error)
 	// EmojiToAPIEmoji converts a gts model emoji into its api (frontend) representation for serialization on the API.
 	EmojiToAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (apimodel.Emoji, error)
 	// EmojiToAdminAPIEmoji converts a gts model emoji into an API representation with extra admin information.
@@ -72,16 +78,22 @@ type TypeConverter interface {
 	EmojiCategoryToAPIEmojiCategory(ctx context.Context, category *gtsmodel.EmojiCategory) (*apimodel.EmojiCategory, error)
 	// TagToAPITag converts a gts model tag into its api (frontend) representation for serialization on the API.
 	TagToAPITag(ctx context.Context, t *gtsmodel.Tag) (apimodel.Tag, error)
+	// TagToAPITagWithHistory is like TagToAPITag, but also includes the last week of daily usage history of the tag.
+	TagToAPITagWithHistory(ctx context.Context, t *gtsmodel.Tag) (apimodel.Tag, error)
 	// StatusToAPIStatus converts a gts model status into its api (frontend) representation for serialization on the API.
 	//
 	// Requesting account can be nil.
 	StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*apimodel.Status, error)
+	// StatusRevisionToAPIStatusEdit converts one version of a status, authored by the given account, into its api representation as part of the status' edit history.
+	StatusRevisionToAPIStatusEdit(ctx context.Context, r *gtsmodel.StatusRevision, author *gtsmodel.Account) (*apimodel.StatusEdit, error)
 	// VisToAPIVis converts a gts visibility into its api equivalent
 	VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) apimodel.Visibility
 	// InstanceToAPIV1Instance converts a gts instance into its api equivalent for serving at /api/v1/instance
 	InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV1, error)
 	// InstanceToAPIV2Instance converts a gts instance into its api equivalent for serving at /api/v2/instance
 	InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV2, error)
+	// InstanceConfigurationToAPIInstanceConfiguration returns the limits of this instance, for serving at /api/v1/instance/configuration
+	InstanceConfigurationToAPIInstanceConfiguration(ctx context.Context) (*apimodel.InstanceConfiguration, error)
 	// RelationshipToAPIRelationship converts a gts relationship into its api equivalent for serving in various places
 	RelationshipToAPIRelationship(ctx context.Context, r *gtsmodel.Relationship) (*apimodel.Relationship, error)
 	// NotificationToAPINotification converts a gts notification into a api notification
@@ -94,6 +106,8 @@ type TypeConverter interface {
 	ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Report, requestingAccount *gtsmodel.Account) (*apimodel.AdminReport, error)
 	// ListToAPIList converts one gts model list into an api model list, for serving at /api/v1/lists/{id}
 	ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimodel.List, error)
+	// PreviewCardToAPICard converts one gts model preview card into an api model card, for serving as a status card.
+	PreviewCardToAPICard(ctx context.Context, pc *gtsmodel.PreviewCard) (*apimodel.Card, error)
 
 	/*
 		INTERNAL (gts) MODEL TO FRONTEND (rss) MODEL
@@ -170,9 +184,9 @@ type TypeConverter interface {
 	StatusToASRepliesCollection(ctx context.Context, status *gtsmodel.Status, onlyOtherAccounts bool) (vocab.ActivityStreamsCollection, error)
 	// StatusURIsToASRepliesPage returns a collection page with appropriate next/part of pagination.
 	StatusURIsToASRepliesPage(ctx context.Context, status *gtsmodel.Status, onlyOtherAccounts bool, minID string, replies map[string]*url.URL) (vocab.ActivityStreamsCollectionPage, error)
-	// OutboxToASCollection returns an ordered collection with appropriate id, next, and last fields.
+	// OutboxToASCollection returns an ordered collection with appropriate id, first, and totalItems fields.
 	// The returned collection won't have any actual entries; just links to where entries can be obtained.
-	OutboxToASCollection(ctx context.Context, outboxID string) (vocab.ActivityStreamsOrderedCollection, error)
+	OutboxToASCollection(ctx context.Context, outboxID string, totalItems int) (vocab.ActivityStreamsOrderedCollection, error)
 	// StatusesToASOutboxPage returns an ordered collection page using the given statuses and parameters as contents.
 	//
 	// The maxID and minID should be the parameters that were passed to the database to obtain the given statuses.
@@ -185,6 +199,9 @@ type TypeConverter interface {
 	// StatusesToASFeaturedCollection converts a slice of statuses into an ordered collection
 	// of URIs, suitable for serializing and serving via the activitypub API.
 	StatusesToASFeaturedCollection(ctx context.Context, featuredCollectionID string, statuses []*gtsmodel.Status) (vocab.ActivityStreamsOrderedCollection, error)
+	// AccountsToASCollection converts a slice of accounts into an ordered collection
+	// of their URIs, suitable for serving as a followers or following collection.
+	AccountsToASCollection(ctx context.Context, collectionID string, accounts []*gtsmodel.Account) (vocab.ActivityStreamsOrderedCollection, error)
 	// ReportToASFlag converts a gts model report into an activitystreams FLAG, suitable for federation.
 	ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error)
 
@@ -209,6 +226,9 @@ type TypeConverter interface {
 	// but just the AP URI of the note. This is useful in cases where you want to give a remote server something to dereference,
 	// and still have control over whether or not they're allowed to actually see the contents.
 	WrapNoteInCreate(note vocab.ActivityStreamsNote, objectIRIOnly bool) (vocab.ActivityStreamsCreate, error)
+	// WrapNoteInUpdate wraps a Note with an Update activity from the given originAccount,
+	// addressed to the same recipients as the Note itself.
+	WrapNoteInUpdate(note vocab.ActivityStreamsNote, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error)
 }
 
 type converter struct {
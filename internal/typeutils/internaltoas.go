@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -621,6 +622,9 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 	sensitiveProp.AppendXMLSchemaBoolean(*s.Sensitive)
 	status.SetActivityStreamsSensitive(sensitiveProp)
 
+	// interaction policy
+	ap.SetInteractionPolicy(status, s.InteractionPolicy)
+
 	return status, nil
 }
 
@@ -1412,10 +1416,11 @@ we want something that looks like this:
 		"@context": "https://www.w3.org/ns/activitystreams",
 		"id": "https://example.org/users/whatever/outbox",
 		"type": "OrderedCollection",
-		"first": "https://example.org/users/whatever/outbox?page=true"
+		"first": "https://example.org/users/whatever/outbox?page=true",
+		"totalItems": 42
 	}
 */
-func (c *converter) OutboxToASCollection(ctx context.Context, outboxID string) (vocab.ActivityStreamsOrderedCollection, error) {
+func (c *converter) OutboxToASCollection(ctx context.Context, outboxID string, totalItems int) (vocab.ActivityStreamsOrderedCollection, error) {
 	collection := streams.NewActivityStreamsOrderedCollection()
 
 	collectionIDProp := streams.NewJSONLDIdProperty()
@@ -1435,6 +1440,10 @@ func (c *converter) OutboxToASCollection(ctx context.Context, outboxID string) (
 	collectionFirstProp.SetIRI(collectionFirstPropIDURI)
 	collection.SetActivityStreamsFirst(collectionFirstProp)
 
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(totalItems)
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
 	return collection, nil
 }
 
@@ -1466,6 +1475,34 @@ func (c *converter) StatusesToASFeaturedCollection(ctx context.Context, featured
 	return collection, nil
 }
 
+func (c *converter) AccountsToASCollection(ctx context.Context, collectionID string, accounts []*gtsmodel.Account) (vocab.ActivityStreamsOrderedCollection, error) {
+	collection := streams.NewActivityStreamsOrderedCollection()
+
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	collectionIDURI, err := url.Parse(collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url %s", collectionID)
+	}
+	collectionIDProp.SetIRI(collectionIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	itemsProp := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, a := range accounts {
+		uri, err := url.Parse(a.URI)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing url %s", a.URI)
+		}
+		itemsProp.AppendIRI(uri)
+	}
+	collection.SetActivityStreamsOrderedItems(itemsProp)
+
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(accounts))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	return collection, nil
+}
+
 func (c *converter) ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error) {
 	flag := streams.NewActivityStreamsFlag()
 
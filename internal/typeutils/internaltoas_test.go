@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -304,7 +305,7 @@ func (suite *InternalToASTestSuite) TestOutboxToASCollection() {
 	testAccount := suite.testAccounts["admin_account"]
 	ctx := context.Background()
 
-	collection, err := suite.typeconverter.OutboxToASCollection(ctx, testAccount.OutboxURI)
+	collection, err := suite.typeconverter.OutboxToASCollection(ctx, testAccount.OutboxURI, 4)
 	suite.NoError(err)
 
 	ser, err := ap.Serialize(collection)
@@ -317,6 +318,57 @@ func (suite *InternalToASTestSuite) TestOutboxToASCollection() {
   "@context": "https://www.w3.org/ns/activitystreams",
   "first": "http://localhost:8080/users/admin/outbox?page=true",
   "id": "http://localhost:8080/users/admin/outbox",
+  "totalItems": 4,
+  "type": "OrderedCollection"
+}`, string(bytes))
+}
+
+func (suite *InternalToASTestSuite) TestAccountsToASCollection() {
+	testAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	collection, err := suite.typeconverter.AccountsToASCollection(ctx, testAccount.FollowersURI, []*gtsmodel.Account{
+		suite.testAccounts["admin_account"],
+		suite.testAccounts["local_account_2"],
+	})
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(collection)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://localhost:8080/users/the_mighty_zork/followers",
+  "orderedItems": [
+    "http://localhost:8080/users/admin",
+    "http://localhost:8080/users/1happyturtle"
+  ],
+  "totalItems": 2,
+  "type": "OrderedCollection"
+}`, string(bytes))
+}
+
+func (suite *InternalToASTestSuite) TestAccountsToASCollectionEmpty() {
+	testAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	collection, err := suite.typeconverter.AccountsToASCollection(ctx, testAccount.FollowingURI, nil)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(collection)
+	suite.NoError(err)
+
+	bytes, err := json.MarshalIndent(ser, "", "  ")
+	suite.NoError(err)
+
+	suite.Equal(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "http://localhost:8080/users/the_mighty_zork/following",
+  "orderedItems": [],
+  "totalItems": 0,
   "type": "OrderedCollection"
 }`, string(bytes))
 }
@@ -480,6 +532,38 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASFromDB() {
 }`, trimmed)
 }
 
+func (suite *InternalToASTestSuite) TestStatusToASWithInteractionPolicy() {
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	testStatus.InteractionPolicy = gtsmodel.StatusInteractionPolicy{
+		CanReplyTo: gtsmodel.InteractionPolicyFollowersOnly,
+		CanBoost:   gtsmodel.InteractionPolicySelfOnly,
+	}
+	ctx := context.Background()
+
+	asStatus, err := suite.typeconverter.StatusToAS(ctx, testStatus)
+	suite.NoError(err)
+
+	ser, err := ap.Serialize(asStatus)
+	suite.NoError(err)
+
+	suite.Equal(map[string]interface{}{
+		"canReply":    "followers_only",
+		"canAnnounce": "self_only",
+		"canLike":     "everyone",
+	}, ser["interactionPolicy"])
+
+	// The policy should survive a round trip.
+	t, err := streams.ToType(ctx, ser)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	status, err := suite.typeconverter.ASStatusToStatus(ctx, t.(ap.Statusable))
+	suite.NoError(err)
+	suite.Equal(testStatus.InteractionPolicy, status.InteractionPolicy)
+}
+
 func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
 	testStatusID := suite.testStatuses["admin_account_status_3"].ID
 	ctx := context.Background()
@@ -28,6 +28,7 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -44,6 +45,7 @@ const (
 	instancePollsMaxExpiration                  = 2629746 // seconds
 	instanceAccountsMaxFeaturedTags             = 10
 	instanceSourceURL                           = "https://github.com/superseriousbusiness/gotosocial"
+	tagHistoryDays                              = 7 // days of usage history to include for tags
 )
 
 var instanceStatusesSupportedMimeTypes = []string{
@@ -81,6 +83,19 @@ func (c *converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		FollowRequestsCount: frc,
 	}
 
+	if a.IsLocal() {
+		// Let the user know if their account
+		// is pending deletion but not gone yet.
+		user, err := c.db.GetUserByAccountID(ctx, a.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, fmt.Errorf("AccountToAPIAccountSensitive: error getting user: %w", err)
+		}
+
+		if user != nil && !user.DeleteRequestedAt.IsZero() {
+			apiAccount.Source.DeletionRequestedAt = util.FormatISO8601(user.DeleteRequestedAt)
+		}
+	}
+
 	return apiAccount, nil
 }
 
@@ -504,6 +519,59 @@ func (c *converter) MentionToAPIMention(ctx context.Context, m *gtsmodel.Mention
 	}, nil
 }
 
+func (c *converter) MentionsToAPIMentions(ctx context.Context, mentions []*gtsmodel.Mention) ([]apimodel.Mention, error) {
+	var errs gtserror.MultiError
+
+	// Gather IDs of target
+	// accounts not yet populated.
+	targetIDs := make([]string, 0, len(mentions))
+	for _, mention := range mentions {
+		if mention.TargetAccount == nil {
+			targetIDs = append(targetIDs, mention.TargetAccountID)
+		}
+	}
+
+	if len(targetIDs) > 0 {
+		// Load all missing target accounts at once. We only
+		// need the barebones account to build the mention.
+		targets, err := c.db.GetAccountsByIDs(gtscontext.SetBarebones(ctx), targetIDs)
+		if err != nil {
+			errs.Appendf("error fetching mention target accounts from database: %v", err)
+		}
+
+		targetsByID := make(map[string]*gtsmodel.Account, len(targets))
+		for _, target := range targets {
+			targetsByID[target.ID] = target
+		}
+
+		for _, mention := range mentions {
+			if mention.TargetAccount == nil {
+				mention.TargetAccount = targetsByID[mention.TargetAccountID]
+			}
+		}
+	}
+
+	// Preallocate expected frontend slice
+	apiMentions := make([]apimodel.Mention, 0, len(mentions))
+
+	// Convert GTS models to frontend models
+	for _, mention := range mentions {
+		if mention.TargetAccount == nil {
+			errs.Appendf("target account %s of mention %s not found", mention.TargetAccountID, mention.ID)
+			continue
+		}
+
+		apiMention, err := c.MentionToAPIMention(ctx, mention)
+		if err != nil {
+			errs.Appendf("error converting mention %s to api mention: %v", mention.ID, err)
+			continue
+		}
+		apiMentions = append(apiMentions, apiMention)
+	}
+
+	return apiMentions, errs.Combine()
+}
+
 func (c *converter) EmojiToAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (apimodel.Emoji, error) {
 	var category string
 	if e.CategoryID != "" {
@@ -569,6 +637,29 @@ func (c *converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag) (apimodel.
 	}, nil
 }
 
+func (c *converter) TagToAPITagWithHistory(ctx context.Context, t *gtsmodel.Tag) (apimodel.Tag, error) {
+	apiTag, err := c.TagToAPITag(ctx, t)
+	if err != nil {
+		return apimodel.Tag{}, err
+	}
+
+	history, err := c.db.GetTagHistory(ctx, t.ID, tagHistoryDays)
+	if err != nil {
+		return apimodel.Tag{}, fmt.Errorf("TagToAPITagWithHistory: error getting history of tag %s: %w", t.ID, err)
+	}
+
+	apiTag.History = make([]apimodel.TagHistory, 0, len(history))
+	for _, day := range history {
+		apiTag.History = append(apiTag.History, apimodel.TagHistory{
+			Day:      strconv.FormatInt(day.Day.Unix(), 10),
+			Uses:     strconv.Itoa(day.Uses),
+			Accounts: strconv.Itoa(day.Accounts),
+		})
+	}
+
+	return apiTag, nil
+}
+
 func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*apimodel.Status, error) {
 	if err := c.db.PopulateStatus(ctx, s); err != nil {
 		// Ensure author account present + correct;
@@ -608,7 +699,7 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		interacts = &statusInteractions{}
 	}
 
-	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, s.Attachments, s.AttachmentIDs)
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, s.Attachments, s.ID, s.AttachmentIDs)
 	if err != nil {
 		log.Errorf(ctx, "error converting status attachments: %v", err)
 	}
@@ -740,20 +831,10 @@ func (c *converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 	}
 
 	// configuration
-	instance.Configuration.Statuses.MaxCharacters = config.GetStatusesMaxChars()
-	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
-	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
+	instance.Configuration.Statuses = instanceConfigurationStatuses()
 	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
-	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
-	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
-	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
-	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
-	instance.Configuration.MediaAttachments.VideoFrameRateLimit = instanceMediaAttachmentsVideoFrameRateLimit
-	instance.Configuration.MediaAttachments.VideoMatrixLimit = instanceMediaAttachmentsVideoMatrixLimit
-	instance.Configuration.Polls.MaxOptions = config.GetStatusesPollMaxOptions()
-	instance.Configuration.Polls.MaxCharactersPerOption = config.GetStatusesPollOptionMaxChars()
-	instance.Configuration.Polls.MinExpiration = instancePollsMinExpiration
-	instance.Configuration.Polls.MaxExpiration = instancePollsMaxExpiration
+	instance.Configuration.MediaAttachments = instanceConfigurationMediaAttachments()
+	instance.Configuration.Polls = instanceConfigurationPolls()
 	instance.Configuration.Accounts.AllowCustomCSS = config.GetAccountsAllowCustomCSS()
 	instance.Configuration.Accounts.MaxFeaturedTags = instanceAccountsMaxFeaturedTags
 	instance.Configuration.Emojis.EmojiSizeLimit = int(config.GetMediaEmojiLocalMaxSize())
@@ -832,9 +913,9 @@ func (c *converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 		Version:       config.GetSoftwareVersion(),
 		SourceURL:     instanceSourceURL,
 		Description:   i.Description,
-		Usage:         apimodel.InstanceV2Usage{}, // todo: not implemented
-		Languages:     []string{},                 // todo: not implemented
-		Rules:         []interface{}{},            // todo: not implemented
+		Usage:         apimodel.InstanceV2Usage{},  // todo: not implemented
+		Languages:     []string{},                  // todo: not implemented
+		Rules:         []apimodel.InstanceV2Rule{}, // todo: not implemented
 	}
 
 	// thumbnail
@@ -866,20 +947,10 @@ func (c *converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 
 	// configuration
 	instance.Configuration.URLs.Streaming = "wss://" + i.Domain
-	instance.Configuration.Statuses.MaxCharacters = config.GetStatusesMaxChars()
-	instance.Configuration.Statuses.MaxMediaAttachments = config.GetStatusesMediaMaxFiles()
-	instance.Configuration.Statuses.CharactersReservedPerURL = instanceStatusesCharactersReservedPerURL
+	instance.Configuration.Statuses = instanceConfigurationStatuses()
 	instance.Configuration.Statuses.SupportedMimeTypes = instanceStatusesSupportedMimeTypes
-	instance.Configuration.MediaAttachments.SupportedMimeTypes = media.SupportedMIMETypes
-	instance.Configuration.MediaAttachments.ImageSizeLimit = int(config.GetMediaImageMaxSize())
-	instance.Configuration.MediaAttachments.ImageMatrixLimit = instanceMediaAttachmentsImageMatrixLimit
-	instance.Configuration.MediaAttachments.VideoSizeLimit = int(config.GetMediaVideoMaxSize())
-	instance.Configuration.MediaAttachments.VideoFrameRateLimit = instanceMediaAttachmentsVideoFrameRateLimit
-	instance.Configuration.MediaAttachments.VideoMatrixLimit = instanceMediaAttachmentsVideoMatrixLimit
-	instance.Configuration.Polls.MaxOptions = config.GetStatusesPollMaxOptions()
-	instance.Configuration.Polls.MaxCharactersPerOption = config.GetStatusesPollOptionMaxChars()
-	instance.Configuration.Polls.MinExpiration = instancePollsMinExpiration
-	instance.Configuration.Polls.MaxExpiration = instancePollsMaxExpiration
+	instance.Configuration.MediaAttachments = instanceConfigurationMediaAttachments()
+	instance.Configuration.Polls = instanceConfigurationPolls()
 	instance.Configuration.Accounts.AllowCustomCSS = config.GetAccountsAllowCustomCSS()
 	instance.Configuration.Accounts.MaxFeaturedTags = instanceAccountsMaxFeaturedTags
 	instance.Configuration.Emojis.EmojiSizeLimit = int(config.GetMediaEmojiLocalMaxSize())
@@ -887,6 +958,7 @@ func (c *converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	// registrations
 	instance.Registrations.Enabled = config.GetAccountsRegistrationOpen()
 	instance.Registrations.ApprovalRequired = config.GetAccountsApprovalRequired()
+	instance.Registrations.ReasonRequired = config.GetAccountsReasonRequired()
 	instance.Registrations.Message = nil // todo: not implemented
 
 	// contact
@@ -907,9 +979,27 @@ func (c *converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 		instance.Contact.Account = account
 	}
 
+	// privacy policy
+	privacyPolicy, err := c.db.GetInstancePolicy(ctx, gtsmodel.InstancePolicyTypePrivacyPolicy)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, fmt.Errorf("InstanceToAPIV2Instance: db error getting instance privacy policy: %w", err)
+	}
+	if privacyPolicy != nil {
+		updatedAt := util.FormatISO8601(privacyPolicy.UpdatedAt)
+		instance.PrivacyPolicyUpdatedAt = &updatedAt
+	}
+
 	return instance, nil
 }
 
+func (c *converter) InstanceConfigurationToAPIInstanceConfiguration(ctx context.Context) (*apimodel.InstanceConfiguration, error) {
+	return &apimodel.InstanceConfiguration{
+		Statuses:         instanceConfigurationStatuses(),
+		MediaAttachments: instanceConfigurationMediaAttachments(),
+		Polls:            instanceConfigurationPolls(),
+	}, nil
+}
+
 func (c *converter) RelationshipToAPIRelationship(ctx context.Context, r *gtsmodel.Relationship) (*apimodel.Relationship, error) {
 	return &apimodel.Relationship{
 		ID:                  r.ID,
@@ -1104,6 +1194,25 @@ func (c *converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		}
 	}
 
+	targetType := "account"
+	var targetTag *apimodel.Tag
+	if r.TargetTagID != "" {
+		if r.TargetTag == nil {
+			r.TargetTag, err = c.db.GetTagByID(ctx, r.TargetTagID)
+			if err != nil {
+				return nil, fmt.Errorf("ReportToAdminAPIReport: error getting target tag with id %s from the db: %w", r.TargetTagID, err)
+			}
+		}
+
+		apiTag, err := c.TagToAPITag(ctx, r.TargetTag)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToAdminAPIReport: error converting target tag with id %s to api tag: %w", r.TargetTagID, err)
+		}
+
+		targetType = "hashtag"
+		targetTag = &apiTag
+	}
+
 	statuses := make([]*apimodel.Status, 0, len(r.StatusIDs))
 	if len(r.StatusIDs) != 0 && len(r.Statuses) == 0 {
 		r.Statuses, err = c.db.GetStatuses(ctx, r.StatusIDs)
@@ -1134,6 +1243,8 @@ func (c *converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		UpdatedAt:            util.FormatISO8601(r.UpdatedAt),
 		Account:              account,
 		TargetAccount:        targetAccount,
+		TargetType:           targetType,
+		TargetTag:            targetTag,
 		AssignedAccount:      actionTakenByAccount,
 		ActionTakenByAccount: actionTakenByAccount,
 		ActionTakenComment:   actionTakenComment,
@@ -1150,22 +1261,130 @@ func (c *converter) ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimo
 	}, nil
 }
 
-// convertAttachmentsToAPIAttachments will convert a slice of GTS model attachments to frontend API model attachments, falling back to IDs if no GTS models supplied.
-func (c *converter) convertAttachmentsToAPIAttachments(ctx context.Context, attachments []*gtsmodel.MediaAttachment, attachmentIDs []string) ([]apimodel.Attachment, error) {
+func (c *converter) PreviewCardToAPICard(ctx context.Context, pc *gtsmodel.PreviewCard) (*apimodel.Card, error) {
+	return &apimodel.Card{
+		URL:          pc.URL,
+		Title:        pc.Title,
+		Description:  pc.Description,
+		Type:         pc.Type,
+		ProviderName: pc.ProviderName,
+		ProviderURL:  pc.ProviderURL,
+		Image:        pc.Image,
+		Width:        pc.Width,
+		Height:       pc.Height,
+	}, nil
+}
+
+func (c *converter) StatusRevisionToAPIStatusEdit(ctx context.Context, r *gtsmodel.StatusRevision, author *gtsmodel.Account) (*apimodel.StatusEdit, error) {
+	apiAuthorAccount, err := c.AccountToAPIAccountPublic(ctx, author)
+	if err != nil {
+		return nil, fmt.Errorf("StatusRevisionToAPIStatusEdit: error converting status author: %w", err)
+	}
+
+	// Media or mentions may have been removed since this
+	// revision; the db skips any that can't be found.
+	attachments, err := c.db.GetAttachmentsByIDs(ctx, r.AttachmentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("StatusRevisionToAPIStatusEdit: error getting attachments: %w", err)
+	}
+
+	apiAttachments := make([]apimodel.Attachment, 0, len(attachments))
+	for _, attachment := range attachments {
+		apiAttachment, err := c.AttachmentToAPIAttachment(ctx, attachment)
+		if err != nil {
+			log.Errorf(ctx, "error converting attachment %s: %v", attachment.ID, err)
+			continue
+		}
+		apiAttachments = append(apiAttachments, apiAttachment)
+	}
+
+	mentions, err := c.db.GetMentions(ctx, r.MentionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("StatusRevisionToAPIStatusEdit: error getting mentions: %w", err)
+	}
+
+	apiMentions, err := c.MentionsToAPIMentions(ctx, mentions)
+	if err != nil {
+		log.Errorf(ctx, "error converting mentions: %v", err)
+	}
+
+	return &apimodel.StatusEdit{
+		Content:          r.Content,
+		SpoilerText:      r.ContentWarning,
+		Sensitive:        r.Sensitive != nil && *r.Sensitive,
+		Visibility:       c.VisToAPIVis(ctx, r.Visibility),
+		CreatedAt:        util.FormatISO8601(r.RevisedAt),
+		Account:          apiAuthorAccount,
+		MediaAttachments: apiAttachments,
+		Mentions:         apiMentions,
+	}, nil
+}
+
+// instanceConfigurationStatuses returns the status limits of this instance.
+func instanceConfigurationStatuses() apimodel.InstanceConfigurationStatuses {
+	return apimodel.InstanceConfigurationStatuses{
+		MaxCharacters:            config.GetStatusesMaxChars(),
+		MaxMediaAttachments:      config.GetStatusesMediaMaxFiles(),
+		CharactersReservedPerURL: instanceStatusesCharactersReservedPerURL,
+	}
+}
+
+// instanceConfigurationMediaAttachments returns the media limits of this instance.
+func instanceConfigurationMediaAttachments() apimodel.InstanceConfigurationMediaAttachments {
+	return apimodel.InstanceConfigurationMediaAttachments{
+		SupportedMimeTypes:  media.SupportedMIMETypes,
+		ImageSizeLimit:      int(config.GetMediaImageMaxSize()),
+		ImageMatrixLimit:    instanceMediaAttachmentsImageMatrixLimit,
+		VideoSizeLimit:      int(config.GetMediaVideoMaxSize()),
+		VideoFrameRateLimit: instanceMediaAttachmentsVideoFrameRateLimit,
+		VideoMatrixLimit:    instanceMediaAttachmentsVideoMatrixLimit,
+	}
+}
+
+// instanceConfigurationPolls returns the poll limits of this instance.
+func instanceConfigurationPolls() apimodel.InstanceConfigurationPolls {
+	return apimodel.InstanceConfigurationPolls{
+		MaxOptions:             config.GetStatusesPollMaxOptions(),
+		MaxCharactersPerOption: config.GetStatusesPollOptionMaxChars(),
+		MinExpiration:          instancePollsMinExpiration,
+		MaxExpiration:          instancePollsMaxExpiration,
+	}
+}
+
+// convertAttachmentsToAPIAttachments will convert a slice of GTS model attachments to frontend API model attachments,
+// falling back to fetching the attachments of the given status ID if no GTS models supplied. The returned attachments
+// are ordered according to attachmentIDs.
+func (c *converter) convertAttachmentsToAPIAttachments(ctx context.Context, attachments []*gtsmodel.MediaAttachment, statusID string, attachmentIDs []string) ([]apimodel.Attachment, error) {
 	var errs gtserror.MultiError
 
-	if len(attachments) == 0 {
+	if len(attachments) == 0 && len(attachmentIDs) != 0 {
 		// GTS model attachments were not populated
 
+		// Fetch all GTS models for this status in one go
+		byStatus, err := c.db.GetAttachmentsByStatusID(ctx, statusID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error fetching attachments for status %s from database: %v", statusID, err)
+		}
+
+		byID := make(map[string]*gtsmodel.MediaAttachment, len(byStatus))
+		for _, attachment := range byStatus {
+			byID[attachment.ID] = attachment
+		}
+
 		// Preallocate expected GTS slice
 		attachments = make([]*gtsmodel.MediaAttachment, 0, len(attachmentIDs))
 
-		// Fetch GTS models for attachment IDs
+		// Order GTS models by attachment IDs
 		for _, id := range attachmentIDs {
-			attachment, err := c.db.GetAttachmentByID(ctx, id)
-			if err != nil {
-				errs.Appendf("error fetching attachment %s from database: %v", id, err)
-				continue
+			attachment, ok := byID[id]
+			if !ok {
+				// Not (yet) attached to this status in the
+				// database, fall back to fetching it by ID.
+				attachment, err = c.db.GetAttachmentByID(ctx, id)
+				if err != nil {
+					errs.Appendf("error fetching attachment %s from database: %v", id, err)
+					continue
+				}
 			}
 			attachments = append(attachments, attachment)
 		}
@@ -1240,17 +1459,9 @@ func (c *converter) convertMentionsToAPIMentions(ctx context.Context, mentions [
 		}
 	}
 
-	// Preallocate expected frontend slice
-	apiMentions := make([]apimodel.Mention, 0, len(mentions))
-
-	// Convert GTS models to frontend models
-	for _, mention := range mentions {
-		apiMention, err := c.MentionToAPIMention(ctx, mention)
-		if err != nil {
-			errs.Appendf("error converting mention %s to api mention: %v", mention.ID, err)
-			continue
-		}
-		apiMentions = append(apiMentions, apiMention)
+	apiMentions, err := c.MentionsToAPIMentions(ctx, mentions)
+	if err != nil {
+		errs.Append(err)
 	}
 
 	return apiMentions, errs.Combine()
@@ -1268,8 +1479,8 @@ func (c *converter) convertTagsToAPITags(ctx context.Context, tags []*gtsmodel.T
 
 		// Fetch GTS models for tag IDs
 		for _, id := range tagIDs {
-			tag := new(gtsmodel.Tag)
-			if err := c.db.GetByID(ctx, id, tag); err != nil {
+			tag, err := c.db.GetTagByID(ctx, id)
+			if err != nil {
 				errs.Appendf("error fetching tag %s from database: %v", id, err)
 				continue
 			}
@@ -771,6 +771,7 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
   "registrations": {
     "enabled": true,
     "approval_required": true,
+    "reason_required": true,
     "message": null
   },
   "contact": {
@@ -802,7 +803,8 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
       }
     }
   },
-  "rules": []
+  "rules": [],
+  "privacy_policy_updated_at": null
 }`, string(b))
 }
 
@@ -1082,6 +1084,8 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     },
     "created_by_application_id": "01F8MGY43H3N2C8EWPR2FPYEXG"
   },
+  "target_type": "account",
+  "target_tag": null,
   "assigned_account": {
     "id": "01F8MH17FWEB39HZJ76B6VXSKF",
     "username": "admin",
@@ -1294,6 +1298,8 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "fields": []
     }
   },
+  "target_type": "account",
+  "target_tag": null,
   "assigned_account": null,
   "action_taken_by_account": null,
   "statuses": [
@@ -1508,6 +1514,8 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       }
     }
   },
+  "target_type": "account",
+  "target_tag": null,
   "assigned_account": {
     "id": "01F8MH17FWEB39HZJ76B6VXSKF",
     "username": "admin",
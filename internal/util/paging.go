@@ -42,13 +42,14 @@ type PageableResponseParams struct {
 	PrevMinIDValue   string        // value to use for prev min id
 	Limit            int           // limit number of entries to return
 	ExtraQueryParams []string      // any extra query parameters to provide in the link header, should be in the format 'example=value'
+	Filtered         bool          // items were filtered from a page of results, so link to next/prev pages even if no items are left
 }
 
 // PackagePageableResponse is a convenience function for returning
 // a bunch of pageable items (notifications, statuses, etc), as well
 // as a Link header to inform callers of where to find next/prev items.
 func PackagePageableResponse(params PageableResponseParams) (*apimodel.PageableResponse, gtserror.WithCode) {
-	if len(params.Items) == 0 {
+	if len(params.Items) == 0 && !params.Filtered {
 		// No items to page through.
 		return EmptyPageableResponse(), nil
 	}
@@ -64,11 +65,10 @@ func PackagePageableResponse(params PageableResponseParams) (*apimodel.PageableR
 	}
 
 	var (
-		protocol        = config.GetProtocol()
-		host            = config.GetHost()
-		nextLink        string
-		prevLink        string
-		linkHeaderParts = make([]string, 0, 2)
+		protocol = config.GetProtocol()
+		host     = config.GetHost()
+		nextLink string
+		prevLink string
 	)
 
 	// Parse next link.
@@ -92,8 +92,6 @@ func PackagePageableResponse(params PageableResponseParams) (*apimodel.PageableR
 			}
 			return u.String()
 		}()
-
-		linkHeaderParts = append(linkHeaderParts, `<`+nextLink+`>; rel="next"`)
 	}
 
 	// Parse prev link.
@@ -117,18 +115,34 @@ func PackagePageableResponse(params PageableResponseParams) (*apimodel.PageableR
 			}
 			return u.String()
 		}()
-
-		linkHeaderParts = append(linkHeaderParts, `<`+prevLink+`>; rel="prev"`)
 	}
 
 	return &apimodel.PageableResponse{
 		Items:      params.Items,
-		LinkHeader: strings.Join(linkHeaderParts, ", "),
+		LinkHeader: LinkHeaderFromPage(prevLink, nextLink),
 		NextLink:   nextLink,
 		PrevLink:   prevLink,
 	}, nil
 }
 
+// LinkHeaderFromPage returns a Link header value pointing callers to the
+// given previous and next pages, which should be absolute URLs. Either may
+// be empty, in which case that relation is left out of the header; if both
+// are empty, an empty string is returned and no header should be set.
+func LinkHeaderFromPage(prevURL string, nextURL string) string {
+	linkHeaderParts := make([]string, 0, 2)
+
+	if nextURL != "" {
+		linkHeaderParts = append(linkHeaderParts, `<`+nextURL+`>; rel="next"`)
+	}
+
+	if prevURL != "" {
+		linkHeaderParts = append(linkHeaderParts, `<`+prevURL+`>; rel="prev"`)
+	}
+
+	return strings.Join(linkHeaderParts, ", ")
+}
+
 // EmptyPageableResponse just returns an empty
 // PageableResponse with no link header or items.
 func EmptyPageableResponse() *apimodel.PageableResponse {
@@ -114,6 +114,18 @@ func (suite *PagingSuite) TestPagingNoPrevID() {
 	suite.Equal(``, resp.PrevLink)
 }
 
+func (suite *PagingSuite) TestLinkHeaderFromPage() {
+	var (
+		prev = "https://example.org/api/v1/admin/domain_stats?limit=20&offset=0"
+		next = "https://example.org/api/v1/admin/domain_stats?limit=20&offset=40"
+	)
+
+	suite.Equal(`<`+next+`>; rel="next", <`+prev+`>; rel="prev"`, util.LinkHeaderFromPage(prev, next))
+	suite.Equal(`<`+next+`>; rel="next"`, util.LinkHeaderFromPage("", next))
+	suite.Equal(`<`+prev+`>; rel="prev"`, util.LinkHeaderFromPage(prev, ""))
+	suite.Empty(util.LinkHeaderFromPage("", ""))
+}
+
 func (suite *PagingSuite) TestPagingNoItems() {
 	config.SetHost("example.org")
 
@@ -134,6 +146,27 @@ func (suite *PagingSuite) TestPagingNoItems() {
 	suite.Empty(resp.PrevLink)
 }
 
+func (suite *PagingSuite) TestPagingFilteredNoItems() {
+	config.SetHost("example.org")
+
+	params := util.PageableResponseParams{
+		Items:          []interface{}{},
+		Path:           "/api/v1/statuses/01H11KA68PM4NNYJEG0FJQ90R3/favourited_by",
+		NextMaxIDValue: "01H11KA1DM2VH3747YDE7FV5HN",
+		PrevMinIDValue: "01H11KBBVRRDYYC5KEPME1NP5R",
+		Limit:          10,
+		Filtered:       true,
+	}
+
+	resp, errWithCode := util.PackagePageableResponse(params)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Empty(resp.Items)
+	suite.Equal(`<https://example.org/api/v1/statuses/01H11KA68PM4NNYJEG0FJQ90R3/favourited_by?limit=10&max_id=01H11KA1DM2VH3747YDE7FV5HN>; rel="next", <https://example.org/api/v1/statuses/01H11KA68PM4NNYJEG0FJQ90R3/favourited_by?limit=10&min_id=01H11KBBVRRDYYC5KEPME1NP5R>; rel="prev"`, resp.LinkHeader)
+}
+
 func TestPagingSuite(t *testing.T) {
 	suite.Run(t, &PagingSuite{})
 }
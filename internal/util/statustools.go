@@ -18,7 +18,10 @@
 package util
 
 import (
+	"strings"
 	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 func IsPlausiblyInHashtag(r rune) bool {
@@ -31,6 +34,14 @@ func IsPermittedInHashtag(r rune) bool {
 	return unicode.IsLetter(r) || unicode.IsNumber(r)
 }
 
+// NormalizeHashtag returns the form of the given hashtag (with or without
+// the leading '#') which is used to store and look it up: NFC-normalized
+// and lowercased, so that visually-identical and differently-cased uses
+// of a hashtag all map to the same tag.
+func NormalizeHashtag(text string) string {
+	return strings.ToLower(norm.NFC.String(strings.TrimPrefix(text, "#")))
+}
+
 // Decides where to break before or after a #hashtag or @mention
 func IsMentionOrHashtagBoundary(r rune) bool {
 	return unicode.IsSpace(r) || unicode.IsPunct(r)
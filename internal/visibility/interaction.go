@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+package visibility
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Interaction is a kind of interaction with a status
+// which is governed by the status' interaction policy.
+type Interaction int
+
+const (
+	InteractionReply Interaction = iota
+	InteractionBoost
+	InteractionLike
+)
+
+// PolicyValue returns the interaction policy value of the
+// given status which governs this kind of interaction.
+func (i Interaction) PolicyValue(status *gtsmodel.Status) gtsmodel.InteractionPolicyValue {
+	switch i {
+	case InteractionReply:
+		return status.InteractionPolicy.CanReplyTo
+	case InteractionBoost:
+		return status.InteractionPolicy.CanBoost
+	case InteractionLike:
+		return status.InteractionPolicy.CanLike
+	default:
+		return gtsmodel.InteractionPolicySelfOnly
+	}
+}
+
+// StatusInteractionPermitted checks if the interaction policy of given status permits requester to perform the given interaction
+// with it. This applies equally to local and remote requesters, and doesn't check whether the status is visible to requester.
+func (f *Filter) StatusInteractionPermitted(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, interaction Interaction) (bool, error) {
+	if requester.ID == status.AccountID {
+		// Status author can always
+		// interact with own statuses.
+		return true, nil
+	}
+
+	switch value := interaction.PolicyValue(status); value {
+	case "", gtsmodel.InteractionPolicyEveryone:
+		return true, nil
+
+	case gtsmodel.InteractionPolicyFollowersOnly:
+		return f.state.DB.IsFollowing(ctx, requester.ID, status.AccountID)
+
+	case gtsmodel.InteractionPolicyMentionedOnly:
+		mentions := status.Mentions
+		if !status.MentionsPopulated() {
+			var err error
+			mentions, err = f.state.DB.GetMentions(ctx, status.MentionIDs)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return false, err
+			}
+		}
+
+		for _, mention := range mentions {
+			if mention.TargetAccountID == requester.ID {
+				return true, nil
+			}
+		}
+
+		log.Tracef(ctx, "requester not mentioned in status with %s interaction policy", value)
+		return false, nil
+
+	default:
+		// Self only, or something
+		// unrecognized; either way,
+		// nobody else is permitted.
+		log.Tracef(ctx, "requester not permitted by %s interaction policy", value)
+		return false, nil
+	}
+}
@@ -5,9 +5,13 @@ set -eu
 EXPECT=$(cat <<"EOF"
 {
     "account-domain": "peepee",
+    "accounts-admin-delete-limit": 10,
+    "accounts-admin-delete-window": 3600000000000,
     "accounts-allow-custom-css": true,
     "accounts-approval-required": false,
     "accounts-custom-css-length": 5000,
+    "accounts-delete-notifications-batch-pause": 100000000,
+    "accounts-delete-notifications-batch-size": 5000,
     "accounts-reason-required": false,
     "accounts-registration-open": true,
     "advanced-cookies-samesite": "strict",
@@ -47,6 +51,7 @@ EXPECT=$(cat <<"EOF"
             "list-sweep-freq": 60000000000,
             "list-ttl": 1800000000000,
             "media-max-size": 1000,
+            "media-stale-window": 0,
             "media-sweep-freq": 60000000000,
             "media-ttl": 1800000000000,
             "mention-max-size": 2000,
@@ -64,6 +69,9 @@ EXPECT=$(cat <<"EOF"
             "status-max-size": 2000,
             "status-sweep-freq": 60000000000,
             "status-ttl": 1800000000000,
+            "tag-max-size": 2000,
+            "tag-sweep-freq": 60000000000,
+            "tag-ttl": 1800000000000,
             "tombstone-max-size": 500,
             "tombstone-sweep-freq": 60000000000,
             "tombstone-ttl": 1800000000000,
@@ -71,6 +79,9 @@ EXPECT=$(cat <<"EOF"
             "user-sweep-freq": 60000000000,
             "user-ttl": 1800000000000,
             "webfinger-max-size": 250,
+            "webfinger-response-max-size": 250,
+            "webfinger-response-stale-window": 86400000000000,
+            "webfinger-response-ttl": 3600000000000,
             "webfinger-sweep-freq": 900000000000,
             "webfinger-ttl": 86400000000000
         },
@@ -94,7 +105,9 @@ EXPECT=$(cat <<"EOF"
     "db-user": "sex-haver",
     "dry-run": true,
     "email": "",
+    "geoip-db-path": "/gotosocial/GeoLite2-Country.mmdb",
     "host": "example.com",
+    "instance-contact-account-username": "admin",
     "instance-deliver-to-shared-inboxes": false,
     "instance-expose-peers": true,
     "instance-expose-public-timeline": true,
@@ -107,17 +120,27 @@ EXPECT=$(cat <<"EOF"
     "letsencrypt-port": 80,
     "log-client-ip": false,
     "log-db-queries": true,
+    "log-geoip": true,
     "log-level": "info",
     "media-description-max-chars": 5000,
     "media-description-min-chars": 69,
     "media-emoji-local-max-size": 420,
     "media-emoji-remote-max-size": 420,
     "media-image-max-size": 420,
+    "media-per-domain-cache-limit": 1048576,
+    "media-prune-dead-instances": true,
+    "media-recache-timeout": 10000000000,
+    "media-refetch-emoji-timeout": 30000000000,
+    "media-refetch-timeout": 1800000000000,
     "media-remote-cache-days": 30,
+    "media-strip-metadata": false,
     "media-video-max-size": 420,
+    "media-warm-after-prune": true,
+    "media-warm-budget": 1048576,
     "oidc-admin-groups": [
         "steamy"
     ],
+    "oidc-auto-provision": false,
     "oidc-client-id": "1234",
     "oidc-client-secret": "shhhh its a secret",
     "oidc-enabled": true,
@@ -148,6 +171,7 @@ EXPECT=$(cat <<"EOF"
     "statuses-poll-option-max-chars": 50,
     "storage-backend": "local",
     "storage-local-base-path": "/root/store",
+    "storage-media-path-template": "{yyyy}/{accountID}/{mediaSize}/{mediaID}.{extension}",
     "storage-s3-access-key": "minio",
     "storage-s3-bucket": "gts",
     "storage-s3-endpoint": "localhost:9000",
@@ -179,6 +203,8 @@ EOF
 OUTPUT=$(GTS_LOG_LEVEL='info' \
 GTS_LOG_DB_QUERIES=true \
 GTS_LOG_CLIENT_IP=false \
+GTS_LOG_GEOIP=true \
+GTS_GEOIP_DB_PATH='/gotosocial/GeoLite2-Country.mmdb' \
 GTS_APPLICATION_NAME=gts \
 GTS_LANDING_PAGE_USER=admin \
 GTS_HOST=example.com \
@@ -207,6 +233,7 @@ GTS_INSTANCE_EXPOSE_SUSPENDED=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED_WEB=true \
 GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
+GTS_INSTANCE_CONTACT_ACCOUNT_USERNAME=admin \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
 GTS_ACCOUNTS_REGISTRATION_OPEN=true \
@@ -219,8 +246,17 @@ GTS_MEDIA_DESCRIPTION_MAX_CHARS=5000 \
 GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
+GTS_MEDIA_STRIP_METADATA=false \
+GTS_MEDIA_REFETCH_TIMEOUT='30m' \
+GTS_MEDIA_REFETCH_EMOJI_TIMEOUT='30s' \
+GTS_MEDIA_PER_DOMAIN_CACHE_LIMIT=1048576 \
+GTS_MEDIA_PRUNE_DEAD_INSTANCES=true \
+GTS_MEDIA_RECACHE_TIMEOUT='10s' \
+GTS_MEDIA_WARM_AFTER_PRUNE=true \
+GTS_MEDIA_WARM_BUDGET=1048576 \
 GTS_STORAGE_BACKEND='local' \
 GTS_STORAGE_LOCAL_BASE_PATH='/root/store' \
+GTS_STORAGE_MEDIA_PATH_TEMPLATE='{yyyy}/{accountID}/{mediaSize}/{mediaID}.{extension}' \
 GTS_STORAGE_S3_ACCESS_KEY='minio' \
 GTS_STORAGE_S3_SECRET_KEY='miniostorage' \
 GTS_STORAGE_S3_ENDPOINT='localhost:9000' \
@@ -245,6 +281,7 @@ GTS_OIDC_CLIENT_SECRET='shhhh its a secret' \
 GTS_OIDC_SCOPES='read,write' \
 GTS_OIDC_LINK_EXISTING=true \
 GTS_OIDC_ADMIN_GROUPS='steamy' \
+GTS_OIDC_AUTO_PROVISION=false \
 GTS_SMTP_HOST='example.com' \
 GTS_SMTP_PORT=4269 \
 GTS_SMTP_USERNAME='sex-haver' \
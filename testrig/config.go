@@ -66,6 +66,7 @@ var testDefaults = config.Configuration{
 	InstanceExposeSuspended:        true,
 	InstanceExposeSuspendedWeb:     true,
 	InstanceDeliverToSharedInboxes: true,
+	InstanceContactAccountUsername: "",
 
 	AccountsRegistrationOpen: true,
 	AccountsApprovalRequired: true,
@@ -73,6 +74,12 @@ var testDefaults = config.Configuration{
 	AccountsAllowCustomCSS:   true,
 	AccountsCustomCSSLength:  10000,
 
+	AccountsDeleteNotificationsBatchSize:  5000,
+	AccountsDeleteNotificationsBatchPause: 0, // don't slow tests down
+
+	AccountsAdminDeleteLimit:  10,
+	AccountsAdminDeleteWindow: time.Hour,
+
 	MediaImageMaxSize:        10485760, // 10mb
 	MediaVideoMaxSize:        41943040, // 40mb
 	MediaDescriptionMinChars: 0,
@@ -80,12 +87,21 @@ var testDefaults = config.Configuration{
 	MediaRemoteCacheDays:     30,
 	MediaEmojiLocalMaxSize:   51200,  // 50kb
 	MediaEmojiRemoteMaxSize:  102400, // 100kb
+	MediaStripMetadata:       true,
+	MediaRefetchTimeout:      time.Hour,
+	MediaRefetchEmojiTimeout: time.Minute,
+	MediaPerDomainCacheLimit: 0, // no limit
+	MediaPruneDeadInstances:  false,
+	MediaRecacheTimeout:      5 * time.Second,
+	MediaWarmAfterPrune:      false,
+	MediaWarmBudget:          104857600, // 100mb
 
 	// the testrig only uses in-memory storage, so we can
 	// safely set this value to 'test' to avoid running storage
 	// migrations, and other silly things like that
-	StorageBackend:       "test",
-	StorageLocalBasePath: "",
+	StorageBackend:           "test",
+	StorageLocalBasePath:     "",
+	StorageMediaPathTemplate: "{accountID}/attachment/{mediaSize}/{mediaID}.{extension}",
 
 	StatusesMaxChars:           5000,
 	StatusesCWMaxChars:         100,
@@ -106,6 +122,7 @@ var testDefaults = config.Configuration{
 	OIDCClientSecret:     "",
 	OIDCScopes:           []string{oidc.ScopeOpenID, "profile", "email", "groups"},
 	OIDCLinkExisting:     false,
+	OIDCAutoProvision:    true,
 
 	SMTPHost:               "",
 	SMTPPort:               0,
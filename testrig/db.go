@@ -37,6 +37,7 @@ var testModels = []interface{}{
 	&gtsmodel.Block{},
 	&gtsmodel.DomainBlock{},
 	&gtsmodel.EmailDomainBlock{},
+	&gtsmodel.EmailTemplate{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
 	&gtsmodel.List{},
@@ -60,6 +61,12 @@ var testModels = []interface{}{
 	&gtsmodel.EmojiCategory{},
 	&gtsmodel.Tombstone{},
 	&gtsmodel.Report{},
+	&gtsmodel.SignIn{},
+	&gtsmodel.SavedSearch{},
+	&gtsmodel.PreviewCard{},
+	&gtsmodel.MediaAttachmentVariant{},
+	&gtsmodel.StatusRevision{},
+	&gtsmodel.InstancePolicy{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
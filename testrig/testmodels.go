@@ -350,6 +350,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			HideCollections:         FalseBool(),
 			SuspensionOrigin:        "",
 			EnableRSS:               FalseBool(),
+			System:                  TrueBool(),
 		},
 		"unconfirmed_account": {
 			ID:                      "01F8MH0BBE4FHXPH513MBVFHB0",
@@ -1766,17 +1767,21 @@ func NewTestTags() map[string]*gtsmodel.Tag {
 			UpdatedAt:              TimeMustParse("2022-05-14T13:21:09+02:00"),
 			Useable:                TrueBool(),
 			Listable:               TrueBool(),
+			Trendable:              TrueBool(),
+			RequiresReview:         FalseBool(),
 			LastStatusAt:           TimeMustParse("2022-05-14T13:21:09+02:00"),
 		},
 		"Hashtag": {
 			ID:                     "01FCT9SGYA71487N8D0S1M638G",
-			URL:                    "http://localhost:8080/tags/Hashtag",
-			Name:                   "Hashtag",
+			URL:                    "http://localhost:8080/tags/hashtag",
+			Name:                   "hashtag",
 			FirstSeenFromAccountID: "",
 			CreatedAt:              TimeMustParse("2022-05-14T13:21:09+02:00"),
 			UpdatedAt:              TimeMustParse("2022-05-14T13:21:09+02:00"),
 			Useable:                TrueBool(),
 			Listable:               TrueBool(),
+			Trendable:              TrueBool(),
+			RequiresReview:         FalseBool(),
 			LastStatusAt:           TimeMustParse("2022-05-14T13:21:09+02:00"),
 		},
 	}
@@ -125,6 +125,8 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 			responseCode, responseBytes, responseContentType, responseContentLength = WebfingerResponse(req)
 		} else if strings.Contains(req.URL.String(), ".well-known/host-meta") {
 			responseCode, responseBytes, responseContentType, responseContentLength = HostMetaResponse(req)
+		} else if strings.Contains(req.URL.String(), "nodeinfo") {
+			responseCode, responseBytes, responseContentType, responseContentLength = NodeInfoResponse(req)
 		} else if note, ok := mockHTTPClient.TestRemoteStatuses[req.URL.String()]; ok {
 			// the request is for a note that we have stored
 			noteI, err := streams.Serialize(note)
@@ -266,6 +268,55 @@ func HostMetaResponse(req *http.Request) (responseCode int, responseBytes []byte
 	return
 }
 
+func NodeInfoResponse(req *http.Request) (responseCode int, responseBytes []byte, responseContentType string, responseContentLength int) {
+	var body any
+
+	switch req.URL.String() {
+	case "http://fossbros-anonymous.io/.well-known/nodeinfo":
+		body = &apimodel.WellKnownResponse{
+			Links: []apimodel.Link{
+				{
+					Rel:  "http://nodeinfo.diaspora.software/ns/schema/2.0",
+					Href: "http://fossbros-anonymous.io/nodeinfo/2.0",
+				},
+			},
+		}
+	case "http://fossbros-anonymous.io/nodeinfo/2.0":
+		body = &apimodel.Nodeinfo{
+			Version: "2.0",
+			Software: apimodel.NodeInfoSoftware{
+				Name:    "mastodon",
+				Version: "4.1.2",
+			},
+			Protocols: []string{"activitypub"},
+			Usage: apimodel.NodeInfoUsage{
+				Users: apimodel.NodeInfoUsers{
+					Total: 1024,
+				},
+			},
+		}
+	}
+
+	if body == nil {
+		log.Debugf(nil, "nodeinfo response not available for %s", req.URL)
+		responseCode = http.StatusNotFound
+		responseBytes = []byte(`{"error":"not found"}`)
+		responseContentType = applicationJSON
+		responseContentLength = len(responseBytes)
+		return
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		panic(err)
+	}
+	responseCode = http.StatusOK
+	responseBytes = b
+	responseContentType = applicationJSON
+	responseContentLength = len(b)
+	return
+}
+
 func WebfingerResponse(req *http.Request) (responseCode int, responseBytes []byte, responseContentType string, responseContentLength int) {
 	var wfr *apimodel.WellKnownResponse
 